@@ -56,6 +56,13 @@ func main() {
 	}
 	defer db.Close()
 
+	// Apply pending schema migrations when enabled
+	if cfg.RunMigrations {
+		if err := db.Migrate(context.Background(), "ai-recommendations-service", cfg.MigrationsDir, logger.WithService("ai-recommendations-service")); err != nil {
+			logger.WithService("ai-recommendations-service").Fatalf("Failed to migrate database: %v", err)
+		}
+	}
+
 	// Connect to Redis with AI recommendations-specific DB
 	opt, err := redis.ParseURL(cfg.RedisURL)
 	if err != nil {
@@ -71,11 +78,21 @@ func main() {
 
 	// Initialize core services
 	cacheService := services.NewCacheService(redisClient, structuredLogger)
-	claudeClient := services.NewClaudeClient(cfg, structuredLogger)
+	llmProvider, err := services.NewLLMProvider(cfg, structuredLogger)
+	if err != nil {
+		logger.WithService("ai-recommendations-service").Fatalf("Failed to initialize LLM provider: %v", err)
+	}
 	promptBuilder := services.NewPromptBuilder(cacheService, structuredLogger)
+	templateStore := services.NewPromptTemplateStore(db.DB, promptBuilder, structuredLogger)
+	if err := templateStore.Load(); err != nil {
+		// Built-in templates remain in place, so recommendations keep working without stored versions
+		structuredLogger.WithError(err).Warn("Failed to load prompt templates from database")
+	}
 	realtimeAggregator := services.NewRealtimeAggregator(cacheService, structuredLogger)
 	ownershipAnalyzer := services.NewOwnershipAnalyzer(db.DB, cacheService, structuredLogger)
-	aiEngine := services.NewAIEngine(claudeClient, promptBuilder, realtimeAggregator, ownershipAnalyzer, structuredLogger)
+	aiEngine := services.NewAIEngine(llmProvider, promptBuilder, realtimeAggregator, ownershipAnalyzer, structuredLogger)
+	accuracyTracker := services.NewAccuracyTracker(db.DB, structuredLogger)
+	aiEngine.SetAccuracyTracker(accuracyTracker)
 
 	// Initialize WebSocket hub for real-time recommendation updates
 	wsHub := websocket.NewRecommendationHub(structuredLogger)
@@ -89,6 +106,7 @@ func main() {
 	recommendationHandler := handlers.NewRecommendationHandler(
 		db.DB,
 		aiEngine,
+		accuracyTracker,
 		wsHub,
 		cfg,
 		structuredLogger,
@@ -105,13 +123,16 @@ func main() {
 		cfg,
 		structuredLogger,
 	)
-	healthHandler := handlers.NewHealthHandler(db.DB, redisClient, claudeClient, structuredLogger)
+	accuracyHandler := handlers.NewAccuracyHandler(accuracyTracker, structuredLogger)
+	promptTemplateHandler := handlers.NewPromptTemplateHandler(templateStore, accuracyTracker, structuredLogger)
+	healthHandler := handlers.NewHealthHandler(db.DB, redisClient, llmProvider, structuredLogger)
 
 	// Setup API routes for AI recommendations service
 	apiV1 := router.Group("/api/v1")
 	{
 		// AI Recommendations endpoints
 		apiV1.POST("/recommendations/players", recommendationHandler.GetPlayerRecommendations)
+		apiV1.POST("/recommendations/players/stream", recommendationHandler.StreamPlayerRecommendations)
 		apiV1.POST("/recommendations/lineup", recommendationHandler.GetLineupRecommendations)
 		apiV1.POST("/recommendations/swap", recommendationHandler.GetSwapRecommendations)
 		
@@ -124,6 +145,23 @@ func main() {
 		apiV1.GET("/ownership/:contestId", ownershipHandler.GetOwnershipData)
 		apiV1.GET("/ownership/:contestId/leverage", ownershipHandler.GetLeverageOpportunities)
 		apiV1.GET("/ownership/:contestId/trends", ownershipHandler.GetOwnershipTrends)
+
+		// Recommendation accuracy endpoints
+		apiV1.GET("/accuracy", accuracyHandler.GetAccuracyReport)
+		apiV1.POST("/accuracy/results", accuracyHandler.SubmitResults)
+
+		// Prompt template administration and experiments
+		admin := apiV1.Group("/admin")
+		{
+			admin.GET("/prompt-templates", promptTemplateHandler.ListTemplates)
+			admin.POST("/prompt-templates", promptTemplateHandler.CreateTemplateVersion)
+			admin.POST("/prompt-templates/:key/versions/:version/activate", promptTemplateHandler.ActivateTemplateVersion)
+			admin.GET("/prompt-experiments", promptTemplateHandler.ListExperiments)
+			admin.POST("/prompt-experiments", promptTemplateHandler.CreateExperiment)
+			admin.POST("/prompt-experiments/:id/start", promptTemplateHandler.StartExperiment)
+			admin.POST("/prompt-experiments/:id/stop", promptTemplateHandler.StopExperiment)
+			admin.GET("/prompt-experiments/:id/results", promptTemplateHandler.GetExperimentResults)
+		}
 	}
 
 	// WebSocket endpoint for real-time recommendation updates
@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/stitts-dev/dfs-sim/services/ai-recommendations-service/internal/services"
+)
+
+// AccuracyHandler handles recommendation outcome scoring endpoints
+type AccuracyHandler struct {
+	accuracyTracker *services.AccuracyTracker
+	logger          *logrus.Logger
+}
+
+// ContestResultsRequest carries actual player results for a completed contest
+type ContestResultsRequest struct {
+	ContestID uint                    `json:"contest_id" binding:"required"`
+	Results   []services.PlayerResult `json:"results" binding:"required,min=1,dive"`
+}
+
+// NewAccuracyHandler creates a new accuracy handler
+func NewAccuracyHandler(accuracyTracker *services.AccuracyTracker, logger *logrus.Logger) *AccuracyHandler {
+	return &AccuracyHandler{
+		accuracyTracker: accuracyTracker,
+		logger:          logger,
+	}
+}
+
+// GetAccuracyReport returns hit rates and calibration curves for scored recommendations
+func (h *AccuracyHandler) GetAccuracyReport(c *gin.Context) {
+	filter := services.AccuracyFilter{
+		Sport:              c.Query("sport"),
+		RecommendationType: c.Query("recommendation_type"),
+		PromptTemplate:     c.Query("prompt_template"),
+	}
+
+	if sinceParam := c.Query("since"); sinceParam != "" {
+		since, err := time.Parse(time.RFC3339, sinceParam)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid since timestamp, expected RFC3339"})
+			return
+		}
+		filter.Since = &since
+	}
+
+	report, err := h.accuracyTracker.GetReport(filter)
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to build accuracy report")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to build accuracy report"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data":   report,
+	})
+}
+
+// SubmitResults scores stored recommendations for a contest against actual results
+func (h *AccuracyHandler) SubmitResults(c *gin.Context) {
+	var request ContestResultsRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format", "details": err.Error()})
+		return
+	}
+
+	summary, err := h.accuracyTracker.ScoreContest(request.ContestID, request.Results)
+	if err != nil {
+		h.logger.WithError(err).WithField("contest_id", request.ContestID).Error("Failed to score contest results")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to score contest results"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data":   summary,
+	})
+}
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"time"
 
@@ -16,7 +17,7 @@ import (
 type HealthHandler struct {
 	db           *gorm.DB
 	redisClient  *redis.Client
-	claudeClient *services.ClaudeClient
+	llmProvider  services.LLMProvider
 	logger       *logrus.Logger
 }
 
@@ -73,9 +74,10 @@ type RedisMetrics struct {
 	HitRate          float64       `json:"hit_rate_percent"`
 }
 
-// ClaudeMetrics represents Claude API health metrics
+// ClaudeMetrics represents LLM provider health metrics (usage fields are Claude API only)
 type ClaudeMetrics struct {
 	Status            string  `json:"status"`
+	Provider          string  `json:"provider"`
 	CircuitBreakerState string `json:"circuit_breaker_state"`
 	RequestsPerMinute int64   `json:"requests_per_minute"`
 	TokensPerHour     int64   `json:"tokens_per_hour"`
@@ -97,11 +99,11 @@ type ServiceMetrics struct {
 var startTime = time.Now()
 
 // NewHealthHandler creates a new health handler
-func NewHealthHandler(db *gorm.DB, redisClient *redis.Client, claudeClient *services.ClaudeClient, logger *logrus.Logger) *HealthHandler {
+func NewHealthHandler(db *gorm.DB, redisClient *redis.Client, llmProvider services.LLMProvider, logger *logrus.Logger) *HealthHandler {
 	return &HealthHandler{
 		db:           db,
 		redisClient:  redisClient,
-		claudeClient: claudeClient,
+		llmProvider:  llmProvider,
 		logger:       logger,
 	}
 }
@@ -273,13 +275,22 @@ func (h *HealthHandler) checkClaude() HealthCheck {
 	status := "healthy"
 	message := ""
 
-	if !h.claudeClient.IsHealthy() {
+	if !h.llmProvider.IsHealthy() {
 		status = "unhealthy"
-		message = "Claude API circuit breaker is open"
+		message = fmt.Sprintf("%s provider is unavailable", h.llmProvider.Name())
 	}
 
-	// Check rate limits
-	requestsPerMinute, tokensPerHour, requestLimit, tokenLimit := h.claudeClient.GetUsageStats()
+	// Rate limits only apply to the Claude API
+	claudeClient, ok := h.llmProvider.(*services.ClaudeClient)
+	if !ok {
+		return HealthCheck{
+			Status:    status,
+			Message:   message,
+			CheckedAt: time.Now(),
+		}
+	}
+
+	requestsPerMinute, tokensPerHour, requestLimit, tokenLimit := claudeClient.GetUsageStats()
 	
 	if requestsPerMinute >= requestLimit {
 		status = "degraded"
@@ -320,7 +331,7 @@ func (h *HealthHandler) isRedisReady() bool {
 }
 
 func (h *HealthHandler) isClaudeReady() bool {
-	return h.claudeClient.IsHealthy()
+	return h.llmProvider.IsHealthy()
 }
 
 // Metrics collection methods
@@ -382,16 +393,25 @@ func (h *HealthHandler) getRedisMetrics() RedisMetrics {
 }
 
 func (h *HealthHandler) getClaudeMetrics() ClaudeMetrics {
-	requestsPerMinute, tokensPerHour, requestLimit, tokenLimit := h.claudeClient.GetUsageStats()
-	
 	status := "healthy"
-	if !h.claudeClient.IsHealthy() {
+	if !h.llmProvider.IsHealthy() {
 		status = "unhealthy"
 	}
 
+	claudeClient, ok := h.llmProvider.(*services.ClaudeClient)
+	if !ok {
+		return ClaudeMetrics{
+			Status:   status,
+			Provider: h.llmProvider.Name(),
+		}
+	}
+
+	requestsPerMinute, tokensPerHour, requestLimit, tokenLimit := claudeClient.GetUsageStats()
+
 	return ClaudeMetrics{
 		Status:              status,
-		CircuitBreakerState: h.claudeClient.GetCircuitBreakerState().String(),
+		Provider:            claudeClient.Name(),
+		CircuitBreakerState: claudeClient.GetCircuitBreakerState().String(),
 		RequestsPerMinute:   requestsPerMinute,
 		TokensPerHour:       tokensPerHour,
 		RequestLimit:        requestLimit,
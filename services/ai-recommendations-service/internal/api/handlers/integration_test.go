@@ -74,6 +74,7 @@ func (suite *IntegrationTestSuite) SetupSuite() {
 	recommendationHandler := handlers.NewRecommendationHandler(
 		suite.db,
 		mockAIEngine,
+		nil, // No accuracy tracking needed for HTTP tests
 		nil, // No WebSocket hub needed for HTTP tests
 		suite.cfg,
 		suite.logger,
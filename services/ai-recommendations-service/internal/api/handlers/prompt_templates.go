@@ -0,0 +1,210 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/stitts-dev/dfs-sim/services/ai-recommendations-service/internal/models"
+	"github.com/stitts-dev/dfs-sim/services/ai-recommendations-service/internal/services"
+)
+
+// PromptTemplateHandler handles prompt template administration and experiment endpoints
+type PromptTemplateHandler struct {
+	templateStore   *services.PromptTemplateStore
+	accuracyTracker *services.AccuracyTracker
+	logger          *logrus.Logger
+}
+
+// CreateExperimentRequest defines a new prompt experiment
+type CreateExperimentRequest struct {
+	Name        string                     `json:"name" binding:"required"`
+	TemplateKey string                     `json:"template_key" binding:"required"`
+	Variants    []models.ExperimentVariant `json:"variants" binding:"required,min=2,dive"`
+}
+
+// NewPromptTemplateHandler creates a new prompt template handler
+func NewPromptTemplateHandler(
+	templateStore *services.PromptTemplateStore,
+	accuracyTracker *services.AccuracyTracker,
+	logger *logrus.Logger,
+) *PromptTemplateHandler {
+	return &PromptTemplateHandler{
+		templateStore:   templateStore,
+		accuracyTracker: accuracyTracker,
+		logger:          logger,
+	}
+}
+
+// ListTemplates returns stored template versions, optionally filtered by template_key
+func (h *PromptTemplateHandler) ListTemplates(c *gin.Context) {
+	templates, err := h.templateStore.ListVersions(c.Query("template_key"))
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to list prompt templates")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list prompt templates"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data":   templates,
+	})
+}
+
+// CreateTemplateVersion stores a new template version
+func (h *PromptTemplateHandler) CreateTemplateVersion(c *gin.Context) {
+	var input services.TemplateVersionInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format", "details": err.Error()})
+		return
+	}
+
+	template, err := h.templateStore.CreateVersion(input)
+	if err != nil {
+		h.logger.WithError(err).WithField("template_key", input.TemplateKey).Error("Failed to create prompt template version")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to create template version", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"status": "success",
+		"data":   template,
+	})
+}
+
+// ActivateTemplateVersion makes a stored version the one served for its key
+func (h *PromptTemplateHandler) ActivateTemplateVersion(c *gin.Context) {
+	version, err := strconv.Atoi(c.Param("version"))
+	if err != nil || version < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid template version"})
+		return
+	}
+
+	template, err := h.templateStore.ActivateVersion(c.Param("key"), version)
+	if err != nil {
+		if errors.Is(err, services.ErrTemplateNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Template version not found"})
+			return
+		}
+		h.logger.WithError(err).Error("Failed to activate prompt template version")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to activate template version"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data":   template,
+	})
+}
+
+// ListExperiments returns all prompt experiments
+func (h *PromptTemplateHandler) ListExperiments(c *gin.Context) {
+	experiments, err := h.templateStore.ListExperiments()
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to list prompt experiments")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list experiments"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data":   experiments,
+	})
+}
+
+// CreateExperiment stores a draft prompt experiment
+func (h *PromptTemplateHandler) CreateExperiment(c *gin.Context) {
+	var request CreateExperimentRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format", "details": err.Error()})
+		return
+	}
+
+	experiment, err := h.templateStore.CreateExperiment(request.Name, request.TemplateKey, request.Variants)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to create experiment", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"status": "success",
+		"data":   experiment,
+	})
+}
+
+// StartExperiment begins assigning users to an experiment's variants
+func (h *PromptTemplateHandler) StartExperiment(c *gin.Context) {
+	h.changeExperimentStatus(c, h.templateStore.StartExperiment)
+}
+
+// StopExperiment stops an experiment
+func (h *PromptTemplateHandler) StopExperiment(c *gin.Context) {
+	h.changeExperimentStatus(c, h.templateStore.StopExperiment)
+}
+
+// GetExperimentResults compares scored recommendation outcomes across an experiment's variants
+func (h *PromptTemplateHandler) GetExperimentResults(c *gin.Context) {
+	id, ok := h.parseExperimentID(c)
+	if !ok {
+		return
+	}
+
+	experiment, err := h.templateStore.GetExperiment(id)
+	if err != nil {
+		h.respondExperimentError(c, err)
+		return
+	}
+
+	report, err := h.accuracyTracker.GetReport(services.AccuracyFilter{ExperimentID: &id})
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to build experiment report")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to build experiment results"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"experiment": experiment,
+			"overall":    report.Overall,
+			"variants":   report.ByExperimentVariant,
+		},
+	})
+}
+
+func (h *PromptTemplateHandler) changeExperimentStatus(c *gin.Context, change func(uint) (*models.PromptExperiment, error)) {
+	id, ok := h.parseExperimentID(c)
+	if !ok {
+		return
+	}
+
+	experiment, err := change(id)
+	if err != nil {
+		h.respondExperimentError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data":   experiment,
+	})
+}
+
+func (h *PromptTemplateHandler) parseExperimentID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid experiment ID"})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+func (h *PromptTemplateHandler) respondExperimentError(c *gin.Context, err error) {
+	if errors.Is(err, services.ErrExperimentNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Experiment not found"})
+		return
+	}
+	c.JSON(http.StatusConflict, gin.H{"error": "Experiment update failed", "details": err.Error()})
+}
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -18,27 +19,30 @@ import (
 
 // RecommendationHandler handles AI recommendation endpoints
 type RecommendationHandler struct {
-	db        *gorm.DB
-	aiEngine  *services.AIEngine
-	wsHub     *websocket.RecommendationHub
-	config    *config.Config
-	logger    *logrus.Logger
+	db              *gorm.DB
+	aiEngine        *services.AIEngine
+	accuracyTracker *services.AccuracyTracker
+	wsHub           *websocket.RecommendationHub
+	config          *config.Config
+	logger          *logrus.Logger
 }
 
 // NewRecommendationHandler creates a new recommendation handler
 func NewRecommendationHandler(
 	db *gorm.DB,
 	aiEngine *services.AIEngine,
+	accuracyTracker *services.AccuracyTracker,
 	wsHub *websocket.RecommendationHub,
 	config *config.Config,
 	logger *logrus.Logger,
 ) *RecommendationHandler {
 	return &RecommendationHandler{
-		db:       db,
-		aiEngine: aiEngine,
-		wsHub:    wsHub,
-		config:   config,
-		logger:   logger,
+		db:              db,
+		aiEngine:        aiEngine,
+		accuracyTracker: accuracyTracker,
+		wsHub:           wsHub,
+		config:          config,
+		logger:          logger,
 	}
 }
 
@@ -110,6 +114,105 @@ func (h *RecommendationHandler) GetPlayerRecommendations(c *gin.Context) {
 	})
 }
 
+// StreamPlayerRecommendations starts a streamed recommendation whose partial results are pushed
+// to the user's /ws/ai-recommendations connection. Generation stops if the user disconnects.
+func (h *RecommendationHandler) StreamPlayerRecommendations(c *gin.Context) {
+	var request models.SmartRecommendationRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		h.logger.WithError(err).Warn("Invalid streamed recommendation request")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format", "details": err.Error()})
+		return
+	}
+
+	if err := h.validateRecommendationRequest(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Validation failed", "details": err.Error()})
+		return
+	}
+
+	userID := h.getUserIDString(c)
+	if userID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "User ID is required for streamed recommendations"})
+		return
+	}
+
+	streamID, streamCtx, release, err := h.wsHub.StartUserStream(userID)
+	if err != nil {
+		c.JSON(http.StatusConflict, gin.H{"error": "WebSocket connection required", "details": err.Error()})
+		return
+	}
+
+	aiRequest := &services.RecommendationRequest{
+		ContestID:           uint(request.ContestID),
+		UserID:              h.getUserID(c),
+		Players:             h.convertToPlayerRecommendations(request),
+		Context:             h.buildPromptContext(request),
+		RequestType:         "player_recommendations",
+		IncludeRealTimeData: request.IncludeRealTimeData,
+		IncludeLeverageAnalysis: true,
+		MaxRecommendations:  request.MaxRecommendations,
+		CacheResults:        false,
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"contest_id": request.ContestID,
+		"user_id":    userID,
+		"stream_id":  streamID,
+	}).Info("Starting streamed player recommendation request")
+
+	go h.runRecommendationStream(streamCtx, release, userID, streamID, aiRequest)
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"status": "streaming",
+		"data": gin.H{
+			"stream_id": streamID,
+			"websocket": fmt.Sprintf("/ws/ai-recommendations/%s", userID),
+		},
+	})
+}
+
+// runRecommendationStream forwards partial results over WebSocket until generation completes or is cancelled
+func (h *RecommendationHandler) runRecommendationStream(ctx context.Context, release context.CancelFunc, userID, streamID string, aiRequest *services.RecommendationRequest) {
+	defer release()
+
+	response, err := h.aiEngine.GenerateRecommendationsStream(ctx, aiRequest, func(chunk *models.RecommendationChunk) error {
+		h.wsHub.BroadcastToUser(userID, &models.RecommendationUpdate{
+			Type:      "recommendation_partial",
+			UserID:    userID,
+			Data:      gin.H{"stream_id": streamID, "chunk": chunk},
+			Timestamp: time.Now(),
+		})
+		return nil
+	})
+
+	if err != nil {
+		if ctx.Err() != nil {
+			h.logger.WithFields(logrus.Fields{
+				"user_id":   userID,
+				"stream_id": streamID,
+			}).Info("Streamed recommendation cancelled")
+			return
+		}
+
+		h.logger.WithError(err).Error("Failed to stream AI recommendations")
+		h.wsHub.BroadcastToUser(userID, &models.RecommendationUpdate{
+			Type:      "recommendation_error",
+			UserID:    userID,
+			Data:      gin.H{"stream_id": streamID, "error": err.Error()},
+			Timestamp: time.Now(),
+		})
+		return
+	}
+
+	h.saveRecommendationToDatabase(aiRequest, response)
+
+	h.wsHub.BroadcastToUser(userID, &models.RecommendationUpdate{
+		Type:      "recommendation_complete",
+		UserID:    userID,
+		Data:      gin.H{"stream_id": streamID, "response": response},
+		Timestamp: time.Now(),
+	})
+}
+
 // GetLineupRecommendations provides lineup-level AI recommendations
 func (h *RecommendationHandler) GetLineupRecommendations(c *gin.Context) {
 	var request struct {
@@ -358,9 +461,24 @@ func (h *RecommendationHandler) saveRecommendationToDatabase(request *services.R
 		TokensUsed:     &response.TokensUsed,
 		ResponseTimeMs: func(v int) *int { return &v }(int(response.ProcessingTimeMs)),
 	}
+	if response.PromptTemplate != "" {
+		recommendation.PromptTemplate = &response.PromptTemplate
+	}
+	if response.Experiment != nil {
+		recommendation.ExperimentID = &response.Experiment.ExperimentID
+		recommendation.ExperimentVariant = &response.Experiment.Variant
+	}
 
 	if err := h.db.Create(&recommendation).Error; err != nil {
 		h.logger.WithError(err).Error("Failed to save recommendation to database")
+		return
+	}
+
+	// Persist each recommended player so it can be scored once the slate completes
+	if h.accuracyTracker != nil {
+		if err := h.accuracyTracker.RecordRecommendations(recommendation.ID, request, response); err != nil {
+			h.logger.WithError(err).Error("Failed to record recommendation outcomes")
+		}
 	}
 }
 
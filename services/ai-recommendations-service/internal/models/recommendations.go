@@ -16,9 +16,38 @@ type AIRecommendation struct {
 	Confidence     float64         `json:"confidence" gorm:"not null"`
 	TokensUsed     *int            `json:"tokens_used"`
 	ResponseTimeMs *int            `json:"response_time_ms"`
+	PromptTemplate    *string      `json:"prompt_template" gorm:"size:100"` // template ID and version that produced the prompt
+	ExperimentID      *uint        `json:"experiment_id"`
+	ExperimentVariant *string      `json:"experiment_variant" gorm:"size:50"`
 	CreatedAt      time.Time       `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
 }
 
+// RecommendationOutcome tracks a single recommended player from generation through post-slate scoring
+type RecommendationOutcome struct {
+	ID                 uint       `json:"id" gorm:"primaryKey"`
+	AIRecommendationID uint       `json:"ai_recommendation_id" gorm:"not null;index"`
+	UserID             uint       `json:"user_id" gorm:"not null"`
+	ContestID          uint       `json:"contest_id" gorm:"not null;index"`
+	PlayerID           uint       `json:"player_id" gorm:"not null"`
+	PlayerName         string     `json:"player_name" gorm:"size:100"`
+	Sport              string     `json:"sport" gorm:"size:50;not null"`
+	ContestType        string     `json:"contest_type" gorm:"size:50"`
+	RecommendationType string     `json:"recommendation_type" gorm:"size:50;not null"` // "player_recommendations", "lineup_optimization", "late_swap"
+	PromptTemplate     string     `json:"prompt_template" gorm:"size:100"`              // template ID and version, e.g. "golf_gpp_001@v1"
+	ExperimentID       *uint      `json:"experiment_id" gorm:"index"`
+	ExperimentVariant  string     `json:"experiment_variant" gorm:"size:50"`
+	ModelUsed          string     `json:"model_used" gorm:"size:50"`
+	Confidence         float64    `json:"confidence" gorm:"not null"`
+	Salary             float64    `json:"salary"`
+	ProjectedPoints    float64    `json:"projected_points"`
+	ProjectedOwnership float64    `json:"projected_ownership"`
+	ActualPoints       *float64   `json:"actual_points"`
+	ActualOwnership    *float64   `json:"actual_ownership"`
+	Hit                *bool      `json:"hit"`
+	ScoredAt           *time.Time `json:"scored_at"`
+	CreatedAt          time.Time  `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+}
+
 // OwnershipSnapshot represents real-time ownership data
 type OwnershipSnapshot struct {
 	ID                  uint       `json:"id" gorm:"primaryKey"`
@@ -80,18 +109,45 @@ type LeverageOpportunity struct {
 
 // PromptTemplate represents dynamic AI prompt templates
 type PromptTemplate struct {
+	ID              uint            `json:"id" gorm:"primaryKey"`
+	TemplateKey     string          `json:"template_key" gorm:"size:100;not null;uniqueIndex:idx_prompt_templates_key_version"` // prompt builder lookup key, e.g. 'golf_gpp_player_recommendations'
+	TemplateID      string          `json:"template_id" gorm:"size:50;not null"`                                                // stable ID shared by every version, e.g. 'golf_gpp_001'
+	Name            string          `json:"name" gorm:"size:100;not null"`
+	Sport           string          `json:"sport" gorm:"size:50;not null"`
+	ContestType     *string         `json:"contest_type" gorm:"size:50"` // 'gpp', 'cash', 'satellite'
+	Template        string          `json:"template" gorm:"type:text;not null"`
+	SystemPrompt    string          `json:"system_prompt" gorm:"type:text"`
+	Variables       json.RawMessage `json:"variables" gorm:"type:jsonb"` // Template variables and their descriptions
+	OptimalLength   int             `json:"optimal_length"`
+	ComplexityLevel string          `json:"complexity_level" gorm:"size:20"` // 'simple', 'moderate', 'complex'
+	Version         int             `json:"version" gorm:"default:1;uniqueIndex:idx_prompt_templates_key_version"`
+	IsActive        bool            `json:"is_active" gorm:"default:false"`
+	Notes           *string         `json:"notes" gorm:"type:text"`
+	ActivatedAt     *time.Time      `json:"activated_at"`
+	CreatedAt       time.Time       `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	UpdatedAt       time.Time       `json:"updated_at" gorm:"default:CURRENT_TIMESTAMP"`
+}
+
+// PromptExperiment splits users across template versions to compare them on scored outcomes
+type PromptExperiment struct {
 	ID          uint            `json:"id" gorm:"primaryKey"`
 	Name        string          `json:"name" gorm:"size:100;not null;uniqueIndex"`
-	Sport       string          `json:"sport" gorm:"size:50;not null"`
-	ContestType *string         `json:"contest_type" gorm:"size:50"` // 'gpp', 'cash', 'satellite'
-	Template    string          `json:"template" gorm:"type:text;not null"`
-	Variables   json.RawMessage `json:"variables" gorm:"type:jsonb"` // Template variables and their descriptions
-	Version     int             `json:"version" gorm:"default:1"`
-	IsActive    bool            `json:"is_active" gorm:"default:true"`
+	TemplateKey string          `json:"template_key" gorm:"size:100;not null;index"`
+	Status      string          `json:"status" gorm:"size:20;not null;default:'draft'"` // 'draft', 'running', 'stopped'
+	Variants    json.RawMessage `json:"variants" gorm:"type:jsonb;not null"`            // []ExperimentVariant
+	StartedAt   *time.Time      `json:"started_at"`
+	StoppedAt   *time.Time      `json:"stopped_at"`
 	CreatedAt   time.Time       `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
 	UpdatedAt   time.Time       `json:"updated_at" gorm:"default:CURRENT_TIMESTAMP"`
 }
 
+// ExperimentVariant maps a named arm of an experiment to a template version
+type ExperimentVariant struct {
+	Name            string `json:"name" binding:"required"`
+	TemplateVersion int    `json:"template_version" binding:"required,min=1"`
+	Weight          int    `json:"weight" binding:"min=0"` // relative share of users, defaults to 1
+}
+
 // ModelPerformance tracks AI model effectiveness
 type ModelPerformance struct {
 	ID                       uint      `json:"id" gorm:"primaryKey"`
@@ -142,4 +198,13 @@ type RecommendationUpdate struct {
 	Message      string      `json:"message"`
 	Data         interface{} `json:"data"`
 	Timestamp    time.Time   `json:"timestamp"`
-}
\ No newline at end of file
+}
+// RecommendationChunk represents a partial result delivered while a recommendation is streamed
+type RecommendationChunk struct {
+	RequestID      string                `json:"request_id"`
+	Sequence       int                   `json:"sequence"`
+	ChunkType      string                `json:"chunk_type"` // "recommendation", "insight", "stack"
+	Recommendation *PlayerRecommendation `json:"recommendation,omitempty"`
+	Insight        *ContextInsight       `json:"insight,omitempty"`
+	Stack          *StackSuggestion      `json:"stack,omitempty"`
+}
@@ -0,0 +1,480 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stitts-dev/dfs-sim/services/ai-recommendations-service/internal/models"
+	"gorm.io/gorm"
+)
+
+const (
+	calibrationBucketCount = 10
+	calibrationPriorWeight = 50.0             // Samples needed before history outweighs the stated confidence
+	calibrationRefresh     = 15 * time.Minute // How long cached confidence weights are reused
+	calibrationWindow      = 90 * 24 * time.Hour
+)
+
+// valueTargets are the points-per-$1K a player must return to count as a hit when no projection was given
+var valueTargets = map[string]float64{
+	"nba":  5.0,
+	"nfl":  3.0,
+	"mlb":  2.5,
+	"nhl":  2.5,
+	"golf": 7.0,
+}
+
+// AccuracyTracker persists recommended players and scores them against actual results
+type AccuracyTracker struct {
+	db     *gorm.DB
+	logger *logrus.Logger
+
+	mu          sync.RWMutex
+	weights     map[string]float64 // segment key -> confidence multiplier
+	refreshedAt time.Time
+}
+
+// PlayerResult is the actual post-slate outcome for a player
+type PlayerResult struct {
+	PlayerID        uint     `json:"player_id" binding:"required"`
+	ActualPoints    float64  `json:"actual_points"`
+	ActualOwnership *float64 `json:"actual_ownership"`
+}
+
+// ScoringSummary reports how many outcomes a results upload scored
+type ScoringSummary struct {
+	ContestID      uint    `json:"contest_id"`
+	Scored         int     `json:"scored"`
+	Hits           int     `json:"hits"`
+	HitRate        float64 `json:"hit_rate"`
+	UnmatchedCount int     `json:"unmatched_count"` // recommended players with no result supplied
+}
+
+// AccuracyFilter narrows an accuracy report
+type AccuracyFilter struct {
+	Sport              string
+	RecommendationType string
+	PromptTemplate     string
+	ExperimentID       *uint
+	Since              *time.Time
+}
+
+// CalibrationBucket compares stated confidence with the observed hit rate for a confidence range
+type CalibrationBucket struct {
+	LowerBound    float64 `json:"lower_bound"`
+	UpperBound    float64 `json:"upper_bound"`
+	Count         int     `json:"count"`
+	AvgConfidence float64 `json:"avg_confidence"`
+	HitRate       float64 `json:"hit_rate"`
+}
+
+// AccuracySummary aggregates scored outcomes for one segment
+type AccuracySummary struct {
+	Count              int                 `json:"count"`
+	Hits               int                 `json:"hits"`
+	HitRate            float64             `json:"hit_rate"`
+	AvgConfidence      float64             `json:"avg_confidence"`
+	BrierScore         float64             `json:"brier_score"`          // mean squared error of confidence vs hit, lower is better
+	MeanPointsError    float64             `json:"mean_points_error"`    // actual minus projected fantasy points
+	MeanOwnershipError float64             `json:"mean_ownership_error"` // actual minus projected ownership percentage
+	ConfidenceWeight   float64             `json:"confidence_weight"`    // multiplier applied to future confidence
+	Calibration        []CalibrationBucket `json:"calibration"`
+}
+
+// AccuracyReport breaks scored outcomes down by sport, recommendation type and prompt template
+type AccuracyReport struct {
+	Overall              AccuracySummary            `json:"overall"`
+	BySport              map[string]AccuracySummary `json:"by_sport"`
+	ByRecommendationType map[string]AccuracySummary `json:"by_recommendation_type"`
+	ByPromptTemplate     map[string]AccuracySummary `json:"by_prompt_template"`
+	ByExperimentVariant  map[string]AccuracySummary `json:"by_experiment_variant,omitempty"`
+	GeneratedAt          time.Time                  `json:"generated_at"`
+}
+
+// NewAccuracyTracker creates a new accuracy tracker
+func NewAccuracyTracker(db *gorm.DB, logger *logrus.Logger) *AccuracyTracker {
+	return &AccuracyTracker{
+		db:      db,
+		logger:  logger,
+		weights: make(map[string]float64),
+	}
+}
+
+// RecordRecommendations stores every recommended player of a stored AI recommendation
+func (at *AccuracyTracker) RecordRecommendations(aiRecommendationID uint, request *RecommendationRequest, response *RecommendationResponse) error {
+	var outcomes []models.RecommendationOutcome
+	skipped := 0
+
+	var experimentID *uint
+	var experimentVariant string
+	if response.Experiment != nil {
+		experimentID = &response.Experiment.ExperimentID
+		experimentVariant = response.Experiment.Variant
+	}
+
+	for _, rec := range response.Recommendations {
+		// Players that couldn't be resolved to the pool can never be scored
+		if rec.PlayerID == 0 {
+			skipped++
+			continue
+		}
+
+		outcomes = append(outcomes, models.RecommendationOutcome{
+			AIRecommendationID: aiRecommendationID,
+			UserID:             request.UserID,
+			ContestID:          request.ContestID,
+			PlayerID:           rec.PlayerID,
+			PlayerName:         rec.PlayerName,
+			Sport:              request.Context.Sport,
+			ContestType:        request.Context.ContestType,
+			RecommendationType: request.RequestType,
+			PromptTemplate:     response.PromptTemplate,
+			ExperimentID:       experimentID,
+			ExperimentVariant:  experimentVariant,
+			ModelUsed:          response.ModelUsed,
+			Confidence:         rec.Confidence,
+			Salary:             rec.Salary,
+			ProjectedPoints:    rec.Projection,
+			ProjectedOwnership: rec.Ownership,
+		})
+	}
+
+	if skipped > 0 {
+		at.logger.WithFields(logrus.Fields{
+			"ai_recommendation_id": aiRecommendationID,
+			"skipped":              skipped,
+		}).Warn("Skipped recommendations without a player ID")
+	}
+
+	if len(outcomes) == 0 {
+		return nil
+	}
+
+	if err := at.db.Create(&outcomes).Error; err != nil {
+		return fmt.Errorf("failed to record recommendation outcomes: %w", err)
+	}
+	return nil
+}
+
+// ScoreContest applies actual results to every unscored recommendation for a contest
+func (at *AccuracyTracker) ScoreContest(contestID uint, results []PlayerResult) (*ScoringSummary, error) {
+	resultsByPlayer := make(map[uint]PlayerResult, len(results))
+	for _, result := range results {
+		resultsByPlayer[result.PlayerID] = result
+	}
+
+	var outcomes []models.RecommendationOutcome
+	if err := at.db.Where("contest_id = ? AND scored_at IS NULL", contestID).Find(&outcomes).Error; err != nil {
+		return nil, fmt.Errorf("failed to load recommendation outcomes: %w", err)
+	}
+
+	summary := &ScoringSummary{ContestID: contestID}
+	now := time.Now()
+
+	err := at.db.Transaction(func(tx *gorm.DB) error {
+		for i := range outcomes {
+			outcome := &outcomes[i]
+			result, ok := resultsByPlayer[outcome.PlayerID]
+			if !ok {
+				summary.UnmatchedCount++
+				continue
+			}
+
+			EvaluateOutcome(outcome, result, now)
+			if err := tx.Save(outcome).Error; err != nil {
+				return fmt.Errorf("failed to save outcome %d: %w", outcome.ID, err)
+			}
+
+			summary.Scored++
+			if *outcome.Hit {
+				summary.Hits++
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if summary.Scored > 0 {
+		summary.HitRate = float64(summary.Hits) / float64(summary.Scored)
+		at.invalidateWeights()
+	}
+
+	at.logger.WithFields(logrus.Fields{
+		"contest_id": contestID,
+		"scored":     summary.Scored,
+		"hits":       summary.Hits,
+		"unmatched":  summary.UnmatchedCount,
+	}).Info("Scored AI recommendation outcomes")
+
+	return summary, nil
+}
+
+// GetReport builds an accuracy report from scored outcomes matching the filter
+func (at *AccuracyTracker) GetReport(filter AccuracyFilter) (*AccuracyReport, error) {
+	outcomes, err := at.loadScoredOutcomes(filter)
+	if err != nil {
+		return nil, err
+	}
+	return BuildAccuracyReport(outcomes), nil
+}
+
+// ConfidenceWeight returns the calibration multiplier for a segment, falling back from
+// sport+type+template to sport+type and finally to 1 when there is no history
+func (at *AccuracyTracker) ConfidenceWeight(sport, recommendationType, promptTemplate string) float64 {
+	at.refreshWeightsIfStale()
+
+	at.mu.RLock()
+	defer at.mu.RUnlock()
+
+	for _, key := range []string{
+		segmentKey(sport, recommendationType, promptTemplate),
+		segmentKey(sport, recommendationType, ""),
+	} {
+		if weight, ok := at.weights[key]; ok {
+			return weight
+		}
+	}
+	return 1.0
+}
+
+func (at *AccuracyTracker) refreshWeightsIfStale() {
+	at.mu.RLock()
+	fresh := time.Since(at.refreshedAt) < calibrationRefresh
+	at.mu.RUnlock()
+	if fresh {
+		return
+	}
+
+	since := time.Now().Add(-calibrationWindow)
+	outcomes, err := at.loadScoredOutcomes(AccuracyFilter{Since: &since})
+
+	at.mu.Lock()
+	defer at.mu.Unlock()
+
+	// Record the attempt even on failure so a broken database doesn't add a query to every request
+	at.refreshedAt = time.Now()
+	if err != nil {
+		at.logger.WithError(err).Warn("Failed to refresh recommendation confidence weights")
+		return
+	}
+
+	segments := make(map[string][]models.RecommendationOutcome)
+	for _, outcome := range outcomes {
+		full := segmentKey(outcome.Sport, outcome.RecommendationType, outcome.PromptTemplate)
+		partial := segmentKey(outcome.Sport, outcome.RecommendationType, "")
+		segments[full] = append(segments[full], outcome)
+		segments[partial] = append(segments[partial], outcome)
+	}
+
+	weights := make(map[string]float64, len(segments))
+	for key, segment := range segments {
+		weights[key] = summarize(segment).ConfidenceWeight
+	}
+	at.weights = weights
+}
+
+func (at *AccuracyTracker) invalidateWeights() {
+	at.mu.Lock()
+	defer at.mu.Unlock()
+	at.refreshedAt = time.Time{}
+}
+
+func (at *AccuracyTracker) loadScoredOutcomes(filter AccuracyFilter) ([]models.RecommendationOutcome, error) {
+	query := at.db.Where("scored_at IS NOT NULL")
+	if filter.Sport != "" {
+		query = query.Where("sport = ?", filter.Sport)
+	}
+	if filter.RecommendationType != "" {
+		query = query.Where("recommendation_type = ?", filter.RecommendationType)
+	}
+	if filter.PromptTemplate != "" {
+		query = query.Where("prompt_template = ?", filter.PromptTemplate)
+	}
+	if filter.ExperimentID != nil {
+		query = query.Where("experiment_id = ?", *filter.ExperimentID)
+	}
+	if filter.Since != nil {
+		query = query.Where("created_at >= ?", *filter.Since)
+	}
+
+	var outcomes []models.RecommendationOutcome
+	if err := query.Find(&outcomes).Error; err != nil {
+		return nil, fmt.Errorf("failed to load scored outcomes: %w", err)
+	}
+	return outcomes, nil
+}
+
+// EvaluateOutcome scores a recommendation against its actual result. A recommendation hits
+// when the player meets their projection, or the sport's value target if none was given.
+func EvaluateOutcome(outcome *models.RecommendationOutcome, result PlayerResult, scoredAt time.Time) {
+	actualPoints := result.ActualPoints
+	outcome.ActualPoints = &actualPoints
+	outcome.ActualOwnership = result.ActualOwnership
+
+	var hit bool
+	switch {
+	case outcome.ProjectedPoints > 0:
+		hit = actualPoints >= outcome.ProjectedPoints
+	case outcome.Salary > 0:
+		target, ok := valueTargets[strings.ToLower(outcome.Sport)]
+		if !ok {
+			target = 4.0
+		}
+		hit = actualPoints/(outcome.Salary/1000) >= target
+	default:
+		hit = actualPoints > 0
+	}
+
+	outcome.Hit = &hit
+	outcome.ScoredAt = &scoredAt
+}
+
+// BuildAccuracyReport aggregates scored outcomes into overall and per-segment summaries
+func BuildAccuracyReport(outcomes []models.RecommendationOutcome) *AccuracyReport {
+	bySport := make(map[string][]models.RecommendationOutcome)
+	byType := make(map[string][]models.RecommendationOutcome)
+	byTemplate := make(map[string][]models.RecommendationOutcome)
+	byVariant := make(map[string][]models.RecommendationOutcome)
+
+	for _, outcome := range outcomes {
+		bySport[outcome.Sport] = append(bySport[outcome.Sport], outcome)
+		byType[outcome.RecommendationType] = append(byType[outcome.RecommendationType], outcome)
+		template := outcome.PromptTemplate
+		if template == "" {
+			template = "unknown"
+		}
+		byTemplate[template] = append(byTemplate[template], outcome)
+		if outcome.ExperimentVariant != "" {
+			byVariant[outcome.ExperimentVariant] = append(byVariant[outcome.ExperimentVariant], outcome)
+		}
+	}
+
+	report := &AccuracyReport{
+		Overall:              summarize(outcomes),
+		BySport:              make(map[string]AccuracySummary, len(bySport)),
+		ByRecommendationType: make(map[string]AccuracySummary, len(byType)),
+		ByPromptTemplate:     make(map[string]AccuracySummary, len(byTemplate)),
+		GeneratedAt:          time.Now(),
+	}
+	for key, group := range bySport {
+		report.BySport[key] = summarize(group)
+	}
+	for key, group := range byType {
+		report.ByRecommendationType[key] = summarize(group)
+	}
+	for key, group := range byTemplate {
+		report.ByPromptTemplate[key] = summarize(group)
+	}
+	if len(byVariant) > 0 {
+		report.ByExperimentVariant = make(map[string]AccuracySummary, len(byVariant))
+		for key, group := range byVariant {
+			report.ByExperimentVariant[key] = summarize(group)
+		}
+	}
+
+	return report
+}
+
+// summarize computes hit rate, Brier score, calibration curve and confidence weight for scored outcomes
+func summarize(outcomes []models.RecommendationOutcome) AccuracySummary {
+	summary := AccuracySummary{ConfidenceWeight: 1.0}
+
+	buckets := make([]CalibrationBucket, calibrationBucketCount)
+	bucketHits := make([]int, calibrationBucketCount)
+	for i := range buckets {
+		buckets[i].LowerBound = float64(i) / calibrationBucketCount
+		buckets[i].UpperBound = float64(i+1) / calibrationBucketCount
+	}
+
+	var confidenceSum, brierSum, pointsErrSum, ownershipErrSum float64
+	pointsCount, ownershipCount := 0, 0
+
+	for _, outcome := range outcomes {
+		if outcome.Hit == nil {
+			continue
+		}
+
+		summary.Count++
+		observed := 0.0
+		if *outcome.Hit {
+			summary.Hits++
+			observed = 1.0
+		}
+
+		confidence := math.Max(0, math.Min(1, outcome.Confidence))
+		confidenceSum += confidence
+		brierSum += (confidence - observed) * (confidence - observed)
+
+		if outcome.ActualPoints != nil && outcome.ProjectedPoints > 0 {
+			pointsErrSum += *outcome.ActualPoints - outcome.ProjectedPoints
+			pointsCount++
+		}
+		if outcome.ActualOwnership != nil {
+			ownershipErrSum += *outcome.ActualOwnership - outcome.ProjectedOwnership
+			ownershipCount++
+		}
+
+		idx := int(confidence * calibrationBucketCount)
+		if idx >= calibrationBucketCount {
+			idx = calibrationBucketCount - 1
+		}
+		buckets[idx].Count++
+		buckets[idx].AvgConfidence += confidence
+		bucketHits[idx] += int(observed)
+	}
+
+	if summary.Count == 0 {
+		return summary
+	}
+
+	n := float64(summary.Count)
+	summary.HitRate = float64(summary.Hits) / n
+	summary.AvgConfidence = confidenceSum / n
+	summary.BrierScore = brierSum / n
+	if pointsCount > 0 {
+		summary.MeanPointsError = pointsErrSum / float64(pointsCount)
+	}
+	if ownershipCount > 0 {
+		summary.MeanOwnershipError = ownershipErrSum / float64(ownershipCount)
+	}
+	summary.ConfidenceWeight = CalibrationWeight(summary.AvgConfidence, summary.HitRate, summary.Count)
+
+	for i := range buckets {
+		if buckets[i].Count == 0 {
+			continue
+		}
+		buckets[i].AvgConfidence /= float64(buckets[i].Count)
+		buckets[i].HitRate = float64(bucketHits[i]) / float64(buckets[i].Count)
+		summary.Calibration = append(summary.Calibration, buckets[i])
+	}
+	sort.Slice(summary.Calibration, func(i, j int) bool {
+		return summary.Calibration[i].LowerBound < summary.Calibration[j].LowerBound
+	})
+
+	return summary
+}
+
+// CalibrationWeight returns the multiplier that maps stated confidence onto the observed hit rate,
+// shrunk toward 1 when the sample is small and clamped to avoid overreacting to a bad week
+func CalibrationWeight(avgConfidence, hitRate float64, samples int) float64 {
+	if samples == 0 || avgConfidence <= 0 {
+		return 1.0
+	}
+
+	raw := hitRate / avgConfidence
+	shrink := float64(samples) / (float64(samples) + calibrationPriorWeight)
+	weight := 1 + (raw-1)*shrink
+
+	return math.Max(0.5, math.Min(1.2, weight))
+}
+
+func segmentKey(sport, recommendationType, promptTemplate string) string {
+	return strings.Join([]string{sport, recommendationType, promptTemplate}, "|")
+}
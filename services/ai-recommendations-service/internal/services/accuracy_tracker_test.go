@@ -0,0 +1,104 @@
+package services_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stitts-dev/dfs-sim/services/ai-recommendations-service/internal/models"
+	"github.com/stitts-dev/dfs-sim/services/ai-recommendations-service/internal/services"
+)
+
+func scoredOutcome(sport, template string, confidence, projected, actual float64) models.RecommendationOutcome {
+	outcome := models.RecommendationOutcome{
+		Sport:              sport,
+		RecommendationType: "player_recommendations",
+		PromptTemplate:     template,
+		Confidence:         confidence,
+		Salary:             8000,
+		ProjectedPoints:    projected,
+	}
+	services.EvaluateOutcome(&outcome, services.PlayerResult{ActualPoints: actual}, time.Now())
+	return outcome
+}
+
+func TestEvaluateOutcome(t *testing.T) {
+	hit := scoredOutcome("nba", "", 0.8, 40, 41)
+	require.NotNil(t, hit.Hit)
+	assert.True(t, *hit.Hit)
+
+	miss := scoredOutcome("nba", "", 0.8, 40, 30)
+	assert.False(t, *miss.Hit)
+
+	// Without a projection the sport value target decides: golf needs 7 pts/$1K, so 56 on $8K hits
+	noProjection := scoredOutcome("golf", "", 0.6, 0, 56)
+	assert.True(t, *noProjection.Hit)
+}
+
+func TestBuildAccuracyReport(t *testing.T) {
+	var outcomes []models.RecommendationOutcome
+	for i := 0; i < 10; i++ {
+		// High-confidence picks hit 8 of 10
+		actual := 50.0
+		if i < 2 {
+			actual = 20
+		}
+		outcomes = append(outcomes, scoredOutcome("nfl", "nfl_gpp_001@v1", 0.85, 40, actual))
+	}
+	for i := 0; i < 10; i++ {
+		// Low-confidence picks hit 3 of 10
+		actual := 10.0
+		if i < 3 {
+			actual = 30
+		}
+		outcomes = append(outcomes, scoredOutcome("golf", "golf_gpp_001@v1", 0.35, 25, actual))
+	}
+
+	report := services.BuildAccuracyReport(outcomes)
+
+	assert.Equal(t, 20, report.Overall.Count)
+	assert.Equal(t, 11, report.Overall.Hits)
+	assert.InDelta(t, 0.55, report.Overall.HitRate, 1e-9)
+	assert.InDelta(t, 0.8, report.BySport["nfl"].HitRate, 1e-9)
+	assert.InDelta(t, 0.3, report.ByPromptTemplate["golf_gpp_001@v1"].HitRate, 1e-9)
+
+	require.Len(t, report.Overall.Calibration, 2)
+	assert.InDelta(t, 0.3, report.Overall.Calibration[0].LowerBound, 1e-9)
+	assert.InDelta(t, 0.3, report.Overall.Calibration[0].HitRate, 1e-9)
+	assert.InDelta(t, 0.8, report.Overall.Calibration[1].LowerBound, 1e-9)
+	assert.InDelta(t, 0.8, report.Overall.Calibration[1].HitRate, 1e-9)
+
+	// Brier score for the nfl segment: 8 hits at (0.85-1)^2 plus 2 misses at 0.85^2
+	expectedBrier := (8*0.0225 + 2*0.7225) / 10
+	assert.InDelta(t, expectedBrier, report.BySport["nfl"].BrierScore, 1e-9)
+}
+
+func TestBuildAccuracyReport_PointsErrorSkipsUnprojected(t *testing.T) {
+	outcomes := []models.RecommendationOutcome{
+		scoredOutcome("golf", "", 0.6, 50, 54),
+		scoredOutcome("golf", "", 0.6, 60, 58),
+		// Scored against the value target, so it has no projection to miss by
+		scoredOutcome("golf", "", 0.6, 0, 56),
+	}
+
+	report := services.BuildAccuracyReport(outcomes)
+
+	assert.Equal(t, 3, report.Overall.Count)
+	assert.InDelta(t, 1.0, report.Overall.MeanPointsError, 1e-9)
+}
+
+func TestCalibrationWeight(t *testing.T) {
+	assert.Equal(t, 1.0, services.CalibrationWeight(0.8, 0.4, 0))
+
+	// Overconfident history pulls the weight down, more strongly with more samples
+	small := services.CalibrationWeight(0.8, 0.4, 10)
+	large := services.CalibrationWeight(0.8, 0.4, 500)
+	assert.Less(t, small, 1.0)
+	assert.Less(t, large, small)
+	assert.GreaterOrEqual(t, large, 0.5)
+
+	// Underconfident history is capped
+	assert.Equal(t, 1.2, services.CalibrationWeight(0.3, 0.9, 10000))
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"crypto/md5"
 	"fmt"
+	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -11,12 +13,22 @@ import (
 	"github.com/stitts-dev/dfs-sim/services/ai-recommendations-service/internal/models"
 )
 
+// Patterns used to pull structured fields out of recommendation lines
+var (
+	positionTeamPattern = regexp.MustCompile(`\(([A-Z0-9/]{1,5}),\s*([^)]*)\)`)
+	salaryPattern       = regexp.MustCompile(`\$\s*([\d,]+(?:\.\d+)?)`)
+	projectionPattern   = regexp.MustCompile(`([\d.]+)\s*(?:proj|projected)`)
+	ownershipPattern    = regexp.MustCompile(`([\d.]+)\s*%`)
+	confidencePattern   = regexp.MustCompile(`(?i)confidence[:\s]+(\d+(?:\.\d+)?)`)
+)
+
 // AIEngine orchestrates the AI recommendation generation process
 type AIEngine struct {
-	claudeClient       *ClaudeClient
+	llm                LLMProvider
 	promptBuilder      *PromptBuilder
 	realtimeAggregator *RealtimeAggregator
 	ownershipAnalyzer  *OwnershipAnalyzer
+	accuracyTracker    *AccuracyTracker
 	logger             *logrus.Logger
 }
 
@@ -44,6 +56,9 @@ type RecommendationResponse struct {
 	Confidence            float64                        `json:"confidence"`
 	ReasoningPath         []string                       `json:"reasoning_path"`
 	ModelUsed             string                         `json:"model_used"`
+	Provider              string                         `json:"provider"`
+	PromptTemplate        string                         `json:"prompt_template"` // template ID and version, e.g. "golf_gpp_001@v1"
+	Experiment            *ExperimentAssignment          `json:"experiment,omitempty"`
 	TimestampGenerated    time.Time                      `json:"timestamp_generated"`
 	ProcessingTimeMs      int64                          `json:"processing_time_ms"`
 	TokensUsed            int                            `json:"tokens_used"`
@@ -76,14 +91,14 @@ type LineupAnalysisResponse struct {
 
 // NewAIEngine creates a new AI engine instance
 func NewAIEngine(
-	claudeClient *ClaudeClient,
+	llm LLMProvider,
 	promptBuilder *PromptBuilder,
 	realtimeAggregator *RealtimeAggregator,
 	ownershipAnalyzer *OwnershipAnalyzer,
 	logger *logrus.Logger,
 ) *AIEngine {
 	return &AIEngine{
-		claudeClient:       claudeClient,
+		llm:                llm,
 		promptBuilder:      promptBuilder,
 		realtimeAggregator: realtimeAggregator,
 		ownershipAnalyzer:  ownershipAnalyzer,
@@ -91,6 +106,11 @@ func NewAIEngine(
 	}
 }
 
+// SetAccuracyTracker enables confidence calibration from scored recommendation outcomes
+func (ae *AIEngine) SetAccuracyTracker(tracker *AccuracyTracker) {
+	ae.accuracyTracker = tracker
+}
+
 // GenerateRecommendations orchestrates the AI recommendation generation process
 func (ae *AIEngine) GenerateRecommendations(ctx context.Context, request *RecommendationRequest) (*RecommendationResponse, error) {
 	startTime := time.Now()
@@ -120,6 +140,119 @@ func (ae *AIEngine) GenerateRecommendations(ctx context.Context, request *Recomm
 		ReasoningPath:     []string{},
 	}
 
+	// Steps 1-3: enrich context and build the prompt
+	prompt, systemPrompt, err := ae.prepareGeneration(ctx, request, response)
+	if err != nil {
+		return nil, err
+	}
+
+	// Step 4: Generate AI response
+	ae.addToReasoningPath(response, fmt.Sprintf("Generating AI recommendations with %s", ae.llm.Name()))
+	claudeResponse, err := ae.llm.Complete(ctx, ae.buildLLMRequest(request, prompt, systemPrompt))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get AI response: %w", err)
+	}
+
+	// Steps 5-8: parse, enrich and score
+	if err := ae.completeGeneration(ctx, request, response, claudeResponse, startTime); err != nil {
+		return nil, err
+	}
+
+	ae.logger.WithFields(logrus.Fields{
+		"request_id":         requestID,
+		"processing_time_ms": response.ProcessingTimeMs,
+		"tokens_used":        response.TokensUsed,
+		"confidence":         response.Confidence,
+		"recommendations":    len(response.Recommendations),
+	}).Info("AI recommendation generation completed")
+
+	return response, nil
+}
+
+// GenerateRecommendationsStream runs the recommendation pipeline against the streaming
+// Claude API, emitting each recommendation, insight and stack as soon as its line is complete.
+// Cancelling ctx aborts the upstream request.
+func (ae *AIEngine) GenerateRecommendationsStream(ctx context.Context, request *RecommendationRequest, emit func(*models.RecommendationChunk) error) (*RecommendationResponse, error) {
+	startTime := time.Now()
+	requestID := ae.generateRequestID(request)
+
+	ae.logger.WithFields(logrus.Fields{
+		"request_id":   requestID,
+		"contest_id":   request.ContestID,
+		"user_id":      request.UserID,
+		"request_type": request.RequestType,
+	}).Info("Starting streamed AI recommendation generation")
+
+	response := &RecommendationResponse{
+		RequestID:          requestID,
+		TimestampGenerated: time.Now(),
+		ReasoningPath:      []string{},
+	}
+
+	prompt, systemPrompt, err := ae.prepareGeneration(ctx, request, response)
+	if err != nil {
+		return nil, err
+	}
+
+	ae.addToReasoningPath(response, fmt.Sprintf("Streaming AI recommendations from %s", ae.llm.Name()))
+	parser := &recommendationTextParser{engine: ae}
+	var pending strings.Builder
+	sequence := 0
+
+	emitLine := func(line string) error {
+		chunk := parser.parseLine(line)
+		if chunk == nil {
+			return nil
+		}
+		sequence++
+		chunk.RequestID = requestID
+		chunk.Sequence = sequence
+		return emit(chunk)
+	}
+
+	claudeResponse, err := ae.llm.Stream(ctx, ae.buildLLMRequest(request, prompt, systemPrompt), func(text string) error {
+		pending.WriteString(text)
+		buffered := pending.String()
+		lastNewline := strings.LastIndex(buffered, "\n")
+		if lastNewline < 0 {
+			return nil
+		}
+		pending.Reset()
+		pending.WriteString(buffered[lastNewline+1:])
+		for _, line := range strings.Split(buffered[:lastNewline], "\n") {
+			if err := emitLine(line); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to stream AI response: %w", err)
+	}
+
+	// Flush a trailing line without a newline
+	if pending.Len() > 0 {
+		if err := emitLine(pending.String()); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := ae.completeGeneration(ctx, request, response, claudeResponse, startTime); err != nil {
+		return nil, err
+	}
+
+	ae.logger.WithFields(logrus.Fields{
+		"request_id":         requestID,
+		"processing_time_ms": response.ProcessingTimeMs,
+		"chunks_emitted":     sequence,
+		"confidence":         response.Confidence,
+	}).Info("Streamed AI recommendation generation completed")
+
+	return response, nil
+}
+
+// prepareGeneration enriches the request context and builds the prompt (steps 1-3)
+func (ae *AIEngine) prepareGeneration(ctx context.Context, request *RecommendationRequest, response *RecommendationResponse) (string, string, error) {
 	// Step 1: Enhance context with real-time data
 	if request.IncludeRealTimeData {
 		ae.addToReasoningPath(response, "Collecting real-time data updates")
@@ -154,19 +287,19 @@ func (ae *AIEngine) GenerateRecommendations(ctx context.Context, request *Recomm
 
 	// Step 3: Build AI prompt
 	ae.addToReasoningPath(response, "Building dynamic AI prompt")
-	prompt, systemPrompt, err := ae.promptBuilder.BuildRecommendationPrompt(request.Context, request.Players)
+	rendered, err := ae.promptBuilder.BuildRecommendationPromptForUser(request.Context, request.Players, request.UserID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to build prompt: %w", err)
+		return "", "", fmt.Errorf("failed to build prompt: %w", err)
 	}
+	response.PromptTemplate = rendered.TemplateVersion
+	response.Experiment = rendered.Experiment
+	response.Provider = ae.llm.Name()
 
-	// Step 4: Generate AI response
-	ae.addToReasoningPath(response, "Generating AI recommendations with Claude")
-	claudeConfig := ae.claudeClient.BuildDefaultConfig(request.RequestType)
-	claudeResponse, err := ae.claudeClient.SendMessage(ctx, prompt, systemPrompt, claudeConfig)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get AI response: %w", err)
-	}
+	return rendered.Prompt, rendered.SystemPrompt, nil
+}
 
+// completeGeneration parses the AI output and computes the final metrics (steps 5-8)
+func (ae *AIEngine) completeGeneration(ctx context.Context, request *RecommendationRequest, response *RecommendationResponse, claudeResponse *ClaudeResponse, startTime time.Time) error {
 	response.ModelUsed = claudeResponse.Model
 	response.TokensUsed = claudeResponse.Usage.InputTokens + claudeResponse.Usage.OutputTokens
 
@@ -174,11 +307,12 @@ func (ae *AIEngine) GenerateRecommendations(ctx context.Context, request *Recomm
 	ae.addToReasoningPath(response, "Parsing and structuring AI response")
 	if err := ae.parseAIResponse(claudeResponse, response); err != nil {
 		ae.logger.WithError(err).Error("Failed to parse AI response")
-		return nil, fmt.Errorf("failed to parse AI response: %w", err)
+		return fmt.Errorf("failed to parse AI response: %w", err)
 	}
 
 	// Step 6: Enhance with computed metrics
 	ae.addToReasoningPath(response, "Computing additional metrics and insights")
+	ae.matchRecommendedPlayers(response, request.Players)
 	ae.enhanceResponseWithMetrics(response, request)
 
 	// Step 7: Generate real-time alerts
@@ -190,25 +324,30 @@ func (ae *AIEngine) GenerateRecommendations(ctx context.Context, request *Recomm
 
 	// Step 8: Calculate final confidence and quality scores
 	ae.addToReasoningPath(response, "Calculating confidence and quality metrics")
-	response.Confidence = ae.calculateOverallConfidence(response, claudeResponse.Usage)
+	response.Confidence = ae.calculateOverallConfidence(request, response, claudeResponse.Usage)
 
 	// Calculate processing time
 	response.ProcessingTimeMs = time.Since(startTime).Milliseconds()
 
 	// Cache response if enabled
 	if request.CacheResults && response.Confidence > 0.7 {
-		ae.cacheResponse(requestID, response)
+		ae.cacheResponse(response.RequestID, response)
 	}
 
-	ae.logger.WithFields(logrus.Fields{
-		"request_id":         requestID,
-		"processing_time_ms": response.ProcessingTimeMs,
-		"tokens_used":        response.TokensUsed,
-		"confidence":         response.Confidence,
-		"recommendations":    len(response.Recommendations),
-	}).Info("AI recommendation generation completed")
+	return nil
+}
 
-	return response, nil
+// buildLLMRequest packages the rendered prompt with the structured request data
+func (ae *AIEngine) buildLLMRequest(request *RecommendationRequest, prompt, systemPrompt string) *LLMRequest {
+	return &LLMRequest{
+		RequestType:        request.RequestType,
+		Prompt:             prompt,
+		SystemPrompt:       systemPrompt,
+		Config:             ae.llm.BuildDefaultConfig(request.RequestType),
+		Context:            request.Context,
+		Players:            request.Players,
+		MaxRecommendations: request.MaxRecommendations,
+	}
 }
 
 // AnalyzeLineup provides detailed analysis of an existing lineup
@@ -226,8 +365,14 @@ func (ae *AIEngine) AnalyzeLineup(ctx context.Context, request *LineupAnalysisRe
 	prompt, systemPrompt := ae.buildLineupAnalysisPrompt(request)
 
 	// Get AI analysis
-	claudeConfig := ae.claudeClient.BuildDefaultConfig("lineup_analysis")
-	claudeResponse, err := ae.claudeClient.SendMessage(ctx, prompt, systemPrompt, claudeConfig)
+	claudeResponse, err := ae.llm.Complete(ctx, &LLMRequest{
+		RequestType:  "lineup_analysis",
+		Prompt:       prompt,
+		SystemPrompt: systemPrompt,
+		Config:       ae.llm.BuildDefaultConfig("lineup_analysis"),
+		Context:      request.Context,
+		Players:      request.Lineup,
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get AI analysis: %w", err)
 	}
@@ -321,57 +466,144 @@ func (ae *AIEngine) parseRecommendationText(aiText string) (
 	var stacks []models.StackSuggestion
 
 	// Parse sections of the AI response
-	lines := strings.Split(aiText, "\n")
-	currentSection := ""
-
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if line == "" {
+	parser := &recommendationTextParser{engine: ae}
+	for _, line := range strings.Split(aiText, "\n") {
+		chunk := parser.parseLine(line)
+		if chunk == nil {
 			continue
 		}
 
-		// Identify sections
-		if strings.Contains(strings.ToUpper(line), "RECOMMENDATIONS") {
-			currentSection = "recommendations"
-			continue
-		} else if strings.Contains(strings.ToUpper(line), "INSIGHTS") {
-			currentSection = "insights"
-			continue
-		} else if strings.Contains(strings.ToUpper(line), "STACKS") {
-			currentSection = "stacks"
-			continue
+		switch chunk.ChunkType {
+		case "recommendation":
+			recommendations = append(recommendations, *chunk.Recommendation)
+		case "insight":
+			insights = append(insights, *chunk.Insight)
+		case "stack":
+			stacks = append(stacks, *chunk.Stack)
 		}
+	}
 
-		// Parse content based on section
-		switch currentSection {
-		case "recommendations":
-			if rec := ae.parseRecommendationLine(line); rec != nil {
-				recommendations = append(recommendations, *rec)
-			}
-		case "insights":
-			if insight := ae.parseInsightLine(line); insight != nil {
-				insights = append(insights, *insight)
-			}
-		case "stacks":
-			if stack := ae.parseStackLine(line); stack != nil {
-				stacks = append(stacks, *stack)
-			}
+	return recommendations, insights, stacks
+}
+
+// recommendationTextParser tracks the current section while AI output is consumed line by line,
+// so the same parsing serves both complete and streamed responses
+type recommendationTextParser struct {
+	engine         *AIEngine
+	currentSection string
+}
+
+// parseLine returns the item parsed from a single line, or nil for headers and unparseable lines
+func (p *recommendationTextParser) parseLine(line string) *models.RecommendationChunk {
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return nil
+	}
+
+	// Identify sections
+	if strings.Contains(strings.ToUpper(line), "RECOMMENDATIONS") {
+		p.currentSection = "recommendations"
+		return nil
+	} else if strings.Contains(strings.ToUpper(line), "INSIGHTS") {
+		p.currentSection = "insights"
+		return nil
+	} else if strings.Contains(strings.ToUpper(line), "STACKS") {
+		p.currentSection = "stacks"
+		return nil
+	}
+
+	// Parse content based on section
+	switch p.currentSection {
+	case "recommendations":
+		if rec := p.engine.parseRecommendationLine(line); rec != nil {
+			return &models.RecommendationChunk{ChunkType: "recommendation", Recommendation: rec}
+		}
+	case "insights":
+		if insight := p.engine.parseInsightLine(line); insight != nil {
+			return &models.RecommendationChunk{ChunkType: "insight", Insight: insight}
+		}
+	case "stacks":
+		if stack := p.engine.parseStackLine(line); stack != nil {
+			return &models.RecommendationChunk{ChunkType: "stack", Stack: stack}
 		}
 	}
 
-	return recommendations, insights, stacks
+	return nil
 }
 
 func (ae *AIEngine) parseRecommendationLine(line string) *models.PlayerRecommendation {
-	// Simplified parsing - would be more sophisticated in production
-	if strings.Contains(line, "$") && strings.Contains(line, "%") {
-		return &models.PlayerRecommendation{
-			PlayerName:      "Parsed Player", // Would extract from line
-			RecommendReason: line,
-			Confidence:      0.8,
+	// Recommendation lines carry at least a salary and an ownership percentage
+	if !strings.Contains(line, "$") || !strings.Contains(line, "%") {
+		return nil
+	}
+
+	rec := &models.PlayerRecommendation{
+		PlayerName:      "Parsed Player",
+		RecommendReason: line,
+		Confidence:      0.8,
+	}
+
+	// Name is everything before the first detail marker, e.g. "- Scottie Scheffler (G, USA) | $11000 ..."
+	name := strings.TrimLeft(line, "-*•0123456789. ")
+	if idx := strings.IndexAny(name, "(|$:"); idx >= 0 {
+		name = name[:idx]
+	}
+	if name = strings.TrimSpace(name); name != "" {
+		rec.PlayerName = name
+	}
+
+	if match := positionTeamPattern.FindStringSubmatch(line); match != nil {
+		rec.Position = strings.TrimSpace(match[1])
+		rec.Team = strings.TrimSpace(match[2])
+	}
+	if match := salaryPattern.FindStringSubmatch(line); match != nil {
+		rec.Salary = parseNumber(match[1])
+	}
+	if match := projectionPattern.FindStringSubmatch(line); match != nil {
+		rec.Projection = parseNumber(match[1])
+	}
+	if match := ownershipPattern.FindStringSubmatch(line); match != nil {
+		rec.Ownership = parseNumber(match[1])
+	}
+	if match := confidencePattern.FindStringSubmatch(line); match != nil {
+		rec.Confidence = min(1.0, parseNumber(match[1])/100)
+	}
+
+	return rec
+}
+
+// matchRecommendedPlayers resolves parsed recommendations back to the request's player pool
+func (ae *AIEngine) matchRecommendedPlayers(response *RecommendationResponse, players []models.PlayerRecommendation) {
+	byName := make(map[string]models.PlayerRecommendation, len(players))
+	for _, player := range players {
+		byName[strings.ToLower(player.PlayerName)] = player
+	}
+
+	for i := range response.Recommendations {
+		rec := &response.Recommendations[i]
+		player, ok := byName[strings.ToLower(rec.PlayerName)]
+		if !ok {
+			continue
+		}
+
+		rec.PlayerID = player.PlayerID
+		rec.Opponent = player.Opponent
+		if rec.Position == "" {
+			rec.Position = player.Position
+		}
+		if rec.Team == "" {
+			rec.Team = player.Team
+		}
+		if rec.Salary == 0 {
+			rec.Salary = player.Salary
+		}
+		if rec.Projection == 0 {
+			rec.Projection = player.Projection
+		}
+		if rec.Ownership == 0 {
+			rec.Ownership = player.Ownership
 		}
 	}
-	return nil
 }
 
 func (ae *AIEngine) parseInsightLine(line string) *models.ContextInsight {
@@ -445,7 +677,7 @@ func (ae *AIEngine) generateRealTimeAlerts(ctx context.Context, contestID uint,
 	return alerts
 }
 
-func (ae *AIEngine) calculateOverallConfidence(response *RecommendationResponse, usage ClaudeUsage) float64 {
+func (ae *AIEngine) calculateOverallConfidence(request *RecommendationRequest, response *RecommendationResponse, usage ClaudeUsage) float64 {
 	confidence := 0.8 // Base confidence
 
 	// Adjust based on data quality
@@ -467,6 +699,11 @@ func (ae *AIEngine) calculateOverallConfidence(response *RecommendationResponse,
 		confidence += 0.05
 	}
 
+	// Scale by how well past recommendations from this segment actually performed
+	if ae.accuracyTracker != nil {
+		confidence *= ae.accuracyTracker.ConfidenceWeight(request.Context.Sport, request.RequestType, response.PromptTemplate)
+	}
+
 	return min(1.0, confidence)
 }
 
@@ -592,11 +829,19 @@ func (ae *AIEngine) cacheResponse(requestID string, response *RecommendationResp
 
 // Health check
 func (ae *AIEngine) IsHealthy() bool {
-	return ae.claudeClient.IsHealthy() && 
+	return ae.llm.IsHealthy() && 
 		   ae.realtimeAggregator.IsHealthy()
 }
 
 // Utility functions
+func parseNumber(raw string) float64 {
+	value, err := strconv.ParseFloat(strings.ReplaceAll(raw, ",", ""), 64)
+	if err != nil {
+		return 0
+	}
+	return value
+}
+
 func min(a, b float64) float64 {
 	if a < b {
 		return a
@@ -17,6 +17,7 @@ import (
 // ClaudeClient handles interaction with the Claude API
 type ClaudeClient struct {
 	httpClient     *http.Client
+	streamClient   *http.Client
 	cache          *CacheService
 	logger         *logrus.Logger
 	apiKey         string
@@ -130,6 +131,13 @@ func NewClaudeClient(cfg *config.Config, logger *logrus.Logger) *ClaudeClient {
 		httpClient: &http.Client{
 			Timeout: 60 * time.Second, // Allow longer timeout for AI processing
 		},
+		streamClient: &http.Client{
+			// Streams stay open for the whole generation, so only bound the wait for headers
+			Transport: &http.Transport{
+				Proxy:                 http.ProxyFromEnvironment,
+				ResponseHeaderTimeout: 60 * time.Second,
+			},
+		},
 		logger:         logger,
 		apiKey:         cfg.ClaudeAPIKey,
 		baseURL:        "https://api.anthropic.com/v1",
@@ -373,6 +381,21 @@ func (c *ClaudeClient) GenerateRecommendations(ctx context.Context, prompt, syst
 	return c.SendMessage(ctx, prompt, systemPrompt, config)
 }
 
+// Name identifies the provider
+func (c *ClaudeClient) Name() string {
+	return ProviderClaude
+}
+
+// Complete implements LLMProvider using the Messages API
+func (c *ClaudeClient) Complete(ctx context.Context, request *LLMRequest) (*ClaudeResponse, error) {
+	return c.SendMessage(ctx, request.Prompt, request.SystemPrompt, request.Config)
+}
+
+// Stream implements LLMProvider using the streaming Messages API
+func (c *ClaudeClient) Stream(ctx context.Context, request *LLMRequest, handler ClaudeStreamHandler) (*ClaudeResponse, error) {
+	return c.StreamMessage(ctx, request.Prompt, request.SystemPrompt, request.Config, handler)
+}
+
 // SetCacheService sets the cache service for caching responses
 func (c *ClaudeClient) SetCacheService(cache *CacheService) {
 	c.cache = cache
@@ -0,0 +1,257 @@
+package services
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ClaudeStreamEvent represents a single server-sent event from the Claude streaming API
+type ClaudeStreamEvent struct {
+	Type         string              `json:"type"` // "message_start", "content_block_delta", "message_delta", "message_stop", "error", ...
+	Index        int                 `json:"index"`
+	Message      *ClaudeResponse     `json:"message,omitempty"`
+	ContentBlock *ClaudeContentBlock `json:"content_block,omitempty"`
+	Delta        *ClaudeStreamDelta  `json:"delta,omitempty"`
+	Usage        *ClaudeUsage        `json:"usage,omitempty"`
+	Error        *ClaudeError        `json:"error,omitempty"`
+}
+
+// ClaudeStreamDelta represents the incremental payload of a streaming event
+type ClaudeStreamDelta struct {
+	Type         string `json:"type"` // "text_delta" for content blocks
+	Text         string `json:"text"`
+	StopReason   string `json:"stop_reason"`
+	StopSequence string `json:"stop_sequence"`
+}
+
+// ClaudeStreamHandler receives text deltas as they arrive. Returning an error aborts the stream.
+type ClaudeStreamHandler func(text string) error
+
+// StreamMessage sends a message to Claude API with streaming enabled and invokes handler
+// for every text delta. The accumulated response is returned once the stream completes.
+func (c *ClaudeClient) StreamMessage(ctx context.Context, prompt string, systemPrompt string, config ClaudeConfig, handler ClaudeStreamHandler) (*ClaudeResponse, error) {
+	// Check rate limits
+	if err := c.checkRateLimits(); err != nil {
+		return nil, fmt.Errorf("rate limit exceeded: %w", err)
+	}
+
+	request := ClaudeRequest{
+		Model:       config.Model,
+		MaxTokens:   config.MaxTokens,
+		Temperature: config.Temperature,
+		TopP:        config.TopP,
+		TopK:        config.TopK,
+		Messages: []ClaudeMessage{
+			{
+				Role:    "user",
+				Content: prompt,
+			},
+		},
+		Stream: true,
+		System: systemPrompt,
+	}
+
+	response, err := c.circuitBreaker.Execute(func() (interface{}, error) {
+		return c.makeStreamRequest(ctx, request, handler)
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("claude API stream failed: %w", err)
+	}
+
+	claudeResponse := response.(*ClaudeResponse)
+
+	// Track token usage
+	c.trackTokenUsage(claudeResponse.Usage.InputTokens + claudeResponse.Usage.OutputTokens)
+
+	return claudeResponse, nil
+}
+
+// makeStreamRequest opens the SSE connection, retrying only until the stream has started
+func (c *ClaudeClient) makeStreamRequest(ctx context.Context, request ClaudeRequest, handler ClaudeStreamHandler) (*ClaudeResponse, error) {
+	// Only the rate limiter slot is serialized; the stream itself may outlive other requests
+	c.mu.Lock()
+	select {
+	case <-c.rateLimiter.C:
+	case <-ctx.Done():
+		c.mu.Unlock()
+		return nil, ctx.Err()
+	}
+	err := c.trackRequest()
+	c.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	requestBody, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	var lastErr error
+	for attempt := 0; attempt < c.retryAttempts; attempt++ {
+		if attempt > 0 {
+			// Exponential backoff
+			backoff := time.Duration(1<<uint(attempt)) * time.Second
+			select {
+			case <-time.After(backoff):
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+"/messages", bytes.NewBuffer(requestBody))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Accept", "text/event-stream")
+		req.Header.Set("x-api-key", c.apiKey)
+		req.Header.Set("anthropic-version", "2023-06-01")
+
+		resp, err := c.streamClient.Do(req)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			lastErr = err
+			continue
+		}
+
+		if resp.StatusCode == http.StatusOK {
+			defer resp.Body.Close()
+			return c.readEventStream(ctx, resp.Body, handler)
+		}
+
+		var claudeErr ClaudeError
+		decodeErr := json.NewDecoder(resp.Body).Decode(&claudeErr)
+		resp.Body.Close()
+		if decodeErr != nil {
+			lastErr = fmt.Errorf("API request failed with status %d", resp.StatusCode)
+			continue
+		}
+
+		switch resp.StatusCode {
+		case http.StatusUnauthorized:
+			return nil, fmt.Errorf("invalid API credentials: %s", claudeErr.Message)
+		case http.StatusBadRequest:
+			return nil, fmt.Errorf("bad request: %s", claudeErr.Message)
+		case http.StatusTooManyRequests:
+			lastErr = fmt.Errorf("rate limit exceeded: %s", claudeErr.Message)
+		default:
+			lastErr = fmt.Errorf("unexpected error (status %d): %s", resp.StatusCode, claudeErr.Message)
+		}
+	}
+
+	return nil, fmt.Errorf("failed after %d attempts: %w", c.retryAttempts, lastErr)
+}
+
+// readEventStream parses the SSE body and assembles the final response
+func (c *ClaudeClient) readEventStream(ctx context.Context, body io.Reader, handler ClaudeStreamHandler) (*ClaudeResponse, error) {
+	response := &ClaudeResponse{}
+	var text strings.Builder
+
+	reader := bufio.NewReader(body)
+	// An event's data can span several data: lines, which join with newlines
+	var data []string
+
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil && err != io.EOF {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			return nil, fmt.Errorf("failed to read event stream: %w", err)
+		}
+
+		line = strings.TrimRight(line, "\r\n")
+
+		switch {
+		case strings.HasPrefix(line, "data:"):
+			data = append(data, strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+		case line == "" && len(data) > 0:
+			// Blank line terminates the event
+			done, eventErr := c.applyStreamEvent(strings.Join(data, "\n"), response, &text, handler)
+			data = data[:0]
+			if eventErr != nil {
+				return nil, eventErr
+			}
+			if done {
+				response.Content = []ClaudeContentBlock{{Type: "text", Text: text.String()}}
+				return response, nil
+			}
+		}
+
+		if err == io.EOF {
+			break
+		}
+	}
+
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	return nil, fmt.Errorf("event stream ended before message_stop")
+}
+
+// applyStreamEvent folds a single event into the response. It reports true once the message is complete.
+func (c *ClaudeClient) applyStreamEvent(payload string, response *ClaudeResponse, text *strings.Builder, handler ClaudeStreamHandler) (bool, error) {
+	var event ClaudeStreamEvent
+	if err := json.Unmarshal([]byte(payload), &event); err != nil {
+		return false, fmt.Errorf("failed to decode stream event: %w", err)
+	}
+
+	switch event.Type {
+	case "message_start":
+		if event.Message != nil {
+			response.ID = event.Message.ID
+			response.Type = event.Message.Type
+			response.Role = event.Message.Role
+			response.Model = event.Message.Model
+			response.Usage = event.Message.Usage
+		}
+	case "content_block_delta":
+		if event.Delta == nil || event.Delta.Type != "text_delta" || event.Delta.Text == "" {
+			return false, nil
+		}
+		text.WriteString(event.Delta.Text)
+		if handler != nil {
+			if err := handler(event.Delta.Text); err != nil {
+				return false, fmt.Errorf("stream handler aborted: %w", err)
+			}
+		}
+	case "message_delta":
+		if event.Delta != nil {
+			response.StopReason = event.Delta.StopReason
+			response.StopSequence = event.Delta.StopSequence
+		}
+		if event.Usage != nil {
+			response.Usage.OutputTokens = event.Usage.OutputTokens
+		}
+	case "message_stop":
+		return true, nil
+	case "error":
+		if event.Error != nil {
+			return false, fmt.Errorf("claude stream error (%s): %s", event.Error.Type, event.Error.Message)
+		}
+		return false, fmt.Errorf("claude stream error")
+	default:
+		// ping, content_block_start and content_block_stop carry nothing we need
+		c.logger.WithField("event_type", event.Type).Debug("Ignored Claude stream event")
+	}
+
+	return false, nil
+}
+
+// SetBaseURL overrides the Claude API base URL (used for proxies and local fakes)
+func (c *ClaudeClient) SetBaseURL(baseURL string) {
+	c.baseURL = strings.TrimRight(baseURL, "/")
+	c.logger.WithField("base_url", c.baseURL).Debug("Claude API base URL overridden")
+}
@@ -0,0 +1,134 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stitts-dev/dfs-sim/services/ai-recommendations-service/internal/services"
+	"github.com/stitts-dev/dfs-sim/shared/pkg/config"
+)
+
+// writeSSE writes a single server-sent event and flushes it to the client
+func writeSSE(w http.ResponseWriter, event, data string) {
+	fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, data)
+	w.(http.Flusher).Flush()
+}
+
+func newStreamTestClient(t *testing.T, handler http.HandlerFunc) *services.ClaudeClient {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+
+	client := services.NewClaudeClient(&config.Config{ClaudeAPIKey: "test-api-key"}, logger)
+	client.SetBaseURL(server.URL)
+	return client
+}
+
+func TestClaudeClient_StreamMessage(t *testing.T) {
+	client := newStreamTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/messages", r.URL.Path)
+		assert.Equal(t, "test-api-key", r.Header.Get("x-api-key"))
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		writeSSE(w, "message_start", `{"type":"message_start","message":{"id":"msg_1","type":"message","role":"assistant","model":"claude-test","usage":{"input_tokens":12,"output_tokens":1}}}`)
+		writeSSE(w, "content_block_start", `{"type":"content_block_start","index":0,"content_block":{"type":"text","text":""}}`)
+		writeSSE(w, "ping", `{"type":"ping"}`)
+		writeSSE(w, "content_block_delta", `{"type":"content_block_delta","index":0,"delta":{"type":"text_delta","text":"RECOMMENDATIONS\nScottie $11000 "}}`)
+		writeSSE(w, "content_block_delta", `{"type":"content_block_delta","index":0,"delta":{"type":"text_delta","text":"25%\n"}}`)
+		writeSSE(w, "content_block_stop", `{"type":"content_block_stop","index":0}`)
+		writeSSE(w, "message_delta", `{"type":"message_delta","delta":{"stop_reason":"end_turn"},"usage":{"output_tokens":9}}`)
+		writeSSE(w, "message_stop", `{"type":"message_stop"}`)
+	})
+
+	var deltas []string
+	response, err := client.StreamMessage(context.Background(), "prompt", "system", client.BuildDefaultConfig("strategy"), func(text string) error {
+		deltas = append(deltas, text)
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"RECOMMENDATIONS\nScottie $11000 ", "25%\n"}, deltas)
+	assert.Equal(t, "msg_1", response.ID)
+	assert.Equal(t, "claude-test", response.Model)
+	assert.Equal(t, "end_turn", response.StopReason)
+	assert.Equal(t, 12, response.Usage.InputTokens)
+	assert.Equal(t, 9, response.Usage.OutputTokens)
+	require.Len(t, response.Content, 1)
+	assert.Equal(t, "RECOMMENDATIONS\nScottie $11000 25%\n", response.Content[0].Text)
+}
+
+func TestClaudeClient_StreamMessageErrorEvent(t *testing.T) {
+	client := newStreamTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		writeSSE(w, "message_start", `{"type":"message_start","message":{"id":"msg_2","model":"claude-test"}}`)
+		writeSSE(w, "error", `{"type":"error","error":{"type":"overloaded_error","message":"Overloaded"}}`)
+	})
+
+	_, err := client.StreamMessage(context.Background(), "prompt", "system", client.BuildDefaultConfig("strategy"), nil)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "overloaded_error")
+}
+
+func TestClaudeClient_StreamMessageMultiLineData(t *testing.T) {
+	client := newStreamTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		writeSSE(w, "message_start", `{"type":"message_start","message":{"id":"msg_4","model":"claude-test"}}`)
+		// One event's JSON split across data: lines, which join with newlines
+		fmt.Fprint(w, "event: content_block_delta\ndata: {\"type\":\"content_block_delta\",\ndata: \"delta\":{\"type\":\"text_delta\",\"text\":\"split\"}}\n\n")
+		writeSSE(w, "message_stop", `{"type":"message_stop"}`)
+	})
+
+	response, err := client.StreamMessage(context.Background(), "prompt", "system", client.BuildDefaultConfig("strategy"), nil)
+	require.NoError(t, err)
+	require.Len(t, response.Content, 1)
+	assert.Equal(t, "split", response.Content[0].Text)
+}
+
+func TestClaudeClient_StreamMessageCancellation(t *testing.T) {
+	serverDone := make(chan struct{})
+	client := newStreamTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		defer close(serverDone)
+		w.Header().Set("Content-Type", "text/event-stream")
+		writeSSE(w, "message_start", `{"type":"message_start","message":{"id":"msg_3","model":"claude-test"}}`)
+		writeSSE(w, "content_block_delta", `{"type":"content_block_delta","index":0,"delta":{"type":"text_delta","text":"partial"}}`)
+
+		// Hold the stream open until the client goes away
+		select {
+		case <-r.Context().Done():
+		case <-time.After(10 * time.Second):
+			t.Error("client did not abort the stream")
+		}
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	start := time.Now()
+
+	_, err := client.StreamMessage(ctx, "prompt", "system", client.BuildDefaultConfig("strategy"), func(text string) error {
+		assert.Equal(t, "partial", text)
+		cancel()
+		return nil
+	})
+
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, context.Canceled) || strings.Contains(err.Error(), "canceled"))
+	assert.Less(t, time.Since(start), 5*time.Second)
+
+	select {
+	case <-serverDone:
+	case <-time.After(5 * time.Second):
+		t.Fatal("fake server never observed the disconnect")
+	}
+}
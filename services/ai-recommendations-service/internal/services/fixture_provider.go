@@ -0,0 +1,184 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Fixture provider modes accepted in AI_FIXTURE_MODE
+const (
+	FixtureModeReplay = "replay"
+	FixtureModeRecord = "record"
+)
+
+// FixtureProvider serves recorded responses keyed by the rendered prompt. In record mode it
+// forwards requests to an upstream provider and writes each response to disk, so regression
+// tests can replay real model output without network access.
+type FixtureProvider struct {
+	dir      string
+	mode     string
+	upstream LLMProvider
+	logger   *logrus.Logger
+}
+
+// LLMFixture is the on-disk format of a recorded response
+type LLMFixture struct {
+	Key          string          `json:"key"`
+	RequestType  string          `json:"request_type"`
+	Provider     string          `json:"provider"`
+	SystemPrompt string          `json:"system_prompt"`
+	Prompt       string          `json:"prompt"`
+	Response     *ClaudeResponse `json:"response"`
+}
+
+// NewFixtureProvider creates a fixture provider reading from (and in record mode writing to) dir
+func NewFixtureProvider(dir, mode string, upstream LLMProvider, logger *logrus.Logger) (*FixtureProvider, error) {
+	if mode == "" {
+		mode = FixtureModeReplay
+	}
+
+	switch mode {
+	case FixtureModeReplay:
+	case FixtureModeRecord:
+		if upstream == nil {
+			return nil, fmt.Errorf("fixture provider in record mode requires an upstream provider")
+		}
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, fmt.Errorf("failed to create fixture directory: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unknown fixture mode %q (expected %s or %s)", mode, FixtureModeReplay, FixtureModeRecord)
+	}
+
+	return &FixtureProvider{
+		dir:      dir,
+		mode:     mode,
+		upstream: upstream,
+		logger:   logger,
+	}, nil
+}
+
+// FixtureKey returns the stable key a request is recorded under
+func FixtureKey(request *LLMRequest) string {
+	hash := sha256.Sum256([]byte(request.RequestType + "\x00" + request.SystemPrompt + "\x00" + request.Prompt))
+	return fmt.Sprintf("%x", hash)[:16]
+}
+
+// Name identifies the provider
+func (fp *FixtureProvider) Name() string {
+	return ProviderFixture
+}
+
+// BuildDefaultConfig delegates to the upstream provider when recording
+func (fp *FixtureProvider) BuildDefaultConfig(recommendationType string) ClaudeConfig {
+	if fp.upstream != nil {
+		return fp.upstream.BuildDefaultConfig(recommendationType)
+	}
+	return ClaudeConfig{Model: "fixture", MaxTokens: 4000}
+}
+
+// IsHealthy reports upstream health when recording, otherwise whether fixtures are readable
+func (fp *FixtureProvider) IsHealthy() bool {
+	if fp.mode == FixtureModeRecord {
+		return fp.upstream.IsHealthy()
+	}
+	_, err := os.Stat(fp.dir)
+	return err == nil
+}
+
+// Complete replays (or records) the response for a request
+func (fp *FixtureProvider) Complete(ctx context.Context, request *LLMRequest) (*ClaudeResponse, error) {
+	if fp.mode == FixtureModeRecord {
+		response, err := fp.upstream.Complete(ctx, request)
+		if err != nil {
+			return nil, err
+		}
+		return response, fp.save(request, response)
+	}
+
+	return fp.load(request)
+}
+
+// Stream replays a recorded response line by line, or records a live stream
+func (fp *FixtureProvider) Stream(ctx context.Context, request *LLMRequest, handler ClaudeStreamHandler) (*ClaudeResponse, error) {
+	if fp.mode == FixtureModeRecord {
+		response, err := fp.upstream.Stream(ctx, request, handler)
+		if err != nil {
+			return nil, err
+		}
+		return response, fp.save(request, response)
+	}
+
+	response, err := fp.load(request)
+	if err != nil {
+		return nil, err
+	}
+	if err := streamResponseText(ctx, response, handler); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func (fp *FixtureProvider) path(key string) string {
+	return filepath.Join(fp.dir, key+".json")
+}
+
+func (fp *FixtureProvider) load(request *LLMRequest) (*ClaudeResponse, error) {
+	key := FixtureKey(request)
+
+	data, err := os.ReadFile(fp.path(key))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("no recorded fixture %s for %s request (record with AI_FIXTURE_MODE=record)", key, request.RequestType)
+		}
+		return nil, fmt.Errorf("failed to read fixture %s: %w", key, err)
+	}
+
+	var fixture LLMFixture
+	if err := json.Unmarshal(data, &fixture); err != nil {
+		return nil, fmt.Errorf("failed to decode fixture %s: %w", key, err)
+	}
+	if fixture.Response == nil {
+		return nil, fmt.Errorf("fixture %s has no response", key)
+	}
+
+	fp.logger.WithFields(logrus.Fields{
+		"fixture":      key,
+		"request_type": request.RequestType,
+	}).Debug("Replayed LLM fixture")
+
+	return fixture.Response, nil
+}
+
+func (fp *FixtureProvider) save(request *LLMRequest, response *ClaudeResponse) error {
+	key := FixtureKey(request)
+	fixture := LLMFixture{
+		Key:          key,
+		RequestType:  request.RequestType,
+		Provider:     fp.upstream.Name(),
+		SystemPrompt: request.SystemPrompt,
+		Prompt:       request.Prompt,
+		Response:     response,
+	}
+
+	data, err := json.MarshalIndent(fixture, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode fixture %s: %w", key, err)
+	}
+	if err := os.WriteFile(fp.path(key), data, 0o644); err != nil {
+		return fmt.Errorf("failed to write fixture %s: %w", key, err)
+	}
+
+	fp.logger.WithFields(logrus.Fields{
+		"fixture":      key,
+		"request_type": request.RequestType,
+	}).Info("Recorded LLM fixture")
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stitts-dev/dfs-sim/services/ai-recommendations-service/internal/models"
+	"github.com/stitts-dev/dfs-sim/shared/pkg/config"
+)
+
+// LLM provider names accepted in AI_PROVIDER
+const (
+	ProviderClaude  = "claude"
+	ProviderLocal   = "local"
+	ProviderFixture = "fixture"
+)
+
+// LLMProvider generates AI completions for the recommendation engine
+type LLMProvider interface {
+	// Name identifies the provider in logs, metrics and stored recommendations
+	Name() string
+	// BuildDefaultConfig returns the generation parameters for a recommendation type
+	BuildDefaultConfig(recommendationType string) ClaudeConfig
+	// Complete returns the full response for a request
+	Complete(ctx context.Context, request *LLMRequest) (*ClaudeResponse, error)
+	// Stream invokes handler for each text delta and returns the accumulated response
+	Stream(ctx context.Context, request *LLMRequest, handler ClaudeStreamHandler) (*ClaudeResponse, error)
+	// IsHealthy reports whether the provider can currently serve requests
+	IsHealthy() bool
+}
+
+// LLMRequest carries the rendered prompt together with the structured inputs it was built from,
+// so providers that don't read prose (local rules, fixtures) can still produce a response
+type LLMRequest struct {
+	RequestType        string
+	Prompt             string
+	SystemPrompt       string
+	Config             ClaudeConfig
+	Context            models.PromptContext
+	Players            []models.PlayerRecommendation
+	MaxRecommendations int
+}
+
+// NewLLMProvider creates the provider selected by AI_PROVIDER
+func NewLLMProvider(cfg *config.Config, logger *logrus.Logger) (LLMProvider, error) {
+	var provider LLMProvider
+
+	switch cfg.AIProvider {
+	case "", ProviderClaude:
+		provider = NewClaudeClient(cfg, logger)
+	case ProviderLocal:
+		provider = NewLocalProvider(logger)
+	case ProviderFixture:
+		var upstream LLMProvider
+		if cfg.AIFixtureMode == FixtureModeRecord {
+			upstream = NewClaudeClient(cfg, logger)
+		}
+		fixtureProvider, err := NewFixtureProvider(cfg.AIFixtureDir, cfg.AIFixtureMode, upstream, logger)
+		if err != nil {
+			return nil, err
+		}
+		provider = fixtureProvider
+	default:
+		return nil, fmt.Errorf("unknown AI provider %q (expected %s, %s or %s)",
+			cfg.AIProvider, ProviderClaude, ProviderLocal, ProviderFixture)
+	}
+
+	logger.WithField("provider", provider.Name()).Info("Initialized LLM provider")
+	return provider, nil
+}
@@ -0,0 +1,122 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stitts-dev/dfs-sim/services/ai-recommendations-service/internal/models"
+	"github.com/stitts-dev/dfs-sim/services/ai-recommendations-service/internal/services"
+)
+
+func testGolfRequest() *services.RecommendationRequest {
+	return &services.RecommendationRequest{
+		ContestID:   42,
+		UserID:      7,
+		RequestType: "player_recommendations",
+		Players: []models.PlayerRecommendation{
+			{PlayerID: 1, PlayerName: "Scottie Scheffler", Position: "G", Team: "USA", Salary: 11500, Projection: 68.0, Ownership: 32.0},
+			{PlayerID: 2, PlayerName: "Rory McIlroy", Position: "G", Team: "NIR", Salary: 10200, Projection: 62.5, Ownership: 21.0},
+			{PlayerID: 3, PlayerName: "Sahith Theegala", Position: "G", Team: "USA", Salary: 7800, Projection: 52.0, Ownership: 6.5},
+			{PlayerID: 4, PlayerName: "Min Woo Lee", Position: "G", Team: "AUS", Salary: 7400, Projection: 49.0, Ownership: 4.0},
+			{PlayerID: 5, PlayerName: "No Projection", Position: "G", Team: "USA", Salary: 6000},
+		},
+		Context: models.PromptContext{
+			Sport:             "golf",
+			ContestType:       "gpp",
+			OwnershipStrategy: "contrarian",
+		},
+		MaxRecommendations: 3,
+	}
+}
+
+func newTestEngine(provider services.LLMProvider) *services.AIEngine {
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+
+	return services.NewAIEngine(provider, services.NewPromptBuilder(nil, logger), nil, nil, logger)
+}
+
+func TestLocalProvider_GenerateRecommendations(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+
+	engine := newTestEngine(services.NewLocalProvider(logger))
+	response, err := engine.GenerateRecommendations(context.Background(), testGolfRequest())
+	require.NoError(t, err)
+
+	require.Len(t, response.Recommendations, 3)
+	seen := map[uint]bool{}
+	for _, rec := range response.Recommendations {
+		assert.NotZero(t, rec.PlayerID, "recommendation %q should resolve to a pool player", rec.PlayerName)
+		assert.NotEqual(t, uint(5), rec.PlayerID, "players without projections must not be recommended")
+		assert.False(t, seen[rec.PlayerID], "duplicate recommendation for %d", rec.PlayerID)
+		seen[rec.PlayerID] = true
+
+		assert.Greater(t, rec.Salary, 0.0)
+		assert.Greater(t, rec.Projection, 0.0)
+		assert.Greater(t, rec.Value, 0.0)
+		assert.InDelta(t, 0.7, rec.Confidence, 0.21)
+		assert.NotEmpty(t, rec.RiskLevel)
+	}
+	assert.NotEmpty(t, response.ContextInsights)
+	assert.NotEmpty(t, response.StackSuggestions)
+	assert.Equal(t, "local-rules-v1", response.ModelUsed)
+
+	// Output is deterministic for identical input
+	again, err := engine.GenerateRecommendations(context.Background(), testGolfRequest())
+	require.NoError(t, err)
+	assert.Equal(t, response.Recommendations, again.Recommendations)
+}
+
+func TestLocalProvider_StreamMatchesComplete(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+
+	engine := newTestEngine(services.NewLocalProvider(logger))
+
+	var chunks []*models.RecommendationChunk
+	streamed, err := engine.GenerateRecommendationsStream(context.Background(), testGolfRequest(), func(chunk *models.RecommendationChunk) error {
+		chunks = append(chunks, chunk)
+		return nil
+	})
+	require.NoError(t, err)
+
+	var streamedRecs int
+	for i, chunk := range chunks {
+		assert.Equal(t, i+1, chunk.Sequence)
+		if chunk.ChunkType == "recommendation" {
+			streamedRecs++
+		}
+	}
+	assert.Equal(t, 3, streamedRecs)
+	assert.Len(t, streamed.Recommendations, streamedRecs)
+}
+
+func TestFixtureProvider_RecordAndReplay(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+	dir := t.TempDir()
+
+	recorder, err := services.NewFixtureProvider(dir, services.FixtureModeRecord, services.NewLocalProvider(logger), logger)
+	require.NoError(t, err)
+	recorded, err := newTestEngine(recorder).GenerateRecommendations(context.Background(), testGolfRequest())
+	require.NoError(t, err)
+
+	replayer, err := services.NewFixtureProvider(dir, services.FixtureModeReplay, nil, logger)
+	require.NoError(t, err)
+	replayed, err := newTestEngine(replayer).GenerateRecommendations(context.Background(), testGolfRequest())
+	require.NoError(t, err)
+
+	assert.Equal(t, recorded.Recommendations, replayed.Recommendations)
+	assert.Equal(t, recorded.ContextInsights, replayed.ContextInsights)
+
+	// A prompt that was never recorded fails loudly rather than silently succeeding
+	changed := testGolfRequest()
+	changed.Context.ContestType = "cash"
+	_, err = newTestEngine(replayer).GenerateRecommendations(context.Background(), changed)
+	assert.ErrorContains(t, err, "no recorded fixture")
+}
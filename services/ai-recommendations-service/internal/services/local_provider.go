@@ -0,0 +1,331 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stitts-dev/dfs-sim/services/ai-recommendations-service/internal/models"
+)
+
+const localProviderModel = "local-rules-v1"
+
+// LocalProvider is a deterministic, rule-based LLM stand-in. It scores the players in the
+// request from their projections, salaries and ownership and renders the result in the same
+// sectioned text format the AI engine parses, so the full pipeline runs without network access.
+type LocalProvider struct {
+	logger *logrus.Logger
+}
+
+// scoredPlayer pairs a player with the local provider's ranking score
+type scoredPlayer struct {
+	player     models.PlayerRecommendation
+	value      float64
+	leverage   float64
+	score      float64
+	confidence int
+}
+
+// NewLocalProvider creates a new rule-based provider
+func NewLocalProvider(logger *logrus.Logger) *LocalProvider {
+	return &LocalProvider{logger: logger}
+}
+
+// Name identifies the provider
+func (lp *LocalProvider) Name() string {
+	return ProviderLocal
+}
+
+// BuildDefaultConfig returns a config tagged with the local model name
+func (lp *LocalProvider) BuildDefaultConfig(recommendationType string) ClaudeConfig {
+	return ClaudeConfig{
+		Model:     localProviderModel,
+		MaxTokens: 4000,
+	}
+}
+
+// IsHealthy always reports true since the provider has no external dependencies
+func (lp *LocalProvider) IsHealthy() bool {
+	return true
+}
+
+// Complete renders a response for the request
+func (lp *LocalProvider) Complete(ctx context.Context, request *LLMRequest) (*ClaudeResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var text string
+	if request.RequestType == "lineup_analysis" {
+		text = lp.renderLineupAnalysis(request)
+	} else {
+		text = lp.renderRecommendations(request)
+	}
+
+	lp.logger.WithFields(logrus.Fields{
+		"request_type": request.RequestType,
+		"players":      len(request.Players),
+	}).Debug("Generated local provider response")
+
+	return &ClaudeResponse{
+		ID:         fmt.Sprintf("local-%s", request.RequestType),
+		Type:       "message",
+		Role:       "assistant",
+		Content:    []ClaudeContentBlock{{Type: "text", Text: text}},
+		Model:      localProviderModel,
+		StopReason: "end_turn",
+		Usage: ClaudeUsage{
+			InputTokens:  estimateTokens(request.SystemPrompt + request.Prompt),
+			OutputTokens: estimateTokens(text),
+		},
+	}, nil
+}
+
+// Stream delivers the rendered response one line at a time
+func (lp *LocalProvider) Stream(ctx context.Context, request *LLMRequest, handler ClaudeStreamHandler) (*ClaudeResponse, error) {
+	response, err := lp.Complete(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := streamResponseText(ctx, response, handler); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// renderRecommendations ranks the player pool and writes the recommendation sections
+func (lp *LocalProvider) renderRecommendations(request *LLMRequest) string {
+	ranked := lp.rankPlayers(request.Players, request.Context)
+
+	limit := request.MaxRecommendations
+	if limit <= 0 {
+		limit = 8
+	}
+	if len(ranked) > limit {
+		ranked = ranked[:limit]
+	}
+
+	var b strings.Builder
+
+	b.WriteString("RECOMMENDATIONS\n")
+	for _, sp := range ranked {
+		p := sp.player
+		fmt.Fprintf(&b, "- %s (%s, %s) | $%.0f | %.1f proj | %.1f%% own | confidence %d | %.2f pts/$1K, leverage %.2f\n",
+			p.PlayerName, p.Position, p.Team, p.Salary, p.Projection, p.Ownership, sp.confidence, sp.value, sp.leverage)
+	}
+
+	b.WriteString("\nINSIGHTS\n")
+	for _, insight := range lp.buildInsights(ranked, request.Context) {
+		fmt.Fprintf(&b, "- %s\n", insight)
+	}
+
+	b.WriteString("\nSTACKS\n")
+	for _, stack := range lp.buildStacks(ranked) {
+		fmt.Fprintf(&b, "- %s\n", stack)
+	}
+
+	return b.String()
+}
+
+// rankPlayers scores players according to contest type and ownership strategy
+func (lp *LocalProvider) rankPlayers(players []models.PlayerRecommendation, promptCtx models.PromptContext) []scoredPlayer {
+	ranked := make([]scoredPlayer, 0, len(players))
+
+	for _, player := range players {
+		if player.Projection <= 0 {
+			continue
+		}
+
+		value := 0.0
+		if player.Salary > 0 {
+			value = player.Projection / (player.Salary / 1000)
+		}
+
+		// Leverage rewards projected points that the field is under-owning
+		ownership := player.Ownership / 100
+		leverage := 1 - ownership
+
+		score := player.Projection
+		switch promptCtx.ContestType {
+		case "cash":
+			score = player.Projection*0.6 + value*4
+		default:
+			score = player.Projection * (0.75 + 0.25*leverage)
+		}
+
+		switch promptCtx.OwnershipStrategy {
+		case "contrarian":
+			score *= 0.85 + 0.3*leverage
+		case "chalk":
+			score *= 0.85 + 0.3*ownership
+		}
+
+		ranked = append(ranked, scoredPlayer{
+			player:   player,
+			value:    value,
+			leverage: leverage,
+			score:    score,
+		})
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].score != ranked[j].score {
+			return ranked[i].score > ranked[j].score
+		}
+		return ranked[i].player.PlayerID < ranked[j].player.PlayerID
+	})
+
+	// Confidence scales from 90 for the top play down toward 55 for the last ranked
+	for i := range ranked {
+		confidence := 90
+		if len(ranked) > 1 {
+			confidence = 90 - int(35*float64(i)/float64(len(ranked)-1))
+		}
+		ranked[i].confidence = confidence
+	}
+
+	return ranked
+}
+
+// buildInsights summarizes salary efficiency and ownership of the selected players
+func (lp *LocalProvider) buildInsights(ranked []scoredPlayer, promptCtx models.PromptContext) []string {
+	if len(ranked) == 0 {
+		return []string{"No players with projections were supplied"}
+	}
+
+	var insights []string
+
+	bestValue := ranked[0]
+	lowestOwned := ranked[0]
+	for _, sp := range ranked {
+		if sp.value > bestValue.value {
+			bestValue = sp
+		}
+		if sp.player.Ownership < lowestOwned.player.Ownership {
+			lowestOwned = sp
+		}
+	}
+
+	insights = append(insights, fmt.Sprintf("Best salary efficiency: %s at %.2f points per $1K",
+		bestValue.player.PlayerName, bestValue.value))
+	insights = append(insights, fmt.Sprintf("Lowest owned core play: %s at %.1f%% projected ownership",
+		lowestOwned.player.PlayerName, lowestOwned.player.Ownership))
+
+	switch promptCtx.ContestType {
+	case "cash":
+		insights = append(insights, "Cash game build: weighted toward salary efficiency over leverage")
+	default:
+		insights = append(insights, "Tournament build: projections discounted by projected ownership")
+	}
+
+	return insights
+}
+
+// buildStacks pairs selected players who share a team
+func (lp *LocalProvider) buildStacks(ranked []scoredPlayer) []string {
+	byTeam := make(map[string][]string)
+	var teams []string
+	for _, sp := range ranked {
+		team := sp.player.Team
+		if team == "" {
+			continue
+		}
+		if _, seen := byTeam[team]; !seen {
+			teams = append(teams, team)
+		}
+		byTeam[team] = append(byTeam[team], sp.player.PlayerName)
+	}
+
+	var stacks []string
+	for _, team := range teams {
+		if names := byTeam[team]; len(names) >= 2 {
+			stacks = append(stacks, fmt.Sprintf("%s group: %s", team, strings.Join(names, " + ")))
+		}
+	}
+
+	if len(stacks) == 0 {
+		stacks = append(stacks, "No same-team groupings among the top plays")
+	}
+	return stacks
+}
+
+// renderLineupAnalysis writes strength, weakness and suggestion lines for a lineup
+func (lp *LocalProvider) renderLineupAnalysis(request *LLMRequest) string {
+	var b strings.Builder
+
+	if len(request.Players) == 0 {
+		b.WriteString("Weakness: lineup is empty\n")
+		return b.String()
+	}
+
+	totalOwnership := 0.0
+	best := request.Players[0]
+	worst := request.Players[0]
+	for _, player := range request.Players {
+		totalOwnership += player.Ownership
+		if playerValue(player) > playerValue(best) {
+			best = player
+		}
+		if playerValue(player) < playerValue(worst) {
+			worst = player
+		}
+	}
+	avgOwnership := totalOwnership / float64(len(request.Players))
+
+	fmt.Fprintf(&b, "Strength: %s provides the best value at %.2f points per $1K\n", best.PlayerName, playerValue(best))
+	fmt.Fprintf(&b, "Weakness: %s is the least efficient slot at %.2f points per $1K\n", worst.PlayerName, playerValue(worst))
+
+	switch {
+	case avgOwnership > 20:
+		fmt.Fprintf(&b, "Weakness: average ownership of %.1f%% leaves little leverage\n", avgOwnership)
+		b.WriteString("Suggestion: pivot one chalk player to a lower-owned option at similar salary\n")
+	case avgOwnership < 8:
+		fmt.Fprintf(&b, "Strength: average ownership of %.1f%% is highly contrarian\n", avgOwnership)
+	}
+
+	fmt.Fprintf(&b, "Suggestion: consider upgrading %s\n", worst.PlayerName)
+
+	return b.String()
+}
+
+// playerValue returns projected points per $1K of salary
+func playerValue(player models.PlayerRecommendation) float64 {
+	if player.Salary <= 0 {
+		return 0
+	}
+	return player.Projection / (player.Salary / 1000)
+}
+
+// estimateTokens approximates token usage for providers that don't report it
+func estimateTokens(text string) int {
+	return (len(text) + 3) / 4
+}
+
+// streamResponseText replays a complete response through a stream handler line by line
+func streamResponseText(ctx context.Context, response *ClaudeResponse, handler ClaudeStreamHandler) error {
+	if handler == nil {
+		return nil
+	}
+
+	for _, block := range response.Content {
+		if block.Type != "text" {
+			continue
+		}
+		for _, line := range strings.SplitAfter(block.Text, "\n") {
+			if line == "" {
+				continue
+			}
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if err := handler(line); err != nil {
+				return fmt.Errorf("stream handler aborted: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
@@ -2,7 +2,9 @@ package services
 
 import (
 	"fmt"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/sirupsen/logrus"
@@ -11,8 +13,10 @@ import (
 
 // PromptBuilder creates dynamic AI prompts based on context
 type PromptBuilder struct {
+	mu             sync.RWMutex
 	templates      map[string]*PromptTemplate
 	sportModifiers map[string]SportModifier
+	templateStore  *PromptTemplateStore
 	cache          *CacheService
 	logger         *logrus.Logger
 }
@@ -20,6 +24,7 @@ type PromptBuilder struct {
 // PromptTemplate represents a template for AI prompts
 type PromptTemplate struct {
 	ID              string
+	Version         int // Incremented whenever the prompt text changes so outcomes can be compared per version
 	Name            string
 	Sport           string
 	ContestType     string
@@ -30,6 +35,14 @@ type PromptTemplate struct {
 	ComplexityLevel string // "simple", "moderate", "complex"
 }
 
+// RenderedPrompt is a built prompt together with the template version that produced it
+type RenderedPrompt struct {
+	Prompt          string
+	SystemPrompt    string
+	TemplateVersion string
+	Experiment      *ExperimentAssignment // nil when the user isn't enrolled in an experiment
+}
+
 // TemplateVariable represents a dynamic variable in the template
 type TemplateVariable struct {
 	Name        string
@@ -67,16 +80,33 @@ func NewPromptBuilder(cache *CacheService, logger *logrus.Logger) *PromptBuilder
 
 // BuildRecommendationPrompt creates a dynamic recommendation prompt
 func (pb *PromptBuilder) BuildRecommendationPrompt(ctx models.PromptContext, players []models.PlayerRecommendation) (string, string, error) {
+	rendered, err := pb.BuildRecommendationPromptForUser(ctx, players, 0)
+	if err != nil {
+		return "", "", err
+	}
+	return rendered.Prompt, rendered.SystemPrompt, nil
+}
+
+// BuildRecommendationPromptForUser creates a recommendation prompt, using the user's experiment
+// variant when a prompt experiment is running for the selected template
+func (pb *PromptBuilder) BuildRecommendationPromptForUser(ctx models.PromptContext, players []models.PlayerRecommendation, userID uint) (*RenderedPrompt, error) {
 	// Select appropriate template
-	template, err := pb.selectTemplate(ctx.Sport, ctx.ContestType, "player_recommendations")
+	key, template, err := pb.selectTemplate(ctx.Sport, ctx.ContestType, "player_recommendations")
 	if err != nil {
-		return "", "", fmt.Errorf("failed to select template: %w", err)
+		return nil, fmt.Errorf("failed to select template: %w", err)
+	}
+
+	var assignment *ExperimentAssignment
+	if pb.templateStore != nil && userID != 0 {
+		if variant, variantAssignment := pb.templateStore.VariantFor(key, userID); variant != nil {
+			template, assignment = variant, variantAssignment
+		}
 	}
 
 	// Build context-specific prompt
 	prompt, err := pb.buildPromptFromTemplate(template, ctx, players)
 	if err != nil {
-		return "", "", fmt.Errorf("failed to build prompt from template: %w", err)
+		return nil, fmt.Errorf("failed to build prompt from template: %w", err)
 	}
 
 	// Get system prompt
@@ -85,15 +115,20 @@ func (pb *PromptBuilder) BuildRecommendationPrompt(ctx models.PromptContext, pla
 	pb.logger.WithFields(logrus.Fields{
 		"sport":        ctx.Sport,
 		"contest_type": ctx.ContestType,
-		"template":     template.Name,
+		"template":     template.VersionLabel(),
 		"prompt_length": len(prompt),
 	}).Debug("Built recommendation prompt")
 
-	return prompt, systemPrompt, nil
+	return &RenderedPrompt{
+		Prompt:          prompt,
+		SystemPrompt:    systemPrompt,
+		TemplateVersion: template.VersionLabel(),
+		Experiment:      assignment,
+	}, nil
 }
 
-// selectTemplate chooses the best template for the context
-func (pb *PromptBuilder) selectTemplate(sport, contestType, promptType string) (*PromptTemplate, error) {
+// selectTemplate chooses the best template for the context and returns it with its key
+func (pb *PromptBuilder) selectTemplate(sport, contestType, promptType string) (string, *PromptTemplate, error) {
 	// Priority order: sport+contest+type -> sport+type -> sport -> default
 	templateKeys := []string{
 		fmt.Sprintf("%s_%s_%s", sport, contestType, promptType),
@@ -102,13 +137,25 @@ func (pb *PromptBuilder) selectTemplate(sport, contestType, promptType string) (
 		"default_recommendations",
 	}
 
+	pb.mu.RLock()
+	defer pb.mu.RUnlock()
+
 	for _, key := range templateKeys {
 		if template, exists := pb.templates[key]; exists {
-			return template, nil
+			return key, template, nil
 		}
 	}
 
-	return nil, fmt.Errorf("no suitable template found for sport=%s, contest_type=%s, prompt_type=%s", sport, contestType, promptType)
+	return "", nil, fmt.Errorf("no suitable template found for sport=%s, contest_type=%s, prompt_type=%s", sport, contestType, promptType)
+}
+
+// VersionLabel returns the "ID@vN" label recorded with recommendations built from the template
+func (t *PromptTemplate) VersionLabel() string {
+	version := t.Version
+	if version == 0 {
+		version = 1
+	}
+	return fmt.Sprintf("%s@v%d", t.ID, version)
 }
 
 // buildPromptFromTemplate constructs the final prompt from template and context
@@ -299,8 +346,12 @@ func (pb *PromptBuilder) addRealTimeContext(prompt string, realTimeData []models
 		if data, exists := dataByType[dataType]; exists {
 			realTimeSection += fmt.Sprintf("\n%s UPDATES:\n", strings.ToUpper(dataType))
 			for _, item := range data {
+				impact := 0.0
+				if item.ImpactRating != nil {
+					impact = *item.ImpactRating
+				}
 				realTimeSection += fmt.Sprintf("- %s (Confidence: %.0f%%, Impact: %.1f)\n",
-					pb.formatRealTimeData(item), item.Confidence*100, item.ImpactRating)
+					pb.formatRealTimeData(item), item.Confidence*100, impact)
 			}
 		}
 	}
@@ -376,6 +427,7 @@ func (pb *PromptBuilder) formatPositionBreakdown(players []models.PlayerRecommen
 	for pos, count := range positions {
 		breakdown = append(breakdown, fmt.Sprintf("%s: %d", pos, count))
 	}
+	sort.Strings(breakdown) // Deterministic prompts keep fixture keys stable
 	
 	return strings.Join(breakdown, ", ")
 }
@@ -414,6 +466,7 @@ func (pb *PromptBuilder) formatSuccessfulPatterns(patterns map[string]float64) s
 	for pattern, value := range patterns {
 		formatted = append(formatted, fmt.Sprintf("%s (%.0f%% success)", pattern, value*100))
 	}
+	sort.Strings(formatted)
 	if len(formatted) == 0 {
 		return "No historical patterns available"
 	}
@@ -555,20 +608,38 @@ func (pb *PromptBuilder) initializeSportModifiers() {
 	// Add modifiers for other sports as needed
 }
 
+// SetTemplateStore enables database-backed template versions and prompt experiments
+func (pb *PromptBuilder) SetTemplateStore(store *PromptTemplateStore) {
+	pb.templateStore = store
+}
+
 // GetTemplate returns a specific template by key
 func (pb *PromptBuilder) GetTemplate(key string) (*PromptTemplate, bool) {
+	pb.mu.RLock()
+	defer pb.mu.RUnlock()
+
 	template, exists := pb.templates[key]
 	return template, exists
 }
 
 // ListTemplates returns all available templates
 func (pb *PromptBuilder) ListTemplates() map[string]*PromptTemplate {
-	return pb.templates
+	pb.mu.RLock()
+	defer pb.mu.RUnlock()
+
+	templates := make(map[string]*PromptTemplate, len(pb.templates))
+	for key, template := range pb.templates {
+		templates[key] = template
+	}
+	return templates
 }
 
 // AddCustomTemplate allows adding custom templates
 func (pb *PromptBuilder) AddCustomTemplate(key string, template *PromptTemplate) {
+	pb.mu.Lock()
 	pb.templates[key] = template
+	pb.mu.Unlock()
+
 	pb.logger.WithFields(logrus.Fields{
 		"key":          key,
 		"template_name": template.Name,
@@ -0,0 +1,547 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stitts-dev/dfs-sim/services/ai-recommendations-service/internal/models"
+	"gorm.io/gorm"
+)
+
+// Prompt experiment statuses
+const (
+	ExperimentStatusDraft   = "draft"
+	ExperimentStatusRunning = "running"
+	ExperimentStatusStopped = "stopped"
+)
+
+// ErrTemplateNotFound is returned when a template key or version doesn't exist
+var ErrTemplateNotFound = errors.New("prompt template not found")
+
+// ErrExperimentNotFound is returned when an experiment ID doesn't exist
+var ErrExperimentNotFound = errors.New("prompt experiment not found")
+
+// PromptTemplateStore persists versioned prompt templates, keeps the prompt builder loaded with
+// the active version of each, and assigns users to running experiment variants
+type PromptTemplateStore struct {
+	db      *gorm.DB
+	builder *PromptBuilder
+	logger  *logrus.Logger
+
+	mu          sync.RWMutex
+	experiments map[string]*runningExperiment // template key -> running experiment
+}
+
+// runningExperiment caches an experiment with its variant templates resolved
+type runningExperiment struct {
+	experiment models.PromptExperiment
+	variants   []models.ExperimentVariant
+	templates  map[int]*PromptTemplate // template version -> template
+}
+
+// ExperimentAssignment records which experiment arm served a user
+type ExperimentAssignment struct {
+	ExperimentID    uint   `json:"experiment_id"`
+	ExperimentName  string `json:"experiment_name"`
+	Variant         string `json:"variant"`
+	TemplateVersion int    `json:"template_version"`
+}
+
+// TemplateVersionInput describes a new version of a prompt template. Empty fields are
+// inherited from the latest existing version of the same key.
+type TemplateVersionInput struct {
+	TemplateKey     string             `json:"template_key" binding:"required"`
+	Name            string             `json:"name"`
+	Sport           string             `json:"sport"`
+	ContestType     string             `json:"contest_type"`
+	BasePrompt      string             `json:"base_prompt" binding:"required"`
+	SystemPrompt    string             `json:"system_prompt"`
+	Variables       []TemplateVariable `json:"variables"`
+	OptimalLength   int                `json:"optimal_length"`
+	ComplexityLevel string             `json:"complexity_level"`
+	Notes           string             `json:"notes"`
+	Activate        bool               `json:"activate"`
+}
+
+// NewPromptTemplateStore creates a template store and attaches it to the prompt builder
+func NewPromptTemplateStore(db *gorm.DB, builder *PromptBuilder, logger *logrus.Logger) *PromptTemplateStore {
+	store := &PromptTemplateStore{
+		db:          db,
+		builder:     builder,
+		logger:      logger,
+		experiments: make(map[string]*runningExperiment),
+	}
+	builder.SetTemplateStore(store)
+	return store
+}
+
+// Load seeds missing built-in templates as version 1, installs the active version of every
+// template into the prompt builder and caches running experiments
+func (s *PromptTemplateStore) Load() error {
+	if err := s.seedDefaults(); err != nil {
+		return err
+	}
+
+	var active []models.PromptTemplate
+	if err := s.db.Where("is_active = ?", true).Find(&active).Error; err != nil {
+		return fmt.Errorf("failed to load active prompt templates: %w", err)
+	}
+	for i := range active {
+		template, err := toPromptTemplate(&active[i])
+		if err != nil {
+			return err
+		}
+		s.builder.AddCustomTemplate(active[i].TemplateKey, template)
+	}
+
+	var running []models.PromptExperiment
+	if err := s.db.Where("status = ?", ExperimentStatusRunning).Find(&running).Error; err != nil {
+		return fmt.Errorf("failed to load running prompt experiments: %w", err)
+	}
+	for i := range running {
+		if err := s.installExperiment(&running[i]); err != nil {
+			return err
+		}
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"active_templates":    len(active),
+		"running_experiments": len(running),
+	}).Info("Loaded prompt templates from database")
+
+	return nil
+}
+
+// seedDefaults stores built-in templates whose key has no versions in the database yet
+func (s *PromptTemplateStore) seedDefaults() error {
+	builtIn := s.builder.ListTemplates()
+	keys := make([]string, 0, len(builtIn))
+	for key := range builtIn {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		var count int64
+		if err := s.db.Model(&models.PromptTemplate{}).Where("template_key = ?", key).Count(&count).Error; err != nil {
+			return fmt.Errorf("failed to check prompt template %s: %w", key, err)
+		}
+		if count > 0 {
+			continue
+		}
+
+		row, err := fromPromptTemplate(key, builtIn[key])
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		row.Version = 1
+		row.IsActive = true
+		row.ActivatedAt = &now
+
+		if err := s.db.Create(row).Error; err != nil {
+			return fmt.Errorf("failed to seed prompt template %s: %w", key, err)
+		}
+		s.logger.WithField("template_key", key).Info("Seeded built-in prompt template")
+	}
+
+	return nil
+}
+
+// ListVersions returns stored template versions, newest first, optionally for a single key
+func (s *PromptTemplateStore) ListVersions(templateKey string) ([]models.PromptTemplate, error) {
+	query := s.db.Order("template_key ASC, version DESC")
+	if templateKey != "" {
+		query = query.Where("template_key = ?", templateKey)
+	}
+
+	var templates []models.PromptTemplate
+	if err := query.Find(&templates).Error; err != nil {
+		return nil, fmt.Errorf("failed to list prompt templates: %w", err)
+	}
+	return templates, nil
+}
+
+// CreateVersion stores a new version of a template, activating it if requested
+func (s *PromptTemplateStore) CreateVersion(input TemplateVersionInput) (*models.PromptTemplate, error) {
+	var latest models.PromptTemplate
+	err := s.db.Where("template_key = ?", input.TemplateKey).Order("version DESC").First(&latest).Error
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		if input.Sport == "" {
+			return nil, fmt.Errorf("sport is required for new template key %s", input.TemplateKey)
+		}
+		latest = models.PromptTemplate{TemplateKey: input.TemplateKey, TemplateID: input.TemplateKey}
+	case err != nil:
+		return nil, fmt.Errorf("failed to load latest version of %s: %w", input.TemplateKey, err)
+	}
+
+	row := &models.PromptTemplate{
+		TemplateKey:     input.TemplateKey,
+		TemplateID:      latest.TemplateID,
+		Name:            firstNonEmpty(input.Name, latest.Name, input.TemplateKey),
+		Sport:           firstNonEmpty(input.Sport, latest.Sport),
+		ContestType:     latest.ContestType,
+		Template:        input.BasePrompt,
+		SystemPrompt:    firstNonEmpty(input.SystemPrompt, latest.SystemPrompt),
+		Variables:       latest.Variables,
+		OptimalLength:   latest.OptimalLength,
+		ComplexityLevel: firstNonEmpty(input.ComplexityLevel, latest.ComplexityLevel),
+		Version:         latest.Version + 1,
+	}
+	if input.ContestType != "" {
+		row.ContestType = &input.ContestType
+	}
+	if input.OptimalLength > 0 {
+		row.OptimalLength = input.OptimalLength
+	}
+	if input.Notes != "" {
+		row.Notes = &input.Notes
+	}
+	if input.Variables != nil {
+		variables, err := json.Marshal(input.Variables)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode template variables: %w", err)
+		}
+		row.Variables = variables
+	}
+
+	if err := s.db.Create(row).Error; err != nil {
+		return nil, fmt.Errorf("failed to create version %d of %s: %w", row.Version, input.TemplateKey, err)
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"template_key": row.TemplateKey,
+		"version":      row.Version,
+	}).Info("Created prompt template version")
+
+	if input.Activate {
+		return s.ActivateVersion(row.TemplateKey, row.Version)
+	}
+	return row, nil
+}
+
+// ActivateVersion makes a version the one served for its key and reloads it into the prompt builder
+func (s *PromptTemplateStore) ActivateVersion(templateKey string, version int) (*models.PromptTemplate, error) {
+	var row models.PromptTemplate
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("template_key = ? AND version = ?", templateKey, version).First(&row).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrTemplateNotFound
+			}
+			return fmt.Errorf("failed to load %s version %d: %w", templateKey, version, err)
+		}
+
+		if err := tx.Model(&models.PromptTemplate{}).
+			Where("template_key = ? AND is_active = ?", templateKey, true).
+			Update("is_active", false).Error; err != nil {
+			return fmt.Errorf("failed to deactivate current version of %s: %w", templateKey, err)
+		}
+
+		now := time.Now()
+		row.IsActive = true
+		row.ActivatedAt = &now
+		if err := tx.Save(&row).Error; err != nil {
+			return fmt.Errorf("failed to activate %s version %d: %w", templateKey, version, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	template, err := toPromptTemplate(&row)
+	if err != nil {
+		return nil, err
+	}
+	s.builder.AddCustomTemplate(templateKey, template)
+
+	s.logger.WithFields(logrus.Fields{
+		"template_key": templateKey,
+		"version":      version,
+	}).Info("Activated prompt template version")
+
+	return &row, nil
+}
+
+// CreateExperiment stores a draft experiment splitting users across versions of a template
+func (s *PromptTemplateStore) CreateExperiment(name, templateKey string, variants []models.ExperimentVariant) (*models.PromptExperiment, error) {
+	if len(variants) < 2 {
+		return nil, fmt.Errorf("an experiment needs at least two variants")
+	}
+
+	names := make(map[string]bool, len(variants))
+	for i := range variants {
+		variant := &variants[i]
+		if names[variant.Name] {
+			return nil, fmt.Errorf("duplicate variant name %q", variant.Name)
+		}
+		names[variant.Name] = true
+		if variant.Weight == 0 {
+			variant.Weight = 1
+		}
+
+		var count int64
+		if err := s.db.Model(&models.PromptTemplate{}).
+			Where("template_key = ? AND version = ?", templateKey, variant.TemplateVersion).
+			Count(&count).Error; err != nil {
+			return nil, fmt.Errorf("failed to check %s version %d: %w", templateKey, variant.TemplateVersion, err)
+		}
+		if count == 0 {
+			return nil, fmt.Errorf("%w: %s version %d", ErrTemplateNotFound, templateKey, variant.TemplateVersion)
+		}
+	}
+
+	encoded, err := json.Marshal(variants)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode experiment variants: %w", err)
+	}
+
+	experiment := &models.PromptExperiment{
+		Name:        name,
+		TemplateKey: templateKey,
+		Status:      ExperimentStatusDraft,
+		Variants:    encoded,
+	}
+	if err := s.db.Create(experiment).Error; err != nil {
+		return nil, fmt.Errorf("failed to create experiment %s: %w", name, err)
+	}
+	return experiment, nil
+}
+
+// ListExperiments returns all experiments, newest first
+func (s *PromptTemplateStore) ListExperiments() ([]models.PromptExperiment, error) {
+	var experiments []models.PromptExperiment
+	if err := s.db.Order("created_at DESC").Find(&experiments).Error; err != nil {
+		return nil, fmt.Errorf("failed to list prompt experiments: %w", err)
+	}
+	return experiments, nil
+}
+
+// GetExperiment returns an experiment by ID
+func (s *PromptTemplateStore) GetExperiment(id uint) (*models.PromptExperiment, error) {
+	var experiment models.PromptExperiment
+	if err := s.db.First(&experiment, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrExperimentNotFound
+		}
+		return nil, fmt.Errorf("failed to load experiment %d: %w", id, err)
+	}
+	return &experiment, nil
+}
+
+// StartExperiment begins assigning users to an experiment's variants
+func (s *PromptTemplateStore) StartExperiment(id uint) (*models.PromptExperiment, error) {
+	experiment, err := s.GetExperiment(id)
+	if err != nil {
+		return nil, err
+	}
+	if experiment.Status != ExperimentStatusDraft {
+		return nil, fmt.Errorf("experiment %s is %s, only draft experiments can be started", experiment.Name, experiment.Status)
+	}
+
+	s.mu.RLock()
+	current, conflict := s.experiments[experiment.TemplateKey]
+	s.mu.RUnlock()
+	if conflict {
+		return nil, fmt.Errorf("experiment %s is already running on %s", current.experiment.Name, experiment.TemplateKey)
+	}
+
+	now := time.Now()
+	experiment.Status = ExperimentStatusRunning
+	experiment.StartedAt = &now
+	if err := s.db.Save(experiment).Error; err != nil {
+		return nil, fmt.Errorf("failed to start experiment %s: %w", experiment.Name, err)
+	}
+
+	if err := s.installExperiment(experiment); err != nil {
+		return nil, err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"experiment":   experiment.Name,
+		"template_key": experiment.TemplateKey,
+	}).Info("Started prompt experiment")
+
+	return experiment, nil
+}
+
+// StopExperiment stops assigning users to an experiment; its recorded outcomes are kept
+func (s *PromptTemplateStore) StopExperiment(id uint) (*models.PromptExperiment, error) {
+	experiment, err := s.GetExperiment(id)
+	if err != nil {
+		return nil, err
+	}
+	if experiment.Status != ExperimentStatusRunning {
+		return nil, fmt.Errorf("experiment %s is not running", experiment.Name)
+	}
+
+	now := time.Now()
+	experiment.Status = ExperimentStatusStopped
+	experiment.StoppedAt = &now
+	if err := s.db.Save(experiment).Error; err != nil {
+		return nil, fmt.Errorf("failed to stop experiment %s: %w", experiment.Name, err)
+	}
+
+	s.mu.Lock()
+	delete(s.experiments, experiment.TemplateKey)
+	s.mu.Unlock()
+
+	s.logger.WithField("experiment", experiment.Name).Info("Stopped prompt experiment")
+	return experiment, nil
+}
+
+// VariantFor returns the template variant and assignment for a user when an experiment is
+// running on the template key, or nil when the active version should be used
+func (s *PromptTemplateStore) VariantFor(templateKey string, userID uint) (*PromptTemplate, *ExperimentAssignment) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	running, ok := s.experiments[templateKey]
+	if !ok {
+		return nil, nil
+	}
+
+	variant := AssignVariant(running.experiment.Name, userID, running.variants)
+	return running.templates[variant.TemplateVersion], &ExperimentAssignment{
+		ExperimentID:    running.experiment.ID,
+		ExperimentName:  running.experiment.Name,
+		Variant:         variant.Name,
+		TemplateVersion: variant.TemplateVersion,
+	}
+}
+
+// installExperiment resolves an experiment's variant templates and caches it for assignment
+func (s *PromptTemplateStore) installExperiment(experiment *models.PromptExperiment) error {
+	var variants []models.ExperimentVariant
+	if err := json.Unmarshal(experiment.Variants, &variants); err != nil {
+		return fmt.Errorf("failed to decode variants of experiment %s: %w", experiment.Name, err)
+	}
+
+	versions := make([]int, 0, len(variants))
+	for _, variant := range variants {
+		versions = append(versions, variant.TemplateVersion)
+	}
+
+	var rows []models.PromptTemplate
+	if err := s.db.Where("template_key = ? AND version IN ?", experiment.TemplateKey, versions).Find(&rows).Error; err != nil {
+		return fmt.Errorf("failed to load templates for experiment %s: %w", experiment.Name, err)
+	}
+
+	templates := make(map[int]*PromptTemplate, len(rows))
+	for i := range rows {
+		template, err := toPromptTemplate(&rows[i])
+		if err != nil {
+			return err
+		}
+		templates[rows[i].Version] = template
+	}
+	for _, version := range versions {
+		if templates[version] == nil {
+			return fmt.Errorf("%w: experiment %s references %s version %d", ErrTemplateNotFound, experiment.Name, experiment.TemplateKey, version)
+		}
+	}
+
+	s.mu.Lock()
+	s.experiments[experiment.TemplateKey] = &runningExperiment{
+		experiment: *experiment,
+		variants:   variants,
+		templates:  templates,
+	}
+	s.mu.Unlock()
+
+	return nil
+}
+
+// AssignVariant deterministically maps a user to a variant by hashing the experiment name and
+// user ID, so a user sees the same variant for the life of the experiment
+func AssignVariant(experimentName string, userID uint, variants []models.ExperimentVariant) models.ExperimentVariant {
+	totalWeight := 0
+	for _, variant := range variants {
+		totalWeight += variantWeight(variant)
+	}
+
+	hash := fnv.New32a()
+	fmt.Fprintf(hash, "%s:%d", experimentName, userID)
+	bucket := int(hash.Sum32() % uint32(totalWeight))
+
+	for _, variant := range variants {
+		bucket -= variantWeight(variant)
+		if bucket < 0 {
+			return variant
+		}
+	}
+	return variants[len(variants)-1]
+}
+
+func variantWeight(variant models.ExperimentVariant) int {
+	if variant.Weight <= 0 {
+		return 1
+	}
+	return variant.Weight
+}
+
+// toPromptTemplate converts a stored template version into the prompt builder's representation
+func toPromptTemplate(row *models.PromptTemplate) (*PromptTemplate, error) {
+	template := &PromptTemplate{
+		ID:              row.TemplateID,
+		Version:         row.Version,
+		Name:            row.Name,
+		Sport:           row.Sport,
+		BasePrompt:      row.Template,
+		SystemPrompt:    row.SystemPrompt,
+		OptimalLength:   row.OptimalLength,
+		ComplexityLevel: row.ComplexityLevel,
+	}
+	if row.ContestType != nil {
+		template.ContestType = *row.ContestType
+	}
+	if len(row.Variables) > 0 {
+		if err := json.Unmarshal(row.Variables, &template.Variables); err != nil {
+			return nil, fmt.Errorf("failed to decode variables of %s version %d: %w", row.TemplateKey, row.Version, err)
+		}
+	}
+	return template, nil
+}
+
+// fromPromptTemplate converts a built-in template into a storable row
+func fromPromptTemplate(key string, template *PromptTemplate) (*models.PromptTemplate, error) {
+	row := &models.PromptTemplate{
+		TemplateKey:     key,
+		TemplateID:      firstNonEmpty(template.ID, key),
+		Name:            template.Name,
+		Sport:           template.Sport,
+		Template:        template.BasePrompt,
+		SystemPrompt:    template.SystemPrompt,
+		OptimalLength:   template.OptimalLength,
+		ComplexityLevel: template.ComplexityLevel,
+	}
+	if template.ContestType != "" {
+		contestType := template.ContestType
+		row.ContestType = &contestType
+	}
+	if len(template.Variables) > 0 {
+		variables, err := json.Marshal(template.Variables)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode variables of %s: %w", key, err)
+		}
+		row.Variables = variables
+	}
+	return row, nil
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
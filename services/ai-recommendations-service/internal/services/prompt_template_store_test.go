@@ -0,0 +1,57 @@
+package services_test
+
+import (
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stitts-dev/dfs-sim/services/ai-recommendations-service/internal/models"
+	"github.com/stitts-dev/dfs-sim/services/ai-recommendations-service/internal/services"
+)
+
+func TestAssignVariant(t *testing.T) {
+	variants := []models.ExperimentVariant{
+		{Name: "control", TemplateVersion: 1, Weight: 3},
+		{Name: "concise", TemplateVersion: 2, Weight: 1},
+	}
+
+	counts := map[string]int{}
+	for userID := uint(1); userID <= 4000; userID++ {
+		variant := services.AssignVariant("golf-gpp-concise", userID, variants)
+		counts[variant.Name]++
+
+		// Assignment is stable for a user
+		assert.Equal(t, variant, services.AssignVariant("golf-gpp-concise", userID, variants))
+	}
+
+	// Weights of 3:1 split users roughly 75/25
+	assert.InDelta(t, 3000, counts["control"], 200)
+	assert.InDelta(t, 1000, counts["concise"], 200)
+}
+
+func TestBuildRecommendationPromptForUser_ReportsTemplateVersion(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+	builder := services.NewPromptBuilder(nil, logger)
+
+	request := testGolfRequest()
+	rendered, err := builder.BuildRecommendationPromptForUser(request.Context, request.Players, request.UserID)
+	require.NoError(t, err)
+
+	assert.Equal(t, "golf_gpp_001@v1", rendered.TemplateVersion)
+	assert.Nil(t, rendered.Experiment)
+	assert.NotEmpty(t, rendered.Prompt)
+
+	builder.AddCustomTemplate("golf_gpp_player_recommendations", &services.PromptTemplate{
+		ID:         "golf_gpp_001",
+		Version:    2,
+		Sport:      "golf",
+		BasePrompt: "Pick golfers for {{contest_type}}",
+	})
+	rendered, err = builder.BuildRecommendationPromptForUser(request.Context, request.Players, request.UserID)
+	require.NoError(t, err)
+	assert.Equal(t, "golf_gpp_001@v2", rendered.TemplateVersion)
+	assert.Contains(t, rendered.Prompt, "Pick golfers for gpp")
+}
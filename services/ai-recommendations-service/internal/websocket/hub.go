@@ -1,7 +1,9 @@
 package websocket
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"sync"
 	"time"
@@ -35,6 +37,8 @@ type RecommendationHub struct {
 	clients        map[*Client]bool
 	userClients    map[string][]*Client
 	contestClients map[uint][]*Client
+	userStreams    map[string]map[string]context.CancelFunc // user ID -> stream ID -> cancel
+	streamSeq      uint64
 	broadcast      chan *models.RecommendationUpdate
 	register       chan *Client
 	unregister     chan *Client
@@ -57,6 +61,7 @@ func NewRecommendationHub(logger *logrus.Logger) *RecommendationHub {
 		clients:        make(map[*Client]bool),
 		userClients:    make(map[string][]*Client),
 		contestClients: make(map[uint][]*Client),
+		userStreams:    make(map[string]map[string]context.CancelFunc),
 		broadcast:      make(chan *models.RecommendationUpdate, 256),
 		register:       make(chan *Client),
 		unregister:     make(chan *Client),
@@ -133,9 +138,10 @@ func (h *RecommendationHub) unregisterClient(client *Client) {
 			}
 		}
 
-		// Clean up empty user client slice
+		// Clean up empty user client slice and stop any streams nobody is left to receive
 		if len(h.userClients[client.UserID]) == 0 {
 			delete(h.userClients, client.UserID)
+			h.cancelUserStreams(client.UserID)
 		}
 
 		// Remove from contest clients
@@ -303,6 +309,85 @@ func (h *RecommendationHub) BroadcastToContest(contestID uint, update *models.Re
 	}).Debug("Broadcast update to contest clients")
 }
 
+// BroadcastToUser sends an update directly to every connection of a user
+func (h *RecommendationHub) BroadcastToUser(userID string, update *models.RecommendationUpdate) {
+	h.mutex.RLock()
+	clients := h.userClients[userID]
+	h.mutex.RUnlock()
+
+	message := &RecommendationMessage{
+		Type:      update.Type,
+		Data:      update.Data,
+		Timestamp: update.Timestamp,
+		UserID:    userID,
+	}
+
+	for _, client := range clients {
+		h.sendToClient(client, message)
+	}
+}
+
+// StartUserStream registers a streamed generation for a connected user. The returned context
+// is cancelled when the stream is cancelled by the client or the user's last connection closes.
+func (h *RecommendationHub) StartUserStream(userID string) (string, context.Context, context.CancelFunc, error) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if len(h.userClients[userID]) == 0 {
+		return "", nil, nil, fmt.Errorf("user %s has no active WebSocket connection", userID)
+	}
+
+	h.streamSeq++
+	streamID := fmt.Sprintf("%s-%d-%d", userID, time.Now().UnixNano(), h.streamSeq)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	if h.userStreams[userID] == nil {
+		h.userStreams[userID] = make(map[string]context.CancelFunc)
+	}
+	h.userStreams[userID][streamID] = cancel
+
+	release := func() {
+		cancel()
+		h.mutex.Lock()
+		defer h.mutex.Unlock()
+		if streams, ok := h.userStreams[userID]; ok {
+			delete(streams, streamID)
+			if len(streams) == 0 {
+				delete(h.userStreams, userID)
+			}
+		}
+	}
+
+	return streamID, ctx, release, nil
+}
+
+// CancelUserStream stops a single stream owned by the user
+func (h *RecommendationHub) CancelUserStream(userID, streamID string) bool {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	cancel, ok := h.userStreams[userID][streamID]
+	if ok {
+		cancel()
+	}
+	return ok
+}
+
+// cancelUserStreams stops every stream of a user. Caller must hold the hub mutex.
+func (h *RecommendationHub) cancelUserStreams(userID string) {
+	streams := h.userStreams[userID]
+	for _, cancel := range streams {
+		cancel()
+	}
+
+	if len(streams) > 0 {
+		h.logger.WithFields(logrus.Fields{
+			"user_id": userID,
+			"streams": len(streams),
+		}).Info("Cancelled AI recommendation streams for disconnected user")
+	}
+}
+
 // BroadcastOwnershipAlert sends ownership change alerts
 func (h *RecommendationHub) BroadcastOwnershipAlert(contestID uint, playerID uint, ownershipData interface{}) {
 	update := &models.RecommendationUpdate{
@@ -373,6 +458,7 @@ func (h *RecommendationHub) GetHubStats() map[string]interface{} {
 		"total_clients":     len(h.clients),
 		"unique_users":      len(h.userClients),
 		"contests_tracked":  len(h.contestClients),
+		"streaming_users":   len(h.userStreams),
 		"uptime_seconds":    time.Now().Unix(), // Placeholder
 	}
 
@@ -517,6 +603,18 @@ func (c *Client) handleIncomingMessage(message []byte) {
 			}).Debug("Client unsubscribed from contest")
 		}
 
+	case "cancel_stream":
+		// Client no longer wants the partial results of a streamed recommendation
+		if streamID, ok := clientMsg["stream_id"].(string); ok {
+			cancelled := c.Hub.CancelUserStream(c.UserID, streamID)
+
+			c.Hub.logger.WithFields(logrus.Fields{
+				"user_id":   c.UserID,
+				"stream_id": streamID,
+				"cancelled": cancelled,
+			}).Debug("Client cancelled recommendation stream")
+		}
+
 	case "ping":
 		// Respond to client ping
 		response := &RecommendationMessage{
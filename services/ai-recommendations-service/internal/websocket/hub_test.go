@@ -0,0 +1,111 @@
+package websocket
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stitts-dev/dfs-sim/services/ai-recommendations-service/internal/services"
+	"github.com/stitts-dev/dfs-sim/shared/pkg/config"
+)
+
+func newTestHub() *RecommendationHub {
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+	return NewRecommendationHub(logger)
+}
+
+// connect registers a client for userID without a socket behind it
+func connect(hub *RecommendationHub, userID string) *Client {
+	client := &Client{UserID: userID, Send: make(chan []byte, 16), Hub: hub, LastSeen: time.Now()}
+	hub.registerClient(client)
+	return client
+}
+
+func TestStartUserStream_NeedsConnection(t *testing.T) {
+	hub := newTestHub()
+
+	_, _, _, err := hub.StartUserStream("user-1")
+	assert.Error(t, err, "streams are only pushed to connected users")
+}
+
+func TestUnregisterClient_CancelsStreamsWithLastConnection(t *testing.T) {
+	hub := newTestHub()
+	first, second := connect(hub, "user-1"), connect(hub, "user-1")
+	connect(hub, "user-2")
+
+	_, ctx, release, err := hub.StartUserStream("user-1")
+	require.NoError(t, err)
+	defer release()
+	_, otherCtx, releaseOther, err := hub.StartUserStream("user-2")
+	require.NoError(t, err)
+	defer releaseOther()
+
+	// The stream goes on while the user still has a connection to receive it
+	hub.unregisterClient(first)
+	assert.NoError(t, ctx.Err())
+
+	hub.unregisterClient(second)
+	assert.ErrorIs(t, ctx.Err(), context.Canceled)
+	assert.NoError(t, otherCtx.Err(), "other users' streams are untouched")
+}
+
+func TestUnregisterClient_AbortsClaudeStream(t *testing.T) {
+	serverDone := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer close(serverDone)
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, "event: message_start\ndata: {\"type\":\"message_start\",\"message\":{\"id\":\"msg_1\"}}\n\n")
+		fmt.Fprint(w, "event: content_block_delta\ndata: {\"type\":\"content_block_delta\",\"index\":0,\"delta\":{\"type\":\"text_delta\",\"text\":\"partial\"}}\n\n")
+		w.(http.Flusher).Flush()
+
+		// Hold the stream open until the client goes away
+		select {
+		case <-r.Context().Done():
+		case <-time.After(10 * time.Second):
+			t.Error("client did not abort the stream")
+		}
+	}))
+	defer server.Close()
+
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+	claude := services.NewClaudeClient(&config.Config{ClaudeAPIKey: "test-api-key"}, logger)
+	claude.SetBaseURL(server.URL)
+
+	hub := newTestHub()
+	client := connect(hub, "user-1")
+	_, ctx, release, err := hub.StartUserStream("user-1")
+	require.NoError(t, err)
+	defer release()
+
+	streamErr := make(chan error, 1)
+	go func() {
+		_, err := claude.StreamMessage(ctx, "prompt", "system", claude.BuildDefaultConfig("strategy"), func(text string) error {
+			// The user disconnects once the first partial result arrives
+			hub.unregisterClient(client)
+			return nil
+		})
+		streamErr <- err
+	}()
+
+	select {
+	case err := <-streamErr:
+		assert.ErrorIs(t, err, context.Canceled)
+	case <-time.After(5 * time.Second):
+		t.Fatal("stream kept running after the user disconnected")
+	}
+
+	select {
+	case <-serverDone:
+	case <-time.After(5 * time.Second):
+		t.Fatal("fake server never observed the disconnect")
+	}
+}
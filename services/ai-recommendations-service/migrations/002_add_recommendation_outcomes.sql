@@ -0,0 +1,30 @@
+-- migrations/002_add_recommendation_outcomes.sql
+
+-- Recommendation outcomes for scoring AI recommendations against actual results
+CREATE TABLE recommendation_outcomes (
+    id SERIAL PRIMARY KEY,
+    ai_recommendation_id INTEGER NOT NULL REFERENCES ai_recommendations(id),
+    user_id INTEGER NOT NULL,
+    contest_id INTEGER NOT NULL,
+    player_id INTEGER NOT NULL,
+    player_name VARCHAR(100),
+    sport VARCHAR(50) NOT NULL,
+    contest_type VARCHAR(50),
+    recommendation_type VARCHAR(50) NOT NULL, -- 'player_recommendations', 'lineup_optimization', 'late_swap'
+    prompt_template VARCHAR(100), -- template ID and version, e.g. 'golf_gpp_001@v1'
+    model_used VARCHAR(50),
+    confidence FLOAT NOT NULL,
+    salary FLOAT,
+    projected_points FLOAT,
+    projected_ownership FLOAT,
+    actual_points FLOAT,
+    actual_ownership FLOAT,
+    hit BOOLEAN,
+    scored_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_recommendation_outcomes_recommendation ON recommendation_outcomes (ai_recommendation_id);
+CREATE INDEX idx_recommendation_outcomes_contest_player ON recommendation_outcomes (contest_id, player_id);
+CREATE INDEX idx_recommendation_outcomes_segment ON recommendation_outcomes (sport, recommendation_type, prompt_template);
+CREATE INDEX idx_recommendation_outcomes_scored_at ON recommendation_outcomes (scored_at);
@@ -0,0 +1,46 @@
+-- migrations/003_prompt_template_versions.sql
+
+-- Prompt templates become versioned: every edit inserts a new row and exactly one version per key is active
+ALTER TABLE prompt_templates DROP CONSTRAINT IF EXISTS prompt_templates_name_key;
+ALTER TABLE prompt_templates ADD COLUMN template_key VARCHAR(100);
+ALTER TABLE prompt_templates ADD COLUMN template_id VARCHAR(50);
+ALTER TABLE prompt_templates ADD COLUMN system_prompt TEXT;
+ALTER TABLE prompt_templates ADD COLUMN optimal_length INTEGER;
+ALTER TABLE prompt_templates ADD COLUMN complexity_level VARCHAR(20);
+ALTER TABLE prompt_templates ADD COLUMN notes TEXT;
+ALTER TABLE prompt_templates ADD COLUMN activated_at TIMESTAMP;
+
+UPDATE prompt_templates SET template_key = name, template_id = name WHERE template_key IS NULL;
+
+ALTER TABLE prompt_templates ALTER COLUMN template_key SET NOT NULL;
+ALTER TABLE prompt_templates ALTER COLUMN template_id SET NOT NULL;
+ALTER TABLE prompt_templates ALTER COLUMN is_active SET DEFAULT false;
+
+CREATE UNIQUE INDEX idx_prompt_templates_key_version ON prompt_templates (template_key, version);
+CREATE UNIQUE INDEX idx_prompt_templates_one_active ON prompt_templates (template_key) WHERE is_active;
+
+-- Prompt experiments assign users deterministically to template versions
+CREATE TABLE prompt_experiments (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL UNIQUE,
+    template_key VARCHAR(100) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'draft', -- 'draft', 'running', 'stopped'
+    variants JSONB NOT NULL, -- [{"name": "control", "template_version": 1, "weight": 1}, ...]
+    started_at TIMESTAMP,
+    stopped_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_prompt_experiments_template_key ON prompt_experiments (template_key);
+CREATE UNIQUE INDEX idx_prompt_experiments_one_running ON prompt_experiments (template_key) WHERE status = 'running';
+
+-- Record which template version and experiment arm produced each recommendation
+ALTER TABLE ai_recommendations ADD COLUMN prompt_template VARCHAR(100);
+ALTER TABLE ai_recommendations ADD COLUMN experiment_id INTEGER REFERENCES prompt_experiments(id);
+ALTER TABLE ai_recommendations ADD COLUMN experiment_variant VARCHAR(50);
+
+ALTER TABLE recommendation_outcomes ADD COLUMN experiment_id INTEGER REFERENCES prompt_experiments(id);
+ALTER TABLE recommendation_outcomes ADD COLUMN experiment_variant VARCHAR(50);
+
+CREATE INDEX idx_recommendation_outcomes_experiment ON recommendation_outcomes (experiment_id, experiment_variant);
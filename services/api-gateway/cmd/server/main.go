@@ -116,6 +116,20 @@ func main() {
 			lineups.Any("/*path", serviceProxy.ProxyOptimizationRequest)
 		}
 
+		// Projection source and blended set endpoints (proxied to optimization service)
+		projections := apiV1.Group("/projections")
+		projections.Use(middleware.AuthRequired(cfg.SupabaseJWTSecret))
+		{
+			projections.Any("/*path", serviceProxy.ProxyOptimizationRequest)
+		}
+
+		// Bankroll ledger and settings endpoints (proxied to optimization service)
+		bankroll := apiV1.Group("/bankroll")
+		bankroll.Use(middleware.AuthRequired(cfg.SupabaseJWTSecret))
+		{
+			bankroll.Any("/*path", serviceProxy.ProxyOptimizationRequest)
+		}
+
 		// Sports endpoints (proxied to golf service)
 		sports := apiV1.Group("/sports")
 		{
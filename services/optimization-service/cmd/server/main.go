@@ -16,6 +16,7 @@ import (
 	"github.com/stitts-dev/dfs-sim/services/optimization-service/internal/api/handlers"
 	internalcache "github.com/stitts-dev/dfs-sim/services/optimization-service/internal/cache"
 	"github.com/stitts-dev/dfs-sim/services/optimization-service/internal/optimizer"
+	"github.com/stitts-dev/dfs-sim/services/optimization-service/internal/simulator"
 	"github.com/stitts-dev/dfs-sim/services/optimization-service/internal/websocket"
 	pkgcache "github.com/stitts-dev/dfs-sim/services/optimization-service/pkg/cache"
 	"github.com/stitts-dev/dfs-sim/services/sports-data-service/pkg/providers"
@@ -59,6 +60,13 @@ func main() {
 	}
 	defer db.Close()
 
+	// Apply pending schema migrations when enabled
+	if cfg.RunMigrations {
+		if err := db.Migrate(context.Background(), "optimization-service", cfg.MigrationsDir, logger.WithService("optimization-service")); err != nil {
+			logger.WithService("optimization-service").Fatalf("Failed to migrate database: %v", err)
+		}
+	}
+
 	// Connect to Redis with optimization-specific DB
 	opt, err := redis.ParseURL(cfg.RedisURL)
 	if err != nil {
@@ -129,6 +137,21 @@ func main() {
 		cutProbabilityEngine,
 		dataGolfClient,
 	)
+	if err := positionOptimizer.LoadCourseSkillFits(db.DB); err != nil {
+		logger.WithService("optimization-service").WithError(err).Warn("Failed to load course skill fits, using default skill premiums")
+	}
+	positionOptimizer.SetFieldSimulator(simulator.NewGolfFieldSimulator(
+		simulator.DefaultGolfTournamentConfig(),
+		simulator.DefaultGolfFieldIterations,
+		0,
+	))
+
+	// Course model scores fit on courses with historically fitted skill weights
+	courseModelEngine := optimizer.NewCourseModelEngine(nil, nil, logger.WithService("optimization-service"))
+	if err := courseModelEngine.LoadCourseSkillFits(db.DB); err != nil {
+		logger.WithService("optimization-service").WithError(err).Warn("Failed to load course skill fits, using default course model weights")
+	}
+	positionOptimizer.SetCourseModelEngine(courseModelEngine)
 
 	// Initialize handlers
 	optimizationHandler := handlers.NewOptimizationHandler(
@@ -153,6 +176,8 @@ func main() {
 			positionOptimizer,
 			cutProbabilityEngine,
 			dataGolfClient,
+			wsHub,
+			cfg.LiveGolfFeedDir,
 			structuredLogger,
 		)
 		logger.WithService("optimization-service").Info("Golf optimization handler initialized with DataGolf integration")
@@ -160,6 +185,8 @@ func main() {
 
 	healthHandler := handlers.NewHealthHandler(db, redisClient, structuredLogger)
 	lineupHandler := handlers.NewLineupHandler(db, structuredLogger)
+	projectionHandler := handlers.NewProjectionHandler(db, structuredLogger)
+	bankrollHandler := handlers.NewBankrollHandler(db, cfg, structuredLogger)
 
 	// Setup API routes for optimization service
 	apiV1 := router.Group("/api/v1")
@@ -172,13 +199,40 @@ func main() {
 		apiV1.DELETE("/lineups/:id", lineupHandler.DeleteLineup)
 		apiV1.POST("/lineups/:id/export", lineupHandler.ExportLineup)
 
+		// Uploaded projection sources and the blended sets optimize/simulate requests reference
+		apiV1.GET("/projections/sources", projectionHandler.ListSources)
+		apiV1.POST("/projections/sources", projectionHandler.UploadSource)
+		apiV1.GET("/projections/sources/:id", projectionHandler.GetSource)
+		apiV1.DELETE("/projections/sources/:id", projectionHandler.DeleteSource)
+		apiV1.GET("/projections/sets", projectionHandler.ListSets)
+		apiV1.POST("/projections/sets", projectionHandler.CreateSet)
+		apiV1.GET("/projections/sets/:id", projectionHandler.GetSet)
+		apiV1.PUT("/projections/sets/:id", projectionHandler.UpdateSet)
+		apiV1.DELETE("/projections/sets/:id", projectionHandler.DeleteSet)
+		apiV1.PUT("/projections/sets/:id/overrides", projectionHandler.SetOverrides)
+		apiV1.DELETE("/projections/sets/:id/overrides/:player_key", projectionHandler.DeleteOverride)
+
+		// Bankroll ledger, sizing settings and drawdown alerts
+		apiV1.GET("/bankroll/summary", bankrollHandler.GetSummary)
+		apiV1.GET("/bankroll/transactions", bankrollHandler.ListTransactions)
+		apiV1.POST("/bankroll/transactions", bankrollHandler.RecordTransaction)
+		apiV1.DELETE("/bankroll/transactions/:id", bankrollHandler.DeleteTransaction)
+		apiV1.POST("/bankroll/results", bankrollHandler.RecordResult)
+		apiV1.PUT("/bankroll/settings", bankrollHandler.UpdateSettings)
+
 		// Optimization endpoints
 		apiV1.POST("/optimize", optimizationHandler.OptimizeLineups)
 		apiV1.POST("/optimize/validate", optimizationHandler.ValidateOptimizationRequest)
 		apiV1.GET("/optimize/cache-status", optimizationHandler.GetCacheStatus)
+		apiV1.DELETE("/optimize/:optimization_id", optimizationHandler.CancelOptimization)
+
+		// Final player results feed the game history behind empirical correlations
+		apiV1.POST("/results/players", optimizationHandler.RecordPlayerResults)
 
 		// Simulation endpoints
 		apiV1.POST("/simulate", simulationHandler.RunSimulation)
+		apiV1.POST("/simulate/allocate", simulationHandler.AllocateEntries)
+		apiV1.POST("/simulate/sizing", simulationHandler.SizeEntries)
 		apiV1.GET("/simulate/:id/status", simulationHandler.GetSimulationStatus)
 		apiV1.GET("/simulate/:id/results", simulationHandler.GetSimulationResults)
 
@@ -192,6 +246,10 @@ func main() {
 				golf.GET("/player-projections/:tournament_id", golfOptimizationHandler.GetPlayerProjections)
 				golf.GET("/weather-impact/:tournament_id", golfOptimizationHandler.GetWeatherImpact)
 				golf.GET("/live-updates/:tournament_id", golfOptimizationHandler.GetLiveOptimizationUpdates)
+				golf.POST("/live/:tournament_id", golfOptimizationHandler.StartLiveGolf)
+				golf.GET("/live/:tournament_id", golfOptimizationHandler.GetLiveGolf)
+				golf.GET("/live/:tournament_id/window", golfOptimizationHandler.GetLiveGolfWindow)
+				golf.DELETE("/live/:tournament_id", golfOptimizationHandler.StopLiveGolf)
 			}
 		}
 	}
@@ -3,6 +3,7 @@ module github.com/stitts-dev/dfs-sim/services/optimization-service
 go 1.21
 
 require (
+	github.com/DATA-DOG/go-sqlmock v1.5.2
 	github.com/gin-gonic/gin v1.9.1
 	github.com/go-redis/redis/v8 v8.11.5
 	github.com/google/uuid v1.3.0
@@ -15,6 +16,7 @@ require (
 	gonum.org/v1/gonum v0.14.0
 	gorgonia.org/gorgonia v0.9.17
 	gorgonia.org/tensor v0.9.17
+	gorm.io/driver/postgres v1.5.0
 	gorm.io/gorm v1.25.0
 )
 
@@ -86,5 +88,4 @@ require (
 	gorgonia.org/dawson v1.2.0 // indirect
 	gorgonia.org/vecf32 v0.9.0 // indirect
 	gorgonia.org/vecf64 v0.9.0 // indirect
-	gorm.io/driver/postgres v1.5.0 // indirect
 )
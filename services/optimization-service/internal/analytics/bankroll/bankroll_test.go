@@ -0,0 +1,121 @@
+package bankroll
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stitts-dev/dfs-sim/services/optimization-service/internal/analytics/portfolio"
+	"github.com/stitts-dev/dfs-sim/shared/types"
+)
+
+func transaction(kind string, amount float64, day int) types.BankrollTransaction {
+	signed, err := SignedAmount(kind, amount)
+	if err != nil {
+		panic(err)
+	}
+	return types.BankrollTransaction{
+		Kind:       kind,
+		Amount:     signed,
+		OccurredAt: time.Date(2026, 9, day, 12, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestSummarizeLedger(t *testing.T) {
+	// Out of order on purpose: the ledger is replayed by when transactions occurred
+	ledger := []types.BankrollTransaction{
+		transaction(types.BankrollWinnings, 300, 3),
+		transaction(types.BankrollDeposit, 1000, 1),
+		transaction(types.BankrollEntryFee, 200, 2),
+		transaction(types.BankrollEntryFee, 500, 4),
+		transaction(types.BankrollWithdrawal, 100, 5),
+	}
+
+	summary := Summarize(ledger)
+	assert.InDelta(t, 500.0, summary.Balance, 1e-9)
+	assert.InDelta(t, 1000.0, summary.Deposits, 1e-9)
+	assert.InDelta(t, 100.0, summary.Withdrawals, 1e-9)
+	assert.InDelta(t, 700.0, summary.EntryFees, 1e-9)
+	assert.InDelta(t, 300.0, summary.Winnings, 1e-9)
+	assert.InDelta(t, -400.0, summary.NetProfit, 1e-9)
+	assert.InDelta(t, -400.0/700, summary.ROI, 1e-9)
+	assert.Equal(t, 5, summary.Transactions)
+
+	// Peak 1100 after the winnings, less the withdrawal; the withdrawal is not a drawdown
+	assert.InDelta(t, 1000.0, summary.Peak, 1e-9)
+	assert.InDelta(t, 0.5, summary.Drawdown, 1e-9)
+	assert.InDelta(t, 0.5, summary.MaxDrawdown, 1e-9)
+
+	recovered := Summarize(append(ledger, transaction(types.BankrollWinnings, 400, 6)))
+	assert.InDelta(t, 0.1, recovered.Drawdown, 1e-9)
+	assert.InDelta(t, 0.5, recovered.MaxDrawdown, 1e-9)
+}
+
+func TestSignedAmount(t *testing.T) {
+	amount, err := SignedAmount(types.BankrollEntryFee, 20)
+	require.NoError(t, err)
+	assert.Equal(t, -20.0, amount)
+
+	amount, err = SignedAmount(types.BankrollAdjustment, -5)
+	require.NoError(t, err)
+	assert.Equal(t, -5.0, amount)
+
+	_, err = SignedAmount(types.BankrollDeposit, -5)
+	assert.Error(t, err)
+	_, err = SignedAmount("bonus", 5)
+	assert.Error(t, err)
+}
+
+func TestDrawdownAlert(t *testing.T) {
+	send, alerted := DrawdownAlert(0.25, 0.2, false)
+	assert.True(t, send)
+	assert.True(t, alerted)
+
+	send, alerted = DrawdownAlert(0.3, 0.2, true)
+	assert.False(t, send, "a drawdown alerts once")
+	assert.True(t, alerted)
+
+	send, alerted = DrawdownAlert(0.1, 0.2, true)
+	assert.False(t, send)
+	assert.False(t, alerted, "recovering re-arms the alert")
+
+	send, _ = DrawdownAlert(0.9, 0, false)
+	assert.False(t, send, "a zero threshold disables alerts")
+}
+
+func TestRecommend(t *testing.T) {
+	contest := portfolio.ContestSpec{ContestID: "gpp", EntryFee: 10, MaxEntries: 20}
+	config := SizingConfig{KellyMultiplier: 0.25, FixedFraction: 0.02, MaxFraction: 0.1}
+
+	// Lose the stake three times in four and quadruple it once: +25% ROI with a variance of 4.6875
+	returns := []float64{-1, -1, -1, 4}
+	rec, err := Recommend(5000, contest, returns, config)
+	require.NoError(t, err)
+	assert.InDelta(t, 0.25, rec.ExpectedROI, 1e-9)
+	assert.InDelta(t, 0.25/4.6875, rec.KellyFraction, 1e-9)
+	assert.InDelta(t, 0.25, rec.ProbabilityOfProfit, 1e-9)
+	// Quarter Kelly is about 1.3% of 5000, which buys six $10 entries
+	assert.Equal(t, 6, rec.Kelly.Entries)
+	assert.InDelta(t, 60.0, rec.Kelly.Stake, 1e-9)
+	assert.Equal(t, 10, rec.FixedFraction.Entries)
+
+	// The entry limit and the per-contest cap bound both methods
+	rec, err = Recommend(100000, contest, returns, config)
+	require.NoError(t, err)
+	assert.Equal(t, 20, rec.FixedFraction.Entries)
+	rec, err = Recommend(5000, contest, []float64{0.5, 0.5}, config)
+	require.NoError(t, err)
+	assert.InDelta(t, 1.0, rec.KellyFraction, 1e-9)
+	assert.Equal(t, 20, rec.Kelly.Entries)
+	assert.InDelta(t, 0.04, rec.Kelly.Fraction, 1e-9)
+
+	rec, err = Recommend(5000, contest, []float64{-1, 0.5}, config)
+	require.NoError(t, err)
+	assert.Zero(t, rec.Kelly.Entries, "losing contests are not staked")
+	assert.Zero(t, rec.FixedFraction.Entries)
+
+	_, err = Recommend(5000, contest, returns, SizingConfig{KellyMultiplier: 2, FixedFraction: 0.02, MaxFraction: 0.1})
+	assert.Error(t, err)
+}
@@ -0,0 +1,109 @@
+package bankroll
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/stitts-dev/dfs-sim/shared/types"
+)
+
+// Summary is a bankroll ledger rolled up to its balance, totals and drawdown
+type Summary struct {
+	Balance     float64 `json:"balance"`
+	Deposits    float64 `json:"deposits"`
+	Withdrawals float64 `json:"withdrawals"`
+	EntryFees   float64 `json:"entry_fees"`
+	Winnings    float64 `json:"winnings"`
+	Adjustments float64 `json:"adjustments"`
+	// NetProfit is winnings less entry fees; ROI is that profit as a fraction of entry fees
+	NetProfit float64 `json:"net_profit"`
+	ROI       float64 `json:"roi"`
+	// Peak is the highest balance reached, moved by deposits and withdrawals so that moving money
+	// in or out is not counted as a gain or a drawdown
+	Peak float64 `json:"peak"`
+	// Drawdown is the current drop from Peak as a fraction; MaxDrawdown is the worst seen
+	Drawdown     float64 `json:"drawdown"`
+	MaxDrawdown  float64 `json:"max_drawdown"`
+	Transactions int     `json:"transactions"`
+}
+
+// SignedAmount turns an amount entered as a positive value into the ledger's signed balance
+// change for the kind. Adjustments keep the sign they were given.
+func SignedAmount(kind string, amount float64) (float64, error) {
+	if math.IsNaN(amount) || math.IsInf(amount, 0) {
+		return 0, fmt.Errorf("amount must be a number")
+	}
+	switch kind {
+	case types.BankrollDeposit, types.BankrollWinnings:
+		if amount < 0 {
+			return 0, fmt.Errorf("%s amount must not be negative", kind)
+		}
+		return amount, nil
+	case types.BankrollWithdrawal, types.BankrollEntryFee:
+		if amount < 0 {
+			return 0, fmt.Errorf("%s amount must not be negative", kind)
+		}
+		return -amount, nil
+	case types.BankrollAdjustment:
+		return amount, nil
+	default:
+		return 0, fmt.Errorf("unknown transaction kind %q", kind)
+	}
+}
+
+// Summarize replays a ledger in the order the transactions occurred
+func Summarize(transactions []types.BankrollTransaction) Summary {
+	ordered := make([]types.BankrollTransaction, len(transactions))
+	copy(ordered, transactions)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].OccurredAt.Before(ordered[j].OccurredAt)
+	})
+
+	var summary Summary
+	for _, tx := range ordered {
+		summary.Balance += tx.Amount
+		switch tx.Kind {
+		case types.BankrollDeposit:
+			summary.Deposits += tx.Amount
+			summary.Peak += tx.Amount
+		case types.BankrollWithdrawal:
+			summary.Withdrawals -= tx.Amount
+			summary.Peak += tx.Amount
+		case types.BankrollEntryFee:
+			summary.EntryFees -= tx.Amount
+		case types.BankrollWinnings:
+			summary.Winnings += tx.Amount
+		default:
+			summary.Adjustments += tx.Amount
+		}
+		if summary.Balance > summary.Peak {
+			summary.Peak = summary.Balance
+		}
+
+		summary.Drawdown = 0
+		if summary.Peak > 0 {
+			summary.Drawdown = math.Max(0, (summary.Peak-summary.Balance)/summary.Peak)
+		}
+		if summary.Drawdown > summary.MaxDrawdown {
+			summary.MaxDrawdown = summary.Drawdown
+		}
+	}
+
+	summary.NetProfit = summary.Winnings - summary.EntryFees
+	if summary.EntryFees > 0 {
+		summary.ROI = summary.NetProfit / summary.EntryFees
+	}
+	summary.Transactions = len(ordered)
+	return summary
+}
+
+// DrawdownAlert reports whether a drawdown should raise an alert and whether the drawdown counts
+// as alerted afterwards. A drawdown alerts once when it reaches the threshold and re-arms only
+// after the bankroll recovers above it; a threshold of 0 never alerts.
+func DrawdownAlert(drawdown, threshold float64, alerted bool) (send bool, nowAlerted bool) {
+	if threshold <= 0 || drawdown < threshold {
+		return false, false
+	}
+	return !alerted, true
+}
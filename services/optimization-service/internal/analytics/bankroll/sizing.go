@@ -0,0 +1,124 @@
+package bankroll
+
+import (
+	"errors"
+	"math"
+
+	"github.com/stitts-dev/dfs-sim/services/optimization-service/internal/analytics/portfolio"
+)
+
+// SizingConfig sets how much of the bankroll sizing may put into one contest
+type SizingConfig struct {
+	// KellyMultiplier scales the full Kelly stake; 0.25 is quarter Kelly
+	KellyMultiplier float64 `json:"kelly_multiplier"`
+	// FixedFraction is the share of the bankroll fixed-fraction sizing stakes per contest
+	FixedFraction float64 `json:"fixed_fraction"`
+	// MaxFraction caps either method's share of the bankroll in one contest
+	MaxFraction float64 `json:"max_fraction"`
+}
+
+// Validate checks the fractions are usable shares of a bankroll
+func (c SizingConfig) Validate() error {
+	if c.KellyMultiplier <= 0 || c.KellyMultiplier > 1 {
+		return errors.New("kelly_multiplier must be greater than 0 and at most 1")
+	}
+	if c.FixedFraction <= 0 || c.FixedFraction > 1 {
+		return errors.New("fixed_fraction must be greater than 0 and at most 1")
+	}
+	if c.MaxFraction <= 0 || c.MaxFraction > 1 {
+		return errors.New("max_fraction must be greater than 0 and at most 1")
+	}
+	return nil
+}
+
+// EntrySize is a stake in one contest and the whole entries it buys
+type EntrySize struct {
+	Fraction float64 `json:"fraction"`
+	Stake    float64 `json:"stake"`
+	Entries  int     `json:"entries"`
+}
+
+// Recommendation sizes the entries for one contest from its simulated returns
+type Recommendation struct {
+	ContestID           string  `json:"contest_id"`
+	Name                string  `json:"name,omitempty"`
+	EntryFee            float64 `json:"entry_fee"`
+	MaxEntries          int     `json:"max_entries"`
+	ExpectedROI         float64 `json:"expected_roi"`
+	ROIStdDev           float64 `json:"roi_std_dev"`
+	ProbabilityOfProfit float64 `json:"probability_of_profit"`
+	// KellyFraction is the full Kelly share of the bankroll before the multiplier and cap
+	KellyFraction float64   `json:"kelly_fraction"`
+	Kelly         EntrySize `json:"kelly"`
+	FixedFraction EntrySize `json:"fixed_fraction"`
+}
+
+// Recommend sizes a contest with fractional Kelly and with a fixed fraction of the bankroll.
+// returns holds the simulated per-dollar ROI of the stake in each iteration; the Kelly share is
+// approximated as mean ROI over its variance. Neither method stakes a contest the simulation
+// expects to lose money in, and entries are whole entries within the contest's entry limit.
+func Recommend(bankroll float64, contest portfolio.ContestSpec, returns []float64, config SizingConfig) (Recommendation, error) {
+	rec := Recommendation{
+		ContestID:  contest.ContestID,
+		Name:       contest.Name,
+		EntryFee:   contest.EntryFee,
+		MaxEntries: contest.MaxEntries,
+	}
+	if bankroll <= 0 {
+		return rec, errors.New("bankroll must be positive")
+	}
+	if contest.EntryFee <= 0 {
+		return rec, errors.New("contest needs an entry fee")
+	}
+	if err := config.Validate(); err != nil {
+		return rec, err
+	}
+	if len(returns) == 0 {
+		return rec, nil
+	}
+
+	profitable := 0
+	for _, r := range returns {
+		rec.ExpectedROI += r
+		if r > 0 {
+			profitable++
+		}
+	}
+	rec.ExpectedROI /= float64(len(returns))
+	variance := 0.0
+	for _, r := range returns {
+		variance += (r - rec.ExpectedROI) * (r - rec.ExpectedROI)
+	}
+	variance /= float64(len(returns))
+	rec.ROIStdDev = math.Sqrt(variance)
+	rec.ProbabilityOfProfit = float64(profitable) / float64(len(returns))
+
+	if rec.ExpectedROI <= 0 {
+		return rec, nil
+	}
+	// A sure profit has no Kelly limit short of the whole bankroll, so the cap decides
+	rec.KellyFraction = 1
+	if variance > 0 {
+		rec.KellyFraction = rec.ExpectedROI / variance
+	}
+	rec.Kelly = entrySize(bankroll, math.Min(rec.KellyFraction*config.KellyMultiplier, config.MaxFraction), contest)
+	rec.FixedFraction = entrySize(bankroll, math.Min(config.FixedFraction, config.MaxFraction), contest)
+	return rec, nil
+}
+
+// entrySize buys whole entries with a share of the bankroll, up to the contest's entry limit
+func entrySize(bankroll, fraction float64, contest portfolio.ContestSpec) EntrySize {
+	entries := int(math.Floor(bankroll*fraction/contest.EntryFee + 1e-9))
+	if entries > contest.MaxEntries {
+		entries = contest.MaxEntries
+	}
+	if entries < 0 {
+		entries = 0
+	}
+	stake := float64(entries) * contest.EntryFee
+	return EntrySize{
+		Fraction: stake / bankroll,
+		Stake:    stake,
+		Entries:  entries,
+	}
+}
@@ -0,0 +1,347 @@
+package portfolio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// PayoutTier pays Payout to each rank from MinRank to MaxRank
+type PayoutTier struct {
+	MinRank int     `json:"min_rank"`
+	MaxRank int     `json:"max_rank"`
+	Payout  float64 `json:"payout"`
+}
+
+// ContestSpec is a contest a lineup pool can be entered in
+type ContestSpec struct {
+	ContestID string  `json:"contest_id"`
+	Name      string  `json:"name"`
+	EntryFee  float64 `json:"entry_fee"`
+	FieldSize int     `json:"field_size"`
+	// MaxEntries is how many more entries the user may submit, from the contest's entry limit
+	MaxEntries int          `json:"max_entries"`
+	Payouts    []PayoutTier `json:"payouts"`
+	// MaxDuplicates leaves out lineups expected to be copied by more field entries than this;
+	// 0 allows any duplication and only splits the prize
+	MaxDuplicates float64 `json:"max_duplicates"`
+}
+
+// EntryLineup is a lineup's simulated finishes against the slate's field: FieldAbove holds, per
+// iteration, the fraction of the field that outscored it, and DuplicationRate is the chance one
+// field entry is an exact copy of it
+type EntryLineup struct {
+	LineupID        string    `json:"lineup_id"`
+	FieldAbove      []float64 `json:"-"`
+	DuplicationRate float64   `json:"duplication_rate"`
+}
+
+// AllocationConfig bounds an entry allocation
+type AllocationConfig struct {
+	// Bankroll is the most the allocation may spend on entry fees
+	Bankroll float64 `json:"bankroll"`
+	// MinROI is the simulated ROI, as a fraction of the fee, an entry must beat; 0 keeps only
+	// positive expected value entries
+	MinROI float64 `json:"min_roi"`
+	// MaxContestsPerLineup caps how many contests one lineup is entered in; 0 means no cap
+	MaxContestsPerLineup int `json:"max_contests_per_lineup"`
+}
+
+// EntryAssignment enters one lineup in one contest
+type EntryAssignment struct {
+	LineupID           string  `json:"lineup_id"`
+	ContestID          string  `json:"contest_id"`
+	EntryFee           float64 `json:"entry_fee"`
+	ExpectedPayout     float64 `json:"expected_payout"`
+	ExpectedROI        float64 `json:"expected_roi"`
+	ExpectedDuplicates float64 `json:"expected_duplicates"`
+}
+
+// ContestAllocation summarizes the entries placed in one contest
+type ContestAllocation struct {
+	ContestID      string  `json:"contest_id"`
+	Name           string  `json:"name,omitempty"`
+	Entries        int     `json:"entries"`
+	MaxEntries     int     `json:"max_entries"`
+	Fees           float64 `json:"fees"`
+	ExpectedPayout float64 `json:"expected_payout"`
+	ExpectedROI    float64 `json:"expected_roi"`
+}
+
+// AllocationResult is the chosen entries with the simulated return of the whole portfolio
+type AllocationResult struct {
+	Entries             []EntryAssignment   `json:"entries"`
+	Contests            []ContestAllocation `json:"contests"`
+	TotalFees           float64             `json:"total_fees"`
+	UnusedBankroll      float64             `json:"unused_bankroll"`
+	ExpectedPayout      float64             `json:"expected_payout"`
+	ExpectedProfit      float64             `json:"expected_profit"`
+	ExpectedROI         float64             `json:"expected_roi"`
+	ProfitStdDev        float64             `json:"profit_std_dev"`
+	ProbabilityOfProfit float64             `json:"probability_of_profit"`
+}
+
+// candidate is one lineup considered for one contest
+type candidate struct {
+	lineup, contest int
+	payout          float64
+	roi             float64
+	duplicates      float64
+}
+
+// AllocateEntries assigns pool lineups to contest entries to maximize simulated expected ROI.
+// Each lineup's finish in each iteration is placed in each contest by field size and paid from
+// that contest's payout curve, sharing the prize with its expected duplicates. Entries are then
+// taken best ROI first while the bankroll, each contest's entry limit and the per-lineup contest
+// cap allow, one entry per lineup per contest. The pool's own entries are treated as not
+// displacing one another, which holds while they are a small part of each field.
+func AllocateEntries(ctx context.Context, lineups []EntryLineup, contests []ContestSpec, config AllocationConfig) (*AllocationResult, error) {
+	if len(lineups) == 0 || len(contests) == 0 {
+		return nil, errors.New("at least one lineup and one contest are required")
+	}
+	if config.Bankroll <= 0 {
+		return nil, errors.New("bankroll must be positive")
+	}
+	iterations := len(lineups[0].FieldAbove)
+	if iterations == 0 {
+		return nil, errors.New("lineups have no simulated finishes")
+	}
+	for _, lineup := range lineups {
+		if len(lineup.FieldAbove) != iterations {
+			return nil, fmt.Errorf("lineup %s has %d simulated finishes, expected %d", lineup.LineupID, len(lineup.FieldAbove), iterations)
+		}
+	}
+
+	payouts := make([][]float64, len(contests))
+	for c, contest := range contests {
+		if contest.FieldSize <= 0 || contest.EntryFee <= 0 {
+			return nil, fmt.Errorf("contest %s needs a field size and an entry fee", contest.ContestID)
+		}
+		cumulative, err := cumulativePayouts(contest.Payouts, contest.FieldSize)
+		if err != nil {
+			return nil, fmt.Errorf("contest %s: %w", contest.ContestID, err)
+		}
+		payouts[c] = cumulative
+	}
+
+	candidates := make([]candidate, 0, len(lineups)*len(contests))
+	for l, lineup := range lineups {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		for c, contest := range contests {
+			if contest.MaxEntries <= 0 {
+				continue
+			}
+			duplicates := float64(contest.FieldSize-1) * lineup.DuplicationRate
+			if contest.MaxDuplicates > 0 && duplicates > contest.MaxDuplicates {
+				continue
+			}
+			total := 0.0
+			for _, above := range lineup.FieldAbove {
+				total += entryPayout(payouts[c], contest.FieldSize, above, duplicates)
+			}
+			payout := total / float64(iterations)
+			candidates = append(candidates, candidate{
+				lineup:     l,
+				contest:    c,
+				payout:     payout,
+				roi:        (payout - contest.EntryFee) / contest.EntryFee,
+				duplicates: duplicates,
+			})
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].roi != candidates[j].roi {
+			return candidates[i].roi > candidates[j].roi
+		}
+		return candidates[i].payout > candidates[j].payout
+	})
+
+	result := &AllocationResult{}
+	contestEntries := make([]int, len(contests))
+	lineupContests := make([]int, len(lineups))
+	var chosen []candidate
+	for _, cand := range candidates {
+		if cand.roi <= config.MinROI {
+			break
+		}
+		contest := contests[cand.contest]
+		if contestEntries[cand.contest] >= contest.MaxEntries {
+			continue
+		}
+		if config.MaxContestsPerLineup > 0 && lineupContests[cand.lineup] >= config.MaxContestsPerLineup {
+			continue
+		}
+		if result.TotalFees+contest.EntryFee > config.Bankroll+1e-9 {
+			continue
+		}
+
+		chosen = append(chosen, cand)
+		contestEntries[cand.contest]++
+		lineupContests[cand.lineup]++
+		result.TotalFees += contest.EntryFee
+		result.ExpectedPayout += cand.payout
+		result.Entries = append(result.Entries, EntryAssignment{
+			LineupID:           lineups[cand.lineup].LineupID,
+			ContestID:          contest.ContestID,
+			EntryFee:           contest.EntryFee,
+			ExpectedPayout:     cand.payout,
+			ExpectedROI:        cand.roi,
+			ExpectedDuplicates: cand.duplicates,
+		})
+	}
+
+	result.UnusedBankroll = config.Bankroll - result.TotalFees
+	result.ExpectedProfit = result.ExpectedPayout - result.TotalFees
+	if result.TotalFees > 0 {
+		result.ExpectedROI = result.ExpectedProfit / result.TotalFees
+	}
+	result.Contests = summarizeContests(contests, result.Entries)
+
+	// Entries in the same iteration share the slate's outcome, so the spread comes from summing
+	// the chosen entries per iteration rather than from each entry's own variance
+	if len(chosen) > 0 {
+		profits := make([]float64, iterations)
+		for k := range profits {
+			profits[k] = -result.TotalFees
+		}
+		for _, cand := range chosen {
+			contest := contests[cand.contest]
+			for k, above := range lineups[cand.lineup].FieldAbove {
+				profits[k] += entryPayout(payouts[cand.contest], contest.FieldSize, above, cand.duplicates)
+			}
+		}
+		variance, profitable := 0.0, 0
+		for _, profit := range profits {
+			variance += (profit - result.ExpectedProfit) * (profit - result.ExpectedProfit)
+			if profit > 0 {
+				profitable++
+			}
+		}
+		result.ProfitStdDev = math.Sqrt(variance / float64(iterations))
+		result.ProbabilityOfProfit = float64(profitable) / float64(iterations)
+	}
+	return result, nil
+}
+
+// DefaultPayouts approximates a payout curve for contests without one: cash games pay the top 45%
+// an equal share and tournaments pay the top 20% on a power-law curve. The prize pool defaults to
+// the entry fees less a 15% rake.
+func DefaultPayouts(contestType string, fieldSize int, entryFee, prizePool float64) []PayoutTier {
+	if fieldSize <= 0 {
+		return nil
+	}
+	if prizePool <= 0 {
+		prizePool = entryFee * float64(fieldSize) * 0.85
+	}
+
+	switch strings.ToLower(contestType) {
+	case "cash", "double_up", "50_50", "head_to_head":
+		paid := int(float64(fieldSize) * 0.45)
+		if paid < 1 {
+			paid = 1
+		}
+		return []PayoutTier{{MinRank: 1, MaxRank: paid, Payout: prizePool / float64(paid)}}
+	}
+
+	paid := fieldSize / 5
+	if paid < 1 {
+		paid = 1
+	}
+	weight := func(rank int) float64 { return math.Pow(float64(rank), -1.1) }
+	totalWeight := 0.0
+	for rank := 1; rank <= paid; rank++ {
+		totalWeight += weight(rank)
+	}
+
+	// The top ten ranks pay individually, then tiers double in width: 11-20, 21-40, 41-80...
+	var tiers []PayoutTier
+	for minRank := 1; minRank <= paid; {
+		width := 1
+		if minRank > 10 {
+			width = minRank - 1
+		}
+		maxRank := minRank + width - 1
+		if maxRank > paid {
+			maxRank = paid
+		}
+		tierWeight := 0.0
+		for rank := minRank; rank <= maxRank; rank++ {
+			tierWeight += weight(rank)
+		}
+		tiers = append(tiers, PayoutTier{
+			MinRank: minRank,
+			MaxRank: maxRank,
+			Payout:  prizePool * tierWeight / totalWeight / float64(maxRank-minRank+1),
+		})
+		minRank = maxRank + 1
+	}
+	return tiers
+}
+
+// cumulativePayouts returns the total paid to ranks 1 through r at index r
+func cumulativePayouts(tiers []PayoutTier, fieldSize int) ([]float64, error) {
+	if len(tiers) == 0 {
+		return nil, errors.New("a payout structure is required")
+	}
+	byRank := make([]float64, fieldSize+1)
+	set := make([]bool, fieldSize+1)
+	for _, tier := range tiers {
+		if tier.MinRank < 1 || tier.MaxRank < tier.MinRank || tier.Payout < 0 {
+			return nil, fmt.Errorf("invalid payout tier %d-%d", tier.MinRank, tier.MaxRank)
+		}
+		for rank := tier.MinRank; rank <= tier.MaxRank && rank <= fieldSize; rank++ {
+			if set[rank] {
+				return nil, fmt.Errorf("payout tiers overlap at rank %d", rank)
+			}
+			set[rank] = true
+			byRank[rank] = tier.Payout
+		}
+	}
+
+	cumulative := make([]float64, fieldSize+1)
+	for rank := 1; rank <= fieldSize; rank++ {
+		cumulative[rank] = cumulative[rank-1] + byRank[rank]
+	}
+	return cumulative, nil
+}
+
+// entryPayout is an entry's prize for finishing behind the given fraction of the field, averaged
+// over the ranks it ties for with its expected duplicates
+func entryPayout(cumulative []float64, fieldSize int, fieldAbove, duplicates float64) float64 {
+	rank := 1 + int(fieldAbove*float64(fieldSize-1)+0.5)
+	if rank > fieldSize {
+		rank = fieldSize
+	}
+	last := rank + int(math.Round(duplicates))
+	if last > fieldSize {
+		last = fieldSize
+	}
+	return (cumulative[last] - cumulative[rank-1]) / float64(last-rank+1)
+}
+
+func summarizeContests(contests []ContestSpec, entries []EntryAssignment) []ContestAllocation {
+	summaries := make([]ContestAllocation, len(contests))
+	index := make(map[string]int, len(contests))
+	for i, contest := range contests {
+		summaries[i] = ContestAllocation{ContestID: contest.ContestID, Name: contest.Name, MaxEntries: contest.MaxEntries}
+		index[contest.ContestID] = i
+	}
+	for _, entry := range entries {
+		summary := &summaries[index[entry.ContestID]]
+		summary.Entries++
+		summary.Fees += entry.EntryFee
+		summary.ExpectedPayout += entry.ExpectedPayout
+	}
+	for i := range summaries {
+		if summaries[i].Fees > 0 {
+			summaries[i].ExpectedROI = (summaries[i].ExpectedPayout - summaries[i].Fees) / summaries[i].Fees
+		}
+	}
+	return summaries
+}
@@ -0,0 +1,130 @@
+package portfolio
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// finishes repeats a lineup's field-above fractions to make a simulated history
+func finishes(pattern ...float64) []float64 {
+	out := make([]float64, 0, len(pattern)*25)
+	for i := 0; i < 25; i++ {
+		out = append(out, pattern...)
+	}
+	return out
+}
+
+func TestAllocateEntriesRespectsLimitsAndBankroll(t *testing.T) {
+	lineups := []EntryLineup{
+		{LineupID: "strong", FieldAbove: finishes(0, 0.1, 0.3, 0.9)},
+		{LineupID: "medium", FieldAbove: finishes(0.2, 0.4, 0.5, 0.6)},
+		{LineupID: "weak", FieldAbove: finishes(0.7, 0.8, 0.9, 0.95)},
+	}
+	contests := []ContestSpec{
+		{ContestID: "double-up", EntryFee: 10, FieldSize: 100, MaxEntries: 2,
+			Payouts: []PayoutTier{{MinRank: 1, MaxRank: 45, Payout: 18}}},
+		{ContestID: "gpp", EntryFee: 5, FieldSize: 1000, MaxEntries: 3,
+			Payouts: DefaultPayouts("gpp", 1000, 5, 0)},
+	}
+
+	result, err := AllocateEntries(context.Background(), lineups, contests, AllocationConfig{Bankroll: 1000})
+	require.NoError(t, err)
+
+	perContest := map[string]int{}
+	seen := map[[2]string]bool{}
+	for _, entry := range result.Entries {
+		assert.Greater(t, entry.ExpectedROI, 0.0, "only positive expected value entries are taken")
+		assert.False(t, seen[[2]string{entry.LineupID, entry.ContestID}], "a lineup is entered once per contest")
+		seen[[2]string{entry.LineupID, entry.ContestID}] = true
+		perContest[entry.ContestID]++
+		assert.NotEqual(t, "weak", entry.LineupID)
+	}
+	assert.LessOrEqual(t, perContest["double-up"], 2)
+	assert.LessOrEqual(t, perContest["gpp"], 3)
+	assert.Equal(t, "strong", result.Entries[0].LineupID)
+	assert.InDelta(t, result.ExpectedPayout-result.TotalFees, result.ExpectedProfit, 1e-9)
+	assert.Greater(t, result.ProfitStdDev, 0.0)
+
+	// A bankroll of one double-up fee buys only the single best entry it can afford
+	result, err = AllocateEntries(context.Background(), lineups, contests, AllocationConfig{Bankroll: 10})
+	require.NoError(t, err)
+	assert.LessOrEqual(t, result.TotalFees, 10.0)
+	assert.InDelta(t, 10-result.TotalFees, result.UnusedBankroll, 1e-9)
+}
+
+func TestAllocateEntriesDuplicationRisk(t *testing.T) {
+	chalk := EntryLineup{LineupID: "chalk", FieldAbove: finishes(0, 0, 0.5, 0.5), DuplicationRate: 0.01}
+	contest := ContestSpec{ContestID: "gpp", EntryFee: 1, FieldSize: 1001, MaxEntries: 1,
+		Payouts: []PayoutTier{{MinRank: 1, MaxRank: 1, Payout: 1000}}}
+
+	// Ten expected copies split first place eleven ways
+	result, err := AllocateEntries(context.Background(), []EntryLineup{chalk}, []ContestSpec{contest}, AllocationConfig{Bankroll: 5})
+	require.NoError(t, err)
+	require.Len(t, result.Entries, 1)
+	assert.InDelta(t, 10.0, result.Entries[0].ExpectedDuplicates, 1e-9)
+	assert.InDelta(t, 1000.0/11/2, result.Entries[0].ExpectedPayout, 1e-9)
+
+	contest.MaxDuplicates = 5
+	result, err = AllocateEntries(context.Background(), []EntryLineup{chalk}, []ContestSpec{contest}, AllocationConfig{Bankroll: 5})
+	require.NoError(t, err)
+	assert.Empty(t, result.Entries)
+}
+
+func TestDefaultPayouts(t *testing.T) {
+	gpp := DefaultPayouts("gpp", 10000, 20, 0)
+	total := 0.0
+	for i, tier := range gpp {
+		total += tier.Payout * float64(tier.MaxRank-tier.MinRank+1)
+		if i > 0 {
+			assert.Equal(t, gpp[i-1].MaxRank+1, tier.MinRank)
+			assert.LessOrEqual(t, tier.Payout, gpp[i-1].Payout)
+		}
+	}
+	assert.InDelta(t, 10000*20*0.85, total, 1e-6)
+	assert.Equal(t, 2000, gpp[len(gpp)-1].MaxRank)
+
+	cash := DefaultPayouts("cash", 100, 10, 900)
+	require.Len(t, cash, 1)
+	assert.Equal(t, 45, cash[0].MaxRank)
+	assert.InDelta(t, 20.0, cash[0].Payout, 1e-9)
+}
+
+func TestAllocateEntriesRejectsBadInput(t *testing.T) {
+	lineups := []EntryLineup{{LineupID: "a", FieldAbove: []float64{0.5}}}
+	contest := ContestSpec{ContestID: "c", EntryFee: 1, FieldSize: 10, MaxEntries: 1,
+		Payouts: []PayoutTier{{MinRank: 1, MaxRank: 3, Payout: 2}, {MinRank: 3, MaxRank: 4, Payout: 1}}}
+
+	_, err := AllocateEntries(context.Background(), lineups, []ContestSpec{contest}, AllocationConfig{Bankroll: 10})
+	assert.ErrorContains(t, err, "overlap")
+
+	_, err = AllocateEntries(context.Background(), lineups, nil, AllocationConfig{Bankroll: 10})
+	assert.Error(t, err)
+}
+
+func TestContestReturns(t *testing.T) {
+	lineups := []EntryLineup{
+		{LineupID: "winner", FieldAbove: []float64{0, 0}},
+		{LineupID: "loser", FieldAbove: []float64{0.9, 0.9}},
+	}
+	contest := ContestSpec{ContestID: "double-up", EntryFee: 10, FieldSize: 10, MaxEntries: 1,
+		Payouts: []PayoutTier{{MinRank: 1, MaxRank: 5, Payout: 18}}}
+
+	// One entry is the best lineup alone
+	returns, err := ContestReturns(lineups, contest)
+	require.NoError(t, err)
+	assert.InDeltaSlice(t, []float64{0.8, 0.8}, returns, 1e-9)
+
+	// Two entries split the stake between a cash and a miss
+	contest.MaxEntries = 2
+	returns, err = ContestReturns(lineups, contest)
+	require.NoError(t, err)
+	assert.InDeltaSlice(t, []float64{-0.1, -0.1}, returns, 1e-9)
+
+	contest.MaxEntries = 0
+	returns, err = ContestReturns(lineups, contest)
+	require.NoError(t, err)
+	assert.Empty(t, returns)
+}
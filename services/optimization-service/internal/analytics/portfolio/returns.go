@@ -0,0 +1,70 @@
+package portfolio
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// ContestReturns simulates the per-dollar return of entering a contest with the pool's best
+// lineups. Lineups are ranked by expected payout and the best ones, up to the contest's entry
+// limit, share the stake evenly; the result holds that stake's ROI in each iteration. It is empty
+// when no lineup may be entered.
+func ContestReturns(lineups []EntryLineup, contest ContestSpec) ([]float64, error) {
+	if len(lineups) == 0 {
+		return nil, errors.New("at least one lineup is required")
+	}
+	if contest.FieldSize <= 0 || contest.EntryFee <= 0 {
+		return nil, fmt.Errorf("contest %s needs a field size and an entry fee", contest.ContestID)
+	}
+	iterations := len(lineups[0].FieldAbove)
+	if iterations == 0 {
+		return nil, errors.New("lineups have no simulated finishes")
+	}
+	cumulative, err := cumulativePayouts(contest.Payouts, contest.FieldSize)
+	if err != nil {
+		return nil, fmt.Errorf("contest %s: %w", contest.ContestID, err)
+	}
+
+	type lineupPayouts struct {
+		perIteration []float64
+		mean         float64
+	}
+	var eligible []lineupPayouts
+	for _, lineup := range lineups {
+		if len(lineup.FieldAbove) != iterations {
+			return nil, fmt.Errorf("lineup %s has %d simulated finishes, expected %d", lineup.LineupID, len(lineup.FieldAbove), iterations)
+		}
+		duplicates := float64(contest.FieldSize-1) * lineup.DuplicationRate
+		if contest.MaxDuplicates > 0 && duplicates > contest.MaxDuplicates {
+			continue
+		}
+		payouts := lineupPayouts{perIteration: make([]float64, iterations)}
+		for k, above := range lineup.FieldAbove {
+			payouts.perIteration[k] = entryPayout(cumulative, contest.FieldSize, above, duplicates)
+			payouts.mean += payouts.perIteration[k]
+		}
+		payouts.mean /= float64(iterations)
+		eligible = append(eligible, payouts)
+	}
+
+	entries := contest.MaxEntries
+	if entries > len(eligible) {
+		entries = len(eligible)
+	}
+	if entries <= 0 {
+		return nil, nil
+	}
+	sort.SliceStable(eligible, func(i, j int) bool { return eligible[i].mean > eligible[j].mean })
+
+	returns := make([]float64, iterations)
+	stake := contest.EntryFee * float64(entries)
+	for k := range returns {
+		total := 0.0
+		for _, payouts := range eligible[:entries] {
+			total += payouts.perIteration[k]
+		}
+		returns[k] = (total - stake) / stake
+	}
+	return returns, nil
+}
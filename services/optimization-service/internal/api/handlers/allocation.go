@@ -0,0 +1,315 @@
+package handlers
+
+import (
+	"fmt"
+	"math/rand"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"github.com/stitts-dev/dfs-sim/services/optimization-service/internal/analytics/portfolio"
+	"github.com/stitts-dev/dfs-sim/services/optimization-service/internal/simulator"
+	"github.com/stitts-dev/dfs-sim/shared/types"
+)
+
+const (
+	defaultAllocationIterations = 1000
+	defaultAllocationField      = 500
+	maxAllocationField          = 5000
+)
+
+// AllocationRequest asks for a lineup pool to be spread across several contests on one slate
+type AllocationRequest struct {
+	Lineups    []types.GeneratedLineup    `json:"lineups"`
+	PlayerPool []types.OptimizationPlayer `json:"player_pool"`
+	Contests   []AllocationContest        `json:"contests"`
+	// Bankroll is the most the allocation may spend on entry fees
+	Bankroll             float64 `json:"bankroll"`
+	MinROI               float64 `json:"min_roi,omitempty"`
+	MaxContestsPerLineup int     `json:"max_contests_per_lineup,omitempty"`
+	Iterations           int     `json:"iterations,omitempty"`
+	// FieldSample is how many field lineups are generated to place the pool's finishes
+	FieldSample     int        `json:"field_sample,omitempty"`
+	Seed            int64      `json:"seed,omitempty"`
+	UserID          uuid.UUID  `json:"user_id,omitempty"`
+	ProjectionSetID *uuid.UUID `json:"projection_set_id,omitempty"`
+}
+
+// AllocationContest names a contest to enter. Payouts default to an approximate curve for the
+// contest type; EnteredLineups counts entries already made against its per-user limit.
+type AllocationContest struct {
+	ContestID      uuid.UUID              `json:"contest_id"`
+	Payouts        []portfolio.PayoutTier `json:"payouts,omitempty"`
+	EnteredLineups int                    `json:"entered_lineups,omitempty"`
+	MaxDuplicates  float64                `json:"max_duplicates,omitempty"`
+}
+
+// AllocateEntries assigns a lineup pool to entries across contests to maximize simulated ROI
+func (h *SimulationHandler) AllocateEntries(c *gin.Context) {
+	var req AllocationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{
+			Error: "Invalid request format",
+			Code:  "INVALID_REQUEST",
+			Details: map[string]string{
+				"validation_error": err.Error(),
+			},
+		})
+		return
+	}
+	if req.Iterations == 0 {
+		req.Iterations = defaultAllocationIterations
+	}
+	if req.FieldSample == 0 {
+		req.FieldSample = defaultAllocationField
+	}
+	if err := h.validateAllocationRequest(req); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{
+			Error: "Invalid allocation parameters",
+			Code:  "INVALID_ALLOCATION",
+			Details: map[string]string{
+				"validation_error": err.Error(),
+			},
+		})
+		return
+	}
+
+	contests, ok := h.loadAllocationContests(c, req.Contests)
+	if !ok {
+		return
+	}
+	startTime := time.Now()
+	lineups, seed, ok := h.simulateEntryLineups(c, req.Lineups, req.PlayerPool, contests, req.ProjectionSetID, req.FieldSample, req.Iterations, req.Seed)
+	if !ok {
+		return
+	}
+	specs := make([]portfolio.ContestSpec, len(contests))
+	for i, contest := range contests {
+		specs[i] = allocationContestSpec(contest, req.Contests[i])
+	}
+
+	result, err := portfolio.AllocateEntries(c.Request.Context(), lineups, specs, portfolio.AllocationConfig{
+		Bankroll:             req.Bankroll,
+		MinROI:               req.MinROI,
+		MaxContestsPerLineup: req.MaxContestsPerLineup,
+	})
+	if err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{
+			Error: "Invalid allocation parameters",
+			Code:  "INVALID_ALLOCATION",
+			Details: map[string]string{
+				"validation_error": err.Error(),
+			},
+		})
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"lineups":        len(req.Lineups),
+		"contests":       len(contests),
+		"entries":        len(result.Entries),
+		"total_fees":     result.TotalFees,
+		"expected_roi":   result.ExpectedROI,
+		"execution_time": time.Since(startTime),
+		"user_id":        req.UserID,
+	}).Info("Contest allocation completed")
+
+	c.JSON(http.StatusOK, gin.H{
+		"allocation":   result,
+		"iterations":   req.Iterations,
+		"field_sample": req.FieldSample,
+		"seed":         seed,
+	})
+}
+
+func (h *SimulationHandler) validateAllocationRequest(req AllocationRequest) error {
+	if len(req.Lineups) == 0 || len(req.PlayerPool) == 0 {
+		return fmt.Errorf("lineups and a player pool are required")
+	}
+	if len(req.Contests) == 0 {
+		return fmt.Errorf("at least one contest is required")
+	}
+	if req.Bankroll <= 0 {
+		return fmt.Errorf("bankroll must be positive")
+	}
+	if req.Iterations < 0 || req.Iterations > h.config.MaxSimulations {
+		return fmt.Errorf("iterations must be between 1 and %d", h.config.MaxSimulations)
+	}
+	if req.FieldSample < 0 || req.FieldSample > maxAllocationField {
+		return fmt.Errorf("field_sample must be between 1 and %d", maxAllocationField)
+	}
+	seen := make(map[uuid.UUID]bool, len(req.Contests))
+	for _, contest := range req.Contests {
+		if seen[contest.ContestID] {
+			return fmt.Errorf("contest %s is listed more than once", contest.ContestID)
+		}
+		seen[contest.ContestID] = true
+	}
+	// Entries are keyed by lineup ID, so each lineup needs its own
+	lineupIDs := make(map[string]bool, len(req.Lineups))
+	for i, lineup := range req.Lineups {
+		if lineup.ID == "" {
+			return fmt.Errorf("lineup %d has no id", i)
+		}
+		if lineupIDs[lineup.ID] {
+			return fmt.Errorf("lineup %s is listed more than once", lineup.ID)
+		}
+		lineupIDs[lineup.ID] = true
+	}
+	return nil
+}
+
+// simulateEntryLineups places a lineup pool against one sampled field for the contests' slate,
+// after applying the request's projection set. It returns the seed used so results can be replayed.
+func (h *SimulationHandler) simulateEntryLineups(c *gin.Context, generated []types.GeneratedLineup, pool []types.OptimizationPlayer, contests []types.Contest,
+	projectionSetID *uuid.UUID, fieldSample, iterations int, seed int64) ([]portfolio.EntryLineup, int64, bool) {
+	if projectionSetID != nil {
+		blend, ok := resolveProjectionBlend(c, h.db, h.logger, *projectionSetID)
+		if !ok {
+			return nil, 0, false
+		}
+		blend.ApplyToPool(pool)
+		blend.ApplyToLineups(generated)
+	}
+
+	players := make([]types.Player, len(pool))
+	for i, op := range pool {
+		players[i] = convertOptimizationPlayerToPlayer(op)
+	}
+
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+
+	// Every contest is on the same slate, so one field sample places the pool in all of them
+	outcomes, err := simulator.SimulateFieldOutcomes(c.Request.Context(), generated, players, &contests[0],
+		fieldSample, iterations, rand.New(rand.NewSource(seed)))
+	if err != nil {
+		h.logger.WithError(err).Error("Lineup pool simulation failed")
+		c.JSON(http.StatusUnprocessableEntity, types.ErrorResponse{
+			Error: "Failed to simulate the lineup pool",
+			Code:  "ALLOCATION_SIMULATION_ERROR",
+			Details: map[string]string{
+				"error": err.Error(),
+			},
+		})
+		return nil, 0, false
+	}
+
+	lineups := make([]portfolio.EntryLineup, len(outcomes.LineupIDs))
+	for i, id := range outcomes.LineupIDs {
+		lineups[i] = portfolio.EntryLineup{
+			LineupID:        id,
+			FieldAbove:      outcomes.FieldAbove[i],
+			DuplicationRate: outcomes.DuplicationRates[i],
+		}
+	}
+	return lineups, seed, true
+}
+
+// loadAllocationContests fetches the requested contests in request order
+func (h *SimulationHandler) loadAllocationContests(c *gin.Context, requested []AllocationContest) ([]types.Contest, bool) {
+	ids := make([]uuid.UUID, len(requested))
+	for i, contest := range requested {
+		ids[i] = contest.ContestID
+	}
+	var found []types.Contest
+	if err := h.db.Where("id IN ?", ids).Find(&found).Error; err != nil {
+		h.logger.WithError(err).Error("Failed to load contests for allocation")
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{
+			Error: "Failed to load contests",
+			Code:  "CONTEST_ERROR",
+		})
+		return nil, false
+	}
+
+	byID := make(map[uuid.UUID]types.Contest, len(found))
+	for _, contest := range found {
+		byID[contest.ID] = contest
+	}
+	contests := make([]types.Contest, len(requested))
+	for i, id := range ids {
+		contest, ok := byID[id]
+		if !ok {
+			c.JSON(http.StatusNotFound, types.ErrorResponse{
+				Error: "Contest not found",
+				Code:  "CONTEST_NOT_FOUND",
+				Details: map[string]string{
+					"contest_id": id.String(),
+				},
+			})
+			return nil, false
+		}
+		if len(contest.PositionRequirements) == 0 || contest.EntryFee <= 0 {
+			c.JSON(http.StatusBadRequest, types.ErrorResponse{
+				Error: "Contest cannot be allocated",
+				Code:  "INVALID_ALLOCATION",
+				Details: map[string]string{
+					"contest_id":       id.String(),
+					"validation_error": "contest needs roster positions and an entry fee",
+				},
+			})
+			return nil, false
+		}
+		// The pool is simulated once against the first contest's field, so every contest has to
+		// draw from the same players
+		if i > 0 && !sameSlate(contests[0], contest) {
+			c.JSON(http.StatusBadRequest, types.ErrorResponse{
+				Error: "Contests are on different slates",
+				Code:  "INVALID_ALLOCATION",
+				Details: map[string]string{
+					"contest_id":       id.String(),
+					"validation_error": "contests must share one sport, platform and slate",
+				},
+			})
+			return nil, false
+		}
+		contests[i] = contest
+	}
+	return contests, true
+}
+
+// sameSlate reports whether two contests share a player pool: the same sport and platform, and
+// the same draft group where the platform publishes one, otherwise the same tournament or start
+func sameSlate(a, b types.Contest) bool {
+	if a.SportID != b.SportID || a.Platform != b.Platform {
+		return false
+	}
+	if a.DraftGroupID != "" && b.DraftGroupID != "" {
+		return a.DraftGroupID == b.DraftGroupID
+	}
+	if a.TournamentID != nil && b.TournamentID != nil {
+		return *a.TournamentID == *b.TournamentID
+	}
+	return a.StartTime.Equal(b.StartTime)
+}
+
+// allocationContestSpec sizes a contest for allocation: a capped contest is assumed to fill, and
+// the user's remaining entries come from its per-user limit
+func allocationContestSpec(contest types.Contest, req AllocationContest) portfolio.ContestSpec {
+	fieldSize := contest.TotalEntries
+	if contest.MaxEntries > 0 {
+		fieldSize = contest.MaxEntries
+	}
+	maxPerUser := contest.MaxLineupsPerUser
+	if maxPerUser <= 0 {
+		maxPerUser = 1
+	}
+	payouts := req.Payouts
+	if len(payouts) == 0 {
+		payouts = portfolio.DefaultPayouts(contest.ContestType, fieldSize, contest.EntryFee, contest.PrizePool)
+	}
+
+	return portfolio.ContestSpec{
+		ContestID:     contest.ID.String(),
+		Name:          contest.Name,
+		EntryFee:      contest.EntryFee,
+		FieldSize:     fieldSize,
+		MaxEntries:    maxPerUser - req.EnteredLineups,
+		Payouts:       payouts,
+		MaxDuplicates: req.MaxDuplicates,
+	}
+}
@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stitts-dev/dfs-sim/shared/pkg/config"
+	"github.com/stitts-dev/dfs-sim/shared/types"
+)
+
+func newTestSimulationHandler(t *testing.T) (*SimulationHandler, sqlmock.Sqlmock) {
+	gin.SetMode(gin.TestMode)
+	db, mock := newMockDB(t)
+	log := logrus.New()
+	log.SetLevel(logrus.PanicLevel)
+	return NewSimulationHandler(db, nil, nil, &config.Config{MaxSimulations: 10000}, log), mock
+}
+
+func testAllocationRequest(lineupIDs ...string) AllocationRequest {
+	req := AllocationRequest{
+		PlayerPool: testNBAPool(),
+		Contests:   []AllocationContest{{ContestID: uuid.New()}, {ContestID: uuid.New()}},
+		Bankroll:   100,
+	}
+	for _, id := range lineupIDs {
+		req.Lineups = append(req.Lineups, types.GeneratedLineup{ID: id})
+	}
+	return req
+}
+
+func TestValidateAllocationRequest_LineupIDs(t *testing.T) {
+	handler, _ := newTestSimulationHandler(t)
+
+	tests := []struct {
+		name    string
+		ids     []string
+		wantErr string
+	}{
+		{"unique ids", []string{"a", "b", "c"}, ""},
+		{"missing id", []string{"a", ""}, "lineup 1 has no id"},
+		{"duplicate id", []string{"a", "b", "a"}, "lineup a is listed more than once"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := handler.validateAllocationRequest(testAllocationRequest(tt.ids...))
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestSameSlate(t *testing.T) {
+	sport, tournament, otherTournament := uuid.New(), uuid.New(), uuid.New()
+	start := time.Date(2025, 4, 10, 19, 0, 0, 0, time.UTC)
+	contest := func(platform, draftGroup string, tournamentID *uuid.UUID, startTime time.Time) types.Contest {
+		return types.Contest{SportID: sport, Platform: platform, DraftGroupID: draftGroup, TournamentID: tournamentID, StartTime: startTime}
+	}
+	base := contest("draftkings", "101", &tournament, start)
+
+	tests := []struct {
+		name     string
+		other    types.Contest
+		expected bool
+	}{
+		{"same draft group", contest("draftkings", "101", &tournament, start.Add(time.Hour)), true},
+		{"different draft group", contest("draftkings", "102", &tournament, start), false},
+		{"different platform", contest("fanduel", "101", &tournament, start), false},
+		{"different sport", types.Contest{SportID: uuid.New(), Platform: "draftkings", DraftGroupID: "101"}, false},
+		{"same tournament without a draft group", contest("draftkings", "", &tournament, start.Add(time.Hour)), true},
+		{"different tournament without a draft group", contest("draftkings", "", &otherTournament, start), false},
+		{"same start without either", contest("draftkings", "", nil, start), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, sameSlate(base, tt.other))
+		})
+	}
+	assert.False(t, sameSlate(contest("draftkings", "", nil, start), contest("draftkings", "", nil, start.Add(time.Hour))),
+		"different starts without a draft group or tournament")
+}
+
+func TestAllocateEntries_RejectsContestsOnDifferentSlates(t *testing.T) {
+	handler, mock := newTestSimulationHandler(t)
+	router := gin.New()
+	router.POST("/allocate", handler.AllocateEntries)
+
+	req := testAllocationRequest("a", "b")
+	sport := uuid.New()
+	mock.ExpectQuery(`SELECT \* FROM "contests" WHERE id IN \(\$1,\$2\)`).
+		WithArgs(req.Contests[0].ContestID.String(), req.Contests[1].ContestID.String()).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "sport_id", "platform", "contest_type", "name", "entry_fee", "salary_cap", "draft_group_id", "roster_positions"}).
+			AddRow(req.Contests[0].ContestID, sport, "draftkings", "gpp", "NBA Main", 5.0, 50000, "101", []byte(`{"PG":1}`)).
+			AddRow(req.Contests[1].ContestID, sport, "draftkings", "gpp", "NBA Turbo", 5.0, 50000, "102", []byte(`{"PG":1}`)))
+
+	body, err := json.Marshal(req)
+	require.NoError(t, err)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/allocate", bytes.NewReader(body)))
+
+	require.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+	var response types.ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "INVALID_ALLOCATION", response.Code)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
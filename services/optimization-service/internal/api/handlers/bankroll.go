@@ -0,0 +1,451 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+
+	"github.com/stitts-dev/dfs-sim/services/optimization-service/internal/analytics/bankroll"
+	"github.com/stitts-dev/dfs-sim/shared/pkg/config"
+	"github.com/stitts-dev/dfs-sim/shared/pkg/database"
+	"github.com/stitts-dev/dfs-sim/shared/types"
+)
+
+const (
+	defaultBankrollPageSize = 50
+	maxBankrollPageSize     = 500
+
+	// bankrollDrawdownEvent is the realtime event type drawdown alerts are published as
+	bankrollDrawdownEvent = "bankroll_drawdown"
+)
+
+// BankrollHandler manages each user's bankroll ledger, sizing settings and drawdown alerts
+type BankrollHandler struct {
+	db         *database.DB
+	config     *config.Config
+	httpClient *http.Client
+	logger     *logrus.Logger
+}
+
+// NewBankrollHandler creates a new bankroll handler
+func NewBankrollHandler(db *database.DB, cfg *config.Config, logger *logrus.Logger) *BankrollHandler {
+	return &BankrollHandler{
+		db:         db,
+		config:     cfg,
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+		logger:     logger,
+	}
+}
+
+// bankrollTransactionRequest records one ledger transaction. Amount is positive for every kind
+// but adjustments, whose sign is kept.
+type bankrollTransactionRequest struct {
+	Kind       string     `json:"kind"`
+	Amount     float64    `json:"amount"`
+	ContestID  *uuid.UUID `json:"contest_id"`
+	LineupID   *uuid.UUID `json:"lineup_id"`
+	Entries    int        `json:"entries"`
+	Note       string     `json:"note"`
+	OccurredAt *time.Time `json:"occurred_at"`
+}
+
+// bankrollResultRequest records a finished contest: its entry fees and any winnings. EntryFee
+// defaults to the contest's fee.
+type bankrollResultRequest struct {
+	ContestID  uuid.UUID  `json:"contest_id"`
+	Entries    int        `json:"entries"`
+	EntryFee   *float64   `json:"entry_fee"`
+	Winnings   float64    `json:"winnings"`
+	LineupID   *uuid.UUID `json:"lineup_id"`
+	OccurredAt *time.Time `json:"occurred_at"`
+}
+
+// bankrollSettingsRequest updates the fields it carries
+type bankrollSettingsRequest struct {
+	DrawdownAlertThreshold *float64 `json:"drawdown_alert_threshold"`
+	KellyMultiplier        *float64 `json:"kelly_multiplier"`
+	FixedFraction          *float64 `json:"fixed_fraction"`
+	MaxContestFraction     *float64 `json:"max_contest_fraction"`
+}
+
+// GetSummary returns the user's balance, totals and drawdown with their bankroll settings
+func (h *BankrollHandler) GetSummary(c *gin.Context) {
+	userID, ok := contextUserID(c)
+	if !ok {
+		return
+	}
+	summary, err := loadBankrollSummary(h.db.DB, userID)
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to load bankroll ledger")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+		return
+	}
+	settings, err := loadBankrollSettings(h.db.DB, userID)
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to load bankroll settings")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"summary": summary, "settings": settings})
+}
+
+// ListTransactions pages through the user's ledger, newest first, optionally filtered by ?kind=
+func (h *BankrollHandler) ListTransactions(c *gin.Context) {
+	userID, ok := contextUserID(c)
+	if !ok {
+		return
+	}
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(defaultBankrollPageSize)))
+	if err != nil || limit <= 0 || limit > maxBankrollPageSize {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("limit must be between 1 and %d", maxBankrollPageSize)})
+		return
+	}
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "offset must not be negative"})
+		return
+	}
+
+	query := h.db.Model(&types.BankrollTransaction{}).Where("user_id = ?", userID)
+	if kind := c.Query("kind"); kind != "" {
+		query = query.Where("kind = ?", kind)
+	}
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		h.logger.WithError(err).Error("Failed to count bankroll transactions")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+		return
+	}
+	var transactions []types.BankrollTransaction
+	if err := query.Order("occurred_at DESC, created_at DESC").Limit(limit).Offset(offset).Find(&transactions).Error; err != nil {
+		h.logger.WithError(err).Error("Failed to list bankroll transactions")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"transactions": transactions,
+		"total":        total,
+		"limit":        limit,
+		"offset":       offset,
+	})
+}
+
+// RecordTransaction adds a deposit, withdrawal, entry fee, winnings or adjustment to the ledger
+func (h *BankrollHandler) RecordTransaction(c *gin.Context) {
+	userID, ok := contextUserID(c)
+	if !ok {
+		return
+	}
+	var req bankrollTransactionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format", "details": err.Error()})
+		return
+	}
+	req.Kind = strings.ToLower(strings.TrimSpace(req.Kind))
+	amount, err := bankroll.SignedAmount(req.Kind, req.Amount)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid transaction", "details": err.Error()})
+		return
+	}
+	if req.Amount == 0 || req.Entries < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid transaction", "details": "amount must be non-zero and entries must not be negative"})
+		return
+	}
+
+	transaction := types.BankrollTransaction{
+		UserID:     userID,
+		Kind:       req.Kind,
+		Amount:     amount,
+		ContestID:  req.ContestID,
+		LineupID:   req.LineupID,
+		Entries:    req.Entries,
+		Note:       req.Note,
+		OccurredAt: time.Now(),
+	}
+	if req.OccurredAt != nil {
+		transaction.OccurredAt = *req.OccurredAt
+	}
+	if err := h.db.Create(&transaction).Error; err != nil {
+		h.logger.WithError(err).Error("Failed to record bankroll transaction")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+		return
+	}
+
+	summary := h.checkDrawdown(c.Request.Context(), userID)
+	c.JSON(http.StatusCreated, gin.H{"transaction": transaction, "summary": summary})
+}
+
+// RecordResult books a finished contest as an entry fee row and, when it paid, a winnings row
+func (h *BankrollHandler) RecordResult(c *gin.Context) {
+	userID, ok := contextUserID(c)
+	if !ok {
+		return
+	}
+	var req bankrollResultRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format", "details": err.Error()})
+		return
+	}
+	if req.Entries <= 0 || req.Winnings < 0 || math.IsNaN(req.Winnings) || math.IsInf(req.Winnings, 0) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid result", "details": "entries must be positive and winnings must not be negative"})
+		return
+	}
+
+	var contest types.Contest
+	if err := h.db.First(&contest, "id = ?", req.ContestID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Contest not found"})
+			return
+		}
+		h.logger.WithError(err).Error("Failed to load contest for bankroll result")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+		return
+	}
+	entryFee := contest.EntryFee
+	if req.EntryFee != nil {
+		entryFee = *req.EntryFee
+	}
+	if entryFee < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid result", "details": "entry fee must not be negative"})
+		return
+	}
+
+	occurredAt := time.Now()
+	if req.OccurredAt != nil {
+		occurredAt = *req.OccurredAt
+	}
+	var transactions []types.BankrollTransaction
+	if fees := entryFee * float64(req.Entries); fees > 0 {
+		transactions = append(transactions, types.BankrollTransaction{
+			UserID:     userID,
+			Kind:       types.BankrollEntryFee,
+			Amount:     -fees,
+			ContestID:  &contest.ID,
+			LineupID:   req.LineupID,
+			Entries:    req.Entries,
+			Note:       contest.Name,
+			OccurredAt: occurredAt,
+		})
+	}
+	if req.Winnings > 0 {
+		transactions = append(transactions, types.BankrollTransaction{
+			UserID:     userID,
+			Kind:       types.BankrollWinnings,
+			Amount:     req.Winnings,
+			ContestID:  &contest.ID,
+			LineupID:   req.LineupID,
+			Entries:    req.Entries,
+			Note:       contest.Name,
+			OccurredAt: occurredAt,
+		})
+	}
+	if len(transactions) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid result", "details": "a free contest without winnings has nothing to record"})
+		return
+	}
+	if err := h.db.Create(&transactions).Error; err != nil {
+		h.logger.WithError(err).Error("Failed to record bankroll result")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+		return
+	}
+
+	summary := h.checkDrawdown(c.Request.Context(), userID)
+	c.JSON(http.StatusCreated, gin.H{"transactions": transactions, "summary": summary})
+}
+
+// DeleteTransaction removes one of the user's ledger transactions
+func (h *BankrollHandler) DeleteTransaction(c *gin.Context) {
+	userID, ok := contextUserID(c)
+	if !ok {
+		return
+	}
+	transactionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid transaction ID"})
+		return
+	}
+
+	result := h.db.Where("id = ? AND user_id = ?", transactionID, userID).Delete(&types.BankrollTransaction{})
+	if result.Error != nil {
+		h.logger.WithError(result.Error).Error("Failed to delete bankroll transaction")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+		return
+	}
+	if result.RowsAffected == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Transaction not found"})
+		return
+	}
+
+	summary := h.checkDrawdown(c.Request.Context(), userID)
+	c.JSON(http.StatusOK, gin.H{"message": "Transaction deleted", "summary": summary})
+}
+
+// UpdateSettings changes the user's sizing defaults and drawdown alert threshold
+func (h *BankrollHandler) UpdateSettings(c *gin.Context) {
+	userID, ok := contextUserID(c)
+	if !ok {
+		return
+	}
+	var req bankrollSettingsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format", "details": err.Error()})
+		return
+	}
+	settings, err := loadBankrollSettings(h.db.DB, userID)
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to load bankroll settings")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+		return
+	}
+
+	if req.DrawdownAlertThreshold != nil {
+		if *req.DrawdownAlertThreshold < 0 || *req.DrawdownAlertThreshold >= 1 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid settings", "details": "drawdown_alert_threshold must be at least 0 and below 1"})
+			return
+		}
+		settings.DrawdownAlertThreshold = *req.DrawdownAlertThreshold
+	}
+	if req.KellyMultiplier != nil {
+		settings.KellyMultiplier = *req.KellyMultiplier
+	}
+	if req.FixedFraction != nil {
+		settings.FixedFraction = *req.FixedFraction
+	}
+	if req.MaxContestFraction != nil {
+		settings.MaxContestFraction = *req.MaxContestFraction
+	}
+	if err := bankrollSizingConfig(settings).Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid settings", "details": err.Error()})
+		return
+	}
+
+	if err := h.db.Save(&settings).Error; err != nil {
+		h.logger.WithError(err).Error("Failed to save bankroll settings")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+		return
+	}
+	h.checkDrawdown(c.Request.Context(), userID)
+	c.JSON(http.StatusOK, gin.H{"settings": settings})
+}
+
+// checkDrawdown re-summarizes the ledger after a change and publishes a drawdown alert when the
+// drawdown first reaches the user's threshold. Failures are logged and leave the alert armed.
+func (h *BankrollHandler) checkDrawdown(ctx context.Context, userID uuid.UUID) *bankroll.Summary {
+	summary, err := loadBankrollSummary(h.db.DB, userID)
+	if err != nil {
+		h.logger.WithError(err).WithField("user_id", userID).Warn("Failed to summarize bankroll for drawdown check")
+		return nil
+	}
+	settings, err := loadBankrollSettings(h.db.DB, userID)
+	if err != nil {
+		h.logger.WithError(err).WithField("user_id", userID).Warn("Failed to load bankroll settings for drawdown check")
+		return &summary
+	}
+
+	send, alerted := bankroll.DrawdownAlert(summary.Drawdown, settings.DrawdownAlertThreshold, settings.DrawdownAlerted)
+	if send {
+		if err := h.publishDrawdownAlert(ctx, userID, summary, settings.DrawdownAlertThreshold); err != nil {
+			h.logger.WithError(err).WithField("user_id", userID).Warn("Failed to publish bankroll drawdown alert")
+			return &summary
+		}
+		now := time.Now()
+		settings.DrawdownAlertedAt = &now
+	}
+	if alerted != settings.DrawdownAlerted {
+		settings.DrawdownAlerted = alerted
+		if err := h.db.Save(&settings).Error; err != nil {
+			h.logger.WithError(err).WithField("user_id", userID).Warn("Failed to save bankroll drawdown alert state")
+		}
+	}
+	return &summary
+}
+
+// publishDrawdownAlert sends a bankroll_drawdown event to the realtime service, whose alert
+// engine delivers it to the user's matching alert rules. Impact scales with the drawdown so a
+// 50% drawdown rates 10.
+func (h *BankrollHandler) publishDrawdownAlert(ctx context.Context, userID uuid.UUID, summary bankroll.Summary, threshold float64) error {
+	if h.config.RealtimeServiceURL == "" {
+		return errors.New("realtime service URL is not configured")
+	}
+	data, err := json.Marshal(map[string]interface{}{
+		"user_id":      userID.String(),
+		"balance":      summary.Balance,
+		"peak":         summary.Peak,
+		"drawdown":     summary.Drawdown,
+		"threshold":    threshold,
+		"max_drawdown": summary.MaxDrawdown,
+	})
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(map[string]interface{}{
+		"event_type":    bankrollDrawdownEvent,
+		"source":        "optimization-service",
+		"data":          json.RawMessage(data),
+		"impact_rating": math.Min(10, summary.Drawdown*20),
+	})
+	if err != nil {
+		return err
+	}
+
+	url := strings.TrimRight(h.config.RealtimeServiceURL, "/") + "/api/v1/events"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := h.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("realtime service returned %d", resp.StatusCode)
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"user_id":   userID,
+		"drawdown":  summary.Drawdown,
+		"threshold": threshold,
+	}).Info("Published bankroll drawdown alert")
+	return nil
+}
+
+// loadBankrollSummary replays the user's whole ledger
+func loadBankrollSummary(db *gorm.DB, userID uuid.UUID) (bankroll.Summary, error) {
+	var transactions []types.BankrollTransaction
+	if err := db.Where("user_id = ?", userID).Find(&transactions).Error; err != nil {
+		return bankroll.Summary{}, err
+	}
+	return bankroll.Summarize(transactions), nil
+}
+
+// loadBankrollSettings returns the user's saved settings, or the defaults before they save any
+func loadBankrollSettings(db *gorm.DB, userID uuid.UUID) (types.BankrollSettings, error) {
+	var settings types.BankrollSettings
+	err := db.First(&settings, "user_id = ?", userID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return types.DefaultBankrollSettings(userID), nil
+	}
+	return settings, err
+}
+
+func bankrollSizingConfig(settings types.BankrollSettings) bankroll.SizingConfig {
+	return bankroll.SizingConfig{
+		KellyMultiplier: settings.KellyMultiplier,
+		FixedFraction:   settings.FixedFraction,
+		MaxFraction:     settings.MaxContestFraction,
+	}
+}
@@ -0,0 +1,225 @@
+package handlers
+
+import (
+	"fmt"
+	"math/rand"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"github.com/stitts-dev/dfs-sim/services/optimization-service/internal/live"
+	"github.com/stitts-dev/dfs-sim/services/optimization-service/internal/simulator"
+	"github.com/stitts-dev/dfs-sim/shared/pkg/scoring"
+)
+
+// LiveGolfEntrant is a player in a live tournament with their DataGolf ID for feed matching
+type LiveGolfEntrant struct {
+	simulator.GolfEntrant
+	DGID int `json:"dg_id"`
+}
+
+// StartLiveGolfRequest starts live scoring for a tournament
+type StartLiveGolfRequest struct {
+	Platform string            `json:"platform" binding:"required"`
+	Pars     []int             `json:"pars" binding:"required"`
+	Entrants []LiveGolfEntrant `json:"entrants" binding:"required"`
+	// Config overrides the default tournament shape, such as the cut rule
+	Config *simulator.GolfTournamentConfig `json:"config,omitempty"`
+	// RecordedFeed replays a file from the live feed directory instead of polling DataGolf
+	RecordedFeed string `json:"recorded_feed,omitempty"`
+	BatchSize    int    `json:"batch_size,omitempty"`
+	PollSeconds  int    `json:"poll_seconds,omitempty"`
+	Iterations   int    `json:"iterations,omitempty"`
+}
+
+// StartLiveGolf begins ingesting hole scores for a tournament and broadcasting projections
+func (h *GolfOptimizationHandler) StartLiveGolf(c *gin.Context) {
+	tournamentID := c.Param("tournament_id")
+
+	var request StartLiveGolfRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request format",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	rules, err := scoring.GolfRulesForPlatform(request.Platform)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	config := simulator.DefaultGolfTournamentConfig()
+	if request.Config != nil {
+		config = *request.Config
+	}
+
+	entrants := make([]simulator.GolfEntrant, len(request.Entrants))
+	dgIDs := make(map[int]string, len(request.Entrants))
+	for i, entrant := range request.Entrants {
+		entrants[i] = entrant.GolfEntrant
+		if entrant.DGID != 0 {
+			dgIDs[entrant.DGID] = entrant.PlayerID
+		}
+	}
+
+	tournament, err := simulator.NewLiveGolfTournament(entrants, config, request.Pars, rules)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid tournament",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	feed, err := h.liveGolfFeed(&request, dgIDs)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid live feed",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	pipeline := live.NewGolfLivePipeline(tournamentID, tournament, feed, h.broadcaster, h.logger)
+	if request.PollSeconds > 0 {
+		pipeline.PollInterval = time.Duration(request.PollSeconds) * time.Second
+	}
+	if request.Iterations > 0 {
+		pipeline.Iterations = request.Iterations
+	}
+
+	if err := h.liveManager.Start(tournamentID, pipeline); err != nil {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"tournament_id": tournamentID,
+		"entrants":      len(entrants),
+		"recorded_feed": request.RecordedFeed,
+	}).Info("Started live golf scoring")
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"status":        "started",
+		"tournament_id": tournamentID,
+		"message_type":  live.GolfLiveUpdateMessage,
+	})
+}
+
+// liveGolfFeed picks the recorded feed when one is named, otherwise DataGolf's in-play endpoint
+func (h *GolfOptimizationHandler) liveGolfFeed(request *StartLiveGolfRequest, dgIDs map[int]string) (live.GolfScoreFeed, error) {
+	if request.RecordedFeed != "" {
+		// Only files inside the feed directory can be replayed
+		path := filepath.Join(h.liveFeedDir, filepath.Base(request.RecordedFeed))
+		return live.NewRecordedGolfFeed(path, request.BatchSize)
+	}
+
+	if h.dataGolfClient == nil {
+		return nil, fmt.Errorf("DataGolf is not configured, so a recorded feed is required")
+	}
+	if len(dgIDs) == 0 {
+		return nil, fmt.Errorf("entrants need dg_id values to match the DataGolf feed")
+	}
+	return live.NewDataGolfLiveFeed(h.dataGolfClient, request.Pars, dgIDs), nil
+}
+
+// GetLiveGolf returns the latest leaderboard and projection for a tournament
+func (h *GolfOptimizationHandler) GetLiveGolf(c *gin.Context) {
+	tournamentID := c.Param("tournament_id")
+
+	pipeline, ok := h.liveManager.Pipeline(tournamentID)
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Live scoring has not been started for this tournament"})
+		return
+	}
+
+	snapshot := pipeline.Snapshot()
+	if snapshot == nil {
+		c.JSON(http.StatusAccepted, gin.H{
+			"status":        "pending",
+			"tournament_id": tournamentID,
+		})
+		return
+	}
+
+	response := gin.H{
+		"status":        "success",
+		"tournament_id": tournamentID,
+		"running":       h.liveManager.Running(tournamentID),
+		"snapshot":      snapshot,
+		"analysis":      pipeline.Analysis(),
+	}
+	if tracker, ok := h.liveManager.Tracker(tournamentID); ok {
+		if state := tracker.GetCurrentState(); state != nil {
+			response["state"] = state
+		}
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// GetLiveGolfWindow projects one contest format's scoring window from the live tournament: a
+// single-round showdown or captain slate (format=showdown&round=2), the weekend, or the classic slate
+func (h *GolfOptimizationHandler) GetLiveGolfWindow(c *gin.Context) {
+	tournamentID := c.Param("tournament_id")
+
+	pipeline, ok := h.liveManager.Pipeline(tournamentID)
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Live scoring has not been started for this tournament"})
+		return
+	}
+	tournament := pipeline.Tournament()
+
+	round := tournament.CurrentRound()
+	if roundStr := c.Query("round"); roundStr != "" {
+		parsed, err := strconv.Atoi(roundStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid round"})
+			return
+		}
+		round = parsed
+	}
+
+	format := scoring.GolfContestFormat(c.DefaultQuery("format", string(scoring.GolfFormatClassic)))
+	window, err := scoring.GolfWindowForFormat(format, round)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	iterations := pipeline.Iterations
+	if iterationsStr := c.Query("iterations"); iterationsStr != "" {
+		if parsed, err := strconv.Atoi(iterationsStr); err == nil && parsed > 0 && parsed <= 20000 {
+			iterations = parsed
+		}
+	}
+
+	projection := tournament.ProjectWindow(window, iterations, rand.New(rand.NewSource(time.Now().UnixNano())))
+	c.JSON(http.StatusOK, gin.H{
+		"status":        "success",
+		"tournament_id": tournamentID,
+		"projection":    projection,
+	})
+}
+
+// StopLiveGolf stops polling for a tournament
+func (h *GolfOptimizationHandler) StopLiveGolf(c *gin.Context) {
+	tournamentID := c.Param("tournament_id")
+
+	if !h.liveManager.Stop(tournamentID) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Live scoring is not running for this tournament"})
+		return
+	}
+
+	h.logger.WithField("tournament_id", tournamentID).Info("Stopped live golf scoring")
+	c.JSON(http.StatusOK, gin.H{
+		"status":        "stopped",
+		"tournament_id": tournamentID,
+	})
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 
+	"github.com/stitts-dev/dfs-sim/services/optimization-service/internal/live"
 	"github.com/stitts-dev/dfs-sim/services/optimization-service/internal/optimizer"
 	"github.com/stitts-dev/dfs-sim/services/sports-data-service/pkg/providers"
 	"github.com/stitts-dev/dfs-sim/shared/types"
@@ -24,6 +25,9 @@ type GolfOptimizationHandler struct {
 	positionOptimizer    *optimizer.PositionOptimizer
 	cutProbabilityEngine *optimizer.CutProbabilityEngine
 	dataGolfClient       *providers.DataGolfClient
+	liveManager          *live.GolfLiveManager
+	broadcaster          live.Broadcaster
+	liveFeedDir          string
 	logger               *logrus.Logger
 }
 
@@ -32,12 +36,17 @@ func NewGolfOptimizationHandler(
 	positionOptimizer *optimizer.PositionOptimizer,
 	cutProbabilityEngine *optimizer.CutProbabilityEngine,
 	dataGolfClient *providers.DataGolfClient,
+	broadcaster live.Broadcaster,
+	liveFeedDir string,
 	logger *logrus.Logger,
 ) *GolfOptimizationHandler {
 	return &GolfOptimizationHandler{
 		positionOptimizer:    positionOptimizer,
 		cutProbabilityEngine: cutProbabilityEngine,
 		dataGolfClient:       dataGolfClient,
+		liveManager:          live.NewGolfLiveManager(cutProbabilityEngine, logger),
+		broadcaster:          broadcaster,
+		liveFeedDir:          liveFeedDir,
 		logger:               logger,
 	}
 }
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -10,6 +11,7 @@ import (
 	"gorm.io/gorm"
 
 	"github.com/stitts-dev/dfs-sim/shared/pkg/database"
+	"github.com/stitts-dev/dfs-sim/shared/pkg/workspace"
 	"github.com/stitts-dev/dfs-sim/shared/types"
 )
 
@@ -61,7 +63,22 @@ func (h *LineupHandler) GetUserLineups(c *gin.Context) {
 		return
 	}
 
+	// A workspace_id lists the workspace's lineups; otherwise the user's own
 	query := h.db.Where("user_id = ?", userID)
+	countQuery := h.db.Model(&types.Lineup{}).Where("user_id = ?", userID)
+	if workspaceIDStr := c.Query("workspace_id"); workspaceIDStr != "" {
+		workspaceID, err := uuid.Parse(workspaceIDStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid workspace ID"})
+			return
+		}
+		if _, err := workspace.Authorize(h.db.DB, workspaceID, userID, types.WorkspaceRoleViewer); err != nil {
+			h.respondWorkspaceError(c, err)
+			return
+		}
+		query = h.db.Where("workspace_id = ?", workspaceID)
+		countQuery = h.db.Model(&types.Lineup{}).Where("workspace_id = ?", workspaceID)
+	}
 
 	if sport != "" {
 		query = query.Where("sport = ?", sport)
@@ -79,7 +96,6 @@ func (h *LineupHandler) GetUserLineups(c *gin.Context) {
 
 	// Get total count for pagination
 	var total int64
-	countQuery := h.db.Model(&types.Lineup{}).Where("user_id = ?", userID)
 	if sport != "" {
 		countQuery = countQuery.Where("sport = ?", sport)
 	}
@@ -130,8 +146,22 @@ func (h *LineupHandler) CreateLineup(c *gin.Context) {
 		return
 	}
 
+	// Workspace lineups can be added by the workspace's editors and owners
+	if req.WorkspaceID != nil {
+		if _, err := workspace.Authorize(h.db.DB, *req.WorkspaceID, userID, types.WorkspaceRoleEditor); err != nil {
+			h.respondWorkspaceError(c, err)
+			return
+		}
+	}
+
 	// Create lineup
-	if err := h.db.Create(&req).Error; err != nil {
+	err = h.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&req).Error; err != nil {
+			return err
+		}
+		return recordLineupChange(tx, &req, userID, "lineup.created")
+	})
+	if err != nil {
 		h.logger.WithError(err).Error("Failed to create lineup")
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
 		return
@@ -166,14 +196,8 @@ func (h *LineupHandler) GetLineup(c *gin.Context) {
 		return
 	}
 
-	var lineup types.Lineup
-	if err := h.db.Where("id = ? AND user_id = ?", lineupID, userID).First(&lineup).Error; err != nil {
-		if err == gorm.ErrRecordNotFound {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Lineup not found"})
-			return
-		}
-		h.logger.WithError(err).Error("Failed to fetch lineup")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+	lineup, ok := h.lineupForUser(c, lineupID, userID, types.WorkspaceRoleViewer)
+	if !ok {
 		return
 	}
 
@@ -206,15 +230,8 @@ func (h *LineupHandler) UpdateLineup(c *gin.Context) {
 		return
 	}
 
-	// Find existing lineup
-	var lineup types.Lineup
-	if err := h.db.Where("id = ? AND user_id = ?", lineupID, userID).First(&lineup).Error; err != nil {
-		if err == gorm.ErrRecordNotFound {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Lineup not found"})
-			return
-		}
-		h.logger.WithError(err).Error("Failed to fetch lineup")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+	lineup, ok := h.lineupForUser(c, lineupID, userID, types.WorkspaceRoleEditor)
+	if !ok {
 		return
 	}
 
@@ -233,7 +250,13 @@ func (h *LineupHandler) UpdateLineup(c *gin.Context) {
 	lineup.ActualPoints = req.ActualPoints
 	lineup.IsLocked = req.IsLocked
 
-	if err := h.db.Save(&lineup).Error; err != nil {
+	err = h.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(lineup).Error; err != nil {
+			return err
+		}
+		return recordLineupChange(tx, lineup, userID, "lineup.updated")
+	})
+	if err != nil {
 		h.logger.WithError(err).Error("Failed to update lineup")
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
 		return
@@ -268,20 +291,19 @@ func (h *LineupHandler) DeleteLineup(c *gin.Context) {
 		return
 	}
 
-	// Check if lineup exists and belongs to user
-	var lineup types.Lineup
-	if err := h.db.Where("id = ? AND user_id = ?", lineupID, userID).First(&lineup).Error; err != nil {
-		if err == gorm.ErrRecordNotFound {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Lineup not found"})
-			return
-		}
-		h.logger.WithError(err).Error("Failed to fetch lineup")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+	lineup, ok := h.lineupForUser(c, lineupID, userID, types.WorkspaceRoleEditor)
+	if !ok {
 		return
 	}
 
 	// Delete lineup
-	if err := h.db.Delete(&lineup).Error; err != nil {
+	err = h.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(lineup).Error; err != nil {
+			return err
+		}
+		return recordLineupChange(tx, lineup, userID, "lineup.deleted")
+	})
+	if err != nil {
 		h.logger.WithError(err).Error("Failed to delete lineup")
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
 		return
@@ -321,15 +343,8 @@ func (h *LineupHandler) ExportLineup(c *gin.Context) {
 		platform = "draftkings" // Default to DraftKings
 	}
 
-	// Find lineup
-	var lineup types.Lineup
-	if err := h.db.Where("id = ? AND user_id = ?", lineupID, userID).First(&lineup).Error; err != nil {
-		if err == gorm.ErrRecordNotFound {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Lineup not found"})
-			return
-		}
-		h.logger.WithError(err).Error("Failed to fetch lineup")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+	lineup, ok := h.lineupForUser(c, lineupID, userID, types.WorkspaceRoleViewer)
+	if !ok {
 		return
 	}
 
@@ -344,4 +359,61 @@ func (h *LineupHandler) ExportLineup(c *gin.Context) {
 	}
 
 	c.JSON(http.StatusOK, exportData)
-}
\ No newline at end of file
+}
+
+// lineupForUser loads a lineup the user may act on: their own private lineup, or a workspace
+// lineup where their role allows at least need
+func (h *LineupHandler) lineupForUser(c *gin.Context, lineupID, userID uuid.UUID, need types.WorkspaceRole) (*types.Lineup, bool) {
+	var lineup types.Lineup
+	if err := h.db.Where("id = ?", lineupID).First(&lineup).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Lineup not found"})
+			return nil, false
+		}
+		h.logger.WithError(err).Error("Failed to fetch lineup")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+		return nil, false
+	}
+
+	if lineup.WorkspaceID == nil {
+		if lineup.UserID != userID {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Lineup not found"})
+			return nil, false
+		}
+		return &lineup, true
+	}
+
+	if _, err := workspace.Authorize(h.db.DB, *lineup.WorkspaceID, userID, need); err != nil {
+		if errors.Is(err, workspace.ErrNotMember) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Lineup not found"})
+			return nil, false
+		}
+		h.respondWorkspaceError(c, err)
+		return nil, false
+	}
+	return &lineup, true
+}
+
+func (h *LineupHandler) respondWorkspaceError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, workspace.ErrNotMember):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Workspace not found"})
+	case errors.Is(err, workspace.ErrForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	default:
+		h.logger.WithError(err).Error("Failed to check workspace role")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+	}
+}
+
+// recordLineupChange adds a workspace lineup's change to the workspace audit trail
+func recordLineupChange(tx *gorm.DB, lineup *types.Lineup, actorID uuid.UUID, action string) error {
+	if lineup.WorkspaceID == nil {
+		return nil
+	}
+	return workspace.Record(tx, *lineup.WorkspaceID, actorID, action, "lineup", &lineup.ID, types.AuditDetails{
+		"name":             lineup.Name,
+		"projected_points": lineup.ProjectedPoints,
+		"is_locked":        lineup.IsLocked,
+	})
+}
@@ -0,0 +1,124 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stitts-dev/dfs-sim/shared/types"
+)
+
+// newTestLineupRouter serves the lineup routes as userID
+func newTestLineupRouter(t *testing.T, userID uuid.UUID) (*gin.Engine, sqlmock.Sqlmock) {
+	gin.SetMode(gin.TestMode)
+	db, mock := newMockDB(t)
+	log := logrus.New()
+	log.SetLevel(logrus.PanicLevel)
+	handler := NewLineupHandler(db, log)
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("user_id", userID.String())
+	})
+	router.GET("/lineups/:id", handler.GetLineup)
+	router.DELETE("/lineups/:id", handler.DeleteLineup)
+	return router, mock
+}
+
+// expectLineup answers the lineup lookup; a nil workspaceID makes it private to ownerID
+func expectLineup(mock sqlmock.Sqlmock, lineupID, ownerID uuid.UUID, workspaceID *uuid.UUID) {
+	mock.ExpectQuery(`SELECT \* FROM "lineups" WHERE id = \$1`).
+		WithArgs(lineupID.String()).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "workspace_id", "name", "sport", "platform"}).
+			AddRow(lineupID, ownerID, workspaceID, "Main slate", "nba", "draftkings"))
+}
+
+// expectMember answers the workspace role lookup; an empty role means userID isn't a member
+func expectMember(mock sqlmock.Sqlmock, workspaceID, userID uuid.UUID, role types.WorkspaceRole) {
+	rows := sqlmock.NewRows([]string{"workspace_id", "user_id", "role"})
+	if role != "" {
+		rows.AddRow(workspaceID, userID, role)
+	}
+	mock.ExpectQuery(`SELECT \* FROM "workspace_members" WHERE workspace_id = \$1 AND user_id = \$2`).
+		WithArgs(workspaceID.String(), userID.String()).
+		WillReturnRows(rows)
+}
+
+func sendLineupRequest(router *gin.Engine, method string, lineupID uuid.UUID) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(method, "/lineups/"+lineupID.String(), nil))
+	return w
+}
+
+func TestLineupForUser_PrivateLineup(t *testing.T) {
+	owner, lineupID := uuid.New(), uuid.New()
+
+	router, mock := newTestLineupRouter(t, owner)
+	expectLineup(mock, lineupID, owner, nil)
+	w := sendLineupRequest(router, http.MethodGet, lineupID)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	// Another user's private lineup is hidden rather than forbidden
+	router, mock = newTestLineupRouter(t, uuid.New())
+	expectLineup(mock, lineupID, owner, nil)
+	w = sendLineupRequest(router, http.MethodGet, lineupID)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestLineupForUser_WorkspaceRoles(t *testing.T) {
+	author, workspaceID := uuid.New(), uuid.New()
+
+	tests := []struct {
+		name     string
+		method   string
+		role     types.WorkspaceRole
+		expected int
+	}{
+		{"viewer reads", http.MethodGet, types.WorkspaceRoleViewer, http.StatusOK},
+		{"viewer cannot delete", http.MethodDelete, types.WorkspaceRoleViewer, http.StatusForbidden},
+		{"non-member cannot read", http.MethodGet, "", http.StatusNotFound},
+		{"non-member cannot delete", http.MethodDelete, "", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			member, lineupID := uuid.New(), uuid.New()
+			router, mock := newTestLineupRouter(t, member)
+			expectLineup(mock, lineupID, author, &workspaceID)
+			expectMember(mock, workspaceID, member, tt.role)
+
+			w := sendLineupRequest(router, tt.method, lineupID)
+			assert.Equal(t, tt.expected, w.Code)
+			assert.NoError(t, mock.ExpectationsWereMet(), "nothing is written without the role")
+		})
+	}
+}
+
+func TestDeleteLineup_EditorRecordsAudit(t *testing.T) {
+	author, editor := uuid.New(), uuid.New()
+	workspaceID, lineupID := uuid.New(), uuid.New()
+
+	router, mock := newTestLineupRouter(t, editor)
+	expectLineup(mock, lineupID, author, &workspaceID)
+	expectMember(mock, workspaceID, editor, types.WorkspaceRoleEditor)
+	mock.ExpectBegin()
+	mock.ExpectExec(`DELETE FROM "lineups"`).
+		WithArgs(lineupID.String()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	// The audit entry names the editor, not the lineup's author
+	mock.ExpectQuery(`INSERT INTO "workspace_audit`).
+		WithArgs(workspaceID.String(), editor.String(), "lineup.deleted", "lineup", lineupID.String(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()))
+	mock.ExpectCommit()
+
+	w := sendLineupRequest(router, http.MethodDelete, lineupID)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -1,21 +1,27 @@
 package handlers
 
 import (
+	"context"
 	"crypto/md5"
+	"errors"
 	"fmt"
 	"math"
 	"net/http"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
 
 	"github.com/stitts-dev/dfs-sim/services/optimization-service/internal/websocket"
 	"github.com/stitts-dev/dfs-sim/services/optimization-service/internal/optimizer"
+	"github.com/stitts-dev/dfs-sim/services/optimization-service/internal/simulator"
 	"github.com/stitts-dev/dfs-sim/services/optimization-service/internal/cache"
 	"github.com/stitts-dev/dfs-sim/shared/pkg/config"
 	"github.com/stitts-dev/dfs-sim/shared/pkg/database"
+	"github.com/stitts-dev/dfs-sim/shared/pkg/weather"
 	"github.com/stitts-dev/dfs-sim/shared/types"
 )
 
@@ -28,13 +34,15 @@ type OptimizationHandler struct {
 	logger          *logrus.Logger
 	dpOptimizer     *optimizer.DPOptimizer
 	analyticsEngine *optimizer.AnalyticsEngine
+	correlations    *optimizer.EmpiricalCorrelationEstimator
+	running         *optimizer.RunningOptimizations
 }
 
 // OptimizationRequestV2 represents enhanced optimization request with strategy options
 type OptimizationRequestV2 struct {
 	UserID                 uuid.UUID                        `json:"user_id"`
 	ContestID              uuid.UUID                        `json:"contest_id"`
-	PlayerPool             []types.Player                   `json:"player_pool"`
+	PlayerPool             []types.OptimizationPlayer       `json:"player_pool"`
 	Strategy               optimizer.OptimizationObjective `json:"strategy"`
 	NumLineups             int                              `json:"num_lineups"`
 	SalaryCap              int                              `json:"salary_cap"`
@@ -50,6 +58,10 @@ type OptimizationRequestV2 struct {
 	MinExposure            map[uuid.UUID]float64            `json:"min_exposure"`
 	MaxExposure            map[uuid.UUID]float64            `json:"max_exposure"`
 	OwnershipStrategy      string                           `json:"ownership_strategy"`
+	// OptimizationID and Timeout work as they do for /optimize: the run can be cancelled by ID
+	// and stops after Timeout seconds, 0 running to completion
+	OptimizationID         string                           `json:"optimization_id,omitempty"`
+	Timeout                int                              `json:"timeout,omitempty"`
 }
 
 // OptimizationResponseV2 represents enhanced optimization response with analytics
@@ -60,6 +72,10 @@ type OptimizationResponseV2 struct {
 	PerformanceMetrics *PerformanceMetrics           `json:"performance_metrics"`
 	Strategy          optimizer.OptimizationObjective `json:"strategy"`
 	CorrelationMatrix map[string]float64             `json:"correlation_matrix"`
+	OptimizationID    string                         `json:"optimization_id"`
+	// Partial marks a run stopped by cancellation or its timeout, StopReason says which
+	Partial           bool                           `json:"partial,omitempty"`
+	StopReason        string                         `json:"stop_reason,omitempty"`
 }
 
 // OptimizationAnalytics provides detailed analytics about the optimization
@@ -93,6 +109,11 @@ func NewOptimizationHandler(
 	config *config.Config,
 	logger *logrus.Logger,
 ) *OptimizationHandler {
+	var gormDB *gorm.DB
+	if db != nil {
+		gormDB = db.DB
+	}
+
 	return &OptimizationHandler{
 		db:              db,
 		cache:           cache,
@@ -101,13 +122,15 @@ func NewOptimizationHandler(
 		logger:          logger,
 		dpOptimizer:     optimizer.NewDPOptimizer(),
 		analyticsEngine: optimizer.NewAnalyticsEngine(),
+		correlations:    optimizer.NewEmpiricalCorrelationEstimator(gormDB, logger),
+		running:         optimizer.NewRunningOptimizations(),
 	}
 }
 
 // OptimizeLineups handles lineup optimization requests
 func (h *OptimizationHandler) OptimizeLineups(c *gin.Context) {
 	var req types.OptimizationRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	if err := c.ShouldBindBodyWith(&req, binding.JSON); err != nil {
 		c.JSON(http.StatusBadRequest, types.ErrorResponse{
 			Error: "Invalid request format",
 			Code:  "INVALID_REQUEST",
@@ -118,18 +141,76 @@ func (h *OptimizationHandler) OptimizeLineups(c *gin.Context) {
 		return
 	}
 
+	// Fill constraints and settings from a saved preset, keeping whatever the request overrides
+	if req.PresetID != nil && !h.resolvePreset(c, &req) {
+		return
+	}
+
+	contest, ok := h.loadContest(c, req.ContestID)
+	if !ok {
+		return
+	}
+
+	// Swap the pool's provider projections for a blended projection set
+	if req.ProjectionSetID != nil {
+		blend, ok := resolveProjectionBlend(c, h.db, h.logger, *req.ProjectionSetID)
+		if !ok {
+			return
+		}
+		applied := blend.ApplyToPool(req.PlayerPool)
+		h.logger.WithFields(logrus.Fields{
+			"projection_set_id": req.ProjectionSetID,
+			"players_projected": applied,
+		}).Info("Applied projection set")
+	}
+
 	// Generate cache key for the request
 	cacheKey := h.generateCacheKey(req)
 	
-	// Check cache first
-	if cached, err := h.cache.GetOptimizationResult(c.Request.Context(), cacheKey); err == nil && cached != nil {
+	// Build correlation matrix for golf if applicable; cached results report it too
+	var correlationMatrix map[string]float64
+	if len(req.PlayerPool) > 0 && req.PlayerPool[0].TeeTime != "" {
+		// Golf-specific correlation matrix
+		correlationMatrix = h.buildGolfCorrelationMatrix(req.PlayerPool)
+	} else {
+		// Role correlations estimated from game history, cached per contest slate
+		correlationPlayers := toCorrelationPlayers(req.PlayerPool)
+		correlationMatrix = h.correlations.MatrixForSlate(c.Request.Context(), slateKey(req.ContestID), "", correlationPlayers).ToMap()
+	}
+
+	// Check cache first; unseeded randomized builds are meant to differ on every request
+	reproducible := req.Settings.RandomnessLevel <= 0 || req.Settings.RandomSeed != 0
+	if cached := h.cachedResult(c, cacheKey); reproducible && cached != nil {
 		h.logger.WithField("cache_key", cacheKey).Info("Returning cached optimization result")
 		// Convert cached DPResult back to OptimizationResult
-		response := h.convertFromDPResult(cached)
+		response := h.convertFromDPResult(cached, correlationMatrix)
 		c.JSON(http.StatusOK, response)
 		return
 	}
 
+	// Register the run under its user so they can cancel it by ID; the client disconnecting
+	// cancels it too
+	userID, ok := contextUserID(c)
+	if !ok {
+		return
+	}
+	optimizationID := req.OptimizationID
+	if optimizationID == "" {
+		optimizationID = uuid.New().String()
+	}
+	ctx, release, err := h.running.Start(c.Request.Context(), userID, optimizationID, time.Duration(req.Settings.Timeout)*time.Second)
+	if err != nil {
+		c.JSON(http.StatusConflict, types.ErrorResponse{
+			Error: "Optimization is already running",
+			Code:  "OPTIMIZATION_RUNNING",
+			Details: map[string]string{
+				"optimization_id": optimizationID,
+			},
+		})
+		return
+	}
+	defer release()
+
 	// Create progress channel for WebSocket updates
 	progressChan := make(chan types.ProgressUpdate, 100)
 	defer close(progressChan)
@@ -139,16 +220,6 @@ func (h *OptimizationHandler) OptimizeLineups(c *gin.Context) {
 		go h.forwardProgressToWebSocket(req.UserID, progressChan)
 	}
 
-	// Build correlation matrix for golf if applicable
-	correlationMatrix := make(map[string]float64)
-	if len(req.PlayerPool) > 0 && req.PlayerPool[0].TeeTime != "" {
-		// Golf-specific correlation matrix
-		correlationMatrix = h.buildGolfCorrelationMatrix(req.PlayerPool)
-	} else {
-		// General correlation matrix
-		correlationMatrix = h.buildGeneralCorrelationMatrix(req.PlayerPool)
-	}
-
 	// Send initial progress update
 	progressChan <- types.ProgressUpdate{
 		Type:        "optimization",
@@ -157,6 +228,7 @@ func (h *OptimizationHandler) OptimizeLineups(c *gin.Context) {
 		CurrentStep: "initialization",
 		TotalSteps:  req.Settings.MaxLineups,
 		Timestamp:   time.Now(),
+		OptimizationID: optimizationID,
 	}
 
 	// Run optimization with progress tracking
@@ -174,6 +246,11 @@ func (h *OptimizationHandler) OptimizeLineups(c *gin.Context) {
 		ExcludedPlayers:     req.Settings.ExcludedPlayers,
 		MinExposure:         req.Settings.MinExposure,
 		MaxExposure:         req.Settings.MaxExposure,
+		OptimizationID:      optimizationID,
+		Contest:             contest,
+	}
+	if settings.SalaryCap <= 0 {
+		settings.SalaryCap = contest.SalaryCap
 	}
 	
 	// Convert OptimizationPlayer to Player for optimization
@@ -181,8 +258,13 @@ func (h *OptimizationHandler) OptimizeLineups(c *gin.Context) {
 	for i, op := range req.PlayerPool {
 		players[i] = convertOptimizationPlayerToPlayer(op)
 	}
+	settings.Randomization = randomizationFromSettings(req.Settings, players)
 	
-	result, err := optimizer.OptimizeLineups(players, settings)
+	result, err := optimizer.OptimizeLineups(ctx, players, settings)
+	if err != nil && ctx.Err() != nil {
+		h.respondStoppedOptimization(c, optimizationID, ctx.Err())
+		return
+	}
 	if err != nil {
 		h.logger.WithError(err).Error("Optimization failed")
 		c.JSON(http.StatusInternalServerError, types.ErrorResponse{
@@ -199,11 +281,13 @@ func (h *OptimizationHandler) OptimizeLineups(c *gin.Context) {
 	generatedLineups := make([]types.GeneratedLineup, len(result.Lineups))
 	for i, lineup := range result.Lineups {
 		generatedLineups[i] = types.GeneratedLineup{
-			ID:              fmt.Sprintf("%d", lineup.ID),
+			ID:              lineup.ID,
 			Players:         lineup.Players,
 			TotalSalary:     lineup.TotalSalary,
 			ProjectedPoints: lineup.ProjectedPoints,
 			Exposure:        1.0 / float64(len(result.Lineups)), // Equal exposure
+			StackDescription: lineup.StackDescription,
+			Stacks:          lineup.Stacks,
 		}
 	}
 
@@ -214,12 +298,19 @@ func (h *OptimizationHandler) OptimizeLineups(c *gin.Context) {
 		AverageUniqueess: 0.8, // Placeholder
 		TopProjection:    0.0, // Placeholder
 		AverageProjection: 0.0, // Placeholder
-		StacksGenerated:  0, // Placeholder
+		UnmetTargets:     result.UnmetTargets,
+		StackSummaries:   result.StackSummaries,
+		OptimizationID:   optimizationID,
+		Partial:          result.Partial,
+		StopReason:       result.StopReason,
 	}
 
 	// Calculate average projection
 	totalProjection := 0.0
 	for _, lineup := range result.Lineups {
+		if len(lineup.Stacks) > 0 {
+			metadata.StacksGenerated++
+		}
 		totalProjection += lineup.ProjectedPoints
 		if lineup.ProjectedPoints > metadata.TopProjection {
 			metadata.TopProjection = lineup.ProjectedPoints
@@ -236,22 +327,25 @@ func (h *OptimizationHandler) OptimizeLineups(c *gin.Context) {
 		CorrelationMatrix: correlationMatrix,
 	}
 
-	// Convert result to DPResult for caching
-	dpResult := h.convertToDPResult(result, time.Since(startTime))
-	
-	// Cache the result
-	if err := h.cache.SetOptimizationResult(c.Request.Context(), cacheKey, dpResult, 24*time.Hour); err != nil {
-		h.logger.WithError(err).Warn("Failed to cache optimization result")
+	// Cache the result; a partial set isn't what a complete run of the same request returns
+	if !result.Partial {
+		dpResult := h.convertToDPResult(result, time.Since(startTime))
+		h.cacheResult(c, cacheKey, dpResult, 24*time.Hour)
 	}
 
 	// Send final progress update
+	finalStep, finalMessage := "completed", fmt.Sprintf("Optimization completed! Generated %d lineups in %v", len(result.Lineups), time.Since(startTime))
+	if result.Partial {
+		finalStep, finalMessage = result.StopReason, fmt.Sprintf("Optimization stopped (%s) with %d lineups after %v", result.StopReason, len(result.Lineups), time.Since(startTime))
+	}
 	progressChan <- types.ProgressUpdate{
 		Type:        "optimization",
 		Progress:    1.0,
-		Message:     fmt.Sprintf("Optimization completed! Generated %d lineups in %v", len(result.Lineups), time.Since(startTime)),
-		CurrentStep: "completed",
+		Message:     finalMessage,
+		CurrentStep: finalStep,
 		TotalSteps:  req.Settings.MaxLineups,
 		Timestamp:   time.Now(),
+		OptimizationID: optimizationID,
 	}
 
 	h.logger.WithFields(logrus.Fields{
@@ -259,6 +353,8 @@ func (h *OptimizationHandler) OptimizeLineups(c *gin.Context) {
 		"execution_time":    time.Since(startTime),
 		"user_id":          req.UserID,
 		"contest_id":       req.ContestID,
+		"optimization_id":  optimizationID,
+		"partial":          result.Partial,
 	}).Info("Optimization completed successfully")
 
 	c.JSON(http.StatusOK, response)
@@ -295,7 +391,7 @@ func (h *OptimizationHandler) OptimizeLineupsV2(c *gin.Context) {
 	
 	// Check cache first if analytics is not explicitly requested
 	if !req.UseAnalytics {
-		if cached, err := h.cache.GetOptimizationResult(c.Request.Context(), cacheKey); err == nil && cached != nil {
+		if cached := h.cachedResult(c, cacheKey); cached != nil {
 			h.logger.WithField("cache_key", cacheKey).Info("Returning cached enhanced optimization result")
 			// Convert cached result to V2 format
 			response := h.convertCachedToV2Response(cached, req)
@@ -304,6 +400,34 @@ func (h *OptimizationHandler) OptimizeLineupsV2(c *gin.Context) {
 		}
 	}
 
+	contest, ok := h.loadContest(c, req.ContestID)
+	if !ok {
+		return
+	}
+
+	// Register the run under its user so they can cancel it by ID; the client disconnecting
+	// cancels it too
+	userID, ok := contextUserID(c)
+	if !ok {
+		return
+	}
+	optimizationID := req.OptimizationID
+	if optimizationID == "" {
+		optimizationID = uuid.New().String()
+	}
+	ctx, release, err := h.running.Start(c.Request.Context(), userID, optimizationID, time.Duration(req.Timeout)*time.Second)
+	if err != nil {
+		c.JSON(http.StatusConflict, types.ErrorResponse{
+			Error: "Optimization is already running",
+			Code:  "OPTIMIZATION_RUNNING",
+			Details: map[string]string{
+				"optimization_id": optimizationID,
+			},
+		})
+		return
+	}
+	defer release()
+
 	// Create progress channel for WebSocket updates
 	progressChan := make(chan types.ProgressUpdate, 100)
 	defer close(progressChan)
@@ -329,17 +453,17 @@ func (h *OptimizationHandler) OptimizeLineupsV2(c *gin.Context) {
 	// Convert request to enhanced config
 	config := h.convertToOptimizeConfigV2(req)
 	
-	// Determine contest information
-	if len(req.PlayerPool) > 0 {
-		// Create a minimal contest from the request
-		config.Contest = &types.Contest{
-			ID:       req.ContestID,
-			SportID:  uuid.New(), // This would be determined from players
-			Platform: "draftkings", // Default or from request
-			SalaryCap: req.SalaryCap,
-		}
+	// Convert OptimizationPlayer to Player for optimization
+	players := make([]types.Player, len(req.PlayerPool))
+	for i, op := range req.PlayerPool {
+		players[i] = convertOptimizationPlayerToPlayer(op)
 	}
 
+	// Correlations estimated from game history, cached per contest slate
+	config.Correlations = h.correlations.MatrixForSlate(c.Request.Context(), slateKey(req.ContestID), "", toCorrelationPlayers(req.PlayerPool))
+
+	config.Contest = contest
+
 	// Calculate player analytics if enabled
 	var playerAnalytics map[uuid.UUID]*optimizer.PlayerAnalytics
 	if req.UseAnalytics {
@@ -352,7 +476,7 @@ func (h *OptimizationHandler) OptimizeLineupsV2(c *gin.Context) {
 			Timestamp:   time.Now(),
 		}
 
-		analytics, err := h.analyticsEngine.CalculateBulkAnalytics(req.PlayerPool, make(map[uuid.UUID][]optimizer.PerformanceData))
+		analytics, err := h.analyticsEngine.CalculateBulkAnalytics(players, make(map[uuid.UUID][]optimizer.PerformanceData))
 		if err != nil {
 			h.logger.WithError(err).Warn("Failed to calculate analytics, proceeding without")
 		} else {
@@ -370,7 +494,11 @@ func (h *OptimizationHandler) OptimizeLineupsV2(c *gin.Context) {
 	}
 
 	// Run enhanced optimization
-	lineups, err := h.dpOptimizer.OptimizeWithDPV2(req.PlayerPool, config)
+	lineups, err := h.dpOptimizer.OptimizeWithDPV2(ctx, players, config)
+	if (err != nil || len(lineups) == 0) && ctx.Err() != nil {
+		h.respondStoppedOptimization(c, optimizationID, ctx.Err())
+		return
+	}
 	if err != nil {
 		h.logger.WithError(err).Error("Enhanced optimization failed")
 		c.JSON(http.StatusInternalServerError, types.ErrorResponse{
@@ -415,14 +543,14 @@ func (h *OptimizationHandler) OptimizeLineupsV2(c *gin.Context) {
 		}
 		exposureManager.CompleteLineup()
 	}
-	exposureReport := exposureManager.GenerateExposureReport(req.PlayerPool)
+	exposureReport := exposureManager.GenerateExposureReport(players)
 
 	// Get performance metrics
 	stats := h.dpOptimizer.GetStats()
 	performanceMetrics := &PerformanceMetrics{
 		OptimizationTime: time.Since(startTime),
 		StatesExplored:   stats.StatesCached,
-		CacheHitRate:     float64(stats.CacheHits) / float64(stats.CacheHits + stats.CacheMisses),
+		CacheHitRate:     dpCacheHitRate(stats),
 		MemoryUsage:      stats.MemoryUsage,
 		Algorithm:        "enhanced_dp",
 		PerformanceMode:  req.PerformanceMode,
@@ -435,19 +563,23 @@ func (h *OptimizationHandler) OptimizeLineupsV2(c *gin.Context) {
 		ExposureReport:    exposureReport,
 		PerformanceMetrics: performanceMetrics,
 		Strategy:          req.Strategy,
-		CorrelationMatrix: make(map[string]float64), // TODO: Add correlation matrix
+		CorrelationMatrix: config.Correlations.ToMap(),
+		OptimizationID:    optimizationID,
+		StopReason:        optimizer.StopReason(ctx),
 	}
+	response.Partial = response.StopReason != ""
 
 	// Cache the result (using DPResult conversion)
 	dpResult := &optimizer.DPResult{
 		OptimalScore:     0.0, // Would need to be properly calculated
 		OptimalPlayers:   []uuid.UUID{}, // Would need to be properly set
 		StatesExplored:   int(stats.StatesCached),
-		CacheHitRate:     float64(stats.CacheHits) / float64(stats.CacheHits + stats.CacheMisses),
+		CacheHitRate:     dpCacheHitRate(stats),
 		OptimizationTime: time.Since(startTime),
 	}
-	if err := h.cache.SetOptimizationResult(c.Request.Context(), cacheKey, dpResult, 12*time.Hour); err != nil {
-		h.logger.WithError(err).Warn("Failed to cache enhanced optimization result")
+	// A partial set isn't what a complete run of the same request returns
+	if !response.Partial {
+		h.cacheResult(c, cacheKey, dpResult, 12*time.Hour)
 	}
 
 	// Send final progress update
@@ -582,12 +714,115 @@ func (h *OptimizationHandler) GetCacheStatus(c *gin.Context) {
 // Helper methods
 
 func (h *OptimizationHandler) generateCacheKey(req types.OptimizationRequest) string {
-	// Create hash of the request for cache key
+	// Create hash of the request for cache key; the run's name doesn't change its result, and a
+	// preset's rules and a projection set's projections are already merged into the request
+	req.OptimizationID = ""
+	req.PresetID, req.PresetVersion = nil, 0
+	req.ProjectionSetID = nil
 	hash := md5.New()
 	hash.Write([]byte(fmt.Sprintf("%+v", req)))
 	return fmt.Sprintf("optimization:%d:%x", req.ContestID, hash.Sum(nil))
 }
 
+// CancelOptimization stops one of the user's running optimizations; the request running it
+// responds with the lineups found so far
+func (h *OptimizationHandler) CancelOptimization(c *gin.Context) {
+	userID, ok := contextUserID(c)
+	if !ok {
+		return
+	}
+	optimizationID := c.Param("optimization_id")
+
+	if !h.running.Cancel(userID, optimizationID) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Optimization is not running"})
+		return
+	}
+
+	h.logger.WithField("optimization_id", optimizationID).Info("Cancelled optimization")
+	c.JSON(http.StatusOK, gin.H{
+		"status":          "cancelled",
+		"optimization_id": optimizationID,
+	})
+}
+
+// respondStoppedOptimization reports a run that was cancelled or timed out before it found a lineup
+func (h *OptimizationHandler) respondStoppedOptimization(c *gin.Context, optimizationID string, reason error) {
+	status, code := http.StatusConflict, "OPTIMIZATION_CANCELLED"
+	if errors.Is(reason, context.DeadlineExceeded) {
+		status, code = http.StatusRequestTimeout, "OPTIMIZATION_TIMEOUT"
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"optimization_id": optimizationID,
+		"reason":          reason,
+	}).Warn("Optimization stopped before finding a lineup")
+	c.JSON(status, types.ErrorResponse{
+		Error: "Optimization stopped before any lineup was found",
+		Code:  code,
+		Details: map[string]string{
+			"optimization_id": optimizationID,
+			"error":           reason.Error(),
+		},
+	})
+}
+
+// dpCacheHitRate is the DP optimizer's state cache hit rate, 0 before any lookups; a NaN would
+// make the response unencodable
+func dpCacheHitRate(stats optimizer.DPStats) float64 {
+	lookups := stats.CacheHits + stats.CacheMisses
+	if lookups == 0 {
+		return 0
+	}
+	return float64(stats.CacheHits) / float64(lookups)
+}
+
+// loadContest fetches the contest a request optimizes for, which decides the roster slots and
+// which platform's salaries apply
+func (h *OptimizationHandler) loadContest(c *gin.Context, contestID uuid.UUID) (*types.Contest, bool) {
+	var contest types.Contest
+	if err := h.db.WithContext(c.Request.Context()).First(&contest, "id = ?", contestID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, types.ErrorResponse{
+				Error: "Contest not found",
+				Code:  "CONTEST_NOT_FOUND",
+				Details: map[string]string{
+					"contest_id": contestID.String(),
+				},
+			})
+			return nil, false
+		}
+		h.logger.WithError(err).Error("Failed to load contest for optimization")
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{
+			Error: "Failed to load contest",
+			Code:  "CONTEST_ERROR",
+		})
+		return nil, false
+	}
+	return &contest, true
+}
+
+// cachedResult looks up a previous result; a handler built without a cache never finds one
+func (h *OptimizationHandler) cachedResult(c *gin.Context, cacheKey string) *optimizer.DPResult {
+	if h.cache == nil {
+		return nil
+	}
+	cached, err := h.cache.GetOptimizationResult(c.Request.Context(), cacheKey)
+	if err != nil {
+		return nil
+	}
+	return cached
+}
+
+// cacheResult stores a result for repeat requests, when the handler has a cache
+func (h *OptimizationHandler) cacheResult(c *gin.Context, cacheKey string, result *optimizer.DPResult, ttl time.Duration) {
+	if h.cache == nil {
+		return
+	}
+	if err := h.cache.SetOptimizationResult(c.Request.Context(), cacheKey, result, ttl); err != nil {
+		h.logger.WithError(err).Warn("Failed to cache optimization result")
+	}
+}
+
 func (h *OptimizationHandler) forwardProgressToWebSocket(userID uuid.UUID, progressChan <-chan types.ProgressUpdate) {
 	for progress := range progressChan {
 		h.wsHub.BroadcastToUser(userID, progress)
@@ -609,7 +844,7 @@ func (h *OptimizationHandler) buildGolfCorrelationMatrix(players []types.Optimiz
 			for i, p1 := range group {
 				for j, p2 := range group {
 					if i != j {
-						key := fmt.Sprintf("%d-%d", p1.ID, p2.ID)
+						key := p1.ID.String() + ":" + p2.ID.String()
 						matrix[key] = 0.3 // Moderate correlation for same tee time
 					}
 				}
@@ -620,30 +855,30 @@ func (h *OptimizationHandler) buildGolfCorrelationMatrix(players []types.Optimiz
 	return matrix
 }
 
-func (h *OptimizationHandler) buildGeneralCorrelationMatrix(players []types.OptimizationPlayer) map[string]float64 {
-	matrix := make(map[string]float64)
-	
-	// Team-based correlations
-	teamGroups := make(map[string][]types.OptimizationPlayer)
-	for _, player := range players {
-		teamGroups[player.Team] = append(teamGroups[player.Team], player)
-	}
-
-	// Higher correlation for players from same team
-	for _, group := range teamGroups {
-		if len(group) > 1 {
-			for i, p1 := range group {
-				for j, p2 := range group {
-					if i != j {
-						key := fmt.Sprintf("%d-%d", p1.ID, p2.ID)
-						matrix[key] = 0.2 // Moderate correlation for same team
-					}
-				}
-			}
+// toCorrelationPlayers converts the request pool to the fields correlation estimation needs
+func toCorrelationPlayers(players []types.OptimizationPlayer) []optimizer.OptimizationPlayer {
+	result := make([]optimizer.OptimizationPlayer, len(players))
+	for i, p := range players {
+		result[i] = optimizer.OptimizationPlayer{
+			ID:              p.ID,
+			Name:            p.Name,
+			Team:            p.Team,
+			Opponent:        p.Opponent,
+			Position:        p.Position,
+			ProjectedPoints: p.ProjectedPoints,
+			BattingOrder:    p.BattingOrder,
+			TeeTime:         p.TeeTime,
 		}
 	}
+	return result
+}
 
-	return matrix
+// slateKey identifies a slate for correlation caching; requests without a contest aren't cached
+func slateKey(contestID uuid.UUID) string {
+	if contestID == uuid.Nil {
+		return ""
+	}
+	return contestID.String()
 }
 
 func (h *OptimizationHandler) validateConstraints(req types.OptimizationRequest) error {
@@ -958,8 +1193,9 @@ func (h *OptimizationHandler) convertToDPResult(result *optimizer.OptimizerResul
 	}
 }
 
-// convertFromDPResult converts DPResult back to OptimizationResult for API response
-func (h *OptimizationHandler) convertFromDPResult(dpResult *optimizer.DPResult) types.OptimizationResult {
+// convertFromDPResult converts DPResult back to OptimizationResult for API response. The cache
+// doesn't hold the correlation matrix, so the caller passes the one built for the request.
+func (h *OptimizationHandler) convertFromDPResult(dpResult *optimizer.DPResult, correlationMatrix map[string]float64) types.OptimizationResult {
 	// Create a single lineup from the optimal players (basic reconstruction)
 	lineups := make([]types.GeneratedLineup, 0)
 	
@@ -989,23 +1225,57 @@ func (h *OptimizationHandler) convertFromDPResult(dpResult *optimizer.DPResult)
 	return types.OptimizationResult{
 		Lineups:           lineups,
 		Metadata:          metadata,
-		CorrelationMatrix: make(map[string]float64), // Empty for cached results
+		CorrelationMatrix: correlationMatrix,
 	}
 }
 
+// randomizationFromSettings turns a request's randomness level into per-lineup projection draws,
+// building each player's outcome distribution when the request samples from them
+func randomizationFromSettings(settings types.OptimizationSettings, players []types.Player) *optimizer.RandomizationConfig {
+	if settings.RandomnessLevel <= 0 {
+		return nil
+	}
+
+	randomization := &optimizer.RandomizationConfig{
+		Mode:                optimizer.RandomizeFloorCeiling,
+		Level:               math.Min(settings.RandomnessLevel, 1),
+		ExposurePenalty:     settings.UniquenessFactor,
+		VolatilityOverrides: settings.VolatilityOverrides,
+		Seed:                settings.RandomSeed,
+	}
+	if settings.RandomizationMode == optimizer.RandomizeDistribution {
+		randomization.Mode = optimizer.RandomizeDistribution
+		randomization.Samplers = make(map[uuid.UUID]optimizer.ProjectionSampler, len(players))
+		for _, player := range players {
+			if player.ProjectedPoints != nil && *player.ProjectedPoints > 0 {
+				randomization.Samplers[player.ID] = simulator.NewPlayerDistribution(player)
+			}
+		}
+	}
+	return randomization
+}
+
 // convertOptimizationPlayerToPlayer converts from types.OptimizationPlayer to types.Player
 func convertOptimizationPlayerToPlayer(op types.OptimizationPlayer) types.Player {
+	// A golfer's round 1 tee time stands in for game time, which wave stacking reads
+	var gameTime *time.Time
+	if teeTime, ok := weather.ParseTeeTime(op.TeeTime); ok {
+		gameTime = &teeTime
+	}
+
 	return types.Player{
 		ID:              op.ID,
 		ExternalID:      op.ExternalID,
 		Name:            op.Name,
 		Team:            &op.Team,
+		Opponent:        &op.Opponent,
 		Position:        &op.Position,
 		SalaryDK:        &op.Salary,
 		ProjectedPoints: &op.ProjectedPoints,
 		FloorPoints:     &op.FloorPoints,
 		CeilingPoints:   &op.CeilingPoints,
 		OwnershipDK:     &op.Ownership,
+		GameTime:        gameTime,
 		// Set other fields to reasonable defaults
 		SportID:         uuid.New(), // TODO: Should be passed in request
 		IsActive:        func() *bool { b := true; return &b }(),
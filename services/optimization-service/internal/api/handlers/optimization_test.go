@@ -0,0 +1,244 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/stitts-dev/dfs-sim/services/optimization-service/internal/optimizer"
+	"github.com/stitts-dev/dfs-sim/shared/pkg/config"
+	"github.com/stitts-dev/dfs-sim/shared/pkg/database"
+	"github.com/stitts-dev/dfs-sim/shared/types"
+)
+
+// newMockDB backs a handler with sqlmock; queries nobody expects fail, which the correlation
+// estimator answers by falling back to its priors
+func newMockDB(t *testing.T) (*database.DB, sqlmock.Sqlmock) {
+	t.Helper()
+	sqlDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+	return &database.DB{DB: gormDB}, mock
+}
+
+func newTestOptimizationHandler(t *testing.T) (*OptimizationHandler, sqlmock.Sqlmock) {
+	gin.SetMode(gin.TestMode)
+	db, mock := newMockDB(t)
+	log := logrus.New()
+	log.SetLevel(logrus.PanicLevel)
+	return NewOptimizationHandler(db, nil, nil, &config.Config{}, log), mock
+}
+
+// expectContest answers the handler's contest lookup with a DraftKings NBA classic contest
+func expectContest(mock sqlmock.Sqlmock, contestID uuid.UUID) {
+	mock.ExpectQuery(`SELECT \* FROM "contests" WHERE id = \$1`).
+		WithArgs(contestID.String()).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "sport_id", "platform", "contest_type", "name", "salary_cap"}).
+			AddRow(contestID, uuid.New(), "draftkings", "gpp", "NBA Classic", 50000))
+}
+
+// testNBAPool is three players at each position, salaried so cheaper eights use most of the cap
+func testNBAPool() []types.OptimizationPlayer {
+	var pool []types.OptimizationPlayer
+	for i, position := range []string{"PG", "SG", "SF", "PF", "C"} {
+		for j := 0; j < 3; j++ {
+			pool = append(pool, types.OptimizationPlayer{
+				ID:              uuid.New(),
+				Name:            fmt.Sprintf("%s %d", position, j),
+				Team:            fmt.Sprintf("T%d", (i+j)%4),
+				Opponent:        fmt.Sprintf("T%d", (i+j+1)%4),
+				Position:        position,
+				Salary:          5800 + 300*j,
+				ProjectedPoints: 25 + float64(i) + 3*float64(j),
+				FloorPoints:     15,
+				CeilingPoints:   45,
+			})
+		}
+	}
+	return pool
+}
+
+// newTestOptimizationRouter serves the optimize and cancel routes as userID
+func newTestOptimizationRouter(handler *OptimizationHandler, userID uuid.UUID) *gin.Engine {
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("user_id", userID.String())
+	})
+	router.POST("/optimize", handler.OptimizeLineups)
+	router.POST("/optimize/v2", handler.OptimizeLineupsV2)
+	router.DELETE("/optimize/:optimization_id", handler.CancelOptimization)
+	return router
+}
+
+func postJSON(router *gin.Engine, path string, body interface{}) *httptest.ResponseRecorder {
+	payload, _ := json.Marshal(body)
+	req := httptest.NewRequest(http.MethodPost, path, bytes.NewReader(payload))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestOptimizeLineups_EndToEnd(t *testing.T) {
+	handler, mock := newTestOptimizationHandler(t)
+	router := newTestOptimizationRouter(handler, uuid.New())
+
+	contestID := uuid.New()
+	expectContest(mock, contestID)
+
+	w := postJSON(router, "/optimize", types.OptimizationRequest{
+		ContestID:      contestID,
+		PlayerPool:     testNBAPool(),
+		Settings:       types.OptimizationSettings{MaxLineups: 3, MinDifferentPlayers: 1},
+		OptimizationID: "e2e-run",
+	})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var result types.OptimizationResult
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+	require.Len(t, result.Lineups, 3)
+	for _, lineup := range result.Lineups {
+		assert.Len(t, lineup.Players, 8)
+		// The contest's cap applies when the request doesn't set one
+		assert.LessOrEqual(t, lineup.TotalSalary, 50000)
+		assert.Greater(t, lineup.ProjectedPoints, 0.0)
+	}
+	assert.Equal(t, "e2e-run", result.Metadata.OptimizationID)
+	assert.False(t, result.Metadata.Partial)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestOptimizeLineups_UnknownContest(t *testing.T) {
+	handler, mock := newTestOptimizationHandler(t)
+	router := newTestOptimizationRouter(handler, uuid.New())
+
+	contestID := uuid.New()
+	mock.ExpectQuery(`SELECT \* FROM "contests"`).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	w := postJSON(router, "/optimize", types.OptimizationRequest{
+		ContestID:  contestID,
+		PlayerPool: testNBAPool(),
+		Settings:   types.OptimizationSettings{MaxLineups: 1},
+	})
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Body.String(), "CONTEST_NOT_FOUND")
+}
+
+func TestOptimizeLineupsV2_RegistersRun(t *testing.T) {
+	handler, mock := newTestOptimizationHandler(t)
+
+	request := OptimizationRequestV2{
+		ContestID:       uuid.New(),
+		PlayerPool:      testNBAPool(),
+		Strategy:        optimizer.Balanced,
+		NumLineups:      2,
+		SalaryCap:       50000,
+		PerformanceMode: "speed",
+		OptimizationID:  "v2-run",
+		Timeout:         30,
+	}
+
+	userID := uuid.New()
+	router := newTestOptimizationRouter(handler, userID)
+
+	// A run of the user's already registered under the ID is refused, as on v1
+	_, release, err := handler.running.Start(context.Background(), userID, "v2-run", 0)
+	require.NoError(t, err)
+	expectContest(mock, request.ContestID)
+	w := postJSON(router, "/optimize/v2", request)
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Contains(t, w.Body.String(), "OPTIMIZATION_RUNNING")
+	release()
+
+	// Once released the ID is free, and the response names the run
+	expectContest(mock, request.ContestID)
+	w = postJSON(router, "/optimize/v2", request)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var response OptimizationResponseV2
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "v2-run", response.OptimizationID)
+	assert.False(t, response.Partial)
+	assert.NotEmpty(t, response.Lineups)
+	assert.False(t, handler.running.Cancel(userID, "v2-run"), "the run is released when the request finishes")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestOptimizeLineupsV2_SameIDFromAnotherUser(t *testing.T) {
+	handler, mock := newTestOptimizationHandler(t)
+	owner := uuid.New()
+	_, release, err := handler.running.Start(context.Background(), owner, "shared-run", 0)
+	require.NoError(t, err)
+	defer release()
+
+	// Client-chosen IDs only collide within a user
+	request := OptimizationRequestV2{
+		ContestID:       uuid.New(),
+		PlayerPool:      testNBAPool(),
+		Strategy:        optimizer.Balanced,
+		NumLineups:      1,
+		SalaryCap:       50000,
+		PerformanceMode: "speed",
+		OptimizationID:  "shared-run",
+	}
+	expectContest(mock, request.ContestID)
+	w := postJSON(newTestOptimizationRouter(handler, uuid.New()), "/optimize/v2", request)
+	assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCancelOptimization_OnlyTheOwner(t *testing.T) {
+	handler, _ := newTestOptimizationHandler(t)
+	owner := uuid.New()
+	ctx, release, err := handler.running.Start(context.Background(), owner, "owned-run", 0)
+	require.NoError(t, err)
+	defer release()
+
+	cancel := func(userID uuid.UUID) int {
+		w := httptest.NewRecorder()
+		newTestOptimizationRouter(handler, userID).ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/optimize/owned-run", nil))
+		return w.Code
+	}
+
+	assert.Equal(t, http.StatusNotFound, cancel(uuid.New()), "another user's run looks like no run")
+	assert.NoError(t, ctx.Err())
+
+	assert.Equal(t, http.StatusOK, cancel(owner))
+	assert.Equal(t, optimizer.StopCancelled, optimizer.StopReason(ctx))
+}
+
+func TestCancelOptimization_NeedsAuthenticatedUser(t *testing.T) {
+	handler, _ := newTestOptimizationHandler(t)
+	router := gin.New()
+	router.DELETE("/optimize/:optimization_id", handler.CancelOptimization)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/optimize/some-run", nil))
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestConvertFromDPResult_ReportsRequestCorrelations(t *testing.T) {
+	handler, _ := newTestOptimizationHandler(t)
+	matrix := map[string]float64{"a:b": 0.5}
+
+	response := handler.convertFromDPResult(&optimizer.DPResult{OptimalScore: 250, OptimalPlayers: []uuid.UUID{uuid.New()}}, matrix)
+	assert.Equal(t, matrix, response.CorrelationMatrix, "a cached result reports the request's correlations")
+	assert.Equal(t, 250.0, response.Metadata.TopProjection)
+}
@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+
+	"github.com/stitts-dev/dfs-sim/shared/pkg/database"
+	"github.com/stitts-dev/dfs-sim/shared/pkg/workspace"
+	"github.com/stitts-dev/dfs-sim/shared/types"
+)
+
+// errPresetNotFound is returned for presets that don't exist or the user can't read
+var errPresetNotFound = errors.New("preset not found")
+
+// loadPresetRules fetches a preset version's rules if the user owns the preset, it was shared with
+// them or they belong to its workspace; version 0 is the current version
+func loadPresetRules(db *database.DB, presetID, userID uuid.UUID, version int) (types.PresetRules, int, error) {
+	var preset types.OptimizationPreset
+	err := db.Where("id = ?", presetID).
+		Where("owner_id = ? OR EXISTS (SELECT 1 FROM optimization_preset_shares s WHERE s.preset_id = optimization_presets.id AND s.user_id = ?) OR workspace_id IN (?)",
+			userID, userID, workspace.MemberWorkspaces(db.DB, userID)).
+		First(&preset).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return types.PresetRules{}, 0, errPresetNotFound
+	}
+	if err != nil {
+		return types.PresetRules{}, 0, err
+	}
+
+	if version == 0 {
+		version = preset.CurrentVersion
+	}
+	var presetVersion types.OptimizationPresetVersion
+	err = db.Where("preset_id = ? AND version = ?", preset.ID, version).First(&presetVersion).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return types.PresetRules{}, 0, fmt.Errorf("%w: version %d", errPresetNotFound, version)
+	}
+	return presetVersion.Rules, version, err
+}
+
+// applyPreset rebuilds req on top of a preset's rules by decoding the request body over them:
+// fields the request sends override the preset, fields it omits keep the preset's values, and
+// per-player exposure maps merge with the request's entries winning
+func applyPreset(req *types.OptimizationRequest, rules types.PresetRules, body []byte) error {
+	req.Constraints = rules.Constraints
+	req.Settings = rules.Settings
+	return json.Unmarshal(body, req)
+}
+
+// resolvePreset merges the request's preset into it, responding with an error when the preset
+// can't be used. Access is checked for the authenticated user, not the request's user_id.
+func (h *OptimizationHandler) resolvePreset(c *gin.Context, req *types.OptimizationRequest) bool {
+	userID, ok := contextUserID(c)
+	if !ok {
+		return false
+	}
+
+	rules, version, err := loadPresetRules(h.db, *req.PresetID, userID, req.PresetVersion)
+	if errors.Is(err, errPresetNotFound) {
+		c.JSON(http.StatusNotFound, types.ErrorResponse{
+			Error: "Preset not found",
+			Code:  "PRESET_NOT_FOUND",
+			Details: map[string]string{
+				"preset_id": req.PresetID.String(),
+				"error":     err.Error(),
+			},
+		})
+		return false
+	}
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to load optimization preset")
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{
+			Error: "Failed to load preset",
+			Code:  "PRESET_ERROR",
+		})
+		return false
+	}
+
+	body := c.MustGet(gin.BodyBytesKey).([]byte)
+	if err := applyPreset(req, rules, body); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{
+			Error: "Invalid request format",
+			Code:  "INVALID_REQUEST",
+		})
+		return false
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"preset_id":      req.PresetID,
+		"preset_version": version,
+	}).Info("Applied optimization preset")
+	req.PresetVersion = version
+	return true
+}
@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stitts-dev/dfs-sim/shared/types"
+)
+
+func TestApplyPreset(t *testing.T) {
+	kept, overridden, added := uuid.New(), uuid.New(), uuid.New()
+	rules := types.PresetRules{
+		Constraints: types.OptimizationConstraints{
+			SalaryCap:   50000,
+			MaxExposure: map[string]float64{"QB": 0.5},
+		},
+		Settings: types.OptimizationSettings{
+			MaxLineups:      20,
+			RandomnessLevel: 0.4,
+			StackingRules:   []types.StackingRule{{Type: "team", MinPlayers: 2, MaxPlayers: 3}},
+			MinExposure:     map[uuid.UUID]float64{kept: 0.1, overridden: 0.2},
+		},
+	}
+
+	body, err := json.Marshal(map[string]interface{}{
+		"preset_id": uuid.New(),
+		"constraints": map[string]interface{}{
+			"max_exposure": map[string]float64{"RB": 0.6},
+		},
+		"settings": map[string]interface{}{
+			"max_lineups":    5,
+			"stacking_rules": []types.StackingRule{{Type: "game", MinPlayers: 3}},
+			"min_exposure":   map[string]float64{overridden.String(): 0.5, added.String(): 0.3},
+		},
+	})
+	require.NoError(t, err)
+
+	var req types.OptimizationRequest
+	require.NoError(t, applyPreset(&req, rules, body))
+
+	// Fields the request sends override the preset
+	assert.Equal(t, 5, req.Settings.MaxLineups)
+	assert.Equal(t, []types.StackingRule{{Type: "game", MinPlayers: 3}}, req.Settings.StackingRules, "lists are replaced, not merged")
+	// Fields it omits keep the preset's values
+	assert.Equal(t, 50000, req.Constraints.SalaryCap)
+	assert.Equal(t, 0.4, req.Settings.RandomnessLevel)
+	// Exposure maps merge, with the request's entries winning
+	assert.Equal(t, map[uuid.UUID]float64{kept: 0.1, overridden: 0.5, added: 0.3}, req.Settings.MinExposure)
+	assert.Equal(t, map[string]float64{"QB": 0.5, "RB": 0.6}, req.Constraints.MaxExposure)
+
+	assert.Error(t, applyPreset(&req, rules, []byte(`{"settings": []}`)))
+}
+
+func TestPresetRulesValidate(t *testing.T) {
+	player := uuid.New()
+	tests := []struct {
+		name  string
+		rules types.PresetRules
+		valid bool
+	}{
+		{"empty rules", types.PresetRules{}, true},
+		{"typical rules", types.PresetRules{Settings: types.OptimizationSettings{
+			MaxLineups:      150,
+			RandomnessLevel: 1,
+			MinExposure:     map[uuid.UUID]float64{player: 0.1},
+			MaxExposure:     map[uuid.UUID]float64{player: 1},
+			StackingRules:   []types.StackingRule{{Type: "team", MinPlayers: 2, MaxPlayers: 4}, {Type: "game", MinPlayers: 3}},
+		}}, true},
+		{"negative max lineups", types.PresetRules{Settings: types.OptimizationSettings{MaxLineups: -1}}, false},
+		{"negative min different players", types.PresetRules{Settings: types.OptimizationSettings{MinDifferentPlayers: -1}}, false},
+		{"randomness above 1", types.PresetRules{Settings: types.OptimizationSettings{RandomnessLevel: 1.5}}, false},
+		{"negative randomness", types.PresetRules{Settings: types.OptimizationSettings{RandomnessLevel: -0.1}}, false},
+		{"exposure given as a percentage", types.PresetRules{Settings: types.OptimizationSettings{MaxExposure: map[uuid.UUID]float64{player: 40}}}, false},
+		{"negative exposure", types.PresetRules{Settings: types.OptimizationSettings{MinExposure: map[uuid.UUID]float64{player: -0.1}}}, false},
+		{"stack min above max", types.PresetRules{Settings: types.OptimizationSettings{StackingRules: []types.StackingRule{{Type: "team", MinPlayers: 4, MaxPlayers: 2}}}}, false},
+		{"negative stack min", types.PresetRules{Settings: types.OptimizationSettings{StackingRules: []types.StackingRule{{Type: "team", MinPlayers: -1}}}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rules.Validate()
+			if tt.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestOptimizeLineups_PresetAuthorizedForContextUser(t *testing.T) {
+	handler, mock := newTestOptimizationHandler(t)
+	owner, stranger := uuid.New(), uuid.New()
+	router := gin.New()
+	router.POST("/optimize", func(c *gin.Context) {
+		c.Set("user_id", owner.String())
+		handler.OptimizeLineups(c)
+	})
+
+	presetID, contestID := uuid.New(), uuid.New()
+	rules, err := json.Marshal(types.PresetRules{Settings: types.OptimizationSettings{MaxLineups: 3}})
+	require.NoError(t, err)
+	// The preset is looked up for the authenticated user, never the body's user_id
+	mock.ExpectQuery(`SELECT \* FROM "optimization_presets"`).
+		WithArgs(presetID.String(), owner.String(), owner.String(), owner.String()).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "owner_id", "name", "current_version"}).
+			AddRow(presetID, owner, "Cash", 2))
+	mock.ExpectQuery(`SELECT \* FROM "optimization_preset_versions"`).
+		WithArgs(presetID.String(), 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "preset_id", "version", "rules"}).
+			AddRow(uuid.New(), presetID, 2, rules))
+	// The contest lookup only runs once the preset has been applied
+	mock.ExpectQuery(`SELECT \* FROM "contests"`).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	w := postJSON(router, "/optimize", types.OptimizationRequest{
+		ContestID:  contestID,
+		UserID:     stranger,
+		PlayerPool: testNBAPool(),
+		PresetID:   &presetID,
+	})
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Body.String(), "CONTEST_NOT_FOUND")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestOptimizeLineups_PresetNeedsAuthenticatedUser(t *testing.T) {
+	handler, mock := newTestOptimizationHandler(t)
+	router := gin.New()
+	router.POST("/optimize", handler.OptimizeLineups)
+
+	presetID := uuid.New()
+	w := postJSON(router, "/optimize", types.OptimizationRequest{
+		ContestID:  uuid.New(),
+		UserID:     uuid.New(),
+		PlayerPool: testNBAPool(),
+		PresetID:   &presetID,
+	})
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.NoError(t, mock.ExpectationsWereMet(), "no preset is loaded for the body's user_id")
+}
@@ -0,0 +1,699 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/stitts-dev/dfs-sim/services/optimization-service/internal/projections"
+	"github.com/stitts-dev/dfs-sim/shared/pkg/database"
+	"github.com/stitts-dev/dfs-sim/shared/pkg/workspace"
+	"github.com/stitts-dev/dfs-sim/shared/types"
+)
+
+// maxProjectionUpload bounds an uploaded projection file
+const maxProjectionUpload = 5 << 20
+
+// errProjectionSetNotFound is returned for projection sets that don't exist or the user can't read
+var errProjectionSetNotFound = errors.New("projection set not found")
+
+// ProjectionHandler manages uploaded projection sources and the blended sets built from them
+type ProjectionHandler struct {
+	db     *database.DB
+	logger *logrus.Logger
+}
+
+// NewProjectionHandler creates a new projection handler
+func NewProjectionHandler(db *database.DB, logger *logrus.Logger) *ProjectionHandler {
+	return &ProjectionHandler{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// projectionSetRequest is the body for creating or updating a projection set
+type projectionSetRequest struct {
+	Name        string                   `json:"name"`
+	Description string                   `json:"description"`
+	Sport       string                   `json:"sport"`
+	WorkspaceID *uuid.UUID               `json:"workspace_id"`
+	Weights     *types.ProjectionWeights `json:"weights"`
+}
+
+// projectionOverrideRequest pins one player's projection; the player is named like an upload row
+type projectionOverrideRequest struct {
+	projections.Row
+	FloorPoints   *float64 `json:"floor_points"`
+	CeilingPoints *float64 `json:"ceiling_points"`
+	Note          string   `json:"note"`
+}
+
+// ListSources lists the user's own projection sources, or a workspace's with ?workspace_id=
+func (h *ProjectionHandler) ListSources(c *gin.Context) {
+	userID, ok := contextUserID(c)
+	if !ok {
+		return
+	}
+	query, ok := h.ownedQuery(c, userID)
+	if !ok {
+		return
+	}
+
+	var sources []types.ProjectionSource
+	if err := query.Order("created_at DESC").Find(&sources).Error; err != nil {
+		h.logger.WithError(err).Error("Failed to list projection sources")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"sources": sources})
+}
+
+// UploadSource stores a CSV or JSON projection upload as a named source. The multipart form
+// carries the file under "file" with name, description, sport and workspace_id fields.
+func (h *ProjectionHandler) UploadSource(c *gin.Context) {
+	userID, ok := contextUserID(c)
+	if !ok {
+		return
+	}
+
+	source := types.ProjectionSource{
+		OwnerID:     userID,
+		Name:        strings.TrimSpace(c.PostForm("name")),
+		Description: c.PostForm("description"),
+		Sport:       strings.ToLower(c.PostForm("sport")),
+	}
+	if source.Name == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Source name is required"})
+		return
+	}
+	if workspaceIDStr := c.PostForm("workspace_id"); workspaceIDStr != "" {
+		workspaceID, err := uuid.Parse(workspaceIDStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid workspace ID"})
+			return
+		}
+		if _, err := workspace.Authorize(h.db.DB, workspaceID, userID, types.WorkspaceRoleEditor); err != nil {
+			h.respondWorkspaceError(c, err)
+			return
+		}
+		source.WorkspaceID = &workspaceID
+	}
+
+	file, header, err := c.Request.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "A projection file is required"})
+		return
+	}
+	defer file.Close()
+	data, err := io.ReadAll(io.LimitReader(file, maxProjectionUpload+1))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read projection file"})
+		return
+	}
+	if len(data) > maxProjectionUpload {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "Projection file is too large"})
+		return
+	}
+
+	format := strings.ToLower(c.PostForm("format"))
+	if format == "" {
+		format = projections.DetectFormat(header.Filename, data)
+	}
+	entries, err := projections.Parse(bytes.NewReader(data), format)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid projection file", "details": err.Error()})
+		return
+	}
+	source.EntryCount = len(entries)
+
+	err = h.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&source).Error; err != nil {
+			return err
+		}
+		for i := range entries {
+			entries[i].SourceID = source.ID
+		}
+		if err := tx.CreateInBatches(entries, 500).Error; err != nil {
+			return err
+		}
+		return recordProjectionChange(tx, source.WorkspaceID, userID, "projection_source.created", "projection_source", source.ID,
+			types.AuditDetails{"name": source.Name, "entries": source.EntryCount})
+	})
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to save projection source")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, source)
+}
+
+// GetSource returns a projection source with its entries
+func (h *ProjectionHandler) GetSource(c *gin.Context) {
+	userID, ok := contextUserID(c)
+	if !ok {
+		return
+	}
+	source, ok := h.sourceForUser(c, userID, types.WorkspaceRoleViewer)
+	if !ok {
+		return
+	}
+
+	var entries []types.ProjectionEntry
+	if err := h.db.Where("source_id = ?", source.ID).Order("projected_points DESC").Find(&entries).Error; err != nil {
+		h.logger.WithError(err).Error("Failed to fetch projection entries")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"source": source, "entries": entries})
+}
+
+// DeleteSource removes a projection source unless a projection set still blends it
+func (h *ProjectionHandler) DeleteSource(c *gin.Context) {
+	userID, ok := contextUserID(c)
+	if !ok {
+		return
+	}
+	source, ok := h.sourceForUser(c, userID, types.WorkspaceRoleEditor)
+	if !ok {
+		return
+	}
+
+	var sets []string
+	if err := h.db.Model(&types.ProjectionSet{}).
+		Where("weights @> ?", fmt.Sprintf(`[{"source_id": %q}]`, source.ID)).
+		Pluck("name", &sets).Error; err != nil {
+		h.logger.WithError(err).Error("Failed to check projection source use")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+		return
+	}
+	if len(sets) > 0 {
+		c.JSON(http.StatusConflict, gin.H{"error": "Source is blended by projection sets", "projection_sets": sets})
+		return
+	}
+
+	err := h.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&types.ProjectionSource{}, "id = ?", source.ID).Error; err != nil {
+			return err
+		}
+		return recordProjectionChange(tx, source.WorkspaceID, userID, "projection_source.deleted", "projection_source", source.ID,
+			types.AuditDetails{"name": source.Name})
+	})
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to delete projection source")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Projection source deleted"})
+}
+
+// ListSets lists the user's own projection sets, or a workspace's with ?workspace_id=
+func (h *ProjectionHandler) ListSets(c *gin.Context) {
+	userID, ok := contextUserID(c)
+	if !ok {
+		return
+	}
+	query, ok := h.ownedQuery(c, userID)
+	if !ok {
+		return
+	}
+
+	var sets []types.ProjectionSet
+	if err := query.Order("name ASC").Find(&sets).Error; err != nil {
+		h.logger.WithError(err).Error("Failed to list projection sets")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"projection_sets": sets})
+}
+
+// CreateSet creates a projection set blending sources the user can read
+func (h *ProjectionHandler) CreateSet(c *gin.Context) {
+	userID, ok := contextUserID(c)
+	if !ok {
+		return
+	}
+
+	var req projectionSetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	set := types.ProjectionSet{
+		OwnerID:     userID,
+		WorkspaceID: req.WorkspaceID,
+		Name:        strings.TrimSpace(req.Name),
+		Description: req.Description,
+		Sport:       strings.ToLower(req.Sport),
+	}
+	if set.Name == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Projection set name is required"})
+		return
+	}
+	if req.Weights == nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Source weights are required"})
+		return
+	}
+	set.Weights = *req.Weights
+	if set.WorkspaceID != nil {
+		if _, err := workspace.Authorize(h.db.DB, *set.WorkspaceID, userID, types.WorkspaceRoleEditor); err != nil {
+			h.respondWorkspaceError(c, err)
+			return
+		}
+	}
+	if !h.checkWeights(c, set.Weights, userID) {
+		return
+	}
+
+	err := h.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&set).Error; err != nil {
+			return err
+		}
+		return recordProjectionChange(tx, set.WorkspaceID, userID, "projection_set.created", "projection_set", set.ID,
+			types.AuditDetails{"name": set.Name})
+	})
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to create projection set")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+		return
+	}
+	c.JSON(http.StatusCreated, set)
+}
+
+// GetSet returns a projection set with its overrides
+func (h *ProjectionHandler) GetSet(c *gin.Context) {
+	userID, ok := contextUserID(c)
+	if !ok {
+		return
+	}
+	set, ok := h.setForUser(c, userID, types.WorkspaceRoleViewer)
+	if !ok {
+		return
+	}
+
+	var overrides []types.ProjectionOverride
+	if err := h.db.Where("set_id = ?", set.ID).Order("player_key ASC").Find(&overrides).Error; err != nil {
+		h.logger.WithError(err).Error("Failed to fetch projection overrides")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"projection_set": set, "overrides": overrides})
+}
+
+// UpdateSet renames a projection set or changes its weights
+func (h *ProjectionHandler) UpdateSet(c *gin.Context) {
+	userID, ok := contextUserID(c)
+	if !ok {
+		return
+	}
+	set, ok := h.setForUser(c, userID, types.WorkspaceRoleEditor)
+	if !ok {
+		return
+	}
+
+	var req projectionSetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	updates := map[string]interface{}{}
+	if name := strings.TrimSpace(req.Name); name != "" {
+		updates["name"] = name
+	}
+	if req.Description != "" {
+		updates["description"] = req.Description
+	}
+	if req.Sport != "" {
+		updates["sport"] = strings.ToLower(req.Sport)
+	}
+	if req.Weights != nil {
+		if !h.checkWeights(c, *req.Weights, userID) {
+			return
+		}
+		updates["weights"] = *req.Weights
+	}
+	if len(updates) == 0 {
+		c.JSON(http.StatusOK, set)
+		return
+	}
+
+	err := h.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(set).Updates(updates).Error; err != nil {
+			return err
+		}
+		if err := tx.First(set, "id = ?", set.ID).Error; err != nil {
+			return err
+		}
+		return recordProjectionChange(tx, set.WorkspaceID, userID, "projection_set.updated", "projection_set", set.ID,
+			types.AuditDetails(updates))
+	})
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to update projection set")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+		return
+	}
+	c.JSON(http.StatusOK, set)
+}
+
+// DeleteSet removes a projection set and its overrides
+func (h *ProjectionHandler) DeleteSet(c *gin.Context) {
+	userID, ok := contextUserID(c)
+	if !ok {
+		return
+	}
+	set, ok := h.setForUser(c, userID, types.WorkspaceRoleEditor)
+	if !ok {
+		return
+	}
+
+	err := h.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&types.ProjectionSet{}, "id = ?", set.ID).Error; err != nil {
+			return err
+		}
+		return recordProjectionChange(tx, set.WorkspaceID, userID, "projection_set.deleted", "projection_set", set.ID,
+			types.AuditDetails{"name": set.Name})
+	})
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to delete projection set")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Projection set deleted"})
+}
+
+// SetOverrides pins the projections of the listed players, replacing earlier overrides for them
+func (h *ProjectionHandler) SetOverrides(c *gin.Context) {
+	userID, ok := contextUserID(c)
+	if !ok {
+		return
+	}
+	set, ok := h.setForUser(c, userID, types.WorkspaceRoleEditor)
+	if !ok {
+		return
+	}
+
+	var req []projectionOverrideRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	rows := make([]projections.Row, len(req))
+	for i, override := range req {
+		rows[i] = override.Row
+	}
+	entries, err := projections.Entries(rows)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid overrides", "details": err.Error()})
+		return
+	}
+
+	overrides := make([]types.ProjectionOverride, len(req))
+	for i, override := range req {
+		if override.FloorPoints != nil && override.CeilingPoints != nil && *override.FloorPoints > *override.CeilingPoints {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid overrides", "details": fmt.Sprintf("override %d: floor is above ceiling", i+1)})
+			return
+		}
+		overrides[i] = types.ProjectionOverride{
+			SetID:           set.ID,
+			PlayerKey:       entries[i].PlayerKey,
+			ProjectedPoints: override.ProjectedPoints,
+			FloorPoints:     override.FloorPoints,
+			CeilingPoints:   override.CeilingPoints,
+			Note:            override.Note,
+			UpdatedBy:       userID,
+			UpdatedAt:       time.Now(),
+		}
+	}
+
+	err = h.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.OnConflict{UpdateAll: true}).Create(&overrides).Error; err != nil {
+			return err
+		}
+		players := make([]string, len(overrides))
+		for i, override := range overrides {
+			players[i] = override.PlayerKey
+		}
+		return recordProjectionChange(tx, set.WorkspaceID, userID, "projection_set.overrides_set", "projection_set", set.ID,
+			types.AuditDetails{"players": players})
+	})
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to save projection overrides")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"overrides": overrides})
+}
+
+// DeleteOverride returns a player to the blended projection
+func (h *ProjectionHandler) DeleteOverride(c *gin.Context) {
+	userID, ok := contextUserID(c)
+	if !ok {
+		return
+	}
+	set, ok := h.setForUser(c, userID, types.WorkspaceRoleEditor)
+	if !ok {
+		return
+	}
+	playerKey := c.Param("player_key")
+
+	var deleted int64
+	err := h.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Delete(&types.ProjectionOverride{}, "set_id = ? AND player_key = ?", set.ID, playerKey)
+		if result.Error != nil {
+			return result.Error
+		}
+		deleted = result.RowsAffected
+		if deleted == 0 {
+			return nil
+		}
+		return recordProjectionChange(tx, set.WorkspaceID, userID, "projection_set.override_removed", "projection_set", set.ID,
+			types.AuditDetails{"player": playerKey})
+	})
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to delete projection override")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+		return
+	}
+	if deleted == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Override not found"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Override removed"})
+}
+
+// ownedQuery scopes a source or set listing to the user's private rows, or to a workspace's rows
+// when ?workspace_id= names one the user belongs to
+func (h *ProjectionHandler) ownedQuery(c *gin.Context, userID uuid.UUID) (*gorm.DB, bool) {
+	workspaceIDStr := c.Query("workspace_id")
+	if workspaceIDStr == "" {
+		return h.db.Where("owner_id = ? AND workspace_id IS NULL", userID), true
+	}
+	workspaceID, err := uuid.Parse(workspaceIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid workspace ID"})
+		return nil, false
+	}
+	if _, err := workspace.Authorize(h.db.DB, workspaceID, userID, types.WorkspaceRoleViewer); err != nil {
+		h.respondWorkspaceError(c, err)
+		return nil, false
+	}
+	return h.db.Where("workspace_id = ?", workspaceID), true
+}
+
+// sourceForUser loads the :id source if the user may act on it with need
+func (h *ProjectionHandler) sourceForUser(c *gin.Context, userID uuid.UUID, need types.WorkspaceRole) (*types.ProjectionSource, bool) {
+	var source types.ProjectionSource
+	if !h.ownedForUser(c, &source, "Projection source", userID, need) {
+		return nil, false
+	}
+	return &source, true
+}
+
+// setForUser loads the :id projection set if the user may act on it with need
+func (h *ProjectionHandler) setForUser(c *gin.Context, userID uuid.UUID, need types.WorkspaceRole) (*types.ProjectionSet, bool) {
+	var set types.ProjectionSet
+	if !h.ownedForUser(c, &set, "Projection set", userID, need) {
+		return nil, false
+	}
+	return &set, true
+}
+
+// ownedForUser loads the :id row into dest and checks the user owns it, or that their role in
+// its workspace allows need; anyone else gets a 404
+func (h *ProjectionHandler) ownedForUser(c *gin.Context, dest interface{}, label string, userID uuid.UUID, need types.WorkspaceRole) bool {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid " + strings.ToLower(label) + " ID"})
+		return false
+	}
+	if err := h.db.Where("id = ?", id).First(dest).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": label + " not found"})
+			return false
+		}
+		h.logger.WithError(err).Errorf("Failed to fetch %s", strings.ToLower(label))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+		return false
+	}
+
+	var ownerID uuid.UUID
+	var workspaceID *uuid.UUID
+	switch row := dest.(type) {
+	case *types.ProjectionSource:
+		ownerID, workspaceID = row.OwnerID, row.WorkspaceID
+	case *types.ProjectionSet:
+		ownerID, workspaceID = row.OwnerID, row.WorkspaceID
+	}
+
+	if workspaceID == nil {
+		if ownerID != userID {
+			c.JSON(http.StatusNotFound, gin.H{"error": label + " not found"})
+			return false
+		}
+		return true
+	}
+	if _, err := workspace.Authorize(h.db.DB, *workspaceID, userID, need); err != nil {
+		if errors.Is(err, workspace.ErrNotMember) {
+			c.JSON(http.StatusNotFound, gin.H{"error": label + " not found"})
+			return false
+		}
+		h.respondWorkspaceError(c, err)
+		return false
+	}
+	return true
+}
+
+// checkWeights validates a set's weights and that the user can read every source they blend
+func (h *ProjectionHandler) checkWeights(c *gin.Context, weights types.ProjectionWeights, userID uuid.UUID) bool {
+	if err := weights.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid source weights", "details": err.Error()})
+		return false
+	}
+
+	sourceIDs := projections.SourceIDs(weights)
+	if len(sourceIDs) == 0 {
+		return true
+	}
+	var readable int64
+	err := h.db.Model(&types.ProjectionSource{}).
+		Where("id IN ?", sourceIDs).
+		Where("(owner_id = ? AND workspace_id IS NULL) OR workspace_id IN (?)", userID, workspace.MemberWorkspaces(h.db.DB, userID)).
+		Count(&readable).Error
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to check projection sources")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+		return false
+	}
+	if int(readable) != len(sourceIDs) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid source weights", "details": "a weighted source was not found"})
+		return false
+	}
+	return true
+}
+
+func (h *ProjectionHandler) respondWorkspaceError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, workspace.ErrNotMember):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Workspace not found"})
+	case errors.Is(err, workspace.ErrForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	default:
+		h.logger.WithError(err).Error("Failed to check workspace role")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+	}
+}
+
+// recordProjectionChange adds a workspace source or set's change to the workspace audit trail
+func recordProjectionChange(tx *gorm.DB, workspaceID *uuid.UUID, actorID uuid.UUID, action, entityType string, entityID uuid.UUID, details types.AuditDetails) error {
+	if workspaceID == nil {
+		return nil
+	}
+	return workspace.Record(tx, *workspaceID, actorID, action, entityType, &entityID, details)
+}
+
+// loadProjectionBlend loads a projection set the user owns or reaches through a workspace, with
+// the entries of the sources it blends and its overrides
+func loadProjectionBlend(db *database.DB, setID, userID uuid.UUID) (*projections.Blend, error) {
+	var set types.ProjectionSet
+	err := db.Where("id = ?", setID).
+		Where("(owner_id = ? AND workspace_id IS NULL) OR workspace_id IN (?)", userID, workspace.MemberWorkspaces(db.DB, userID)).
+		First(&set).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errProjectionSetNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []types.ProjectionEntry
+	if sourceIDs := projections.SourceIDs(set.Weights); len(sourceIDs) > 0 {
+		if err := db.Where("source_id IN ?", sourceIDs).Find(&entries).Error; err != nil {
+			return nil, err
+		}
+	}
+	var overrides []types.ProjectionOverride
+	if err := db.Where("set_id = ?", set.ID).Find(&overrides).Error; err != nil {
+		return nil, err
+	}
+	return projections.NewBlend(set.Weights, entries, overrides), nil
+}
+
+// resolveProjectionBlend loads a request's projection set, responding with an error when it
+// can't be used. Access is checked for the authenticated user, not the request's user_id.
+func resolveProjectionBlend(c *gin.Context, db *database.DB, logger *logrus.Logger, setID uuid.UUID) (*projections.Blend, bool) {
+	userID, ok := contextUserID(c)
+	if !ok {
+		return nil, false
+	}
+
+	blend, err := loadProjectionBlend(db, setID, userID)
+	if errors.Is(err, errProjectionSetNotFound) {
+		c.JSON(http.StatusNotFound, types.ErrorResponse{
+			Error: "Projection set not found",
+			Code:  "PROJECTION_SET_NOT_FOUND",
+			Details: map[string]string{
+				"projection_set_id": setID.String(),
+			},
+		})
+		return nil, false
+	}
+	if err != nil {
+		logger.WithError(err).Error("Failed to load projection set")
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{
+			Error: "Failed to load projection set",
+			Code:  "PROJECTION_SET_ERROR",
+		})
+		return nil, false
+	}
+	return blend, true
+}
+
+// contextUserID reads the authenticated user's ID, responding with an error when it is missing
+func contextUserID(c *gin.Context) (uuid.UUID, bool) {
+	userIDInterface, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User ID not found in context"})
+		return uuid.Nil, false
+	}
+	userIDStr, ok := userIDInterface.(string)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID format"})
+		return uuid.Nil, false
+	}
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID format"})
+		return uuid.Nil, false
+	}
+	return userID, true
+}
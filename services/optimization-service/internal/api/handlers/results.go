@@ -0,0 +1,129 @@
+package handlers
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"github.com/stitts-dev/dfs-sim/services/optimization-service/internal/optimizer"
+	"github.com/stitts-dev/dfs-sim/shared/types"
+)
+
+// PlayerResultsRequest reports the final fantasy points of a slate's players. The pool is the
+// slate as it was optimized, so roles (QB1, WR2, BAT3) are assigned exactly as they were when the
+// slate's correlation matrix was built.
+type PlayerResultsRequest struct {
+	Sport    string         `json:"sport,omitempty"`
+	GameDate time.Time      `json:"game_date" binding:"required"`
+	Players  []PlayerResult `json:"players" binding:"required"`
+}
+
+// PlayerResult is one player's final score in a game
+type PlayerResult struct {
+	types.OptimizationPlayer
+	GameID        string  `json:"game_id"`
+	FantasyPoints float64 `json:"fantasy_points"`
+}
+
+// RecordPlayerResults stores final fantasy results in the game history that empirical
+// correlations are estimated from
+func (h *OptimizationHandler) RecordPlayerResults(c *gin.Context) {
+	var req PlayerResultsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{
+			Error: "Invalid request format",
+			Code:  "INVALID_REQUEST",
+			Details: map[string]string{
+				"validation_error": err.Error(),
+			},
+		})
+		return
+	}
+	if err := validatePlayerResults(req); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{
+			Error: "Invalid player results",
+			Code:  "INVALID_RESULTS",
+			Details: map[string]string{
+				"validation_error": err.Error(),
+			},
+		})
+		return
+	}
+
+	results := playerGameResults(req)
+	if err := h.correlations.RecordResults(c.Request.Context(), results); err != nil {
+		h.logger.WithError(err).Error("Failed to record player results")
+		c.JSON(http.StatusInternalServerError, types.ErrorResponse{
+			Error: "Failed to record player results",
+			Code:  "RESULTS_ERROR",
+		})
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"sport":     results[0].Sport,
+		"game_date": req.GameDate,
+		"players":   len(results),
+	}).Info("Player results recorded")
+
+	c.JSON(http.StatusCreated, gin.H{
+		"recorded": len(results),
+		"sport":    results[0].Sport,
+	})
+}
+
+func validatePlayerResults(req PlayerResultsRequest) error {
+	if len(req.Players) == 0 {
+		return fmt.Errorf("at least one player result is required")
+	}
+	seen := make(map[uuid.UUID]bool, len(req.Players))
+	for _, player := range req.Players {
+		switch {
+		case player.ID == uuid.Nil:
+			return fmt.Errorf("every result needs a player id")
+		case seen[player.ID]:
+			return fmt.Errorf("player %s is listed more than once", player.ID)
+		case player.GameID == "" || player.Team == "" || player.Opponent == "":
+			return fmt.Errorf("player %s needs a game_id, team and opponent", player.ID)
+		case math.IsNaN(player.FantasyPoints) || math.IsInf(player.FantasyPoints, 0):
+			return fmt.Errorf("player %s has invalid fantasy points", player.ID)
+		}
+		seen[player.ID] = true
+	}
+	return nil
+}
+
+// playerGameResults tags each result with the role its player filled on the slate
+func playerGameResults(req PlayerResultsRequest) []optimizer.PlayerGameResult {
+	pool := make([]types.OptimizationPlayer, len(req.Players))
+	for i, player := range req.Players {
+		pool[i] = player.OptimizationPlayer
+	}
+	correlationPlayers := toCorrelationPlayers(pool)
+	sport := req.Sport
+	if sport == "" {
+		sport = optimizer.InferSport(correlationPlayers)
+	}
+	roles := optimizer.AssignRoles(sport, correlationPlayers)
+
+	results := make([]optimizer.PlayerGameResult, len(req.Players))
+	for i, player := range req.Players {
+		results[i] = optimizer.PlayerGameResult{
+			Sport:           sport,
+			GameID:          player.GameID,
+			GameDate:        req.GameDate,
+			PlayerID:        player.ID,
+			Team:            player.Team,
+			Opponent:        player.Opponent,
+			Role:            roles[player.ID],
+			FantasyPoints:   player.FantasyPoints,
+			ProjectedPoints: player.ProjectedPoints,
+		}
+	}
+	return results
+}
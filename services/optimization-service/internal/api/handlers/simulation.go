@@ -50,6 +50,8 @@ type SimulationRequest struct {
 	Iterations       int                     `json:"iterations"`
 	UserID           uuid.UUID               `json:"user_id,omitempty"`
 	CorrelationMatrix map[string]float64     `json:"correlation_matrix,omitempty"`
+	// ProjectionSetID re-projects the lineups' players from a blended projection set
+	ProjectionSetID *uuid.UUID `json:"projection_set_id,omitempty"`
 }
 
 
@@ -79,6 +81,14 @@ func (h *SimulationHandler) RunSimulation(c *gin.Context) {
 		return
 	}
 
+	if req.ProjectionSetID != nil {
+		blend, ok := resolveProjectionBlend(c, h.db, h.logger, *req.ProjectionSetID)
+		if !ok {
+			return
+		}
+		blend.ApplyToLineups(req.Lineups)
+	}
+
 	// Generate simulation ID
 	simulationID := fmt.Sprintf("sim_%d", time.Now().UnixNano())
 
@@ -271,7 +281,7 @@ func (h *SimulationHandler) convertLineupResults(results []simulator.LineupResul
 	converted := make([]types.LineupSimulationResult, len(results))
 	for i, result := range results {
 		converted[i] = types.LineupSimulationResult{
-			LineupID:      fmt.Sprintf("%d", result.LineupID),
+			LineupID:      result.LineupID,
 			ExpectedScore: result.ExpectedPoints,
 			ScoreVariance: result.PointsVariance,
 			CashRate:      result.CashRate,
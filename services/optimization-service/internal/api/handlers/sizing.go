@@ -0,0 +1,180 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"github.com/stitts-dev/dfs-sim/services/optimization-service/internal/analytics/bankroll"
+	"github.com/stitts-dev/dfs-sim/services/optimization-service/internal/analytics/portfolio"
+	"github.com/stitts-dev/dfs-sim/shared/types"
+)
+
+// SizingRequest asks how many entries to put into each contest on a slate. Bankroll defaults to
+// the user's ledger balance and the fractions to the user's bankroll settings.
+type SizingRequest struct {
+	Lineups         []types.GeneratedLineup    `json:"lineups"`
+	PlayerPool      []types.OptimizationPlayer `json:"player_pool"`
+	Contests        []AllocationContest        `json:"contests"`
+	Bankroll        float64                    `json:"bankroll,omitempty"`
+	KellyMultiplier float64                    `json:"kelly_multiplier,omitempty"`
+	FixedFraction   float64                    `json:"fixed_fraction,omitempty"`
+	MaxFraction     float64                    `json:"max_fraction,omitempty"`
+	Iterations      int                        `json:"iterations,omitempty"`
+	FieldSample     int                        `json:"field_sample,omitempty"`
+	Seed            int64                      `json:"seed,omitempty"`
+	UserID          uuid.UUID                  `json:"user_id,omitempty"`
+	ProjectionSetID *uuid.UUID                 `json:"projection_set_id,omitempty"`
+}
+
+// SizeEntries recommends Kelly and fixed-fraction entry counts per contest from the lineup pool's
+// simulated ROI and variance in each contest
+func (h *SimulationHandler) SizeEntries(c *gin.Context) {
+	var req SizingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{
+			Error: "Invalid request format",
+			Code:  "INVALID_REQUEST",
+			Details: map[string]string{
+				"validation_error": err.Error(),
+			},
+		})
+		return
+	}
+	if req.Iterations == 0 {
+		req.Iterations = defaultAllocationIterations
+	}
+	if req.FieldSample == 0 {
+		req.FieldSample = defaultAllocationField
+	}
+
+	bankrollSource := "request"
+	settings := types.DefaultBankrollSettings(req.UserID)
+	if req.UserID != uuid.Nil {
+		saved, err := loadBankrollSettings(h.db.DB, req.UserID)
+		if err != nil {
+			h.logger.WithError(err).Error("Failed to load bankroll settings for sizing")
+			c.JSON(http.StatusInternalServerError, types.ErrorResponse{
+				Error: "Failed to load bankroll",
+				Code:  "BANKROLL_ERROR",
+			})
+			return
+		}
+		settings = saved
+		if req.Bankroll == 0 {
+			summary, err := loadBankrollSummary(h.db.DB, req.UserID)
+			if err != nil {
+				h.logger.WithError(err).Error("Failed to load bankroll ledger for sizing")
+				c.JSON(http.StatusInternalServerError, types.ErrorResponse{
+					Error: "Failed to load bankroll",
+					Code:  "BANKROLL_ERROR",
+				})
+				return
+			}
+			req.Bankroll = summary.Balance
+			bankrollSource = "ledger"
+		}
+	}
+	sizing := bankrollSizingConfig(settings)
+	if req.KellyMultiplier != 0 {
+		sizing.KellyMultiplier = req.KellyMultiplier
+	}
+	if req.FixedFraction != 0 {
+		sizing.FixedFraction = req.FixedFraction
+	}
+	if req.MaxFraction != 0 {
+		sizing.MaxFraction = req.MaxFraction
+	}
+	if err := h.validateSizingRequest(req, sizing); err != nil {
+		c.JSON(http.StatusBadRequest, types.ErrorResponse{
+			Error: "Invalid sizing parameters",
+			Code:  "INVALID_SIZING",
+			Details: map[string]string{
+				"validation_error": err.Error(),
+			},
+		})
+		return
+	}
+
+	contests, ok := h.loadAllocationContests(c, req.Contests)
+	if !ok {
+		return
+	}
+	startTime := time.Now()
+	lineups, seed, ok := h.simulateEntryLineups(c, req.Lineups, req.PlayerPool, contests, req.ProjectionSetID, req.FieldSample, req.Iterations, req.Seed)
+	if !ok {
+		return
+	}
+
+	recommendations := make([]bankroll.Recommendation, len(contests))
+	for i, contest := range contests {
+		spec := allocationContestSpec(contest, req.Contests[i])
+		returns, err := portfolio.ContestReturns(lineups, spec)
+		if err == nil {
+			recommendations[i], err = bankroll.Recommend(req.Bankroll, spec, returns, sizing)
+		}
+		if err != nil {
+			c.JSON(http.StatusBadRequest, types.ErrorResponse{
+				Error: "Invalid sizing parameters",
+				Code:  "INVALID_SIZING",
+				Details: map[string]string{
+					"contest_id":       spec.ContestID,
+					"validation_error": err.Error(),
+				},
+			})
+			return
+		}
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"lineups":         len(req.Lineups),
+		"contests":        len(contests),
+		"bankroll":        req.Bankroll,
+		"bankroll_source": bankrollSource,
+		"execution_time":  time.Since(startTime),
+		"user_id":         req.UserID,
+	}).Info("Entry sizing completed")
+
+	c.JSON(http.StatusOK, gin.H{
+		"recommendations": recommendations,
+		"bankroll":        req.Bankroll,
+		"bankroll_source": bankrollSource,
+		"sizing":          sizing,
+		"iterations":      req.Iterations,
+		"field_sample":    req.FieldSample,
+		"seed":            seed,
+	})
+}
+
+func (h *SimulationHandler) validateSizingRequest(req SizingRequest, sizing bankroll.SizingConfig) error {
+	if len(req.Lineups) == 0 || len(req.PlayerPool) == 0 {
+		return fmt.Errorf("lineups and a player pool are required")
+	}
+	if len(req.Contests) == 0 {
+		return fmt.Errorf("at least one contest is required")
+	}
+	if req.Bankroll <= 0 {
+		return fmt.Errorf("bankroll must be positive; record a deposit or pass a bankroll")
+	}
+	if err := sizing.Validate(); err != nil {
+		return err
+	}
+	if req.Iterations < 0 || req.Iterations > h.config.MaxSimulations {
+		return fmt.Errorf("iterations must be between 1 and %d", h.config.MaxSimulations)
+	}
+	if req.FieldSample < 0 || req.FieldSample > maxAllocationField {
+		return fmt.Errorf("field_sample must be between 1 and %d", maxAllocationField)
+	}
+	seen := make(map[uuid.UUID]bool, len(req.Contests))
+	for _, contest := range req.Contests {
+		if seen[contest.ContestID] {
+			return fmt.Errorf("contest %s is listed more than once", contest.ContestID)
+		}
+		seen[contest.ContestID] = true
+	}
+	return nil
+}
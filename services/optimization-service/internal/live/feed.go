@@ -0,0 +1,294 @@
+package live
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/stitts-dev/dfs-sim/services/optimization-service/internal/simulator"
+	"github.com/stitts-dev/dfs-sim/services/sports-data-service/pkg/providers"
+	"github.com/stitts-dev/dfs-sim/shared/types"
+)
+
+// GolfScoreFeed is a source of hole-by-hole scoring. Poll returns the updates since the previous
+// call and io.EOF once a finite feed is exhausted.
+type GolfScoreFeed interface {
+	Poll(ctx context.Context) ([]simulator.GolfHoleUpdate, error)
+}
+
+// RecordedGolfFeed replays a JSON-lines file of hole updates, a fixed number per poll. It is used
+// to rehearse the pipeline against a past event.
+type RecordedGolfFeed struct {
+	updates   []simulator.GolfHoleUpdate
+	batchSize int
+	next      int
+	mu        sync.Mutex
+}
+
+// NewRecordedGolfFeed loads a recorded feed. A batch size of zero or less replays everything in
+// a single poll.
+func NewRecordedGolfFeed(path string, batchSize int) (*RecordedGolfFeed, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open recorded feed: %w", err)
+	}
+	defer file.Close()
+
+	updates, err := ReadGolfHoleUpdates(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read recorded feed %s: %w", path, err)
+	}
+	if batchSize <= 0 {
+		batchSize = len(updates)
+	}
+
+	return &RecordedGolfFeed{updates: updates, batchSize: batchSize}, nil
+}
+
+// ReadGolfHoleUpdates parses one JSON-encoded update per line, skipping blank lines
+func ReadGolfHoleUpdates(r io.Reader) ([]simulator.GolfHoleUpdate, error) {
+	var updates []simulator.GolfHoleUpdate
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		var update simulator.GolfHoleUpdate
+		if err := json.Unmarshal([]byte(text), &update); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		updates = append(updates, update)
+	}
+	return updates, scanner.Err()
+}
+
+// Poll returns the next batch of recorded updates
+func (f *RecordedGolfFeed) Poll(ctx context.Context) ([]simulator.GolfHoleUpdate, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.next >= len(f.updates) {
+		return nil, io.EOF
+	}
+	end := f.next + f.batchSize
+	if end > len(f.updates) {
+		end = len(f.updates)
+	}
+	batch := f.updates[f.next:end]
+	f.next = end
+	return batch, nil
+}
+
+// InPlayClient is the part of the DataGolf client the live feed needs
+type InPlayClient interface {
+	GetInPlayPredictions() (*providers.LivePredictionsResponse, error)
+}
+
+// DataGolfLiveFeed turns DataGolf in-play leaderboard snapshots into hole updates. DataGolf only
+// publishes each player's round, holes completed and score for the day, so the feed diffs
+// consecutive snapshots: when several holes are completed between polls, all but the last are
+// recorded at par and the last carries the change in score. Poll often enough and this is exact;
+// otherwise player totals stay correct while birdie and bogey counts are approximate.
+type DataGolfLiveFeed struct {
+	client  InPlayClient
+	pars    []int
+	players map[int]string // DataGolf dg_id -> player ID
+
+	mu    sync.Mutex
+	state map[int]*playerFeedState
+}
+
+type playerFeedState struct {
+	round     int
+	thru      int
+	today     int
+	withdrawn bool
+	backNine  bool        // started the round on the 10th tee
+	scores    map[int]int // hole number -> strokes this round
+}
+
+// NewDataGolfLiveFeed creates a feed for a course with the given hole pars. Players missing from
+// the dg_id map are ignored.
+func NewDataGolfLiveFeed(client InPlayClient, pars []int, players map[int]string) *DataGolfLiveFeed {
+	return &DataGolfLiveFeed{
+		client:  client,
+		pars:    pars,
+		players: players,
+		state:   make(map[int]*playerFeedState),
+	}
+}
+
+// Poll fetches the in-play leaderboard and returns the holes completed since the last poll
+func (f *DataGolfLiveFeed) Poll(ctx context.Context) ([]simulator.GolfHoleUpdate, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	response, err := f.client.GetInPlayPredictions()
+	if err != nil {
+		return nil, err
+	}
+
+	at := time.Now()
+	if parsed, err := time.Parse(time.RFC3339, response.UpdatedAt); err == nil {
+		at = parsed
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var updates []simulator.GolfHoleUpdate
+	for _, prediction := range response.Predictions {
+		playerID, ok := f.players[prediction.DGID]
+		if !ok {
+			continue
+		}
+		updates = append(updates, f.diff(playerID, prediction, at)...)
+	}
+	return updates, nil
+}
+
+func (f *DataGolfLiveFeed) diff(playerID string, prediction providers.DataGolfLivePrediction, at time.Time) []simulator.GolfHoleUpdate {
+	state, ok := f.state[prediction.DGID]
+	if !ok {
+		state = &playerFeedState{scores: make(map[int]int)}
+		f.state[prediction.DGID] = state
+	}
+
+	if strings.EqualFold(strings.TrimSpace(prediction.Position), "WD") {
+		if state.withdrawn {
+			return nil
+		}
+		state.withdrawn = true
+		return []simulator.GolfHoleUpdate{{PlayerID: playerID, Withdrawn: true, Timestamp: at}}
+	}
+
+	thru, backNine, ok := parseThru(prediction.Thru)
+	today, todayOK := parseToPar(prediction.Today)
+	if !ok || !todayOK || prediction.Round < 1 {
+		return nil
+	}
+
+	if prediction.Round != state.round {
+		if prediction.Round < state.round {
+			return nil
+		}
+		state.round, state.thru, state.today = prediction.Round, 0, 0
+		state.backNine = false
+		state.scores = make(map[int]int)
+	}
+	// The "*" can drop off once a round is finished ("F"), so remember where the round started
+	if backNine {
+		state.backNine = true
+	}
+
+	var updates []simulator.GolfHoleUpdate
+	switch {
+	case thru > state.thru:
+		holes := make([]int, 0, thru-state.thru)
+		for played := state.thru + 1; played <= thru; played++ {
+			holes = append(holes, holeNumber(played, state.backNine))
+		}
+		scores := f.spread(holes, today-state.today)
+		for k, hole := range holes {
+			state.scores[hole] = scores[k]
+			updates = append(updates, f.update(playerID, state.round, hole, scores[k], at))
+		}
+	case thru == state.thru && thru > 0 && today != state.today:
+		// A scoring correction on the last hole shows up as a changed total with no new holes
+		hole := holeNumber(thru, state.backNine)
+		score := state.scores[hole] + today - state.today
+		if score < 1 {
+			return nil
+		}
+		state.scores[hole] = score
+		updates = append(updates, f.update(playerID, state.round, hole, score, at))
+	default:
+		return nil
+	}
+
+	state.thru, state.today = thru, today
+	return updates
+}
+
+// spread assigns strokes to newly completed holes so they sum to par plus delta. The last hole
+// takes the whole change unless that would leave it below one stroke.
+func (f *DataGolfLiveFeed) spread(holes []int, delta int) []int {
+	scores := make([]int, len(holes))
+	for k, hole := range holes {
+		scores[k] = f.pars[hole-1]
+	}
+	for k := len(holes) - 1; k >= 0 && delta != 0; k-- {
+		change := delta
+		if scores[k]+change < 1 {
+			change = 1 - scores[k]
+		}
+		scores[k] += change
+		delta -= change
+	}
+	return scores
+}
+
+func (f *DataGolfLiveFeed) update(playerID string, round, hole, score int, at time.Time) simulator.GolfHoleUpdate {
+	return simulator.GolfHoleUpdate{
+		PlayerID:  playerID,
+		Round:     round,
+		Hole:      types.HoleScore{Hole: hole, Par: f.pars[hole-1], Score: score},
+		Timestamp: at,
+	}
+}
+
+// parseThru reads DataGolf's holes-completed field: "F" for a finished round, an empty string
+// before teeing off, and a trailing "*" for players who started on the back nine
+func parseThru(thru string) (int, bool, bool) {
+	thru = strings.TrimSpace(thru)
+	backNine := strings.HasSuffix(thru, "*")
+	thru = strings.TrimSuffix(thru, "*")
+
+	switch strings.ToUpper(thru) {
+	case "":
+		return 0, backNine, true
+	case "F":
+		return 18, backNine, true
+	}
+	n, err := strconv.Atoi(thru)
+	if err != nil || n < 0 || n > 18 {
+		return 0, false, false
+	}
+	return n, backNine, true
+}
+
+// parseToPar reads a score relative to par such as "-3", "+2" or "E"
+func parseToPar(score string) (int, bool) {
+	score = strings.TrimSpace(score)
+	if score == "" || strings.EqualFold(score, "E") {
+		return 0, true
+	}
+	n, err := strconv.Atoi(strings.TrimPrefix(score, "+"))
+	if err != nil {
+		return 0, false
+	}
+	return n, true
+}
+
+// holeNumber converts the count of holes played into a hole number
+func holeNumber(played int, backNine bool) int {
+	if backNine {
+		return (played+8)%18 + 1
+	}
+	return played
+}
@@ -0,0 +1,155 @@
+package live
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stitts-dev/dfs-sim/services/optimization-service/internal/simulator"
+	"github.com/stitts-dev/dfs-sim/services/sports-data-service/pkg/providers"
+	"github.com/stitts-dev/dfs-sim/shared/pkg/scoring"
+)
+
+var testPars = []int{4, 4, 3, 5, 4, 4, 3, 4, 5, 4, 4, 3, 5, 4, 4, 3, 4, 5}
+
+type fakeInPlayClient struct {
+	snapshots [][]providers.DataGolfLivePrediction
+	calls     int
+}
+
+func (c *fakeInPlayClient) GetInPlayPredictions() (*providers.LivePredictionsResponse, error) {
+	snapshot := c.snapshots[c.calls]
+	c.calls++
+	return &providers.LivePredictionsResponse{Predictions: snapshot}, nil
+}
+
+type recordingBroadcaster struct {
+	messages []interface{}
+}
+
+func (b *recordingBroadcaster) BroadcastToAll(message interface{}) {
+	b.messages = append(b.messages, message)
+}
+
+func TestDataGolfLiveFeed_DiffsSnapshotsIntoHoles(t *testing.T) {
+	client := &fakeInPlayClient{snapshots: [][]providers.DataGolfLivePrediction{
+		{
+			{DGID: 1, Round: 1, Thru: "2", Today: "-1"},
+			{DGID: 2, Round: 1, Thru: "1*", Today: "+1"},
+			{DGID: 99, Round: 1, Thru: "5", Today: "-3"},
+		},
+		{
+			// Player 1 plays three more holes in even par, player 2's bogey is corrected to a par
+			{DGID: 1, Round: 1, Thru: "5", Today: "-1"},
+			{DGID: 2, Round: 1, Thru: "1*", Today: "E"},
+		},
+		{
+			{DGID: 1, Round: 1, Thru: "5", Today: "-1"},
+			{DGID: 2, Round: 1, Thru: "1*", Today: "E", Position: "WD"},
+		},
+	}}
+	feed := NewDataGolfLiveFeed(client, testPars, map[int]string{1: "p1", 2: "p2"})
+	ctx := context.Background()
+
+	updates, err := feed.Poll(ctx)
+	require.NoError(t, err)
+	require.Len(t, updates, 3, "unmapped players are ignored")
+	assert.Equal(t, 1, updates[0].Hole.Hole)
+	assert.Equal(t, 4, updates[0].Hole.Score)
+	assert.Equal(t, 3, updates[1].Hole.Score, "the last new hole carries the change")
+	assert.Equal(t, "p2", updates[2].PlayerID)
+	assert.Equal(t, 10, updates[2].Hole.Hole, "back nine starters open on ten")
+	assert.Equal(t, 5, updates[2].Hole.Score)
+
+	updates, err = feed.Poll(ctx)
+	require.NoError(t, err)
+	require.Len(t, updates, 4)
+	for k, hole := range []int{3, 4, 5} {
+		assert.Equal(t, hole, updates[k].Hole.Hole)
+		assert.Equal(t, testPars[hole-1], updates[k].Hole.Score)
+	}
+	assert.Equal(t, 10, updates[3].Hole.Hole)
+	assert.Equal(t, 4, updates[3].Hole.Score)
+
+	updates, err = feed.Poll(ctx)
+	require.NoError(t, err)
+	require.Len(t, updates, 1)
+	assert.True(t, updates[0].Withdrawn)
+}
+
+func TestDataGolfLiveFeed_KeepsBackNineStartForTheRound(t *testing.T) {
+	client := &fakeInPlayClient{snapshots: [][]providers.DataGolfLivePrediction{
+		{{DGID: 1, Round: 1, Thru: "14*", Today: "-1"}},
+		// The finished round drops the "*"
+		{{DGID: 1, Round: 1, Thru: "F", Today: "-1"}},
+		// and the next round starts on the front nine
+		{{DGID: 1, Round: 2, Thru: "2", Today: "E"}},
+	}}
+	feed := NewDataGolfLiveFeed(client, testPars, map[int]string{1: "p1"})
+	ctx := context.Background()
+
+	updates, err := feed.Poll(ctx)
+	require.NoError(t, err)
+	require.Len(t, updates, 14)
+
+	updates, err = feed.Poll(ctx)
+	require.NoError(t, err)
+	require.Len(t, updates, 4)
+	for k, hole := range []int{6, 7, 8, 9} {
+		assert.Equal(t, hole, updates[k].Hole.Hole, "the last holes of a back nine start are 6-9")
+	}
+
+	updates, err = feed.Poll(ctx)
+	require.NoError(t, err)
+	require.Len(t, updates, 2)
+	assert.Equal(t, 2, updates[0].Round)
+	assert.Equal(t, 1, updates[0].Hole.Hole)
+	assert.Equal(t, 2, updates[1].Hole.Hole)
+}
+
+func TestGolfLivePipeline_ReplaysRecordedFeed(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "feed.jsonl")
+	require.NoError(t, os.WriteFile(path, []byte(`{"player_id":"a","round":1,"hole":{"hole":1,"score":3}}
+{"player_id":"b","round":1,"hole":{"hole":1,"score":4}}
+
+{"player_id":"a","round":1,"hole":{"hole":2,"score":3}}
+`), 0o644))
+
+	feed, err := NewRecordedGolfFeed(path, 2)
+	require.NoError(t, err)
+
+	entrants := []simulator.GolfEntrant{
+		{PlayerID: "a", RoundStdDev: 2.8},
+		{PlayerID: "b", RoundStdDev: 2.8},
+	}
+	tournament, err := simulator.NewLiveGolfTournament(entrants, simulator.DefaultGolfTournamentConfig(), testPars, scoring.DraftKingsGolf)
+	require.NoError(t, err)
+
+	broadcaster := &recordingBroadcaster{}
+	pipeline := NewGolfLivePipeline("t1", tournament, feed, broadcaster, logrus.New())
+	pipeline.Iterations = 50
+
+	ctx := context.Background()
+	require.NoError(t, pipeline.Step(ctx))
+	require.NoError(t, pipeline.Step(ctx))
+	// Nothing new on the final poll, so no third broadcast
+	assert.ErrorIs(t, pipeline.Step(ctx), io.EOF)
+	assert.Len(t, broadcaster.messages, 2)
+
+	snapshot := pipeline.Snapshot()
+	require.NotNil(t, snapshot)
+	assert.Equal(t, "a", snapshot.Standings[0].PlayerID)
+	assert.Equal(t, -2, snapshot.Standings[0].ToPar)
+
+	state, err := pipeline.TournamentState(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, state.PlayersActive)
+	assert.Equal(t, "a", state.LeaderPosition.PlayerID)
+	assert.Equal(t, 2, state.LeaderPosition.ThruHoles)
+}
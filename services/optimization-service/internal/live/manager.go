@@ -0,0 +1,121 @@
+package live
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/stitts-dev/dfs-sim/services/optimization-service/internal/optimizer"
+)
+
+// The late swap tracker can read its state straight from a pipeline
+var _ optimizer.TournamentStateSource = (*GolfLivePipeline)(nil)
+
+// GolfLiveManager runs one live pipeline per tournament, with a late swap state tracker reading
+// from each pipeline
+type GolfLiveManager struct {
+	mu            sync.RWMutex
+	pipelines     map[string]*GolfLivePipeline
+	trackers      map[string]*optimizer.TournamentStateTracker
+	cancels       map[string]context.CancelFunc
+	cutProbEngine *optimizer.CutProbabilityEngine
+	logger        *logrus.Logger
+}
+
+// NewGolfLiveManager creates an empty manager. The cut probability engine may be nil.
+func NewGolfLiveManager(cutProbEngine *optimizer.CutProbabilityEngine, logger *logrus.Logger) *GolfLiveManager {
+	return &GolfLiveManager{
+		pipelines:     make(map[string]*GolfLivePipeline),
+		trackers:      make(map[string]*optimizer.TournamentStateTracker),
+		cancels:       make(map[string]context.CancelFunc),
+		cutProbEngine: cutProbEngine,
+		logger:        logger,
+	}
+}
+
+// Start runs a pipeline in the background until Stop is called or its feed runs out. The
+// tournament's state tracker starts once the pipeline has its first snapshot.
+func (m *GolfLiveManager) Start(tournamentID string, pipeline *GolfLivePipeline) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, running := m.cancels[tournamentID]; running {
+		return fmt.Errorf("live scoring for tournament %s is already running", tournamentID)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	tracker := optimizer.NewTournamentStateTracker(tournamentID, nil, m.cutProbEngine, m.logger)
+	tracker.SetStateSource(pipeline)
+	m.pipelines[tournamentID] = pipeline
+	m.trackers[tournamentID] = tracker
+	m.cancels[tournamentID] = cancel
+
+	go func() {
+		select {
+		case <-pipeline.Ready():
+			if err := tracker.StartTracking(ctx); err != nil {
+				m.logger.WithError(err).WithField("tournament_id", tournamentID).Warn("Failed to start tournament state tracking")
+			}
+		case <-ctx.Done():
+		}
+	}()
+
+	go func() {
+		if err := pipeline.Run(ctx); err != nil && ctx.Err() == nil {
+			m.logger.WithError(err).WithField("tournament_id", tournamentID).Error("Live golf pipeline stopped")
+		}
+
+		// Keep the final snapshot readable but allow the tournament to be restarted
+		m.mu.Lock()
+		if ctx.Err() == nil {
+			delete(m.cancels, tournamentID)
+		}
+		m.mu.Unlock()
+		cancel()
+	}()
+
+	return nil
+}
+
+// Stop cancels a running pipeline. Its last snapshot stays available.
+func (m *GolfLiveManager) Stop(tournamentID string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	cancel, running := m.cancels[tournamentID]
+	if !running {
+		return false
+	}
+	cancel()
+	delete(m.cancels, tournamentID)
+	if tracker, ok := m.trackers[tournamentID]; ok {
+		tracker.StopTracking()
+	}
+	return true
+}
+
+// Pipeline returns the latest pipeline started for a tournament
+func (m *GolfLiveManager) Pipeline(tournamentID string) (*GolfLivePipeline, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	pipeline, ok := m.pipelines[tournamentID]
+	return pipeline, ok
+}
+
+// Tracker returns the late swap state tracker for the latest pipeline started for a tournament
+func (m *GolfLiveManager) Tracker(tournamentID string) (*optimizer.TournamentStateTracker, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	tracker, ok := m.trackers[tournamentID]
+	return tracker, ok
+}
+
+// Running reports whether a tournament's pipeline is still polling
+func (m *GolfLiveManager) Running(tournamentID string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	_, running := m.cancels[tournamentID]
+	return running
+}
@@ -0,0 +1,226 @@
+package live
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/stitts-dev/dfs-sim/services/optimization-service/internal/simulator"
+	"github.com/stitts-dev/dfs-sim/services/optimization-service/internal/websocket"
+	"github.com/stitts-dev/dfs-sim/shared/types"
+)
+
+// GolfLiveUpdateMessage is the WebSocket message type for live golf snapshots
+const GolfLiveUpdateMessage = "golf_live_update"
+
+// Broadcaster pushes messages to connected clients. The optimization WebSocket hub satisfies it.
+type Broadcaster interface {
+	BroadcastToAll(message interface{})
+}
+
+// GolfLiveSnapshot is the leaderboard and projection after the latest poll
+type GolfLiveSnapshot struct {
+	TournamentID string                        `json:"tournament_id"`
+	Round        int                           `json:"round"`
+	Standings    []simulator.GolfLiveStanding  `json:"standings"`
+	Projection   *simulator.GolfLiveProjection `json:"projection"`
+	UpdatedAt    time.Time                     `json:"updated_at"`
+}
+
+// GolfLivePipeline polls a score feed, applies the updates to a live tournament, re-projects the
+// finish and broadcasts the result
+type GolfLivePipeline struct {
+	tournamentID string
+	tournament   *simulator.LiveGolfTournament
+	feed         GolfScoreFeed
+	broadcaster  Broadcaster
+	processor    *LiveTournamentProcessor
+	logger       *logrus.Logger
+
+	PollInterval time.Duration
+	Iterations   int
+
+	mu        sync.RWMutex
+	rng       *rand.Rand
+	snapshot  *GolfLiveSnapshot
+	ready     chan struct{}
+	readyOnce sync.Once
+}
+
+// NewGolfLivePipeline creates a pipeline. The broadcaster may be nil.
+func NewGolfLivePipeline(
+	tournamentID string,
+	tournament *simulator.LiveGolfTournament,
+	feed GolfScoreFeed,
+	broadcaster Broadcaster,
+	logger *logrus.Logger,
+) *GolfLivePipeline {
+	return &GolfLivePipeline{
+		tournamentID: tournamentID,
+		tournament:   tournament,
+		feed:         feed,
+		broadcaster:  broadcaster,
+		processor:    NewLiveTournamentProcessor(),
+		logger:       logger,
+		PollInterval: time.Minute,
+		Iterations:   2000,
+		rng:          rand.New(rand.NewSource(time.Now().UnixNano())),
+		ready:        make(chan struct{}),
+	}
+}
+
+// Run polls the feed until the context is cancelled or a recorded feed runs out
+func (p *GolfLivePipeline) Run(ctx context.Context) error {
+	ticker := time.NewTicker(p.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := p.Step(ctx); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			// A failed poll is retried on the next tick rather than ending the event
+			p.logger.WithError(err).WithField("tournament_id", p.tournamentID).Warn("Live golf poll failed")
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Step runs a single poll. Snapshots are only rebuilt and broadcast when the feed had updates,
+// except for the first step.
+func (p *GolfLivePipeline) Step(ctx context.Context) error {
+	updates, err := p.feed.Poll(ctx)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("failed to poll live scores: %w", err)
+	}
+	eof := errors.Is(err, io.EOF)
+
+	applied := 0
+	for _, update := range updates {
+		if applyErr := p.tournament.Apply(update); applyErr != nil {
+			p.logger.WithError(applyErr).WithField("player_id", update.PlayerID).Debug("Skipping live golf update")
+			continue
+		}
+		applied++
+	}
+
+	p.mu.RLock()
+	stale := p.snapshot == nil || applied > 0
+	p.mu.RUnlock()
+
+	if stale {
+		snapshot := p.rebuild()
+		if p.broadcaster != nil {
+			p.broadcaster.BroadcastToAll(websocket.Message{Type: GolfLiveUpdateMessage, Payload: snapshot})
+		}
+	}
+
+	if eof {
+		return io.EOF
+	}
+	return nil
+}
+
+func (p *GolfLivePipeline) rebuild() *GolfLiveSnapshot {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	snapshot := &GolfLiveSnapshot{
+		TournamentID: p.tournamentID,
+		Round:        p.tournament.CurrentRound(),
+		Standings:    p.tournament.Standings(),
+		Projection:   p.tournament.Project(p.Iterations, p.rng),
+		UpdatedAt:    p.tournament.UpdatedAt(),
+	}
+	p.snapshot = snapshot
+	p.readyOnce.Do(func() { close(p.ready) })
+	return snapshot
+}
+
+// Ready is closed once the first snapshot has been built
+func (p *GolfLivePipeline) Ready() <-chan struct{} {
+	return p.ready
+}
+
+// Snapshot returns the latest snapshot, or nil before the first step
+func (p *GolfLivePipeline) Snapshot() *GolfLiveSnapshot {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.snapshot
+}
+
+// Tournament returns the live tournament the pipeline feeds
+func (p *GolfLivePipeline) Tournament() *simulator.LiveGolfTournament {
+	return p.tournament
+}
+
+// Analysis reads the leaders, cut line and player momentum from the latest snapshot, or returns
+// nil before the first step
+func (p *GolfLivePipeline) Analysis() *LiveTournamentData {
+	snapshot := p.Snapshot()
+	if snapshot == nil {
+		return nil
+	}
+	return p.processor.Ingest(p.tournamentID, p.tournament, snapshot.Projection)
+}
+
+// TournamentState summarises the latest snapshot for the late swap tracker
+func (p *GolfLivePipeline) TournamentState(ctx context.Context) (*types.TournamentState, error) {
+	snapshot := p.Snapshot()
+	if snapshot == nil {
+		return nil, fmt.Errorf("no live data for tournament %s yet", p.tournamentID)
+	}
+	analysis := p.processor.Ingest(p.tournamentID, p.tournament, snapshot.Projection)
+
+	state := &types.TournamentState{
+		TournamentID: p.tournamentID,
+		CurrentRound: snapshot.Round,
+		LastUpdate:   snapshot.UpdatedAt.Format(time.RFC3339),
+	}
+
+	var leader *simulator.GolfLiveStanding
+	for i := range snapshot.Standings {
+		standing := &snapshot.Standings[i]
+		switch standing.Status {
+		case simulator.GolfStatusActive:
+			state.PlayersActive++
+			if leader == nil {
+				leader = standing
+			}
+		case simulator.GolfStatusWithdrawn:
+			state.PlayersWithdrawn++
+		}
+	}
+
+	if analysis.CutLine != nil {
+		state.CutLine = int(math.Round(analysis.CutLine.Current))
+		state.ProjectedCutLine = int(math.Round(analysis.CutLine.Projected))
+	}
+	if leader != nil {
+		state.LeaderPosition = &types.GolfLeaderboardEntry{
+			Position:   leader.Position,
+			PlayerID:   leader.PlayerID,
+			TotalScore: leader.ToPar,
+			ThruHoles:  leader.Thru,
+			RoundScore: leader.Today,
+			Status:     leader.Status,
+		}
+	}
+
+	return state, nil
+}
@@ -0,0 +1,357 @@
+package live
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/stitts-dev/dfs-sim/services/optimization-service/internal/simulator"
+)
+
+const (
+	// momentumWindow is how many recent holes feed the momentum read
+	momentumWindow = 6
+	// momentumDecay down-weights each older hole in the window
+	momentumDecay = 0.8
+	// momentumCarryover is the share of recent scoring vs par expected to persist per hole. Hot
+	// streaks in golf are mostly noise, so this is deliberately small.
+	momentumCarryover = 0.1
+)
+
+// LiveTournamentData is a read of a tournament in progress built from the live scoring pipeline:
+// the leaders, where the cut falls and how each player is trending
+type LiveTournamentData struct {
+	TournamentID     string                            `json:"tournament_id"`
+	CurrentRound     int                               `json:"current_round"`
+	PlayStatus       string                            `json:"play_status"`
+	PlayersOnCourse  int                               `json:"players_on_course"`
+	PlayersCompleted int                               `json:"players_completed"`
+	AverageScore     float64                           `json:"average_score"`
+	Leaders          []*LeaderboardEntry               `json:"leaders"`
+	CutLine          *CutLineStatus                    `json:"cut_line,omitempty"`
+	PlayerData       map[string]*LivePlayerPerformance `json:"player_data"`
+	Leaderboard      []simulator.GolfLiveStanding      `json:"-"`
+	Projection       *simulator.GolfLiveProjection     `json:"-"`
+	UpdatedAt        time.Time                         `json:"updated_at"`
+}
+
+// LivePlayerPerformance is one player's live position, projection and momentum
+type LivePlayerPerformance struct {
+	PlayerID              string             `json:"player_id"`
+	CurrentPosition       int                `json:"current_position"`
+	Score                 int                `json:"score"`
+	Round                 int                `json:"round"`
+	HolesCompleted        int                `json:"holes_completed"`
+	Momentum              *PlayerMomentum    `json:"momentum"`
+	RecentHolePerformance []*HolePerformance `json:"recent_hole_performance"`
+	ProjectedFinish       *ProjectedFinish   `json:"projected_finish,omitempty"`
+	CutLineProbability    float64            `json:"cut_line_probability"`
+	LastUpdate            time.Time          `json:"last_update"`
+}
+
+// HolePerformance is a completed hole
+type HolePerformance struct {
+	HoleNumber int `json:"hole_number"`
+	Score      int `json:"score"`
+	Par        int `json:"par"`
+}
+
+// ProjectedFinish is where the live simulation expects a player to finish
+type ProjectedFinish struct {
+	ProjectedScore    float64 `json:"projected_score"`
+	ProjectedPosition int     `json:"projected_position"`
+	Confidence        float64 `json:"confidence"`
+	RemainingHoles    int     `json:"remaining_holes"`
+}
+
+// LeaderboardEntry is a player near the top of the leaderboard
+type LeaderboardEntry struct {
+	PlayerID string `json:"player_id"`
+	Position int    `json:"position"`
+	Score    int    `json:"score"`
+	Today    int    `json:"today"`
+}
+
+// CutLineStatus is where the cut falls now and where the simulation projects it
+type CutLineStatus struct {
+	Current     float64             `json:"current"`
+	Projected   float64             `json:"projected"`
+	MadeCount   int                 `json:"made_count"`
+	MissedCount int                 `json:"missed_count"`
+	Range       *ConfidenceInterval `json:"range,omitempty"`
+	LastUpdate  time.Time           `json:"last_update"`
+}
+
+// ConfidenceInterval is a range of outcomes and the share of simulations inside it
+type ConfidenceInterval struct {
+	Lower      float64 `json:"lower"`
+	Upper      float64 `json:"upper"`
+	Confidence float64 `json:"confidence"`
+}
+
+// PlayerMomentum is a player's recent scoring against par
+type PlayerMomentum struct {
+	Current         float64            `json:"current"`
+	Trend           string             `json:"trend"`
+	RecentHoles     []*HolePerformance `json:"recent_holes"`
+	PredictedImpact float64            `json:"predicted_impact"`
+	Confidence      float64            `json:"confidence"`
+}
+
+// LiveTournamentProcessor turns scorecards and a live projection into LiveTournamentData
+type LiveTournamentProcessor struct {
+	leaderboardProcessor *LeaderboardProcessor
+	cutLineProcessor     *CutLineProcessor
+	momentumAnalyzer     *MomentumAnalyzer
+}
+
+// LeaderboardProcessor summarises the live leaderboard
+type LeaderboardProcessor struct{}
+
+// CutLineProcessor tracks where the cut falls now and where it is projected to fall
+type CutLineProcessor struct{}
+
+// MomentumAnalyzer reads a player's recent holes for short-term scoring momentum
+type MomentumAnalyzer struct{}
+
+// NewLiveTournamentProcessor creates a processor
+func NewLiveTournamentProcessor() *LiveTournamentProcessor {
+	return &LiveTournamentProcessor{
+		leaderboardProcessor: &LeaderboardProcessor{},
+		cutLineProcessor:     &CutLineProcessor{},
+		momentumAnalyzer:     &MomentumAnalyzer{},
+	}
+}
+
+// Ingest builds live tournament data from the current scorecards and a projection of the finish.
+// The projection may be nil.
+func (ltp *LiveTournamentProcessor) Ingest(
+	tournamentID string,
+	tournament *simulator.LiveGolfTournament,
+	projection *simulator.GolfLiveProjection,
+) *LiveTournamentData {
+	standings := tournament.Standings()
+	round := tournament.CurrentRound()
+
+	liveData := &LiveTournamentData{
+		TournamentID: tournamentID,
+		CurrentRound: round,
+		PlayStatus:   "In Progress",
+		PlayerData:   make(map[string]*LivePlayerPerformance, len(standings)),
+		Leaderboard:  standings,
+		Projection:   projection,
+		UpdatedAt:    tournament.UpdatedAt(),
+	}
+	if projection != nil && projection.HolesRemaining == 0 {
+		liveData.PlayStatus = "Complete"
+	}
+
+	for _, standing := range standings {
+		if standing.Status == simulator.GolfStatusActive && standing.Round == round {
+			switch {
+			case standing.Thru == 18:
+				liveData.PlayersCompleted++
+			case standing.Thru > 0:
+				liveData.PlayersOnCourse++
+			}
+		}
+
+		performance := &LivePlayerPerformance{
+			PlayerID:              standing.PlayerID,
+			CurrentPosition:       standing.Position,
+			Score:                 standing.ToPar,
+			Round:                 standing.Round,
+			HolesCompleted:        standing.HolesPlayed,
+			RecentHolePerformance: ltp.recentHoles(tournament, standing.PlayerID),
+			LastUpdate:            liveData.UpdatedAt,
+		}
+		if projection != nil {
+			if player, ok := projection.Player(standing.PlayerID); ok {
+				performance.CutLineProbability = player.CutProbability
+				performance.ProjectedFinish = &ProjectedFinish{
+					ProjectedScore:    player.ProjectedToPar,
+					ProjectedPosition: int(math.Round(player.AverageFinish)),
+					Confidence:        player.Top10,
+					RemainingHoles:    18*4 - standing.HolesPlayed,
+				}
+			}
+		}
+		performance.Momentum = ltp.momentumAnalyzer.CalculateMomentum(performance)
+		liveData.PlayerData[standing.PlayerID] = performance
+	}
+
+	liveData.Leaders = ltp.leaderboardProcessor.TopN(liveData, 10)
+	liveData.AverageScore = ltp.leaderboardProcessor.AverageScore(liveData)
+	liveData.CutLine = ltp.cutLineProcessor.Status(liveData)
+	return liveData
+}
+
+func (ltp *LiveTournamentProcessor) recentHoles(tournament *simulator.LiveGolfTournament, playerID string) []*HolePerformance {
+	holes := tournament.RecentHoles(playerID, momentumWindow)
+	recent := make([]*HolePerformance, len(holes))
+	for k, hole := range holes {
+		recent[k] = &HolePerformance{HoleNumber: hole.Hole, Score: hole.Score, Par: hole.Par}
+	}
+	return recent
+}
+
+// TopN returns the leading active players
+func (lp *LeaderboardProcessor) TopN(liveData *LiveTournamentData, n int) []*LeaderboardEntry {
+	entries := make([]*LeaderboardEntry, 0, n)
+	for _, standing := range liveData.Leaderboard {
+		if len(entries) == n {
+			break
+		}
+		if standing.Status != simulator.GolfStatusActive {
+			continue
+		}
+		entries = append(entries, &LeaderboardEntry{
+			PlayerID: standing.PlayerID,
+			Position: standing.Position,
+			Score:    standing.ToPar,
+			Today:    standing.Today,
+		})
+	}
+	return entries
+}
+
+// AverageScore is the mean score to par of players still in the event
+func (lp *LeaderboardProcessor) AverageScore(liveData *LiveTournamentData) float64 {
+	total, count := 0, 0
+	for _, standing := range liveData.Leaderboard {
+		if standing.Status == simulator.GolfStatusActive && standing.HolesPlayed > 0 {
+			total += standing.ToPar
+			count++
+		}
+	}
+	if count == 0 {
+		return 0
+	}
+	return float64(total) / float64(count)
+}
+
+// Status reports the cut line. Before the cut, the current line is the score of the last player
+// inside the projected number of survivors; afterwards both values are the actual cut.
+func (clp *CutLineProcessor) Status(liveData *LiveTournamentData) *CutLineStatus {
+	projection := liveData.Projection
+	if projection == nil || projection.ProjectedCutLine == nil {
+		return nil
+	}
+
+	status := &CutLineStatus{
+		Current:    *projection.ProjectedCutLine,
+		Projected:  *projection.ProjectedCutLine,
+		Range:      cutLineInterval(projection.CutLineHistogram),
+		LastUpdate: liveData.UpdatedAt,
+	}
+	for _, standing := range liveData.Leaderboard {
+		switch standing.Status {
+		case simulator.GolfStatusActive:
+			status.MadeCount++
+		case simulator.GolfStatusCut:
+			status.MissedCount++
+		}
+	}
+	if status.MissedCount > 0 {
+		return status
+	}
+
+	// The cut hasn't been made, so count the expected survivors instead
+	status.MadeCount, status.MissedCount = 0, 0
+	expected := 0.0
+	var active []simulator.GolfLiveStanding
+	for _, standing := range liveData.Leaderboard {
+		if standing.Status != simulator.GolfStatusActive {
+			continue
+		}
+		active = append(active, standing)
+		if player, ok := projection.Player(standing.PlayerID); ok {
+			expected += player.CutProbability
+		}
+	}
+	survivors := int(math.Round(expected))
+	if survivors > 0 && survivors <= len(active) {
+		status.Current = float64(active[survivors-1].ToPar)
+	}
+	status.MadeCount = survivors
+	status.MissedCount = len(active) - survivors
+	return status
+}
+
+// CalculateMomentum weights recent holes vs par, newest first. Positive momentum means the
+// player has been scoring under par.
+func (ma *MomentumAnalyzer) CalculateMomentum(playerData *LivePlayerPerformance) *PlayerMomentum {
+	holes := playerData.RecentHolePerformance
+	momentum := &PlayerMomentum{Trend: "Neutral", RecentHoles: holes}
+	if len(holes) == 0 {
+		return momentum
+	}
+
+	weighted, weights := 0.0, 0.0
+	weight := 1.0
+	for k := len(holes) - 1; k >= 0; k-- {
+		weighted += weight * float64(holes[k].Par-holes[k].Score)
+		weights += weight
+		weight *= momentumDecay
+	}
+	momentum.Current = weighted / weights
+
+	// Compare the newer half of the window with the older half
+	half := len(holes) / 2
+	if half > 0 {
+		older, newer := 0, 0
+		for k, hole := range holes {
+			if k < len(holes)-half {
+				older += hole.Par - hole.Score
+			} else {
+				newer += hole.Par - hole.Score
+			}
+		}
+		delta := float64(newer)/float64(half) - float64(older)/float64(len(holes)-half)
+		switch {
+		case delta > 0.25:
+			momentum.Trend = "Positive"
+		case delta < -0.25:
+			momentum.Trend = "Negative"
+		}
+	}
+
+	remaining := 18 - playerData.HolesCompleted%18
+	if remaining == 18 {
+		remaining = 0
+	}
+	momentum.PredictedImpact = momentum.Current * momentumCarryover * float64(remaining)
+	momentum.Confidence = math.Min(1, float64(len(holes))/momentumWindow)
+	return momentum
+}
+
+// cutLineInterval is the 10th to 90th percentile range of simulated cut lines
+func cutLineInterval(histogram map[int]int) *ConfidenceInterval {
+	lines := make([]int, 0, len(histogram))
+	total := 0
+	for line, count := range histogram {
+		lines = append(lines, line)
+		total += count
+	}
+	if total == 0 {
+		return nil
+	}
+	sort.Ints(lines)
+
+	interval := &ConfidenceInterval{Confidence: 0.8}
+	seen := 0
+	lowerSet := false
+	for _, line := range lines {
+		seen += histogram[line]
+		share := float64(seen) / float64(total)
+		if !lowerSet && share >= 0.1 {
+			interval.Lower = float64(line)
+			lowerSet = true
+		}
+		if share >= 0.9 {
+			interval.Upper = float64(line)
+			break
+		}
+	}
+	return interval
+}
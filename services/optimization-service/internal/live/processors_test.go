@@ -0,0 +1,124 @@
+package live
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stitts-dev/dfs-sim/services/optimization-service/internal/simulator"
+	"github.com/stitts-dev/dfs-sim/shared/pkg/scoring"
+	"github.com/stitts-dev/dfs-sim/shared/types"
+)
+
+// staticGolfFeed returns its updates on the first poll and nothing after that
+type staticGolfFeed struct {
+	updates []simulator.GolfHoleUpdate
+	polled  bool
+}
+
+func (f *staticGolfFeed) Poll(ctx context.Context) ([]simulator.GolfHoleUpdate, error) {
+	if f.polled {
+		return nil, io.EOF
+	}
+	f.polled = true
+	return f.updates, nil
+}
+
+// holeUpdates plays the first holes of round one for a player, each at par plus the offset
+func holeUpdates(playerID string, offsets ...int) []simulator.GolfHoleUpdate {
+	updates := make([]simulator.GolfHoleUpdate, len(offsets))
+	for k, offset := range offsets {
+		updates[k] = simulator.GolfHoleUpdate{
+			PlayerID: playerID,
+			Round:    1,
+			Hole:     types.HoleScore{Hole: k + 1, Par: testPars[k], Score: testPars[k] + offset},
+		}
+	}
+	return updates
+}
+
+func TestLiveTournamentProcessor_Ingest(t *testing.T) {
+	entrants := []simulator.GolfEntrant{
+		{PlayerID: "hot", RoundStdDev: 2.8},
+		{PlayerID: "cold", RoundStdDev: 2.8},
+		{PlayerID: "idle", RoundStdDev: 2.8},
+	}
+	tournament, err := simulator.NewLiveGolfTournament(entrants, simulator.DefaultGolfTournamentConfig(), testPars, scoring.DraftKingsGolf)
+	require.NoError(t, err)
+	// Pars early, birdies late for one player; the reverse for the other
+	for _, update := range append(holeUpdates("hot", 0, 0, 0, -1, -1, -1), holeUpdates("cold", -1, -1, -1, 1, 1, 1)...) {
+		require.NoError(t, tournament.Apply(update))
+	}
+
+	cutLine := -1.0
+	projection := &simulator.GolfLiveProjection{
+		Players: []simulator.GolfLivePlayerProjection{
+			{PlayerID: "hot", CutProbability: 0.9, AverageFinish: 1.6},
+			{PlayerID: "cold", CutProbability: 0.6},
+			{PlayerID: "idle", CutProbability: 0.4},
+		},
+		ProjectedCutLine: &cutLine,
+		CutLineHistogram: map[int]int{-3: 1, -2: 2, -1: 4, 0: 2, 1: 1},
+		HolesRemaining:   200,
+	}
+
+	data := NewLiveTournamentProcessor().Ingest("t1", tournament, projection)
+	assert.Equal(t, "In Progress", data.PlayStatus)
+	assert.Equal(t, 2, data.PlayersOnCourse)
+	require.Len(t, data.Leaders, 3)
+	assert.Equal(t, "hot", data.Leaders[0].PlayerID)
+	assert.Equal(t, -3, data.Leaders[0].Score)
+	// -3 and even par; players yet to tee off don't count
+	assert.InDelta(t, -1.5, data.AverageScore, 1e-9)
+
+	hot, cold := data.PlayerData["hot"].Momentum, data.PlayerData["cold"].Momentum
+	assert.Equal(t, "Positive", hot.Trend)
+	assert.Equal(t, "Negative", cold.Trend)
+	assert.Greater(t, hot.Current, 0.0)
+	assert.Less(t, cold.Current, 0.0)
+	assert.Equal(t, 2, data.PlayerData["hot"].ProjectedFinish.ProjectedPosition)
+
+	// Before the cut, 0.9 + 0.6 + 0.4 rounds to two expected survivors; the second is at even par
+	require.NotNil(t, data.CutLine)
+	assert.Equal(t, 2, data.CutLine.MadeCount)
+	assert.Equal(t, 1, data.CutLine.MissedCount)
+	assert.Equal(t, 0.0, data.CutLine.Current)
+	assert.Equal(t, -1.0, data.CutLine.Projected)
+	require.NotNil(t, data.CutLine.Range)
+	assert.Equal(t, -3.0, data.CutLine.Range.Lower)
+	assert.Equal(t, 0.0, data.CutLine.Range.Upper)
+}
+
+func TestGolfLiveManager_TracksPipelineState(t *testing.T) {
+	entrants := []simulator.GolfEntrant{
+		{PlayerID: "a", RoundStdDev: 2.8},
+		{PlayerID: "b", RoundStdDev: 2.8},
+	}
+	tournament, err := simulator.NewLiveGolfTournament(entrants, simulator.DefaultGolfTournamentConfig(), testPars, scoring.DraftKingsGolf)
+	require.NoError(t, err)
+
+	feed := &staticGolfFeed{updates: append(holeUpdates("a", -1, -1), holeUpdates("b", 0)...)}
+	pipeline := NewGolfLivePipeline("t1", tournament, feed, nil, logrus.New())
+	pipeline.Iterations = 50
+
+	manager := NewGolfLiveManager(nil, logrus.New())
+	require.NoError(t, manager.Start("t1", pipeline))
+	tracker, ok := manager.Tracker("t1")
+	require.True(t, ok)
+
+	// The tracker reads the live pipeline, not its placeholder state
+	require.Eventually(t, func() bool { return tracker.GetCurrentState() != nil }, 5*time.Second, 10*time.Millisecond)
+	state := tracker.GetCurrentState()
+	assert.Equal(t, "t1", state.TournamentID)
+	assert.Equal(t, 2, state.PlayersActive)
+	require.NotNil(t, state.LeaderPosition)
+	assert.Equal(t, "a", state.LeaderPosition.PlayerID)
+	assert.Equal(t, -2, state.LeaderPosition.TotalScore)
+
+	manager.Stop("t1")
+}
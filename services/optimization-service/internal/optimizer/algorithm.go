@@ -1,6 +1,7 @@
 package optimizer
 
 import (
+	"context"
 	"fmt"
 	"math"
 	"sort"
@@ -10,6 +11,8 @@ import (
 	"github.com/google/uuid"
 	"github.com/stitts-dev/dfs-sim/shared/types"
 	"github.com/stitts-dev/dfs-sim/shared/pkg/logger"
+	"github.com/stitts-dev/dfs-sim/shared/pkg/scoring"
+	"github.com/stitts-dev/dfs-sim/shared/pkg/weather"
 	"github.com/sirupsen/logrus"
 )
 
@@ -25,6 +28,15 @@ type OptimizeConfig struct {
 	MinExposure         map[uuid.UUID]float64   `json:"min_exposure"`
 	MaxExposure         map[uuid.UUID]float64   `json:"max_exposure"`
 	Contest             *types.Contest          `json:"-"`
+	// PlayerWaves is each golfer's tee-time wave, assigned from the pool when wave stacking
+	// rules are used
+	PlayerWaves map[uuid.UUID]weather.Wave `json:"-"`
+	
+	// OptimizationID names the run in logs and for cancellation; one is generated when empty
+	OptimizationID string `json:"optimization_id,omitempty"`
+
+	// Randomization solves each lineup against perturbed projections (optional)
+	Randomization *RandomizationConfig `json:"randomization,omitempty"`
 	
 	// Portfolio-level constraints (optional)
 	UsePortfolioConstraints bool                 `json:"use_portfolio_constraints"`
@@ -56,6 +68,13 @@ type OptimizerResult struct {
 	TotalCombinations int64                   `json:"total_combinations"`
 	ValidCombinations int64                   `json:"valid_combinations"`
 	Metadata          OptimizerMetadata       `json:"metadata"`
+	// UnmetTargets lists the uniqueness and exposure targets the lineup set fell short of
+	UnmetTargets []types.LineupTargetShortfall `json:"unmet_targets,omitempty"`
+	// StackSummaries counts the lineups by stack description
+	StackSummaries []types.StackSummary `json:"stack_summaries,omitempty"`
+	// Partial is set when the run was cancelled or hit its deadline; Lineups holds the best found so far
+	Partial    bool   `json:"partial,omitempty"`
+	StopReason string `json:"stop_reason,omitempty"`
 }
 
 type OptimizerMetadata struct {
@@ -73,24 +92,36 @@ type lineupCandidate struct {
 	playerPositions map[uuid.UUID]string // playerID -> position slot filled
 }
 
-func OptimizeLineups(players []types.Player, config OptimizeConfig) (*OptimizerResult, error) {
+// OptimizeLineups builds a lineup set, stopping early when ctx is cancelled or its deadline
+// passes. An early stop returns the best lineups found so far marked Partial, or ctx's error if
+// none were found.
+func OptimizeLineups(ctx context.Context, players []types.Player, config OptimizeConfig) (*OptimizerResult, error) {
 	// Generate unique optimization ID for request tracing
-	optimizationID := uuid.New().String()
+	optimizationID := config.OptimizationID
+	if optimizationID == "" {
+		optimizationID = uuid.New().String()
+	}
 	startTime := getCurrentTimeMs()
 	result := &OptimizerResult{}
 
-	// Initialize logger with optimization context
-	sportID := "unknown"
-	if config.Contest != nil {
-		sportID = config.Contest.SportID.String()
+	// The contest decides the roster slots and which salaries apply
+	if config.Contest == nil {
+		return nil, fmt.Errorf("no contest configuration provided")
 	}
-	logger := logger.WithOptimizationContext(optimizationID, sportID, config.Contest.Platform)
+
+	// Initialize logger with optimization context
+	logger := logger.WithOptimizationContext(optimizationID, config.Contest.SportID.String(), config.Contest.Platform)
 	logger.WithFields(logrus.Fields{
 		"total_players": len(players),
 		"salary_cap":    config.SalaryCap,
 		"num_lineups":   config.NumLineups,
 	}).Info("Starting optimization")
 
+	// Waves are split on the whole pool's tee sheet, before any players are filtered out
+	if config.PlayerWaves == nil && hasWaveRules(config.StackingRules) {
+		config.PlayerWaves = assignPlayerWaves(players)
+	}
+
 	// Filter out excluded players
 	filteredPlayers := filterPlayers(players, config, logger)
 
@@ -102,12 +133,15 @@ func OptimizeLineups(players []types.Player, config OptimizeConfig) (*OptimizerR
 	playersByPosition := organizeByPosition(filteredPlayers, logger)
 
 	// Generate all valid lineup combinations
-	validLineups := generateValidLineups(playersByPosition, config, logger)
+	validLineups := generateValidLineups(ctx, playersByPosition, config, logger)
 
 	logger.WithFields(logrus.Fields{
 		"valid_lineups": len(validLineups),
 	}).Debug("Lineup generation completed")
 
+	if len(validLineups) == 0 && ctx.Err() != nil {
+		return nil, fmt.Errorf("optimization stopped before any lineup was found: %w", ctx.Err())
+	}
 	if len(validLineups) == 0 {
 		// Provide detailed error message
 		minSalary := int(float64(config.SalaryCap) * 0.95)
@@ -125,8 +159,22 @@ func OptimizeLineups(players []types.Player, config OptimizeConfig) (*OptimizerR
 		logger.Info("Applying portfolio-level optimization")
 		finalLineups = applyPortfolioOptimization(validLineups, config, logger)
 	} else {
-		// Apply diversity constraints and exposure limits (default behavior)
-		finalLineups = applyDiversityConstraints(validLineups, config)
+		// Build the set one lineup at a time against uniqueness and exposure targets
+		builder := newLineupSetBuilder(config, GetContestSlots(config.Contest), filteredPlayers)
+		if config.Randomization.enabled() {
+			finalLineups = buildRandomizedLineups(ctx, filteredPlayers, config, builder, logger)
+		} else {
+			// Picking from the candidates already found is cheap, so a cancelled run still does it
+			finalLineups = builder.build(validLineups)
+		}
+		result.UnmetTargets = builder.shortfalls()
+		if len(result.UnmetTargets) > 0 {
+			logger.WithField("unmet_targets", len(result.UnmetTargets)).Warn("Lineup set missed some targets")
+		}
+	}
+
+	if len(finalLineups) == 0 && ctx.Err() != nil {
+		return nil, fmt.Errorf("optimization stopped before any lineup was found: %w", ctx.Err())
 	}
 
 	// Convert to model lineups
@@ -158,12 +206,21 @@ func OptimizeLineups(players []types.Player, config OptimizeConfig) (*OptimizerR
 			if player.ProjectedPoints != nil {
 				projectedPoints = *player.ProjectedPoints
 			}
+
+			// Captains are listed in their slot at their captain salary and points
+			if candidate.playerPositions[player.ID] == captainSlot {
+				position = captainSlot
+				salary = slotSalary(salary, captainSlot)
+				projectedPoints *= scoring.CaptainMultiplier
+			}
 			
 			lineupPlayers[j] = types.LineupPlayer{
 				ID:              player.ID,
 				Name:            player.Name,
 				Team:            team,
+				Opponent:        getStringValue(player.Opponent),
 				Position:        position,
+				Slot:            candidate.playerPositions[player.ID],
 				Salary:          salary,
 				ProjectedPoints: projectedPoints,
 			}
@@ -175,13 +232,25 @@ func OptimizeLineups(players []types.Player, config OptimizeConfig) (*OptimizerR
 			TotalSalary:      candidate.totalSalary,
 			ProjectedPoints:  candidate.projectedPoints,
 			Exposure:         0.0, // Will be calculated later
-			StackDescription: "", // TODO: Add stack description logic
+		}
+		// Golfers don't stack by team
+		if !isGolfContest(config.Contest) {
+			lineup.Stacks, lineup.StackDescription = describeStacks(candidate.players)
 		}
 		result.Lineups = append(result.Lineups, lineup)
 	}
+	result.StackSummaries = summarizeStacks(result.Lineups)
 
 	result.OptimizationTime = getCurrentTimeMs() - startTime
 	result.ValidCombinations = int64(len(validLineups))
+	if reason := StopReason(ctx); reason != "" {
+		result.Partial = true
+		result.StopReason = reason
+		logger.WithFields(logrus.Fields{
+			"reason":  reason,
+			"lineups": len(result.Lineups),
+		}).Warn("Optimization stopped early, returning partial results")
+	}
 	
 	// Set metadata
 	result.Metadata = OptimizerMetadata{
@@ -286,7 +355,7 @@ func organizeByPosition(players []types.Player, logger *logrus.Entry) map[string
 	return byPosition
 }
 
-func generateValidLineups(playersByPosition map[string][]types.Player, config OptimizeConfig, logger *logrus.Entry) []lineupCandidate {
+func generateValidLineups(ctx context.Context, playersByPosition map[string][]types.Player, config OptimizeConfig, logger *logrus.Entry) []lineupCandidate {
 
 	// Early validation
 	if config.Contest == nil {
@@ -296,7 +365,7 @@ func generateValidLineups(playersByPosition map[string][]types.Player, config Op
 
 	// Get position slots for this sport/platform  
 	sportName := getSportNameFromID(config.Contest.SportID)
-	slots := GetPositionSlots(sportName, config.Contest.Platform)
+	slots := GetContestSlots(config.Contest)
 	if len(slots) == 0 {
 		logger.WithFields(logrus.Fields{
 			"sport_id": config.Contest.SportID,
@@ -308,11 +377,11 @@ func generateValidLineups(playersByPosition map[string][]types.Player, config Op
 
 	// Check if we should use the new DP optimizer based on config
 	if shouldUseDP(config) {
-		return generateLineupsWithDP(playersByPosition, config, logger)
+		return generateLineupsWithDP(ctx, playersByPosition, config, logger)
 	}
 
 	// Fallback to original backtracking for backward compatibility
-	return generateLineupsWithBacktracking(playersByPosition, config, logger, slots)
+	return generateLineupsWithBacktracking(ctx, playersByPosition, config, logger, slots)
 }
 
 // shouldUseDP determines whether to use the new DP optimizer
@@ -331,7 +400,7 @@ func shouldUseDP(config OptimizeConfig) bool {
 }
 
 // generateLineupsWithDP uses the new dynamic programming optimizer with enhanced analytics
-func generateLineupsWithDP(playersByPosition map[string][]types.Player, config OptimizeConfig, logger *logrus.Entry) []lineupCandidate {
+func generateLineupsWithDP(ctx context.Context, playersByPosition map[string][]types.Player, config OptimizeConfig, logger *logrus.Entry) []lineupCandidate {
 	logger.Info("Using enhanced DP optimizer with analytics and multi-objective framework")
 	
 	// Convert playersByPosition back to a flat list
@@ -378,11 +447,14 @@ func generateLineupsWithDP(playersByPosition map[string][]types.Player, config O
 	}
 	
 	// Use enhanced V2 optimizer for multiple lineups
-	generatedLineups, err := dpOptimizer.OptimizeWithDPV2(allPlayers, configV2)
+	generatedLineups, err := dpOptimizer.OptimizeWithDPV2(ctx, allPlayers, configV2)
 	if err != nil {
+		if ctx.Err() != nil {
+			return nil
+		}
 		logger.WithError(err).Error("Enhanced DP optimization failed")
 		// Fallback to simpler single-lineup DP optimization
-		return generateLineupsWithSimpleDP(allPlayers, config, logger)
+		return generateLineupsWithSimpleDP(ctx, allPlayers, config, logger)
 	}
 	
 	// Convert GeneratedLineup back to lineupCandidate for compatibility
@@ -411,13 +483,16 @@ func generateLineupsWithDP(playersByPosition map[string][]types.Player, config O
 			}
 			
 			// Organize by position for compatibility
-			for _, player := range players {
+			for j, player := range players {
 				position := ""
 				if player.Position != nil {
 					position = *player.Position
 				}
 				candidate.positions[position] = append(candidate.positions[position], player)
 				candidate.playerPositions[player.ID] = position
+				if slot := genLineup.Players[j].Slot; slot != "" {
+					candidate.playerPositions[player.ID] = slot
+				}
 			}
 			
 			validLineups = append(validLineups, candidate)
@@ -458,7 +533,7 @@ func determineOptimizationStrategy(config OptimizeConfig) OptimizationObjective
 }
 
 // generateLineupsWithSimpleDP provides fallback single-lineup DP optimization
-func generateLineupsWithSimpleDP(allPlayers []types.Player, config OptimizeConfig, logger *logrus.Entry) []lineupCandidate {
+func generateLineupsWithSimpleDP(ctx context.Context, allPlayers []types.Player, config OptimizeConfig, logger *logrus.Entry) []lineupCandidate {
 	logger.Info("Using simple DP fallback optimization")
 	
 	dpOptimizer := NewDPOptimizer()
@@ -472,9 +547,9 @@ func generateLineupsWithSimpleDP(allPlayers []types.Player, config OptimizeConfi
 	}
 	exposureManager := NewExposureManager(exposureConfig)
 	
-	for i := 0; i < config.NumLineups; i++ {
+	for i := 0; i < config.NumLineups && ctx.Err() == nil; i++ {
 		platform := strings.ToLower(config.Contest.Platform)
-		result, err := dpOptimizer.OptimizeWithDP(allPlayers, config, platform)
+		result, err := dpOptimizer.OptimizeWithDP(ctx, allPlayers, config, platform)
 		
 		if err != nil {
 			logger.WithError(err).Warnf("Simple DP optimization failed for lineup %d", i+1)
@@ -522,7 +597,7 @@ func generateLineupsWithSimpleDP(allPlayers []types.Player, config OptimizeConfi
 }
 
 // generateLineupsWithBacktracking uses the original backtracking algorithm
-func generateLineupsWithBacktracking(playersByPosition map[string][]types.Player, config OptimizeConfig, logger *logrus.Entry, slots []PositionSlot) []lineupCandidate {
+func generateLineupsWithBacktracking(ctx context.Context, playersByPosition map[string][]types.Player, config OptimizeConfig, logger *logrus.Entry, slots []PositionSlot) []lineupCandidate {
 	logger.Debug("Using backtracking optimizer for lineup generation")
 	
 	var validLineups []lineupCandidate
@@ -566,6 +641,7 @@ func generateLineupsWithBacktracking(playersByPosition map[string][]types.Player
 	}
 
 	// Use recursive backtracking to generate lineups
+	var cancelled cancellationCheck
 	var backtrack func(current *lineupCandidate, slotIndex int, usedPlayers map[uuid.UUID]bool)
 
 	backtrack = func(current *lineupCandidate, slotIndex int, usedPlayers map[uuid.UUID]bool) {
@@ -595,8 +671,8 @@ func generateLineupsWithBacktracking(playersByPosition map[string][]types.Player
 			}
 		}
 
-		// Stop if we've found enough lineups
-		if len(validLineups) >= maxLineups {
+		// Stop if we've found enough lineups or the run was cancelled
+		if len(validLineups) >= maxLineups || cancelled.check(ctx) {
 			return
 		}
 
@@ -693,8 +769,8 @@ func generateLineupsWithBacktracking(playersByPosition map[string][]types.Player
 					continue
 				}
 
-				// Check salary cap
-				playerSalary := getSalaryForPlatform(player, config.Contest.Platform)
+				// Check salary cap; a captain slot costs and scores more
+				playerSalary := slotSalary(getSalaryForPlatform(player, config.Contest.Platform), slot.SlotName)
 				if current.totalSalary+playerSalary > config.SalaryCap {
 					continue
 				}
@@ -705,7 +781,7 @@ func generateLineupsWithBacktracking(playersByPosition map[string][]types.Player
 				current.players = append(current.players, player)
 				current.totalSalary += playerSalary
 				if player.ProjectedPoints != nil {
-					current.projectedPoints += *player.ProjectedPoints
+					current.projectedPoints += *player.ProjectedPoints * slotMultiplier(slot.SlotName)
 				}
 				current.playerPositions[player.ID] = slot.SlotName
 				usedPlayers[player.ID] = true
@@ -717,7 +793,7 @@ func generateLineupsWithBacktracking(playersByPosition map[string][]types.Player
 				current.players = current.players[:len(current.players)-1]
 				current.totalSalary -= playerSalary
 				if player.ProjectedPoints != nil {
-					current.projectedPoints -= *player.ProjectedPoints
+					current.projectedPoints -= *player.ProjectedPoints * slotMultiplier(slot.SlotName)
 				}
 				delete(current.playerPositions, player.ID)
 				usedPlayers[player.ID] = false
@@ -946,14 +1022,14 @@ func isValidLineup(lineup *lineupCandidate, config OptimizeConfig) bool {
 	}
 
 	// Check stacking rules
-	if !validateStackingRules(lineup, config.StackingRules) {
+	if !validateStackingRules(lineup, config.StackingRules, config.PlayerWaves) {
 		return false
 	}
 
 	return true
 }
 
-func validateStackingRules(lineup *lineupCandidate, rules []types.StackingRule) bool {
+func validateStackingRules(lineup *lineupCandidate, rules []types.StackingRule, waves map[uuid.UUID]weather.Wave) bool {
 	for _, rule := range rules {
 		switch rule.Type {
 		case "team":
@@ -964,6 +1040,10 @@ func validateStackingRules(lineup *lineupCandidate, rules []types.StackingRule)
 			if !validateGameStacking(lineup, rule) {
 				return false
 			}
+		case WaveStack:
+			if !validateWaveStacking(lineup, rule, waves) {
+				return false
+			}
 		}
 	}
 	return true
@@ -1024,54 +1104,6 @@ func validateGameStacking(lineup *lineupCandidate, rule types.StackingRule) bool
 	return true
 }
 
-func applyDiversityConstraints(lineups []lineupCandidate, config OptimizeConfig) []lineupCandidate {
-	if len(lineups) == 0 || config.NumLineups == 0 {
-		return nil
-	}
-
-	finalLineups := make([]lineupCandidate, 0, config.NumLineups)
-	playerExposure := make(map[uuid.UUID]int)
-
-	for _, lineup := range lineups {
-		if len(finalLineups) >= config.NumLineups {
-			break
-		}
-
-		// Check diversity constraint
-		if len(finalLineups) > 0 && config.MinDifferentPlayers > 0 {
-			differentCount := countDifferentPlayers(lineup, finalLineups[len(finalLineups)-1])
-			if differentCount < config.MinDifferentPlayers {
-				continue
-			}
-		}
-
-		// Check exposure limits
-		valid := true
-		for _, player := range lineup.players {
-			currentExposure := float64(playerExposure[player.ID]) / float64(len(finalLineups)+1)
-
-			if maxExp, exists := config.MaxExposure[player.ID]; exists && currentExposure > maxExp {
-				valid = false
-				break
-			}
-		}
-
-		if !valid {
-			continue
-		}
-
-		// Add lineup
-		finalLineups = append(finalLineups, lineup)
-
-		// Update exposure counts
-		for _, player := range lineup.players {
-			playerExposure[player.ID]++
-		}
-	}
-
-	return finalLineups
-}
-
 // Helper functions
 
 func hasPlayer(players []types.Player, playerID uuid.UUID) bool {
@@ -1265,6 +1297,11 @@ func convertDPResultToLineup(result *DPResult, allPlayers []types.Player, config
 			logger.WithField("player_id", playerID).Warn("Optimal player not found in player map")
 		}
 	}
+
+	// The DP result only lists players, so place them into the contest's slots
+	if slots := assignLineupSlots(lineup.players, GetContestSlots(config.Contest)); slots != nil {
+		lineup.playerPositions = slots
+	}
 	
 	return lineup
 }
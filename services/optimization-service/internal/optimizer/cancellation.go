@@ -0,0 +1,123 @@
+package optimizer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Reasons an optimization stopped before finishing
+const (
+	StopCancelled = "cancelled"
+	StopDeadline  = "deadline_exceeded"
+)
+
+// cancellationInterval is how many search steps pass between context checks, keeping the check
+// off the hot path of the search
+const cancellationInterval = 256
+
+// cancellationCheck polls a context every cancellationInterval calls and remembers once it is done
+type cancellationCheck struct {
+	calls int
+	done  bool
+}
+
+func (c *cancellationCheck) check(ctx context.Context) bool {
+	if c.done {
+		return true
+	}
+	c.calls++
+	if c.calls%cancellationInterval == 0 {
+		c.done = ctx.Err() != nil
+	}
+	return c.done
+}
+
+// StopReason says why ctx ended, or "" while it is still live
+func StopReason(ctx context.Context) string {
+	switch err := ctx.Err(); {
+	case err == nil:
+		return ""
+	case errors.Is(err, context.DeadlineExceeded):
+		return StopDeadline
+	default:
+		return StopCancelled
+	}
+}
+
+// RunningOptimizations tracks in-flight optimizations so their users can cancel them by ID.
+// Optimization IDs are chosen by clients, so runs are keyed by user as well: two users picking
+// the same ID don't collide, and one can't cancel the other's run.
+type RunningOptimizations struct {
+	mu      sync.Mutex
+	running map[runningKey]*runningOptimization
+}
+
+type runningKey struct {
+	userID         uuid.UUID
+	optimizationID string
+}
+
+type runningOptimization struct {
+	userID uuid.UUID
+	cancel context.CancelFunc
+}
+
+// NewRunningOptimizations creates an empty registry
+func NewRunningOptimizations() *RunningOptimizations {
+	return &RunningOptimizations{running: make(map[runningKey]*runningOptimization)}
+}
+
+// Start registers an optimization and returns its context, which ends when the parent does, the
+// timeout passes (if positive) or Cancel is called. Callers must call the returned release func
+// once the optimization returns.
+func (r *RunningOptimizations) Start(parent context.Context, userID uuid.UUID, optimizationID string, timeout time.Duration) (context.Context, func(), error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := runningKey{userID: userID, optimizationID: optimizationID}
+	if _, running := r.running[key]; running {
+		return nil, nil, fmt.Errorf("optimization %s is already running", optimizationID)
+	}
+
+	var ctx context.Context
+	var cancel context.CancelFunc
+	if timeout > 0 {
+		ctx, cancel = context.WithTimeout(parent, timeout)
+	} else {
+		ctx, cancel = context.WithCancel(parent)
+	}
+	run := &runningOptimization{userID: userID, cancel: cancel}
+	r.running[key] = run
+
+	release := func() {
+		r.mu.Lock()
+		// A cancelled ID may already have been reused by a newer run
+		if r.running[key] == run {
+			delete(r.running, key)
+		}
+		r.mu.Unlock()
+		cancel()
+	}
+	return ctx, release, nil
+}
+
+// Cancel stops the user's running optimization, reporting whether one was found; another user's
+// run under the same ID is left alone
+func (r *RunningOptimizations) Cancel(userID uuid.UUID, optimizationID string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := runningKey{userID: userID, optimizationID: optimizationID}
+	run, running := r.running[key]
+	if !running || run.userID != userID {
+		return false
+	}
+	run.cancel()
+	delete(r.running, key)
+	return true
+}
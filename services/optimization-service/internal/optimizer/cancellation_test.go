@@ -0,0 +1,84 @@
+package optimizer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOptimizeLineups_CancelledReturnsPartialResults(t *testing.T) {
+	players, contest := testGolfPool(30)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	result, err := OptimizeLineups(ctx, players, OptimizeConfig{SalaryCap: 50000, NumLineups: 10, MinDifferentPlayers: 1, Contest: contest})
+	require.NoError(t, err)
+	assert.True(t, result.Partial)
+	assert.Equal(t, StopCancelled, result.StopReason)
+	assert.NotEmpty(t, result.Lineups)
+
+	// The search stopped long before the 1000 candidates an uncancelled run collects
+	assert.Less(t, result.ValidCombinations, int64(1000))
+}
+
+func TestOptimizeLineups_CancelledBeforeAnyLineup(t *testing.T) {
+	players, contest := testGolfPool(14)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := OptimizeLineups(ctx, players, OptimizeConfig{
+		SalaryCap:     50000,
+		NumLineups:    5,
+		Contest:       contest,
+		Randomization: &RandomizationConfig{Mode: RandomizeFloorCeiling, Level: 1, Seed: 1},
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestRunningOptimizations(t *testing.T) {
+	running := NewRunningOptimizations()
+	userID := uuid.New()
+
+	ctx, release, err := running.Start(context.Background(), userID, "opt-1", time.Minute)
+	require.NoError(t, err)
+	_, _, err = running.Start(context.Background(), userID, "opt-1", 0)
+	assert.Error(t, err, "an ID can only run once at a time")
+
+	assert.True(t, running.Cancel(userID, "opt-1"))
+	assert.Equal(t, StopCancelled, StopReason(ctx))
+	assert.False(t, running.Cancel(userID, "opt-1"))
+	release()
+
+	ctx, release, err = running.Start(context.Background(), userID, "opt-2", time.Nanosecond)
+	require.NoError(t, err)
+	defer release()
+	<-ctx.Done()
+	assert.Equal(t, StopDeadline, StopReason(ctx))
+}
+
+func TestRunningOptimizations_PerUser(t *testing.T) {
+	running := NewRunningOptimizations()
+	owner, other := uuid.New(), uuid.New()
+
+	ownerCtx, release, err := running.Start(context.Background(), owner, "shared-id", 0)
+	require.NoError(t, err)
+	defer release()
+
+	// Another user picking the same ID gets their own run
+	otherCtx, releaseOther, err := running.Start(context.Background(), other, "shared-id", 0)
+	require.NoError(t, err, "IDs only collide within a user")
+	defer releaseOther()
+
+	// and can only cancel that one
+	assert.True(t, running.Cancel(other, "shared-id"))
+	assert.Equal(t, StopCancelled, StopReason(otherCtx))
+	assert.False(t, running.Cancel(other, "shared-id"))
+	assert.NoError(t, ownerCtx.Err(), "the owner's run keeps going")
+
+	assert.True(t, running.Cancel(owner, "shared-id"))
+	assert.Equal(t, StopCancelled, StopReason(ownerCtx))
+}
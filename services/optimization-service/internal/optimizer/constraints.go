@@ -3,6 +3,7 @@ package optimizer
 import (
 	"fmt"
 
+	"github.com/stitts-dev/dfs-sim/shared/pkg/scoring"
 	"github.com/stitts-dev/dfs-sim/shared/types"
 )
 
@@ -41,6 +42,9 @@ func GetConstraintsForContest(contest *types.Contest) *LineupConstraints {
 
 	// Set position constraints based on sport and platform
 	sportName := getSportNameFromID(contest.SportID)
+	if isGolfContest(contest) {
+		sportName = "golf"
+	}
 	switch sportName {
 	case "nba":
 		constraints.setupNBAConstraints(contest.Platform)
@@ -51,7 +55,7 @@ func GetConstraintsForContest(contest *types.Contest) *LineupConstraints {
 	case "nhl":
 		constraints.setupNHLConstraints(contest.Platform)
 	case "golf":
-		constraints.setupGolfConstraints(contest.Platform)
+		constraints.setupGolfConstraints(contest.Platform, golfContestWindow(contest).Format)
 	}
 
 	return constraints
@@ -148,11 +152,18 @@ func (lc *LineupConstraints) setupNHLConstraints(platform string) {
 	}
 }
 
-func (lc *LineupConstraints) setupGolfConstraints(platform string) {
+func (lc *LineupConstraints) setupGolfConstraints(platform string, format scoring.GolfContestFormat) {
 	// Golf has no position constraints - just 6 golfers
 	lc.PositionConstraints = map[string]PositionConstraint{
 		"G": {Position: "G", MinRequired: 6, MaxAllowed: 6}, // G for Golfer
 	}
+	// Captain contests list one of the six golfers in the captain slot
+	if format == scoring.GolfFormatCaptain {
+		lc.PositionConstraints = map[string]PositionConstraint{
+			captainSlot: {Position: captainSlot, MinRequired: 1, MaxAllowed: 1},
+			"G":         {Position: "G", MinRequired: 5, MaxAllowed: 5, EligibleSlots: []string{captainSlot}},
+		}
+	}
 
 	// Golf-specific adjustments
 	lc.MaxPlayersPerTeam = 6 // In golf, "team" represents country
@@ -3,40 +3,52 @@ package optimizer
 import (
 	"math"
 
+	"github.com/google/uuid"
 	"github.com/stitts-dev/dfs-sim/shared/types"
 )
 
-// CorrelationMatrix represents correlations between players
+// CorrelationMatrix represents correlations between players, keyed by full player UUIDs
 type CorrelationMatrix struct {
-	correlations map[uint]map[uint]float64
-	byTeam       map[string][]uint
-	byGame       map[string][]uint
-	byPosition   map[string][]uint
+	correlations map[uuid.UUID]map[uuid.UUID]float64
+	byTeam       map[string][]uuid.UUID
+	byGame       map[string][]uuid.UUID
+	byPosition   map[string][]uuid.UUID
+	sport        string
+	roles        map[uuid.UUID]string
+	empirical    *RoleCorrelations
 }
 
-// NewCorrelationMatrix creates a new correlation matrix from players
+// NewCorrelationMatrix creates a new correlation matrix from players using position-pair rules,
+// guessing the sport from the player positions
 func NewCorrelationMatrix(players []OptimizationPlayer) *CorrelationMatrix {
+	return NewCorrelationMatrixForSport(players, InferSport(players), nil)
+}
+
+// NewCorrelationMatrixForSport creates a correlation matrix for a slate. Pairs covered by the
+// empirical role correlations use the estimate; everything else falls back to position-pair rules.
+func NewCorrelationMatrixForSport(players []OptimizationPlayer, sport string, empirical *RoleCorrelations) *CorrelationMatrix {
 	cm := &CorrelationMatrix{
-		correlations: make(map[uint]map[uint]float64),
-		byTeam:       make(map[string][]uint),
-		byGame:       make(map[string][]uint),
-		byPosition:   make(map[string][]uint),
+		correlations: make(map[uuid.UUID]map[uuid.UUID]float64),
+		byTeam:       make(map[string][]uuid.UUID),
+		byGame:       make(map[string][]uuid.UUID),
+		byPosition:   make(map[string][]uuid.UUID),
+		sport:        sport,
+		roles:        AssignRoles(sport, players),
+		empirical:    empirical,
 	}
 
 	// Organize players
 	for _, player := range players {
-		playerID := uint(player.ID.ID())
-		
 		// Direct field access since OptimizationPlayer has concrete fields
 		team := player.Team
 		position := player.Position
 		opponent := player.Opponent
-		
-		cm.byTeam[team] = append(cm.byTeam[team], playerID)
-		cm.byPosition[position] = append(cm.byPosition[position], playerID)
+
+		cm.byTeam[team] = append(cm.byTeam[team], player.ID)
+		cm.byPosition[position] = append(cm.byPosition[position], player.ID)
 
 		gameKey := getGameKey(team, opponent)
-		cm.byGame[gameKey] = append(cm.byGame[gameKey], playerID)
+		cm.byGame[gameKey] = append(cm.byGame[gameKey], player.ID)
 	}
 
 	// Calculate correlations
@@ -46,66 +58,48 @@ func NewCorrelationMatrix(players []OptimizationPlayer) *CorrelationMatrix {
 }
 
 func (cm *CorrelationMatrix) calculateCorrelations(players []OptimizationPlayer) {
-	playerMap := make(map[uint]OptimizationPlayer)
-	for _, p := range players {
-		playerMap[uint(p.ID.ID())] = p
-	}
-
 	// Calculate correlations for each player pair
 	for i := 0; i < len(players); i++ {
 		p1 := players[i]
-
-		p1ID := uint(p1.ID.ID())
-		if cm.correlations[p1ID] == nil {
-			cm.correlations[p1ID] = make(map[uint]float64)
+		if cm.correlations[p1.ID] == nil {
+			cm.correlations[p1.ID] = make(map[uuid.UUID]float64)
 		}
 
 		for j := i + 1; j < len(players); j++ {
 			p2 := players[j]
-
-			p2ID := uint(p2.ID.ID())
-			if cm.correlations[p2ID] == nil {
-				cm.correlations[p2ID] = make(map[uint]float64)
+			if cm.correlations[p2.ID] == nil {
+				cm.correlations[p2.ID] = make(map[uuid.UUID]float64)
 			}
 
 			corr := cm.calculatePairCorrelation(p1, p2)
-			cm.correlations[p1ID][p2ID] = corr
-			cm.correlations[p2ID][p1ID] = corr
+			if corr == 0 {
+				continue
+			}
+			cm.correlations[p1.ID][p2.ID] = corr
+			cm.correlations[p2.ID][p1.ID] = corr
 		}
 	}
 }
 
 func (cm *CorrelationMatrix) calculatePairCorrelation(p1, p2 OptimizationPlayer) float64 {
-	correlation := 0.0
+	sameTeam := p1.Team == p2.Team && p1.Team != ""
+	sameGame := getGameKey(p1.Team, p1.Opponent) == getGameKey(p2.Team, p2.Opponent)
+	if !sameTeam && !sameGame {
+		return 0.0
+	}
 
-	// Same team correlation (teammates)
-	p1Team := p1.Team
-	p2Team := p2.Team
-	p1Position := p1.Position
-	p2Position := p2.Position
-	
-	if p1Team == p2Team && p1Team != "" {
-		// Use sport name from position - golf players are "G"
-		sport := "golf" // Default to golf for now, could be enhanced
-		if p1Position != "G" {
-			sport = "nba" // Simple heuristic for other sports
+	if cm.empirical != nil {
+		if estimate, ok := cm.empirical.Get(cm.roles[p1.ID], cm.roles[p2.ID], sameTeam); ok {
+			return estimate.Correlation
 		}
-		correlation += cm.getTeammateCorrelation(p1Position, p2Position, sport)
 	}
 
-	// Same game correlation
-	p1Opponent := p1.Opponent
-	p2Opponent := p2.Opponent
-	
-	if getGameKey(p1Team, p1Opponent) == getGameKey(p2Team, p2Opponent) {
-		if p1Team != p2Team {
-			// Opponents
-			sport := "golf" // Default to golf for now
-			if p1Position != "G" {
-				sport = "nba" // Simple heuristic for other sports
-			}
-			correlation += cm.getOpponentCorrelation(p1Position, p2Position, sport)
-		}
+	correlation := 0.0
+	if sameTeam {
+		correlation += cm.getTeammateCorrelation(p1.Position, p2.Position, cm.sport)
+	} else {
+		// Opponents in the same game
+		correlation += cm.getOpponentCorrelation(p1.Position, p2.Position, cm.sport)
 	}
 
 	// Cap correlation between -1 and 1
@@ -245,7 +239,7 @@ func (cm *CorrelationMatrix) getGolfOpponentCorrelation(pos1, pos2 string) float
 }
 
 // GetCorrelation returns the correlation between two players
-func (cm *CorrelationMatrix) GetCorrelation(player1ID, player2ID uint) float64 {
+func (cm *CorrelationMatrix) GetCorrelation(player1ID, player2ID uuid.UUID) float64 {
 	if player1ID == player2ID {
 		return 1.0
 	}
@@ -258,10 +252,10 @@ func (cm *CorrelationMatrix) GetCorrelation(player1ID, player2ID uint) float64 {
 }
 
 // GetTeammates returns all teammates for a player
-func (cm *CorrelationMatrix) GetTeammates(playerID uint, players []types.Player) []uint {
+func (cm *CorrelationMatrix) GetTeammates(playerID uuid.UUID, players []types.Player) []uuid.UUID {
 	var playerTeam string
 	for _, p := range players {
-		if uint(p.ID.ID()) == playerID {
+		if p.ID == playerID {
 			if p.Team != nil {
 				playerTeam = *p.Team
 			}
@@ -269,7 +263,7 @@ func (cm *CorrelationMatrix) GetTeammates(playerID uint, players []types.Player)
 		}
 	}
 
-	teammates := make([]uint, 0)
+	teammates := make([]uuid.UUID, 0)
 	for _, id := range cm.byTeam[playerTeam] {
 		if id != playerID {
 			teammates = append(teammates, id)
@@ -280,10 +274,10 @@ func (cm *CorrelationMatrix) GetTeammates(playerID uint, players []types.Player)
 }
 
 // GetGamePartners returns all players in the same game
-func (cm *CorrelationMatrix) GetGamePartners(playerID uint, players []types.Player) []uint {
+func (cm *CorrelationMatrix) GetGamePartners(playerID uuid.UUID, players []types.Player) []uuid.UUID {
 	var gameKey string
 	for _, p := range players {
-		if uint(p.ID.ID()) == playerID {
+		if p.ID == playerID {
 			team := ""
 			if p.Team != nil {
 				team = *p.Team
@@ -297,7 +291,7 @@ func (cm *CorrelationMatrix) GetGamePartners(playerID uint, players []types.Play
 		}
 	}
 
-	partners := make([]uint, 0)
+	partners := make([]uuid.UUID, 0)
 	for _, id := range cm.byGame[gameKey] {
 		if id != playerID {
 			partners = append(partners, id)
@@ -319,9 +313,7 @@ func (cm *CorrelationMatrix) CalculateLineupCorrelation(lineup []OptimizationPla
 	// Sum all pairwise correlations
 	for i := 0; i < len(lineup); i++ {
 		for j := i + 1; j < len(lineup); j++ {
-			player1ID := uint(lineup[i].ID.ID())
-			player2ID := uint(lineup[j].ID.ID())
-			corr := cm.GetCorrelation(player1ID, player2ID)
+			corr := cm.GetCorrelation(lineup[i].ID, lineup[j].ID)
 			totalCorrelation += corr
 			count++
 		}
@@ -336,8 +328,8 @@ func (cm *CorrelationMatrix) CalculateLineupCorrelation(lineup []OptimizationPla
 }
 
 // GetStronglyCorrelatedPlayers returns players with high correlation to the given player
-func (cm *CorrelationMatrix) GetStronglyCorrelatedPlayers(playerID uint, threshold float64) []uint {
-	correlated := make([]uint, 0)
+func (cm *CorrelationMatrix) GetStronglyCorrelatedPlayers(playerID uuid.UUID, threshold float64) []uuid.UUID {
+	correlated := make([]uuid.UUID, 0)
 
 	if playerCorrs, exists := cm.correlations[playerID]; exists {
 		for otherID, corr := range playerCorrs {
@@ -351,8 +343,8 @@ func (cm *CorrelationMatrix) GetStronglyCorrelatedPlayers(playerID uint, thresho
 }
 
 // GetNegativelyCorrelatedPlayers returns players with negative correlation to the given player
-func (cm *CorrelationMatrix) GetNegativelyCorrelatedPlayers(playerID uint, threshold float64) []uint {
-	negCorrelated := make([]uint, 0)
+func (cm *CorrelationMatrix) GetNegativelyCorrelatedPlayers(playerID uuid.UUID, threshold float64) []uuid.UUID {
+	negCorrelated := make([]uuid.UUID, 0)
 
 	if playerCorrs, exists := cm.correlations[playerID]; exists {
 		for otherID, corr := range playerCorrs {
@@ -364,3 +356,22 @@ func (cm *CorrelationMatrix) GetNegativelyCorrelatedPlayers(playerID uint, thres
 
 	return negCorrelated
 }
+
+// Role returns the slate role (QB1, WR2, BAT3, ...) assigned to a player
+func (cm *CorrelationMatrix) Role(playerID uuid.UUID) string {
+	return cm.roles[playerID]
+}
+
+// ToMap flattens the matrix to "uuid:uuid" keys, the format OptimizationResult and the shared
+// simulator use. Each pair appears once.
+func (cm *CorrelationMatrix) ToMap() map[string]float64 {
+	result := make(map[string]float64)
+	for id1, row := range cm.correlations {
+		for id2, corr := range row {
+			if id1.String() < id2.String() {
+				result[id1.String()+":"+id2.String()] = corr
+			}
+		}
+	}
+	return result
+}
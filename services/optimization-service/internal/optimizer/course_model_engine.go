@@ -9,6 +9,7 @@ import (
 
 	"github.com/stitts-dev/dfs-sim/shared/types"
 	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
 )
 
 // CourseModelEngine implements sophisticated course fit modeling
@@ -103,6 +104,8 @@ type PlayerProfile struct {
 type CourseFitCalculator struct {
 	// 5-attribute model weights
 	attributeWeights      map[string]float64
+	// Per-course weights from the historical course fit, used in place of attributeWeights
+	courseSkillFits       map[string]*CourseSkillFit
 	
 	// Advanced modeling components
 	nonLinearAdjustments  map[string]func(float64) float64
@@ -196,6 +199,7 @@ func (cme *CourseModelEngine) initializeCourseFitCalculator() *CourseFitCalculat
 			"short_game_skill":    0.15, // 15% weight
 			"putting_consistency": 0.10, // 10% weight
 		},
+		courseSkillFits:      make(map[string]*CourseSkillFit),
 		nonLinearAdjustments: make(map[string]func(float64) float64),
 		interactionEffects:   make(map[string]map[string]float64),
 		weatherAdjustments:   make(map[string]*WeatherCoefficients),
@@ -311,6 +315,7 @@ func (cfc *CourseFitCalculator) calculateBaseAttributeScore(
 ) AttributeScore {
 	breakdown := make(map[string]float64)
 	total := 0.0
+	weights := cfc.weightsForCourse(courseFeatures.CourseID)
 	
 	// Driving Distance fit
 	distanceMatchScore := cfc.calculateAttributeMatch(
@@ -319,7 +324,7 @@ func (cfc *CourseFitCalculator) calculateBaseAttributeScore(
 		"driving_distance",
 	)
 	breakdown["driving_distance"] = distanceMatchScore
-	total += distanceMatchScore * cfc.attributeWeights["driving_distance"]
+	total += distanceMatchScore * weights["driving_distance"]
 	
 	// Driving Accuracy fit
 	accuracyMatchScore := cfc.calculateAttributeMatch(
@@ -328,7 +333,7 @@ func (cfc *CourseFitCalculator) calculateBaseAttributeScore(
 		"driving_accuracy",
 	)
 	breakdown["driving_accuracy"] = accuracyMatchScore
-	total += accuracyMatchScore * cfc.attributeWeights["driving_accuracy"]
+	total += accuracyMatchScore * weights["driving_accuracy"]
 	
 	// Approach Precision fit
 	approachMatchScore := cfc.calculateAttributeMatch(
@@ -337,7 +342,7 @@ func (cfc *CourseFitCalculator) calculateBaseAttributeScore(
 		"approach_precision",
 	)
 	breakdown["approach_precision"] = approachMatchScore
-	total += approachMatchScore * cfc.attributeWeights["approach_precision"]
+	total += approachMatchScore * weights["approach_precision"]
 	
 	// Short Game fit
 	shortGameMatchScore := cfc.calculateAttributeMatch(
@@ -346,7 +351,7 @@ func (cfc *CourseFitCalculator) calculateBaseAttributeScore(
 		"short_game_skill",
 	)
 	breakdown["short_game_skill"] = shortGameMatchScore
-	total += shortGameMatchScore * cfc.attributeWeights["short_game_skill"]
+	total += shortGameMatchScore * weights["short_game_skill"]
 	
 	// Putting fit
 	puttingMatchScore := cfc.calculateAttributeMatch(
@@ -355,7 +360,7 @@ func (cfc *CourseFitCalculator) calculateBaseAttributeScore(
 		"putting_consistency",
 	)
 	breakdown["putting_consistency"] = puttingMatchScore
-	total += puttingMatchScore * cfc.attributeWeights["putting_consistency"]
+	total += puttingMatchScore * weights["putting_consistency"]
 	
 	return AttributeScore{
 		total:     total,
@@ -363,6 +368,17 @@ func (cfc *CourseFitCalculator) calculateBaseAttributeScore(
 	}
 }
 
+// weightsForCourse returns the course's fitted attribute weights, or the default weights for
+// courses the historical fit hasn't covered
+func (cfc *CourseFitCalculator) weightsForCourse(courseID string) map[string]float64 {
+	if fit := lookupCourseSkillFit(cfc.courseSkillFits, courseID); fit != nil {
+		if weights := fit.attributeWeights(); weights != nil {
+			return weights
+		}
+	}
+	return cfc.attributeWeights
+}
+
 // calculateAttributeMatch calculates how well a player's skill matches course requirements
 func (cfc *CourseFitCalculator) calculateAttributeMatch(
 	playerSkill float64,
@@ -810,6 +826,23 @@ func (cme *CourseModelEngine) UpdateModelAccuracy(calibrationPoints []Calibratio
 	}).Info("Updated course model accuracy")
 }
 
+// LoadCourseSkillFits loads the per-course skill weights fitted from historical strokes gained
+func (cme *CourseModelEngine) LoadCourseSkillFits(db *gorm.DB) error {
+	fits, err := loadCourseSkillFits(db)
+	if err != nil {
+		return err
+	}
+	
+	cme.fitCalculator.courseSkillFits = fits
+	cme.logger.WithField("courses", len(fits)).Info("Loaded course skill fits")
+	return nil
+}
+
+// hasCourseSkillFit reports whether the course has attribute weights fitted from history
+func (cme *CourseModelEngine) hasCourseSkillFit(courseID string) bool {
+	return lookupCourseSkillFit(cme.fitCalculator.courseSkillFits, courseID) != nil
+}
+
 // GetModelStatistics returns current model performance statistics
 func (cme *CourseModelEngine) GetModelStatistics() map[string]interface{} {
 	return map[string]interface{}{
@@ -821,6 +854,7 @@ func (cme *CourseModelEngine) GetModelStatistics() map[string]interface{} {
 		"courses_analyzed":    len(cme.courseFeatureAnalysis),
 		"players_profiled":    len(cme.playerProfiles),
 		"attribute_weights":   cme.fitCalculator.attributeWeights,
+		"fitted_courses":      len(cme.fitCalculator.courseSkillFits),
 	}
 }
 
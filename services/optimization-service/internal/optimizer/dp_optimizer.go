@@ -10,6 +10,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
+	"github.com/stitts-dev/dfs-sim/shared/pkg/scoring"
 	"github.com/stitts-dev/dfs-sim/shared/types"
 )
 
@@ -37,6 +38,7 @@ type OptimizeConfigV2 struct {
 	MinExposure         map[uuid.UUID]float64   `json:"min_exposure"`
 	MaxExposure         map[uuid.UUID]float64   `json:"max_exposure"`
 	Contest             *types.Contest          `json:"-"`
+	Correlations        *CorrelationMatrix      `json:"-"` // Slate matrix; built from position rules when nil
 
 	// New strategy options
 	Strategy           OptimizationObjective `json:"strategy"`
@@ -199,8 +201,9 @@ func getTimeValue(ptr *time.Time) time.Time {
 	return time.Time{}
 }
 
-// OptimizeWithDPV2 performs enhanced dynamic programming optimization
-func (dp *DPOptimizer) OptimizeWithDPV2(players []types.Player, config OptimizeConfigV2) ([]types.GeneratedLineup, error) {
+// OptimizeWithDPV2 performs enhanced dynamic programming optimization. Cancelling ctx stops
+// generation and keeps the lineups already built.
+func (dp *DPOptimizer) OptimizeWithDPV2(ctx context.Context, players []types.Player, config OptimizeConfigV2) ([]types.GeneratedLineup, error) {
 	startTime := time.Now()
 	dp.logger.WithFields(logrus.Fields{
 		"total_players": len(players),
@@ -244,11 +247,14 @@ func (dp *DPOptimizer) OptimizeWithDPV2(players []types.Player, config OptimizeC
 	dp.sortPlayersByValue(enhancedPlayers, config.Strategy)
 
 	// Initialize correlation matrix
-	dp.correlations = NewCorrelationMatrix(optimizationPlayers)
+	dp.correlations = config.Correlations
+	if dp.correlations == nil {
+		dp.correlations = NewCorrelationMatrix(optimizationPlayers)
+	}
 
 	// Get position slots for this sport/platform
 	sportName := getSportNameFromID(config.Contest.SportID)
-	slots := GetPositionSlots(sportName, config.Contest.Platform)
+	slots := GetContestSlots(config.Contest)
 	if len(slots) == 0 {
 		return nil, fmt.Errorf("no position slots found for sport %s (ID: %s), platform %s",
 			sportName, config.Contest.SportID.String(), config.Contest.Platform)
@@ -258,10 +264,13 @@ func (dp *DPOptimizer) OptimizeWithDPV2(players []types.Player, config OptimizeC
 	dp.clearMemoTable()
 
 	// Generate lineups using DP
-	lineups, err := dp.generateLineupsDP(enhancedPlayers, slots, config)
+	lineups, err := dp.generateLineupsDP(ctx, enhancedPlayers, slots, config)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate lineups: %v", err)
 	}
+	if len(lineups) == 0 && ctx.Err() != nil {
+		return nil, fmt.Errorf("optimization stopped before any lineup was found: %w", ctx.Err())
+	}
 
 	// Apply diversity constraints and exposure management
 	finalLineups := dp.applyConstraints(lineups, config)
@@ -282,7 +291,7 @@ func (dp *DPOptimizer) OptimizeWithDPV2(players []types.Player, config OptimizeC
 }
 
 // OptimizeWithDP provides backward compatibility with the original interface
-func (dp *DPOptimizer) OptimizeWithDP(players []types.Player, config OptimizeConfig, platform string) (*DPResult, error) {
+func (dp *DPOptimizer) OptimizeWithDP(ctx context.Context, players []types.Player, config OptimizeConfig, platform string) (*DPResult, error) {
 	// Convert old config to new format
 	configV2 := OptimizeConfigV2{
 		SalaryCap:           config.SalaryCap,
@@ -302,7 +311,7 @@ func (dp *DPOptimizer) OptimizeWithDP(players []types.Player, config OptimizeCon
 	}
 
 	// Use enhanced optimizer
-	lineups, err := dp.OptimizeWithDPV2(players, configV2)
+	lineups, err := dp.OptimizeWithDPV2(ctx, players, configV2)
 	if err != nil {
 		return nil, err
 	}
@@ -385,7 +394,7 @@ func (dp *DPOptimizer) sortPlayersByValue(players []EnhancedPlayer, strategy Opt
 }
 
 // generateLineupsDP generates lineups using dynamic programming
-func (dp *DPOptimizer) generateLineupsDP(players []EnhancedPlayer, slots []PositionSlot, config OptimizeConfigV2) ([]*lineupCandidate, error) {
+func (dp *DPOptimizer) generateLineupsDP(ctx context.Context, players []EnhancedPlayer, slots []PositionSlot, config OptimizeConfigV2) ([]*lineupCandidate, error) {
 	maxLineups := config.NumLineups * 100 // Generate extra for diversity filtering
 	if maxLineups > 10000 {
 		maxLineups = 10000
@@ -394,7 +403,7 @@ func (dp *DPOptimizer) generateLineupsDP(players []EnhancedPlayer, slots []Posit
 	lineups := make([]*lineupCandidate, 0, maxLineups)
 
 	// Use simplified approach for now - can be enhanced with full DP table later
-	for i := 0; i < config.NumLineups; i++ {
+	for i := 0; i < config.NumLineups && ctx.Err() == nil; i++ {
 		lineup := dp.generateSingleLineup(players, slots, config)
 		if lineup != nil {
 			lineups = append(lineups, lineup)
@@ -411,8 +420,10 @@ func (dp *DPOptimizer) generateSingleLineup(players []EnhancedPlayer, slots []Po
 	totalSalary := 0
 	totalScore := 0.0
 	usedPlayers := make(map[uint]bool)
+	playerSlots := make(map[uuid.UUID]string, len(slots))
+	reserve := dp.remainingSlotSalaries(players, slots, config)
 
-	for _, slot := range slots {
+	for slotIndex, slot := range slots {
 		bestPlayer := types.Player{}
 		bestScore := -1.0
 
@@ -430,12 +441,13 @@ func (dp *DPOptimizer) generateSingleLineup(players []EnhancedPlayer, slots []Po
 				continue
 			}
 
-			salary := dp.getPlayerSalary(player, config)
-			if totalSalary+salary > config.SalaryCap {
+			salary := slotSalary(dp.getPlayerSalary(player, config), slot.SlotName)
+			// Leave enough cap to fill the later slots with their cheapest players
+			if totalSalary+salary+reserve[slotIndex+1] > config.SalaryCap {
 				continue
 			}
 
-			score := dp.analytics.GetObjectiveScore(enhancedPlayer, config.Strategy)
+			score := dp.analytics.GetObjectiveScore(enhancedPlayer, config.Strategy) * slotMultiplier(slot.SlotName)
 			if score > bestScore {
 				bestScore = score
 				bestPlayer = player
@@ -446,7 +458,8 @@ func (dp *DPOptimizer) generateSingleLineup(players []EnhancedPlayer, slots []Po
 			selectedPlayers = append(selectedPlayers, bestPlayer)
 			playerID := uint(bestPlayer.ID.ID())
 			usedPlayers[playerID] = true
-			totalSalary += dp.getPlayerSalary(bestPlayer, config)
+			playerSlots[bestPlayer.ID] = slot.SlotName
+			totalSalary += slotSalary(dp.getPlayerSalary(bestPlayer, config), slot.SlotName)
 			totalScore += bestScore
 		}
 	}
@@ -457,13 +470,33 @@ func (dp *DPOptimizer) generateSingleLineup(players []EnhancedPlayer, slots []Po
 			totalSalary:     totalSalary,
 			projectedPoints: totalScore,
 			positions:       make(map[string][]types.Player),
-			playerPositions: make(map[uuid.UUID]string),
+			playerPositions: playerSlots,
 		}
 	}
 
 	return nil
 }
 
+// remainingSlotSalaries returns, for each slot index, the cheapest salary that can fill
+// that slot and every slot after it; the last entry is zero
+func (dp *DPOptimizer) remainingSlotSalaries(players []EnhancedPlayer, slots []PositionSlot, config OptimizeConfigV2) []int {
+	reserve := make([]int, len(slots)+1)
+	for i := len(slots) - 1; i >= 0; i-- {
+		cheapest := 0
+		for _, enhancedPlayer := range players {
+			if !CanPlayerFillSlot(dp.convertSinglePlayerToOptimization(enhancedPlayer.Player), slots[i]) {
+				continue
+			}
+			salary := slotSalary(dp.getPlayerSalary(enhancedPlayer.Player, config), slots[i].SlotName)
+			if cheapest == 0 || salary < cheapest {
+				cheapest = salary
+			}
+		}
+		reserve[i] = reserve[i+1] + cheapest
+	}
+	return reserve
+}
+
 // getPlayerSalary returns appropriate salary based on platform
 func (dp *DPOptimizer) getPlayerSalary(player types.Player, config OptimizeConfigV2) int {
 	// Default to DraftKings, fallback to FanDuel
@@ -519,12 +552,20 @@ func (dp *DPOptimizer) convertToGeneratedLineups(lineups []*lineupCandidate, con
 			if player.ProjectedPoints != nil {
 				projectedPoints = *player.ProjectedPoints
 			}
+			salary := dp.getPlayerSalary(player, config)
+			if lineup.playerPositions[player.ID] == captainSlot {
+				position = captainSlot
+				salary = slotSalary(salary, captainSlot)
+				projectedPoints *= scoring.CaptainMultiplier
+			}
 			lineupPlayers[j] = types.LineupPlayer{
 				ID:              player.ID,
 				Name:            player.Name,
 				Team:            team,
+				Opponent:        getStringValue(player.Opponent),
 				Position:        position,
-				Salary:          dp.getPlayerSalary(player, config),
+				Slot:            lineup.playerPositions[player.ID],
+				Salary:          salary,
 				ProjectedPoints: projectedPoints,
 			}
 		}
@@ -535,7 +576,9 @@ func (dp *DPOptimizer) convertToGeneratedLineups(lineups []*lineupCandidate, con
 			TotalSalary:      lineup.totalSalary,
 			ProjectedPoints:  lineup.projectedPoints,
 			Exposure:         0.0, // Will be calculated later
-			StackDescription: "", // TODO: Add stack description logic
+		}
+		if !isGolfContest(config.Contest) {
+			result[i].Stacks, result[i].StackDescription = describeStacks(lineup.players)
 		}
 	}
 
@@ -0,0 +1,523 @@
+package optimizer
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// defaultCorrelationShrinkage is the number of pseudo-games of prior weight blended into every
+	// estimate, so a role pair seen in 50 games sits halfway between its sample and its prior
+	defaultCorrelationShrinkage = 50.0
+	defaultCorrelationLookback  = 2 * 365 * 24 * time.Hour
+	defaultCorrelationCacheTTL  = 6 * time.Hour
+	minCorrelationSamples       = 3
+)
+
+// PlayerGameResult is one player's fantasy result in a historical game, tagged with the role they
+// filled for their team that day (QB1, WR2, BAT3, ...)
+type PlayerGameResult struct {
+	ID              uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	Sport           string    `gorm:"not null;index" json:"sport"`
+	GameID          string    `gorm:"not null;index" json:"game_id"`
+	GameDate        time.Time `gorm:"not null;index" json:"game_date"`
+	PlayerID        uuid.UUID `gorm:"type:uuid;not null" json:"player_id"`
+	Team            string    `gorm:"not null" json:"team"`
+	Opponent        string    `gorm:"not null" json:"opponent"`
+	Role            string    `gorm:"not null" json:"role"`
+	FantasyPoints   float64   `json:"fantasy_points"`
+	ProjectedPoints float64   `json:"projected_points"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+// TableName specifies the table name for PlayerGameResult
+func (PlayerGameResult) TableName() string {
+	return "player_game_results"
+}
+
+// RoleCorrelation is the estimated correlation between two roles, either on the same team or on
+// opposite sides of the same game
+type RoleCorrelation struct {
+	RoleA       string  `json:"role_a"`
+	RoleB       string  `json:"role_b"`
+	SameTeam    bool    `json:"same_team"`
+	Samples     int     `json:"samples"`
+	Raw         float64 `json:"raw"`
+	Prior       float64 `json:"prior"`
+	Correlation float64 `json:"correlation"`
+}
+
+// RoleCorrelations holds the role-pair estimates for one sport
+type RoleCorrelations struct {
+	Sport       string                     `json:"sport"`
+	Games       int                        `json:"games"`
+	Pairs       map[string]RoleCorrelation `json:"pairs"`
+	EstimatedAt time.Time                  `json:"estimated_at"`
+}
+
+// Get returns the estimate for a role pair in either order
+func (rc *RoleCorrelations) Get(roleA, roleB string, sameTeam bool) (RoleCorrelation, bool) {
+	if rc == nil || roleA == "" || roleB == "" {
+		return RoleCorrelation{}, false
+	}
+	estimate, ok := rc.Pairs[rolePairKey(roleA, roleB, sameTeam)]
+	return estimate, ok
+}
+
+func rolePairKey(roleA, roleB string, sameTeam bool) string {
+	if roleB < roleA {
+		roleA, roleB = roleB, roleA
+	}
+	relation := "opp"
+	if sameTeam {
+		relation = "team"
+	}
+	return roleA + "|" + roleB + "|" + relation
+}
+
+// EstimateRoleCorrelations computes the Pearson correlation of fantasy residuals for every role
+// pair that appears in the results, then shrinks each toward its prior:
+//
+//	(n·raw + k·prior) / (n + k)
+//
+// where n is the number of games the pair was observed in and k is the shrinkage weight. Residuals
+// are points over projection when a projection was stored, otherwise points over the player's mean
+// in the sample, so a stud and a punt play contribute on the same scale.
+func EstimateRoleCorrelations(sport string, results []PlayerGameResult, shrinkage float64) *RoleCorrelations {
+	if shrinkage < 0 {
+		shrinkage = 0
+	}
+
+	playerMeans := make(map[uuid.UUID]float64)
+	playerCounts := make(map[uuid.UUID]int)
+	for _, r := range results {
+		playerMeans[r.PlayerID] += r.FantasyPoints
+		playerCounts[r.PlayerID]++
+	}
+	for id, total := range playerMeans {
+		playerMeans[id] = total / float64(playerCounts[id])
+	}
+
+	// Group residuals by game and team; a role appearing twice for a team keeps the first entry
+	games := make(map[string]map[string]map[string]float64)
+	for _, r := range results {
+		if r.Role == "" {
+			continue
+		}
+		residual := r.FantasyPoints - playerMeans[r.PlayerID]
+		if r.ProjectedPoints > 0 {
+			residual = r.FantasyPoints - r.ProjectedPoints
+		}
+		if games[r.GameID] == nil {
+			games[r.GameID] = make(map[string]map[string]float64)
+		}
+		if games[r.GameID][r.Team] == nil {
+			games[r.GameID][r.Team] = make(map[string]float64)
+		}
+		if _, exists := games[r.GameID][r.Team][r.Role]; !exists {
+			games[r.GameID][r.Team][r.Role] = residual
+		}
+	}
+
+	type accumulator struct {
+		roleA, roleB             string
+		sameTeam                 bool
+		n                        int
+		sumX, sumY, sumXX, sumYY float64
+		sumXY                    float64
+	}
+	pairs := make(map[string]*accumulator)
+	observe := func(roleA string, x float64, roleB string, y float64, sameTeam bool) {
+		if roleB < roleA {
+			roleA, roleB, x, y = roleB, roleA, y, x
+		}
+		key := rolePairKey(roleA, roleB, sameTeam)
+		acc := pairs[key]
+		if acc == nil {
+			acc = &accumulator{roleA: roleA, roleB: roleB, sameTeam: sameTeam}
+			pairs[key] = acc
+		}
+		acc.n++
+		acc.sumX += x
+		acc.sumY += y
+		acc.sumXX += x * x
+		acc.sumYY += y * y
+		acc.sumXY += x * y
+	}
+
+	for _, teams := range games {
+		teamNames := make([]string, 0, len(teams))
+		for team := range teams {
+			teamNames = append(teamNames, team)
+		}
+		sort.Strings(teamNames)
+
+		for i, team := range teamNames {
+			roles := sortedRoles(teams[team])
+			for a := 0; a < len(roles); a++ {
+				for b := a + 1; b < len(roles); b++ {
+					observe(roles[a], teams[team][roles[a]], roles[b], teams[team][roles[b]], true)
+				}
+			}
+			for _, opponent := range teamNames[i+1:] {
+				for _, roleA := range roles {
+					for roleB, y := range teams[opponent] {
+						observe(roleA, teams[team][roleA], roleB, y, false)
+					}
+				}
+			}
+		}
+	}
+
+	estimates := &RoleCorrelations{
+		Sport:       sport,
+		Games:       len(games),
+		Pairs:       make(map[string]RoleCorrelation, len(pairs)),
+		EstimatedAt: time.Now(),
+	}
+	for key, acc := range pairs {
+		if acc.n < minCorrelationSamples {
+			continue
+		}
+		n := float64(acc.n)
+		covariance := acc.sumXY/n - (acc.sumX/n)*(acc.sumY/n)
+		varX := acc.sumXX/n - (acc.sumX/n)*(acc.sumX/n)
+		varY := acc.sumYY/n - (acc.sumY/n)*(acc.sumY/n)
+		if varX <= 0 || varY <= 0 {
+			continue
+		}
+		raw := math.Max(-1, math.Min(1, covariance/math.Sqrt(varX*varY)))
+		prior := RolePrior(sport, acc.roleA, acc.roleB, acc.sameTeam)
+		estimates.Pairs[key] = RoleCorrelation{
+			RoleA:       acc.roleA,
+			RoleB:       acc.roleB,
+			SameTeam:    acc.sameTeam,
+			Samples:     acc.n,
+			Raw:         raw,
+			Prior:       prior,
+			Correlation: (n*raw + shrinkage*prior) / (n + shrinkage),
+		}
+	}
+
+	return estimates
+}
+
+func sortedRoles(roles map[string]float64) []string {
+	sorted := make([]string, 0, len(roles))
+	for role := range roles {
+		sorted = append(sorted, role)
+	}
+	sort.Strings(sorted)
+	return sorted
+}
+
+// RolePrior is the correlation assumed for a role pair before any history is seen. It reuses the
+// position-pair rules, plus a batting order prior for MLB where adjacent hitters correlate more
+// strongly than hitters far apart in the lineup.
+func RolePrior(sport, roleA, roleB string, sameTeam bool) float64 {
+	if sport == "mlb" {
+		slotA, okA := battingSlot(roleA)
+		slotB, okB := battingSlot(roleB)
+		if okA && okB {
+			if !sameTeam {
+				return 0.05
+			}
+			distance := absInt(slotA - slotB)
+			if distance > 4 {
+				distance = 9 - distance // the order wraps around from 9 back to 1
+			}
+			return 0.30 - 0.05*float64(distance-1)
+		}
+	}
+
+	cm := &CorrelationMatrix{}
+	posA, posB := rolePosition(sport, roleA), rolePosition(sport, roleB)
+	if sameTeam {
+		return cm.getTeammateCorrelation(posA, posB, sport)
+	}
+	return cm.getOpponentCorrelation(posA, posB, sport)
+}
+
+// rolePosition maps a role back to the position the prior tables use
+func rolePosition(sport, role string) string {
+	if sport == "mlb" {
+		if role == "SP" {
+			return "P"
+		}
+		if _, ok := battingSlot(role); ok || role == benchRole {
+			return "OF"
+		}
+	}
+	return strings.TrimRight(role, "0123456789")
+}
+
+// benchRole is an MLB hitter outside the nine batting order slots
+const benchRole = "BN"
+
+// battingSlot reads a BAT1-BAT9 role; the order prior's wraparound only holds for those nine slots
+func battingSlot(role string) (int, bool) {
+	if !strings.HasPrefix(role, "BAT") {
+		return 0, false
+	}
+	slot, err := strconv.Atoi(strings.TrimPrefix(role, "BAT"))
+	return slot, err == nil && slot >= 1 && slot <= 9
+}
+
+func absInt(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+// AssignRoles gives each player on a slate the role they are expected to fill for their team.
+// Players are ranked by projection within team and position (QB1, RB2, WR3, C1, D2), MLB pitchers
+// are SP and MLB hitters take their batting order slot (BAT1-BAT9), falling back to projection rank
+// when the order isn't posted. Hitters ranked below a full order are BN. Golf has no team roles.
+func AssignRoles(sport string, players []OptimizationPlayer) map[uuid.UUID]string {
+	roles := make(map[uuid.UUID]string)
+	if sport == "golf" {
+		return roles
+	}
+
+	groups := make(map[string][]OptimizationPlayer)
+	for _, player := range players {
+		position := primaryPosition(player.Position)
+		switch {
+		case sport == "nfl" && (position == "DST" || position == "DEF"):
+			roles[player.ID] = "DST"
+			continue
+		case sport == "mlb" && (position == "P" || position == "SP" || position == "RP"):
+			roles[player.ID] = "SP"
+			continue
+		case sport == "mlb" && player.BattingOrder >= 1 && player.BattingOrder <= 9:
+			roles[player.ID] = fmt.Sprintf("BAT%d", player.BattingOrder)
+			continue
+		case sport == "mlb":
+			position = "BAT"
+		case sport == "nhl" && (position == "LW" || position == "RW"):
+			position = "W"
+		}
+		key := player.Team + ":" + position
+		groups[key] = append(groups[key], player)
+	}
+
+	for key, group := range groups {
+		position := key[strings.Index(key, ":")+1:]
+		sort.SliceStable(group, func(i, j int) bool {
+			return group[i].ProjectedPoints > group[j].ProjectedPoints
+		})
+		for rank, player := range group {
+			if position == "BAT" && rank >= 9 {
+				roles[player.ID] = benchRole
+				continue
+			}
+			roles[player.ID] = fmt.Sprintf("%s%d", position, rank+1)
+		}
+	}
+
+	return roles
+}
+
+func primaryPosition(position string) string {
+	if i := strings.IndexAny(position, "/,"); i >= 0 {
+		position = position[:i]
+	}
+	return strings.ToUpper(strings.TrimSpace(position))
+}
+
+// InferSport guesses the sport from the positions on a slate
+func InferSport(players []OptimizationPlayer) string {
+	counts := make(map[string]int)
+	for _, player := range players {
+		if player.TeeTime != "" {
+			return "golf"
+		}
+		switch primaryPosition(player.Position) {
+		case "G":
+			counts["golf_or_nhl"]++
+		case "QB", "WR", "TE", "DST", "DEF", "K":
+			counts["nfl"]++
+		case "PG", "SG", "SF", "PF":
+			counts["nba"]++
+		case "SP", "RP", "P", "1B", "2B", "3B", "SS", "OF":
+			counts["mlb"]++
+		case "LW", "RW", "W", "D":
+			counts["nhl"]++
+		}
+	}
+
+	best, bestCount := "", 0
+	for _, sport := range []string{"nfl", "nba", "mlb", "nhl"} {
+		if counts[sport] > bestCount {
+			best, bestCount = sport, counts[sport]
+		}
+	}
+	switch {
+	case best != "":
+		return best
+	case counts["golf_or_nhl"] > 0:
+		return "golf"
+	default:
+		return "nba"
+	}
+}
+
+type cachedRoleCorrelations struct {
+	estimates *RoleCorrelations
+	expiresAt time.Time
+}
+
+type cachedSlateMatrix struct {
+	matrix    *CorrelationMatrix
+	expiresAt time.Time
+}
+
+// EmpiricalCorrelationEstimator estimates role correlations from the player_game_results history
+// and builds per-slate correlation matrices from them
+type EmpiricalCorrelationEstimator struct {
+	db        *gorm.DB
+	logger    *logrus.Logger
+	lookback  time.Duration
+	shrinkage float64
+	cacheTTL  time.Duration
+
+	mu      sync.Mutex
+	bySport map[string]cachedRoleCorrelations
+	bySlate map[string]cachedSlateMatrix
+}
+
+// NewEmpiricalCorrelationEstimator creates an estimator. A nil db leaves every slate on the prior
+// position-pair rules.
+func NewEmpiricalCorrelationEstimator(db *gorm.DB, logger *logrus.Logger) *EmpiricalCorrelationEstimator {
+	return &EmpiricalCorrelationEstimator{
+		db:        db,
+		logger:    logger,
+		lookback:  defaultCorrelationLookback,
+		shrinkage: defaultCorrelationShrinkage,
+		cacheTTL:  defaultCorrelationCacheTTL,
+		bySport:   make(map[string]cachedRoleCorrelations),
+		bySlate:   make(map[string]cachedSlateMatrix),
+	}
+}
+
+// RecordResults stores final fantasy results so future estimates include them. A result sent again
+// for the same game and player replaces the stored one (stat corrections). Cached estimates for the
+// affected sports are dropped.
+func (e *EmpiricalCorrelationEstimator) RecordResults(ctx context.Context, results []PlayerGameResult) error {
+	if e.db == nil {
+		return fmt.Errorf("no database configured for correlation history")
+	}
+	if len(results) == 0 {
+		return nil
+	}
+	err := e.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "game_id"}, {Name: "player_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"role", "fantasy_points", "projected_points"}),
+	}).CreateInBatches(results, 500).Error
+	if err != nil {
+		return fmt.Errorf("failed to store player game results: %w", err)
+	}
+
+	e.mu.Lock()
+	for _, r := range results {
+		delete(e.bySport, r.Sport)
+	}
+	e.mu.Unlock()
+	return nil
+}
+
+// RoleCorrelations returns the cached estimates for a sport, recomputing them from history once
+// the cache expires
+func (e *EmpiricalCorrelationEstimator) RoleCorrelations(ctx context.Context, sport string) (*RoleCorrelations, error) {
+	e.mu.Lock()
+	cached, ok := e.bySport[sport]
+	e.mu.Unlock()
+	if ok && time.Now().Before(cached.expiresAt) {
+		return cached.estimates, nil
+	}
+
+	if e.db == nil {
+		return nil, nil
+	}
+
+	var results []PlayerGameResult
+	err := e.db.WithContext(ctx).
+		Where("sport = ? AND game_date >= ?", sport, time.Now().Add(-e.lookback)).
+		Find(&results).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to load %s game results: %w", sport, err)
+	}
+
+	estimates := EstimateRoleCorrelations(sport, results, e.shrinkage)
+	e.logger.WithFields(logrus.Fields{
+		"sport":      sport,
+		"games":      estimates.Games,
+		"role_pairs": len(estimates.Pairs),
+	}).Debug("Estimated role correlations from game history")
+
+	e.mu.Lock()
+	e.bySport[sport] = cachedRoleCorrelations{estimates: estimates, expiresAt: time.Now().Add(e.cacheTTL)}
+	e.mu.Unlock()
+
+	return estimates, nil
+}
+
+// MatrixForSlate returns the correlation matrix for a slate, building it on first use. Slates are
+// cached by key and player pool, so a changed pool for the same slate (a late swap, a new batting
+// order) builds a new matrix. Pass an empty key for ad-hoc pools that shouldn't be cached.
+func (e *EmpiricalCorrelationEstimator) MatrixForSlate(ctx context.Context, slateKey, sport string, players []OptimizationPlayer) *CorrelationMatrix {
+	if sport == "" {
+		sport = InferSport(players)
+	}
+	cacheKey := sport + ":" + slateKey + ":" + playerPoolHash(players)
+
+	if slateKey != "" {
+		e.mu.Lock()
+		cached, ok := e.bySlate[cacheKey]
+		e.mu.Unlock()
+		if ok && time.Now().Before(cached.expiresAt) {
+			return cached.matrix
+		}
+	}
+
+	estimates, err := e.RoleCorrelations(ctx, sport)
+	if err != nil {
+		e.logger.WithError(err).WithField("sport", sport).Warn("Falling back to prior correlations")
+	}
+	matrix := NewCorrelationMatrixForSport(players, sport, estimates)
+
+	if slateKey != "" {
+		e.mu.Lock()
+		for key, cached := range e.bySlate {
+			if time.Now().After(cached.expiresAt) {
+				delete(e.bySlate, key)
+			}
+		}
+		e.bySlate[cacheKey] = cachedSlateMatrix{matrix: matrix, expiresAt: time.Now().Add(e.cacheTTL)}
+		e.mu.Unlock()
+	}
+
+	return matrix
+}
+
+// playerPoolHash fingerprints the fields a correlation matrix is built from
+func playerPoolHash(players []OptimizationPlayer) string {
+	hash := fnv.New64a()
+	for _, p := range players {
+		fmt.Fprintf(hash, "%s|%s|%s|%s|%d|%s|%g;", p.ID, p.Team, p.Opponent, p.Position, p.BattingOrder, p.TeeTime, p.ProjectedPoints)
+	}
+	return strconv.FormatUint(hash.Sum64(), 16)
+}
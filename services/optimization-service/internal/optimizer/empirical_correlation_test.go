@@ -0,0 +1,135 @@
+package optimizer
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssignRoles(t *testing.T) {
+	qb := OptimizationPlayer{ID: uuid.New(), Team: "KC", Position: "QB", ProjectedPoints: 22}
+	wr1 := OptimizationPlayer{ID: uuid.New(), Team: "KC", Position: "WR", ProjectedPoints: 18}
+	wr2 := OptimizationPlayer{ID: uuid.New(), Team: "KC", Position: "WR", ProjectedPoints: 11}
+	dst := OptimizationPlayer{ID: uuid.New(), Team: "BUF", Position: "DST", ProjectedPoints: 7}
+
+	roles := AssignRoles("nfl", []OptimizationPlayer{wr2, qb, dst, wr1})
+	assert.Equal(t, "QB1", roles[qb.ID])
+	assert.Equal(t, "WR1", roles[wr1.ID])
+	assert.Equal(t, "WR2", roles[wr2.ID])
+	assert.Equal(t, "DST", roles[dst.ID])
+
+	leadoff := OptimizationPlayer{ID: uuid.New(), Team: "NYY", Position: "OF", BattingOrder: 1}
+	pitcher := OptimizationPlayer{ID: uuid.New(), Team: "NYY", Position: "SP"}
+	roles = AssignRoles("mlb", []OptimizationPlayer{leadoff, pitcher})
+	assert.Equal(t, "BAT1", roles[leadoff.ID])
+	assert.Equal(t, "SP", roles[pitcher.ID])
+}
+
+func TestAssignRoles_MLBHittersPastANineManOrder(t *testing.T) {
+	var hitters []OptimizationPlayer
+	for i := 0; i < 11; i++ {
+		hitters = append(hitters, OptimizationPlayer{ID: uuid.New(), Team: "NYY", Position: "OF", ProjectedPoints: float64(20 - i)})
+	}
+	misposted := OptimizationPlayer{ID: uuid.New(), Team: "BOS", Position: "1B", BattingOrder: 12, ProjectedPoints: 9}
+
+	roles := AssignRoles("mlb", append(hitters, misposted))
+	assert.Equal(t, "BAT1", roles[hitters[0].ID])
+	assert.Equal(t, "BAT9", roles[hitters[8].ID])
+	assert.Equal(t, "BN", roles[hitters[9].ID], "projection rank never runs past the ninth slot")
+	assert.Equal(t, "BN", roles[hitters[10].ID])
+	assert.Equal(t, "BAT1", roles[misposted.ID], "an impossible posted slot falls back to projection rank")
+
+	// Every pair stays a valid correlation, and bench hitters use the plain hitter prior
+	for _, role := range []string{"BAT1", "BAT5", "BAT9", "BN"} {
+		for _, other := range []string{"BAT1", "BAT9", "BN", "SP"} {
+			prior := RolePrior("mlb", role, other, true)
+			assert.True(t, prior >= -1 && prior <= 1, "%s-%s prior %v", role, other, prior)
+		}
+	}
+	assert.Equal(t, RolePrior("mlb", "SP", "OF", true), RolePrior("mlb", "SP", "BN", true))
+	assert.Equal(t, 0.30, RolePrior("mlb", "BAT9", "BAT1", true), "the order wraps from nine to one")
+	_, ok := battingSlot("BAT12")
+	assert.False(t, ok)
+}
+
+func TestEstimateRoleCorrelations_RecoversSignalAndShrinks(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+	var results []PlayerGameResult
+	qbID, wrID, rbID, dstID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+
+	for g := 0; g < 400; g++ {
+		gameID := fmt.Sprintf("g%d", g)
+		passing := rng.NormFloat64()
+		rushing := rng.NormFloat64()
+		results = append(results,
+			PlayerGameResult{GameID: gameID, PlayerID: qbID, Team: "KC", Opponent: "BUF", Role: "QB1", FantasyPoints: 20 + 6*passing, ProjectedPoints: 20},
+			PlayerGameResult{GameID: gameID, PlayerID: wrID, Team: "KC", Opponent: "BUF", Role: "WR1", FantasyPoints: 15 + 5*(0.8*passing+0.6*rng.NormFloat64()), ProjectedPoints: 15},
+			PlayerGameResult{GameID: gameID, PlayerID: rbID, Team: "KC", Opponent: "BUF", Role: "RB1", FantasyPoints: 12 + 4*rushing, ProjectedPoints: 12},
+			PlayerGameResult{GameID: gameID, PlayerID: dstID, Team: "BUF", Opponent: "KC", Role: "DST", FantasyPoints: 7 + 3*(-0.7*rushing+0.7*rng.NormFloat64()), ProjectedPoints: 7},
+		)
+	}
+
+	unshrunk := EstimateRoleCorrelations("nfl", results, 0)
+	stack, ok := unshrunk.Get("WR1", "QB1", true)
+	require.True(t, ok)
+	assert.InDelta(t, 0.8, stack.Correlation, 0.08)
+	assert.Equal(t, 400, stack.Samples)
+
+	runDefense, ok := unshrunk.Get("RB1", "DST", false)
+	require.True(t, ok)
+	assert.InDelta(t, -0.7, runDefense.Correlation, 0.08)
+
+	// With only a handful of games the estimate stays close to the position-rule prior
+	few := EstimateRoleCorrelations("nfl", results[:4*5], defaultCorrelationShrinkage)
+	shrunk, ok := few.Get("QB1", "WR1", true)
+	require.True(t, ok)
+	assert.Equal(t, 0.50, shrunk.Prior)
+	assert.InDelta(t, shrunk.Prior, shrunk.Correlation, 0.1)
+}
+
+func TestCorrelationMatrix_UsesEmpiricalEstimatesAndFullUUIDs(t *testing.T) {
+	qb := OptimizationPlayer{ID: uuid.New(), Team: "KC", Opponent: "BUF", Position: "QB", ProjectedPoints: 22}
+	wr := OptimizationPlayer{ID: uuid.New(), Team: "KC", Opponent: "BUF", Position: "WR", ProjectedPoints: 18}
+	opp := OptimizationPlayer{ID: uuid.New(), Team: "BUF", Opponent: "KC", Position: "WR", ProjectedPoints: 16}
+
+	empirical := &RoleCorrelations{Pairs: map[string]RoleCorrelation{
+		rolePairKey("QB1", "WR1", true): {Correlation: 0.62},
+	}}
+	cm := NewCorrelationMatrixForSport([]OptimizationPlayer{qb, wr, opp}, "nfl", empirical)
+
+	assert.Equal(t, 0.62, cm.GetCorrelation(qb.ID, wr.ID))
+	assert.Equal(t, 0.62, cm.GetCorrelation(wr.ID, qb.ID))
+	// No estimate for the bring-back, so the position rule applies
+	assert.Equal(t, 0.25, cm.GetCorrelation(qb.ID, opp.ID))
+
+	flat := cm.ToMap()
+	assert.Len(t, flat, 3)
+	key := qb.ID.String() + ":" + wr.ID.String()
+	if wr.ID.String() < qb.ID.String() {
+		key = wr.ID.String() + ":" + qb.ID.String()
+	}
+	assert.Equal(t, 0.62, flat[key])
+}
+
+func TestMatrixForSlate_CachesPerPool(t *testing.T) {
+	estimator := NewEmpiricalCorrelationEstimator(nil, logrus.New())
+	leadoff := OptimizationPlayer{ID: uuid.New(), Team: "NYY", Opponent: "BOS", Position: "OF", BattingOrder: 1, ProjectedPoints: 9}
+	cleanup := OptimizationPlayer{ID: uuid.New(), Team: "NYY", Opponent: "BOS", Position: "1B", BattingOrder: 4, ProjectedPoints: 10}
+	pool := []OptimizationPlayer{leadoff, cleanup}
+
+	first := estimator.MatrixForSlate(context.Background(), "slate", "mlb", pool)
+	assert.Same(t, first, estimator.MatrixForSlate(context.Background(), "slate", "mlb", pool))
+
+	// A new batting order on the same slate is a different pool
+	leadoff.BattingOrder, cleanup.BattingOrder = 4, 1
+	swapped := estimator.MatrixForSlate(context.Background(), "slate", "mlb", []OptimizationPlayer{leadoff, cleanup})
+	assert.NotSame(t, first, swapped)
+
+	assert.NotSame(t, first, estimator.MatrixForSlate(context.Background(), "", "mlb", pool), "ad-hoc pools are not cached")
+}
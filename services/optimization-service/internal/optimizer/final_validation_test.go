@@ -1,6 +1,7 @@
 package optimizer
 
 import (
+	"context"
 	"fmt"
 	"testing"
 	"time"
@@ -118,7 +119,7 @@ func testSingleLineupOptimization(t *testing.T) TestResult {
 	}
 
 	optimizer := NewDPOptimizer()
-	result, err := optimizer.OptimizeWithDPV2(players, config)
+	result, err := optimizer.OptimizeWithDPV2(context.Background(), players, config)
 
 	return TestResult{
 		success:          err == nil && len(result) == 1,
@@ -141,7 +142,7 @@ func testMultipleLineups(t *testing.T, numLineups int) TestResult {
 	}
 
 	optimizer := NewDPOptimizer()
-	result, err := optimizer.OptimizeWithDPV2(players, config)
+	result, err := optimizer.OptimizeWithDPV2(context.Background(), players, config)
 
 	return TestResult{
 		success:          err == nil && len(result) > 0,
@@ -164,7 +165,7 @@ func testOptimizationStrategy(t *testing.T, strategy OptimizationObjective) Test
 	}
 
 	optimizer := NewDPOptimizer()
-	result, err := optimizer.OptimizeWithDPV2(players, config)
+	result, err := optimizer.OptimizeWithDPV2(context.Background(), players, config)
 
 	return TestResult{
 		success:          err == nil && len(result) == 1,
@@ -184,7 +185,7 @@ func testBackwardCompatibility(t *testing.T) TestResult {
 	}
 
 	optimizer := NewDPOptimizer()
-	result, err := optimizer.OptimizeWithDP(players, config, "draftkings")
+	result, err := optimizer.OptimizeWithDP(context.Background(), players, config, "draftkings")
 
 	return TestResult{
 		success:          err == nil && result != nil && result.OptimalScore > 0,
@@ -207,7 +208,7 @@ func testPerformanceBenchmark(t *testing.T, numLineups int) TestResult {
 	}
 
 	optimizer := NewDPOptimizer()
-	result, err := optimizer.OptimizeWithDPV2(players, config)
+	result, err := optimizer.OptimizeWithDPV2(context.Background(), players, config)
 
 	return TestResult{
 		success:          err == nil,
@@ -222,15 +223,21 @@ func createMinimalTestPlayers(count int) []types.Player {
 	teams := []string{"LAL", "GSW", "BOS", "MIA", "DEN", "PHX", "NYK", "CHA"}
 
 	for i := 0; i < count; i++ {
+		position := positions[i%len(positions)]
+		team := teams[i%len(teams)]
+		salary := 4000 + (i%10)*600 // Range: 4000-10000
+		projected := 20.0 + float64(i%20) // Range: 20-40
+		ceiling := 25.0 + float64(i%25) // Range: 25-50
+		floor := 15.0 + float64(i%15) // Range: 15-30
 		players[i] = types.Player{
 			ID:              uuid.New(),
 			Name:            fmt.Sprintf("Player_%d", i+1),
-			Position:        positions[i%len(positions)],
-			Team:            teams[i%len(teams)],
-			SalaryDK:        4000 + (i%10)*600,           // Range: 4000-10000
-			ProjectedPoints: 20.0 + float64(i%20),        // Range: 20-40
-			CeilingPoints:   25.0 + float64(i%25),        // Range: 25-50
-			FloorPoints:     15.0 + float64(i%15),        // Range: 15-30
+			Position:        &position,
+			Team:            &team,
+			SalaryDK:        &salary,
+			ProjectedPoints: &projected,
+			CeilingPoints:   &ceiling,
+			FloorPoints:     &floor,
 		}
 	}
 
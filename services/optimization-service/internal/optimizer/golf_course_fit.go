@@ -0,0 +1,171 @@
+package optimizer
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"unicode"
+
+	"gorm.io/gorm"
+
+	"github.com/stitts-dev/dfs-sim/services/sports-data-service/pkg/providers"
+)
+
+// Skills in the sports data service's course fit regression
+const (
+	fitSkillOffTee          = "sg_ott"
+	fitSkillApproach        = "sg_app"
+	fitSkillAroundGreen     = "sg_arg"
+	fitSkillPutting         = "sg_putt"
+	fitSkillDrivingDistance = "driving_distance"
+	fitSkillDrivingAccuracy = "driving_accuracy"
+)
+
+// skillFitPerStroke converts a course premium in strokes per round into a projection multiplier
+const skillFitPerStroke = 0.1
+
+// CourseSkillFit is a course's fitted skill effects, read from the golf_course_fits tables the
+// sports data service's course fit job writes
+type CourseSkillFit struct {
+	CourseKey    string
+	Observations int
+	RSquared     float64
+	TourRSquared float64
+	Coefficients map[string]float64
+	Premiums     map[string]float64
+	StdErrors    map[string]float64
+	Importance   map[string]float64
+}
+
+type courseFitRow struct {
+	CourseKey    string
+	Observations int
+	RSquared     float64
+	TourRSquared float64
+}
+
+type courseSkillRow struct {
+	CourseKey   string
+	Skill       string
+	Coefficient float64
+	StdError    float64
+	Premium     float64
+	Importance  float64
+}
+
+// loadCourseSkillFits reads every fitted course, keyed by course key
+func loadCourseSkillFits(db *gorm.DB) (map[string]*CourseSkillFit, error) {
+	var fits []courseFitRow
+	if err := db.Table("golf_course_fits").
+		Select("course_key, observations, r_squared, tour_r_squared").
+		Scan(&fits).Error; err != nil {
+		return nil, fmt.Errorf("failed to load course fits: %w", err)
+	}
+
+	var skills []courseSkillRow
+	if err := db.Table("golf_course_skill_premiums").
+		Select("course_key, skill, coefficient, std_error, premium, importance").
+		Scan(&skills).Error; err != nil {
+		return nil, fmt.Errorf("failed to load course skill premiums: %w", err)
+	}
+
+	return buildCourseSkillFits(fits, skills), nil
+}
+
+func buildCourseSkillFits(fits []courseFitRow, skills []courseSkillRow) map[string]*CourseSkillFit {
+	result := make(map[string]*CourseSkillFit, len(fits))
+	for _, row := range fits {
+		result[row.CourseKey] = &CourseSkillFit{
+			CourseKey:    row.CourseKey,
+			Observations: row.Observations,
+			RSquared:     row.RSquared,
+			TourRSquared: row.TourRSquared,
+			Coefficients: make(map[string]float64),
+			Premiums:     make(map[string]float64),
+			StdErrors:    make(map[string]float64),
+			Importance:   make(map[string]float64),
+		}
+	}
+	for _, row := range skills {
+		fit, ok := result[row.CourseKey]
+		if !ok {
+			continue
+		}
+		fit.Coefficients[row.Skill] = row.Coefficient
+		fit.Premiums[row.Skill] = row.Premium
+		fit.StdErrors[row.Skill] = row.StdError
+		fit.Importance[row.Skill] = row.Importance
+	}
+	return result
+}
+
+// lookupCourseSkillFit finds a course's fit by course key, accepting a course name or an ID
+// that normalises to the same key
+func lookupCourseSkillFit(fits map[string]*CourseSkillFit, courseID string) *CourseSkillFit {
+	if fit, ok := fits[courseID]; ok {
+		return fit
+	}
+	return fits[courseFitKey(courseID)]
+}
+
+// courseFitKey normalises a course name the same way the historical warehouse keys courses
+func courseFitKey(course string) string {
+	var b strings.Builder
+	underscore := false
+	for _, r := range strings.ToLower(strings.TrimSpace(course)) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+			underscore = false
+			continue
+		}
+		if !underscore && b.Len() > 0 {
+			b.WriteByte('_')
+			underscore = true
+		}
+	}
+	return strings.TrimSuffix(b.String(), "_")
+}
+
+// courseFitAttributes are the five course fit attributes in the order their weights are summed,
+// so normalising gives the same weights on every call
+var courseFitAttributes = []string{
+	"driving_distance",
+	"driving_accuracy",
+	"approach_precision",
+	"short_game_skill",
+	"putting_consistency",
+}
+
+// attributeWeights turns the fitted importance of each skill into weights for the five course
+// fit attributes. Off-the-tee strokes gained is shared between distance and accuracy.
+func (f *CourseSkillFit) attributeWeights() map[string]float64 {
+	weights := map[string]float64{
+		"driving_distance":    f.Importance[fitSkillDrivingDistance] + f.Importance[fitSkillOffTee]/2,
+		"driving_accuracy":    f.Importance[fitSkillDrivingAccuracy] + f.Importance[fitSkillOffTee]/2,
+		"approach_precision":  f.Importance[fitSkillApproach],
+		"short_game_skill":    f.Importance[fitSkillAroundGreen],
+		"putting_consistency": f.Importance[fitSkillPutting],
+	}
+
+	total := 0.0
+	for _, attribute := range courseFitAttributes {
+		total += weights[attribute]
+	}
+	if total <= 0 {
+		return nil
+	}
+	for attribute := range weights {
+		weights[attribute] /= total
+	}
+	return weights
+}
+
+// skillFitMultiplier values a player's strokes gained profile at the course: the strokes per
+// round the course's premiums add or take away compared with an average course
+func (f *CourseSkillFit) skillFitMultiplier(prediction *providers.PlayerPrediction) float64 {
+	strokes := f.Premiums[fitSkillOffTee]*prediction.SGOffTee +
+		f.Premiums[fitSkillApproach]*prediction.SGApproach +
+		f.Premiums[fitSkillAroundGreen]*prediction.SGAroundGreen +
+		f.Premiums[fitSkillPutting]*prediction.SGPutting
+	return math.Max(0.9, math.Min(1.1, 1.0+strokes*skillFitPerStroke))
+}
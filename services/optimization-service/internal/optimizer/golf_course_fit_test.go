@@ -0,0 +1,162 @@
+package optimizer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/stitts-dev/dfs-sim/services/sports-data-service/pkg/providers"
+)
+
+func TestCourseSkillFits(t *testing.T) {
+	fits := buildCourseSkillFits(
+		[]courseFitRow{{CourseKey: "tpc_sawgrass", Observations: 400, RSquared: 0.12, TourRSquared: 0.1}},
+		[]courseSkillRow{
+			{CourseKey: "tpc_sawgrass", Skill: fitSkillOffTee, Coefficient: 0.8, Premium: -0.2, Importance: 0.2},
+			{CourseKey: "tpc_sawgrass", Skill: fitSkillApproach, Coefficient: 1.3, Premium: 0.3, Importance: 0.5},
+			{CourseKey: "tpc_sawgrass", Skill: fitSkillAroundGreen, Coefficient: 1.0, Importance: 0.1},
+			{CourseKey: "tpc_sawgrass", Skill: fitSkillPutting, Coefficient: 1.0, Importance: 0.1},
+			{CourseKey: "tpc_sawgrass", Skill: fitSkillDrivingAccuracy, Coefficient: 0.1, Premium: 0.05, Importance: 0.1},
+			{CourseKey: "augusta_national", Skill: fitSkillApproach, Coefficient: 1.1},
+		},
+	)
+	require.Len(t, fits, 1)
+
+	fit := lookupCourseSkillFit(fits, "TPC Sawgrass")
+	require.NotNil(t, fit)
+	assert.Nil(t, lookupCourseSkillFit(fits, "Augusta National"))
+
+	weights := fit.attributeWeights()
+	assert.InDelta(t, 0.1, weights["driving_distance"], 1e-9)
+	assert.InDelta(t, 0.2, weights["driving_accuracy"], 1e-9)
+	assert.InDelta(t, 0.5, weights["approach_precision"], 1e-9)
+
+	iron := &providers.PlayerPrediction{SGApproach: 1.0, SGOffTee: -0.5}
+	bomber := &providers.PlayerPrediction{SGApproach: -0.5, SGOffTee: 1.0}
+	assert.InDelta(t, 1.04, fit.skillFitMultiplier(iron), 1e-9)
+	assert.InDelta(t, 0.965, fit.skillFitMultiplier(bomber), 1e-9)
+
+	engine := NewCourseModelEngine(nil, nil, logrus.NewEntry(logrus.New()))
+	engine.fitCalculator.courseSkillFits = fits
+	assert.Equal(t, weights, engine.fitCalculator.weightsForCourse("tpc_sawgrass"))
+	assert.Equal(t, engine.fitCalculator.attributeWeights, engine.fitCalculator.weightsForCourse("augusta_national"))
+}
+
+// expectCourseSkillFits answers the course fit queries with an approach-heavy fit for TPC Sawgrass
+func expectCourseSkillFits(mock sqlmock.Sqlmock) {
+	mock.ExpectQuery(`SELECT course_key, observations, r_squared, tour_r_squared FROM "golf_course_fits"`).
+		WillReturnRows(sqlmock.NewRows([]string{"course_key", "observations", "r_squared", "tour_r_squared"}).
+			AddRow("tpc_sawgrass", 400, 0.12, 0.1))
+	mock.ExpectQuery(`SELECT course_key, skill, coefficient, std_error, premium, importance FROM "golf_course_skill_premiums"`).
+		WillReturnRows(sqlmock.NewRows([]string{"course_key", "skill", "coefficient", "std_error", "premium", "importance"}).
+			AddRow("tpc_sawgrass", fitSkillApproach, 1.3, 0.1, 0.3, 0.8).
+			AddRow("tpc_sawgrass", fitSkillPutting, 1.0, 0.1, 0, 0.05).
+			AddRow("tpc_sawgrass", fitSkillOffTee, 0.8, 0.1, -0.2, 0.15))
+}
+
+func newCourseFitDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	t.Helper()
+	sqlDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { sqlDB.Close() })
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+	return db, mock
+}
+
+func TestCalculateCourseFit_UsesLoadedSkillFits(t *testing.T) {
+	db, mock := newCourseFitDB(t)
+	expectCourseSkillFits(mock)
+
+	engine := NewCourseModelEngine(nil, nil, logrus.NewEntry(logrus.New()))
+	// An elite iron player who loses strokes off the tee and on the greens
+	profile := &PlayerProfile{
+		PlayerID:           "1",
+		DrivingDistance:    30,
+		DrivingAccuracy:    30,
+		ApproachPrecision:  95,
+		ShortGameSkill:     50,
+		PuttingConsistency: 30,
+		UpdatedAt:          time.Now(),
+	}
+	course := &CourseFeatures{
+		CourseID:                "TPC Sawgrass",
+		DifficultyRating:        7,
+		DrivingDistanceWeight:   1,
+		DrivingAccuracyWeight:   1,
+		ApproachPrecisionWeight: 1,
+		ShortGameWeight:         1,
+		PuttingWeight:           1,
+	}
+
+	before, err := engine.CalculateCourseFit(context.Background(), profile, course, nil)
+	require.NoError(t, err)
+	require.NoError(t, engine.LoadCourseSkillFits(db))
+	require.NoError(t, mock.ExpectationsWereMet())
+	after, err := engine.CalculateCourseFit(context.Background(), profile, course, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, before.AttributeBreakdown, after.AttributeBreakdown, "fitted weights change how attributes combine, not how they score")
+	assert.Greater(t, after.FitScore, before.FitScore, "a course that rewards approach play suits the iron player")
+
+	other := *course
+	other.CourseID = "augusta_national"
+	unfitted, err := engine.CalculateCourseFit(context.Background(), profile, &other, nil)
+	require.NoError(t, err)
+	assert.Equal(t, before.FitScore, unfitted.FitScore, "courses without a fit keep the default weights")
+}
+
+func TestPositionOptimizerCourseFitScore(t *testing.T) {
+	db, mock := newCourseFitDB(t)
+	expectCourseSkillFits(mock)
+	engine := NewCourseModelEngine(nil, nil, logrus.NewEntry(logrus.New()))
+	require.NoError(t, engine.LoadCourseSkillFits(db))
+
+	p := NewPositionOptimizer(nil, nil, nil)
+	prediction := &providers.PlayerPrediction{PlayerID: 1, CourseFit: 0.3, SGApproach: 1.8, SGOffTee: -0.8, SGPutting: -0.8}
+	analytics := &providers.CourseAnalytics{CourseID: "tpc_sawgrass", DifficultyRating: 7}
+	analytics.SkillPremiums.DrivingDistance = 1
+	analytics.SkillPremiums.DrivingAccuracy = 1
+	analytics.SkillPremiums.ApproachPrecision = 1
+	analytics.SkillPremiums.ShortGameSkill = 1
+	analytics.SkillPremiums.PuttingConsistency = 1
+
+	assert.Equal(t, 0.3, p.courseFitScore(context.Background(), prediction, analytics), "without the course model DataGolf's fit is used")
+
+	p.SetCourseModelEngine(engine)
+	fit, err := engine.CalculateCourseFit(context.Background(), playerProfileFromPrediction(prediction), courseFeaturesFromAnalytics(analytics), nil)
+	require.NoError(t, err)
+	assert.Equal(t, fit.FitScore, p.courseFitScore(context.Background(), prediction, analytics))
+	assert.NotEqual(t, 0.3, fit.FitScore)
+
+	analytics.CourseID = "augusta_national"
+	assert.Equal(t, 0.3, p.courseFitScore(context.Background(), prediction, analytics), "unfitted courses keep DataGolf's fit")
+}
+
+func TestApplyFieldProbabilities(t *testing.T) {
+	predictions := []providers.PlayerPrediction{
+		{PlayerID: 1, MakeCutProbability: 0.9, WinProbability: 0.1, Top20Probability: 0.6},
+		{PlayerID: 2, MakeCutProbability: 0.8, WinProbability: 0.05, Top20Probability: 0.4},
+	}
+	simulated := map[int]FieldProbabilities{
+		1: {MakeCut: 0.95, Win: 0.2, Top5: 0.4, Top10: 0.55, Top20: 0.7},
+	}
+
+	adjusted := applyFieldProbabilities(predictions, simulated)
+	require.Len(t, adjusted, 2)
+	assert.Equal(t, 0.95, adjusted[0].MakeCutProbability)
+	assert.Equal(t, 0.2, adjusted[0].WinProbability)
+	assert.Equal(t, 0.4, adjusted[0].Top5Probability)
+	assert.Equal(t, 0.55, adjusted[0].Top10Probability)
+	assert.Equal(t, 0.7, adjusted[0].Top20Probability)
+	assert.Equal(t, predictions[1], adjusted[1], "players outside the simulation keep DataGolf's odds")
+	assert.Equal(t, 0.9, predictions[0].MakeCutProbability, "the DataGolf predictions are not modified")
+}
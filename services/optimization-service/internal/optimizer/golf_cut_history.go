@@ -0,0 +1,168 @@
+package optimizer
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// recentFormEvents is how many of a player's latest cut events make up their recent form
+const recentFormEvents = 7
+
+// historicalCutEvent is a finished event with a cut from the historical golf warehouse
+type historicalCutEvent struct {
+	Tour      string
+	EventID   string
+	Year      int
+	CourseKey string
+	CutLine   int
+	FieldSize int
+	EndDate   *time.Time
+}
+
+// historicalCutEntry is how far one player got in one of those events
+type historicalCutEntry struct {
+	Tour      string
+	EventID   string
+	Year      int
+	DGID      int
+	LastRound int
+	SGTotal   float64
+}
+
+// loadHistoricalCutData reads cut lines and player results from the warehouse filled by the
+// sports data service's golf history backfill. Players are keyed by DataGolf ID and courses by
+// course key, matching what CalculateCutProbability is called with.
+func loadHistoricalCutData(db *gorm.DB) (*HistoricalCutData, map[string]*CourseCutModel, error) {
+	var events []historicalCutEvent
+	if err := db.Table("golf_historical_events").
+		Select("tour, event_id, year, course_key, cut_line, field_size, end_date").
+		Where("cut_line IS NOT NULL").
+		Scan(&events).Error; err != nil {
+		return nil, nil, fmt.Errorf("failed to load historical events: %w", err)
+	}
+
+	var entries []historicalCutEntry
+	if err := db.Table("golf_historical_rounds AS r").
+		Select("r.tour, r.event_id, r.year, r.dg_id AS dg_id, MAX(r.round) AS last_round, AVG(r.sg_total) AS sg_total").
+		Joins("JOIN golf_historical_events e ON e.tour = r.tour AND e.event_id = r.event_id AND e.year = r.year").
+		Where("e.cut_line IS NOT NULL").
+		Group("r.tour, r.event_id, r.year, r.dg_id").
+		Scan(&entries).Error; err != nil {
+		return nil, nil, fmt.Errorf("failed to load historical rounds: %w", err)
+	}
+
+	data, models := buildHistoricalCutData(events, entries)
+	return data, models, nil
+}
+
+// buildHistoricalCutData turns warehouse rows into per-player cut rates and per-course cut line
+// models. A player made the cut when they played a third round.
+func buildHistoricalCutData(events []historicalCutEvent, entries []historicalCutEntry) (*HistoricalCutData, map[string]*CourseCutModel) {
+	now := time.Now()
+	data := &HistoricalCutData{
+		cutHistory:     make(map[string][]CutEvent),
+		playerCutRates: make(map[string]*PlayerCutStats),
+		lastUpdated:    now,
+	}
+	models := make(map[string]*CourseCutModel)
+
+	eventByKey := make(map[string]historicalCutEvent, len(events))
+	for _, event := range events {
+		key := fmt.Sprintf("%s:%s:%d", event.Tour, event.EventID, event.Year)
+		eventByKey[key] = event
+
+		cut := CutEvent{
+			TournamentID: event.EventID,
+			CourseID:     event.CourseKey,
+			CutLine:      event.CutLine,
+			FieldSize:    event.FieldSize,
+		}
+		if event.EndDate != nil {
+			cut.Date = *event.EndDate
+		}
+		data.cutHistory[event.CourseKey] = append(data.cutHistory[event.CourseKey], cut)
+	}
+
+	for courseKey, cuts := range data.cutHistory {
+		sort.Slice(cuts, func(i, j int) bool { return cuts[i].Date.Before(cuts[j].Date) })
+
+		model := &CourseCutModel{
+			CourseID:            courseKey,
+			HistoricalCuts:      make([]int, len(cuts)),
+			WeatherFactor:       0.1,
+			FieldStrengthFactor: 0.15,
+			LastUpdated:         now,
+		}
+		total := 0.0
+		for i, cut := range cuts {
+			model.HistoricalCuts[i] = cut.CutLine
+			total += float64(cut.CutLine)
+		}
+		model.AverageCutLine = total / float64(len(cuts))
+		for _, line := range model.HistoricalCuts {
+			model.CutVariance += math.Pow(float64(line)-model.AverageCutLine, 2)
+		}
+		model.CutVariance /= float64(len(cuts))
+		models[courseKey] = model
+	}
+
+	// Order each player's results by date so recent form is their latest events
+	type result struct {
+		date    time.Time
+		madeCut bool
+		sg      float64
+	}
+	results := make(map[int][]result)
+	for _, entry := range entries {
+		event, ok := eventByKey[fmt.Sprintf("%s:%s:%d", entry.Tour, entry.EventID, entry.Year)]
+		if !ok {
+			continue
+		}
+		r := result{madeCut: entry.LastRound > 2, sg: entry.SGTotal}
+		if event.EndDate != nil {
+			r.date = *event.EndDate
+		}
+		results[entry.DGID] = append(results[entry.DGID], r)
+	}
+
+	for dgID, playerResults := range results {
+		sort.Slice(playerResults, func(i, j int) bool { return playerResults[i].date.Before(playerResults[j].date) })
+
+		stats := &PlayerCutStats{
+			PlayerID:          fmt.Sprintf("%d", dgID),
+			TournamentsPlayed: len(playerResults),
+			LastUpdated:       now,
+		}
+		sgTotal := 0.0
+		for _, r := range playerResults {
+			if r.madeCut {
+				stats.CutsMade++
+			}
+			sgTotal += r.sg
+		}
+		stats.CutRate = float64(stats.CutsMade) / float64(stats.TournamentsPlayed)
+		stats.StrokesGainedAvg = sgTotal / float64(stats.TournamentsPlayed)
+
+		recent := playerResults
+		if len(recent) > recentFormEvents {
+			recent = recent[len(recent)-recentFormEvents:]
+		}
+		made := 0
+		stats.RecentForm = make([]bool, len(recent))
+		for i, r := range recent {
+			stats.RecentForm[i] = r.madeCut
+			if r.madeCut {
+				made++
+			}
+		}
+		stats.RecentFormRate = float64(made) / float64(len(recent))
+
+		data.playerCutRates[stats.PlayerID] = stats
+	}
+
+	return data, models
+}
@@ -0,0 +1,109 @@
+package optimizer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildHistoricalCutData(t *testing.T) {
+	day := func(d int) *time.Time {
+		date := time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC)
+		return &date
+	}
+	events := []historicalCutEvent{
+		{Tour: "pga", EventID: "1", Year: 2024, CourseKey: "tpc_sawgrass", CutLine: 1, FieldSize: 3, EndDate: day(1)},
+		{Tour: "pga", EventID: "2", Year: 2024, CourseKey: "tpc_sawgrass", CutLine: 3, FieldSize: 3, EndDate: day(8)},
+	}
+	entries := []historicalCutEntry{
+		{Tour: "pga", EventID: "2", Year: 2024, DGID: 10, LastRound: 2, SGTotal: -1},
+		{Tour: "pga", EventID: "1", Year: 2024, DGID: 10, LastRound: 4, SGTotal: 2},
+		{Tour: "pga", EventID: "1", Year: 2024, DGID: 11, LastRound: 4, SGTotal: 1},
+		// Unknown events are ignored
+		{Tour: "pga", EventID: "9", Year: 2024, DGID: 11, LastRound: 2},
+	}
+
+	data, models := buildHistoricalCutData(events, entries)
+
+	course := models["tpc_sawgrass"]
+	require.NotNil(t, course)
+	assert.Equal(t, []int{1, 3}, course.HistoricalCuts)
+	assert.InDelta(t, 2.0, course.AverageCutLine, 1e-9)
+	assert.InDelta(t, 1.0, course.CutVariance, 1e-9)
+
+	player := data.playerCutRates["10"]
+	require.NotNil(t, player)
+	assert.Equal(t, 2, player.TournamentsPlayed)
+	assert.Equal(t, 1, player.CutsMade)
+	assert.Equal(t, []bool{true, false}, player.RecentForm, "recent form runs oldest to newest")
+	assert.InDelta(t, 0.5, player.StrokesGainedAvg, 1e-9)
+
+	assert.Equal(t, 1, data.playerCutRates["11"].TournamentsPlayed)
+}
+
+// expectHistoricalCutData answers loadHistoricalCutData with one event for dgID, or nothing
+func expectHistoricalCutData(mock sqlmock.Sqlmock, dgID int) {
+	events := sqlmock.NewRows([]string{"tour", "event_id", "year", "course_key", "cut_line", "field_size", "end_date"})
+	entries := sqlmock.NewRows([]string{"tour", "event_id", "year", "dg_id", "last_round", "sg_total"})
+	if dgID > 0 {
+		events.AddRow("pga", "1", 2024, "tpc_sawgrass", 1, 144, time.Date(2024, 3, 17, 0, 0, 0, 0, time.UTC))
+		entries.AddRow("pga", "1", 2024, dgID, 4, 1.5)
+	}
+	mock.ExpectQuery(`FROM "golf_historical_events" WHERE cut_line IS NOT NULL`).WillReturnRows(events)
+	mock.ExpectQuery(`FROM golf_historical_rounds AS r`).WillReturnRows(entries)
+}
+
+func TestCutProbabilityEngine_ReloadsStaleHistory(t *testing.T) {
+	db, mock := newCourseFitDB(t)
+	logger := logrus.New()
+	logger.SetLevel(logrus.PanicLevel)
+
+	// The warehouse is empty at startup
+	expectHistoricalCutData(mock, 0)
+	engine := NewCutProbabilityEngine(db, nil, logger)
+	_, exists := engine.playerCutStats("18417")
+	assert.False(t, exists)
+
+	// Fresh history isn't read again
+	engine.refreshHistoricalData()
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	// Once it's a day old, the next call picks up what the backfill stored since
+	expectHistoricalCutData(mock, 18417)
+	engine.historicalData.lastUpdated = time.Now().Add(-cutHistoryRefreshInterval - time.Minute)
+	engine.refreshHistoricalData()
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	stats, exists := engine.playerCutStats("18417")
+	require.True(t, exists)
+	assert.Equal(t, 1, stats.CutsMade)
+	_, exists = engine.courseModel("tpc_sawgrass")
+	assert.True(t, exists)
+}
+
+func TestCutProbabilityEngine_ReloadHistoricalData(t *testing.T) {
+	db, mock := newCourseFitDB(t)
+	logger := logrus.New()
+	logger.SetLevel(logrus.PanicLevel)
+
+	expectHistoricalCutData(mock, 18417)
+	engine := NewCutProbabilityEngine(db, nil, logger)
+
+	// An empty result keeps what's loaded
+	expectHistoricalCutData(mock, 0)
+	require.NoError(t, engine.ReloadHistoricalData())
+	_, exists := engine.playerCutStats("18417")
+	assert.True(t, exists)
+
+	expectHistoricalCutData(mock, 22085)
+	require.NoError(t, engine.ReloadHistoricalData())
+	_, exists = engine.playerCutStats("22085")
+	assert.True(t, exists)
+	_, exists = engine.playerCutStats("18417")
+	assert.False(t, exists, "reloaded history replaces the old")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log"
 	"math"
+	"sync"
 	"time"
 
 	"github.com/redis/go-redis/v9"
@@ -26,8 +27,11 @@ type CutProbabilityEngine struct {
 	db                *gorm.DB
 	dataGolfClient    *providers.DataGolfClient
 	logger            *logrus.Logger
+	// historyMu guards historicalData and courseModels, which are swapped when history reloads
+	historyMu         sync.RWMutex
 	historicalData    *HistoricalCutData
 	courseModels      map[string]*CourseCutModel
+	historyTTL        time.Duration
 	weatherService    CutProbabilityWeatherService
 	redisClient       *redis.Client
 	cacheTTL          time.Duration
@@ -88,6 +92,10 @@ type CutProbabilityResult struct {
 	CalculatedAt       time.Time
 }
 
+// cutHistoryRefreshInterval is how long loaded cut history is used before it's read again; the
+// sports data service backfills the warehouse nightly
+const cutHistoryRefreshInterval = 24 * time.Hour
+
 // NewCutProbabilityEngine creates a new cut probability engine with DataGolf integration
 func NewCutProbabilityEngine(db *gorm.DB, dataGolfClient *providers.DataGolfClient, logger *logrus.Logger) *CutProbabilityEngine {
 	engine := &CutProbabilityEngine{
@@ -101,9 +109,9 @@ func NewCutProbabilityEngine(db *gorm.DB, dataGolfClient *providers.DataGolfClie
 		},
 		courseModels:   make(map[string]*CourseCutModel),
 		cacheTTL:       4 * time.Hour, // Cache for 4 hours
+		historyTTL:     cutHistoryRefreshInterval,
 	}
 
-	// Initialize with some example data - would be loaded from database in production
 	engine.initializeHistoricalData()
 	
 	logger.WithField("datagolf_enabled", dataGolfClient != nil).Info("Cut probability engine initialized")
@@ -124,6 +132,8 @@ func (c *CutProbabilityEngine) CalculateCutProbability(ctx context.Context, play
 		"course_id":     courseID,
 	}).Debug("Calculating cut probability")
 
+	c.refreshHistoricalData()
+
 	// Check cache first
 	cacheKey := fmt.Sprintf("cutprob_dg:%s:%s", playerID, tournamentID)
 	if cached, err := c.getCachedResult(ctx, cacheKey); err == nil && cached != nil {
@@ -166,7 +176,7 @@ func (c *CutProbabilityEngine) CalculateCutProbability(ctx context.Context, play
 
 	// Course-specific adjustment
 	courseProb := primaryProb
-	if courseModel, exists := c.courseModels[courseID]; exists {
+	if courseModel, exists := c.courseModel(courseID); exists {
 		courseProb = c.adjustForCourse(primaryProb, courseModel, fieldStrength)
 	}
 
@@ -255,7 +265,7 @@ func (c *CutProbabilityEngine) validateInput(playerID, tournamentID, courseID st
 
 // calculateHistoricalCutRate calculates a player's historical cut rate
 func (c *CutProbabilityEngine) calculateHistoricalCutRate(playerID string) (float64, error) {
-	playerStats, exists := c.historicalData.playerCutRates[playerID]
+	playerStats, exists := c.playerCutStats(playerID)
 	if !exists {
 		return 0.70, fmt.Errorf("no historical data found for player %s", playerID)
 	}
@@ -325,7 +335,7 @@ func (c *CutProbabilityEngine) adjustForFieldStrength(baseProb float64, fieldStr
 
 // adjustForRecentForm adjusts cut probability based on recent form
 func (c *CutProbabilityEngine) adjustForRecentForm(baseProb float64, playerID string) (float64, float64) {
-	playerStats, exists := c.historicalData.playerCutRates[playerID]
+	playerStats, exists := c.playerCutStats(playerID)
 	if !exists || len(playerStats.RecentForm) == 0 {
 		return baseProb, 0.0
 	}
@@ -343,7 +353,7 @@ func (c *CutProbabilityEngine) calculateConfidence(playerID, courseID string) fl
 	confidence := 0.5 // Base confidence
 	
 	// Player data quality
-	if playerStats, exists := c.historicalData.playerCutRates[playerID]; exists {
+	if playerStats, exists := c.playerCutStats(playerID); exists {
 		if playerStats.TournamentsPlayed >= 20 {
 			confidence += 0.3
 		} else if playerStats.TournamentsPlayed >= 10 {
@@ -354,7 +364,7 @@ func (c *CutProbabilityEngine) calculateConfidence(playerID, courseID string) fl
 	}
 
 	// Course data quality
-	if courseModel, exists := c.courseModels[courseID]; exists {
+	if courseModel, exists := c.courseModel(courseID); exists {
 		if len(courseModel.HistoricalCuts) >= 10 {
 			confidence += 0.2
 		} else if len(courseModel.HistoricalCuts) >= 5 {
@@ -392,9 +402,26 @@ func (c *CutProbabilityEngine) cacheResult(ctx context.Context, key string, resu
 	return c.redisClient.Set(ctx, key, "cached", c.cacheTTL).Err()
 }
 
-// initializeHistoricalData initializes the engine with example historical data
+// initializeHistoricalData loads cut history from the historical golf warehouse, falling back to
+// example data until the backfill has run
 func (c *CutProbabilityEngine) initializeHistoricalData() {
-	// Example player data - would be loaded from database
+	if c.db != nil {
+		data, courseModels, err := loadHistoricalCutData(c.db)
+		switch {
+		case err != nil:
+			c.logger.WithError(err).Warn("Failed to load historical cut data, using example data")
+		case len(data.playerCutRates) > 0:
+			c.historicalData = data
+			c.courseModels = courseModels
+			c.logger.WithFields(logrus.Fields{
+				"players": len(data.playerCutRates),
+				"courses": len(courseModels),
+			}).Info("Loaded historical cut data")
+			return
+		}
+	}
+
+	// Example player data
 	c.historicalData.playerCutRates["player1"] = &PlayerCutStats{
 		PlayerID:          "player1",
 		TournamentsPlayed: 25,
@@ -418,6 +445,68 @@ func (c *CutProbabilityEngine) initializeHistoricalData() {
 	}
 }
 
+// ReloadHistoricalData reads cut history from the warehouse again, e.g. after a backfill run.
+// The current data is kept when the warehouse has no player results yet.
+func (c *CutProbabilityEngine) ReloadHistoricalData() error {
+	if c.db == nil {
+		return fmt.Errorf("no database configured for historical cut data")
+	}
+	data, courseModels, err := loadHistoricalCutData(c.db)
+	if err != nil {
+		return err
+	}
+	if len(data.playerCutRates) == 0 {
+		return nil
+	}
+
+	c.historyMu.Lock()
+	c.historicalData = data
+	c.courseModels = courseModels
+	c.historyMu.Unlock()
+
+	c.logger.WithFields(logrus.Fields{
+		"players": len(data.playerCutRates),
+		"courses": len(courseModels),
+	}).Info("Reloaded historical cut data")
+	return nil
+}
+
+// refreshHistoricalData reloads cut history once it's older than historyTTL
+func (c *CutProbabilityEngine) refreshHistoricalData() {
+	if c.db == nil {
+		return
+	}
+
+	c.historyMu.Lock()
+	stale := time.Since(c.historicalData.lastUpdated) > c.historyTTL
+	if stale {
+		// Claim the refresh so concurrent calls, and calls after a failed load, keep using the
+		// current data until the next interval
+		c.historicalData.lastUpdated = time.Now()
+	}
+	c.historyMu.Unlock()
+
+	if stale {
+		if err := c.ReloadHistoricalData(); err != nil {
+			c.logger.WithError(err).Warn("Failed to reload historical cut data")
+		}
+	}
+}
+
+func (c *CutProbabilityEngine) playerCutStats(playerID string) (*PlayerCutStats, bool) {
+	c.historyMu.RLock()
+	defer c.historyMu.RUnlock()
+	stats, exists := c.historicalData.playerCutRates[playerID]
+	return stats, exists
+}
+
+func (c *CutProbabilityEngine) courseModel(courseID string) (*CourseCutModel, bool) {
+	c.historyMu.RLock()
+	defer c.historyMu.RUnlock()
+	model, exists := c.courseModels[courseID]
+	return model, exists
+}
+
 // calculateDataGolfWeatherImpact calculates weather impact using DataGolf weather analysis
 func (c *CutProbabilityEngine) calculateDataGolfWeatherImpact(weatherData *providers.WeatherImpactAnalysis, playerID string) float64 {
 	if weatherData == nil {
@@ -469,7 +558,7 @@ func (c *CutProbabilityEngine) calculateEnhancedConfidence(playerID, courseID st
 	}
 	
 	// Adjust based on historical data availability
-	if playerStats, exists := c.historicalData.playerCutRates[playerID]; exists {
+	if playerStats, exists := c.playerCutStats(playerID); exists {
 		if playerStats.TournamentsPlayed > 20 {
 			baseConfidence += 0.05 // More historical data = higher confidence
 		}
@@ -479,7 +568,7 @@ func (c *CutProbabilityEngine) calculateEnhancedConfidence(playerID, courseID st
 	}
 	
 	// Adjust based on course model availability
-	if _, exists := c.courseModels[courseID]; exists {
+	if _, exists := c.courseModel(courseID); exists {
 		baseConfidence += 0.02 // Course-specific model available
 	}
 	
@@ -0,0 +1,64 @@
+package optimizer
+
+import (
+	"math"
+
+	"github.com/stitts-dev/dfs-sim/shared/pkg/scoring"
+	"github.com/stitts-dev/dfs-sim/shared/types"
+)
+
+// captainSlot is the slot whose player scores and costs scoring.CaptainMultiplier times as much
+const captainSlot = "CPT"
+
+// GetContestSlots returns the position slots for a contest. Golf slots depend on the contest's
+// format; every other sport uses the sport and platform alone.
+func GetContestSlots(contest *types.Contest) []PositionSlot {
+	if !isGolfContest(contest) {
+		return GetPositionSlots(getSportNameFromID(contest.SportID), contest.Platform)
+	}
+	return getGolfFormatSlots(contest.Platform, golfContestWindow(contest).Format)
+}
+
+// isGolfContest reports whether a contest is a golf contest. Golf contests are the only ones
+// linked to a tournament, which doesn't depend on resolving the sport ID.
+func isGolfContest(contest *types.Contest) bool {
+	return contest.TournamentID != nil || getSportNameFromID(contest.SportID) == "golf"
+}
+
+// golfContestWindow works out a golf contest's format and scoring window, treating anything it
+// can't place as a classic contest
+func golfContestWindow(contest *types.Contest) scoring.GolfScoringWindow {
+	rosterSlots := make([]string, 0, len(contest.PositionRequirements))
+	for slot := range contest.PositionRequirements {
+		rosterSlots = append(rosterSlots, slot)
+	}
+
+	window, err := scoring.GolfWindowForContest(contest.Name, contest.ContestType, rosterSlots, 0)
+	if err != nil {
+		window, _ = scoring.GolfWindowForFormat(scoring.GolfFormatClassic, 0)
+	}
+	return window
+}
+
+// getGolfFormatSlots returns the golf roster for a contest format. Captain contests fill the
+// captain first so the most expensive slot gets first pick of the pool.
+func getGolfFormatSlots(platform string, format scoring.GolfContestFormat) []PositionSlot {
+	slots := getGolfSlots(platform)
+	if format == scoring.GolfFormatCaptain {
+		slots[0].SlotName = captainSlot
+	}
+	return slots
+}
+
+// slotMultiplier is the salary and points multiplier for a lineup slot
+func slotMultiplier(slotName string) float64 {
+	if slotName == captainSlot {
+		return scoring.CaptainMultiplier
+	}
+	return 1.0
+}
+
+// slotSalary is a player's salary in a slot, rounded to the dollar the way sites price captains
+func slotSalary(salary int, slotName string) int {
+	return int(math.Round(float64(salary) * slotMultiplier(slotName)))
+}
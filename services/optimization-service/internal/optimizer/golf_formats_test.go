@@ -0,0 +1,77 @@
+package optimizer
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stitts-dev/dfs-sim/shared/types"
+)
+
+func TestGolfCaptainContest(t *testing.T) {
+	tournamentID := uuid.New()
+	contest := &types.Contest{
+		Platform:             "draftkings",
+		Name:                 "PGA TOUR Round 3 Captain Showdown",
+		SalaryCap:            50000,
+		TournamentID:         &tournamentID,
+		PositionRequirements: types.PositionRequirements{"CPT": 1, "G": 5},
+	}
+
+	slots := GetContestSlots(contest)
+	require.Len(t, slots, 6)
+	assert.Equal(t, captainSlot, slots[0].SlotName)
+	assert.Equal(t, "G", slots[5].SlotName)
+	window := golfContestWindow(contest)
+	assert.Equal(t, 3, window.FirstRound)
+	assert.Equal(t, 3, window.LastRound)
+
+	players := make([]types.Player, 8)
+	for i := range players {
+		salary := 7800 - 100*i
+		points := 60.0 - 3*float64(i)
+		position, team := "G", fmt.Sprintf("T%d", i)
+		players[i] = types.Player{
+			ID:              uuid.New(),
+			Name:            fmt.Sprintf("Golfer %d", i),
+			Position:        &position,
+			Team:            &team,
+			SalaryDK:        &salary,
+			ProjectedPoints: &points,
+		}
+	}
+
+	result, err := OptimizeLineups(context.Background(), players, OptimizeConfig{SalaryCap: 50000, NumLineups: 3, MinDifferentPlayers: 1, Contest: contest})
+	require.NoError(t, err)
+	require.NotEmpty(t, result.Lineups)
+
+	constraints := GetConstraintsForContest(contest)
+	for _, lineup := range result.Lineups {
+		captains, salary, points := 0, 0, 0.0
+		for _, player := range lineup.Players {
+			salary += player.Salary
+			points += player.ProjectedPoints
+			if player.Position == captainSlot {
+				captains++
+				assert.Equal(t, captainSlot, player.Slot)
+			}
+		}
+		assert.Equal(t, 1, captains)
+		assert.Equal(t, lineup.TotalSalary, salary, "captain salary is counted at 1.5x")
+		assert.InDelta(t, lineup.ProjectedPoints, points, 1e-9)
+		assert.NoError(t, constraints.ValidateLineup(&lineup))
+	}
+
+	// The best lineup captains the top projection: 1.5 x 60 plus the next five golfers
+	best := result.Lineups[0]
+	assert.InDelta(t, 90+57+54+51+48+45, best.ProjectedPoints, 1e-9)
+	for _, player := range best.Players {
+		if player.Position == captainSlot {
+			assert.Equal(t, 11700, player.Salary)
+		}
+	}
+}
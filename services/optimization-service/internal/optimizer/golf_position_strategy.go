@@ -6,9 +6,12 @@ import (
 	"log"
 	"math"
 	"sort"
+	"time"
 
 	"github.com/stitts-dev/dfs-sim/services/sports-data-service/pkg/providers"
+	"github.com/stitts-dev/dfs-sim/shared/pkg/weather"
 	"github.com/stitts-dev/dfs-sim/shared/types"
+	"gorm.io/gorm"
 )
 
 // PositionOptimizer handles position-specific optimization strategies for golf tournaments
@@ -17,6 +20,25 @@ type PositionOptimizer struct {
 	cutProbEngine      *CutProbabilityEngine
 	dataGolfClient     *providers.DataGolfClient
 	strategyConfigs    map[types.TournamentPositionStrategy]*StrategyConfig
+	courseSkillFits    map[string]*CourseSkillFit
+	courseModel        *CourseModelEngine
+	fieldSimulator     FieldSimulator
+}
+
+// FieldProbabilities are a player's finishing probabilities from simulating the whole field
+type FieldProbabilities struct {
+	MakeCut float64
+	Win     float64
+	Top5    float64
+	Top10   float64
+	Top20   float64
+}
+
+// FieldSimulator plays the tournament out for every predicted player, so cut and placement odds
+// come from a simulated leaderboard. Results and waves are keyed by DataGolf player ID; players
+// missing from waves have no known tee time.
+type FieldSimulator interface {
+	SimulateField(predictions []providers.PlayerPrediction, waves map[int]weather.Wave) (map[int]FieldProbabilities, error)
 }
 
 // BaseOptimizerInterface defines the interface for the base optimization engine
@@ -66,6 +88,7 @@ func NewPositionOptimizer(baseOptimizer BaseOptimizerInterface, cutProbEngine *C
 		cutProbEngine:   cutProbEngine,
 		dataGolfClient:  dataGolfClient,
 		strategyConfigs: make(map[types.TournamentPositionStrategy]*StrategyConfig),
+		courseSkillFits: make(map[string]*CourseSkillFit),
 	}
 
 	// Initialize strategy configurations
@@ -74,6 +97,28 @@ func NewPositionOptimizer(baseOptimizer BaseOptimizerInterface, cutProbEngine *C
 	return optimizer
 }
 
+// LoadCourseSkillFits loads the per-course skill premiums fitted from historical strokes gained
+func (p *PositionOptimizer) LoadCourseSkillFits(db *gorm.DB) error {
+	fits, err := loadCourseSkillFits(db)
+	if err != nil {
+		return err
+	}
+	p.courseSkillFits = fits
+	return nil
+}
+
+// SetCourseModelEngine scores course fit with the five-attribute course model on courses whose
+// skill weights have been fitted from history, in place of DataGolf's course fit
+func (p *PositionOptimizer) SetCourseModelEngine(courseModel *CourseModelEngine) {
+	p.courseModel = courseModel
+}
+
+// SetFieldSimulator replaces DataGolf's cut and finishing probabilities with ones simulated for
+// the whole field
+func (p *PositionOptimizer) SetFieldSimulator(fieldSimulator FieldSimulator) {
+	p.fieldSimulator = fieldSimulator
+}
+
 // OptimizeForStrategy optimizes lineups for a specific tournament strategy
 func (p *PositionOptimizer) OptimizeForStrategy(ctx context.Context, request *types.GolfOptimizationRequest) (*types.OptimizationResult, error) {
 	// Get strategy configuration
@@ -193,11 +238,21 @@ func (p *PositionOptimizer) calculateStrategyScores(ctx context.Context, request
 	log.Printf("DataGolf data retrieved: predictions=%d, course_analytics=%v, weather=%v", 
 		len(predictions.Predictions), courseAnalytics != nil, weatherImpact != nil)
 
+	playerPredictions := predictions.Predictions
+	if p.fieldSimulator != nil {
+		simulated, err := p.fieldSimulator.SimulateField(playerPredictions, poolWaves(request.PlayerPool))
+		if err != nil {
+			log.Printf("Field simulation failed: %v, using DataGolf probabilities", err)
+		} else {
+			playerPredictions = applyFieldProbabilities(playerPredictions, simulated)
+		}
+	}
+
 	// Calculate strategy-specific scores for each player
 	for i, player := range request.PlayerPool {
 		// Find DataGolf prediction for this player
 		var playerPrediction *providers.PlayerPrediction
-		for _, pred := range predictions.Predictions {
+		for _, pred := range playerPredictions {
 			if fmt.Sprintf("%d", pred.PlayerID) == player.ExternalID {
 				playerPrediction = &pred
 				break
@@ -209,7 +264,7 @@ func (p *PositionOptimizer) calculateStrategyScores(ctx context.Context, request
 		}
 
 		// Calculate base strategy score using DataGolf probabilities
-		strategyScore := p.calculatePlayerStrategyScore(playerPrediction, courseAnalytics, weatherImpact, config)
+		strategyScore := p.calculatePlayerStrategyScore(ctx, playerPrediction, courseAnalytics, weatherImpact, config)
 		
 		// Update player's projected score with strategy-adjusted value
 		originalScore := player.ProjectedPoints
@@ -232,8 +287,28 @@ func (p *PositionOptimizer) calculateStrategyScores(ctx context.Context, request
 	return nil
 }
 
+// applyFieldProbabilities returns a copy of the predictions with the simulated cut and finishing
+// probabilities in place of DataGolf's. Players the simulation did not cover keep DataGolf's odds.
+func applyFieldProbabilities(predictions []providers.PlayerPrediction, simulated map[int]FieldProbabilities) []providers.PlayerPrediction {
+	adjusted := make([]providers.PlayerPrediction, len(predictions))
+	copy(adjusted, predictions)
+	for i := range adjusted {
+		odds, ok := simulated[adjusted[i].PlayerID]
+		if !ok {
+			continue
+		}
+		adjusted[i].MakeCutProbability = odds.MakeCut
+		adjusted[i].WinProbability = odds.Win
+		adjusted[i].Top5Probability = odds.Top5
+		adjusted[i].Top10Probability = odds.Top10
+		adjusted[i].Top20Probability = odds.Top20
+	}
+	return adjusted
+}
+
 // calculatePlayerStrategyScore calculates strategy-specific score for a player
 func (p *PositionOptimizer) calculatePlayerStrategyScore(
+	ctx context.Context,
 	prediction *providers.PlayerPrediction,
 	courseAnalytics *providers.CourseAnalytics,
 	weatherImpact *providers.WeatherImpactAnalysis,
@@ -253,7 +328,7 @@ func (p *PositionOptimizer) calculatePlayerStrategyScore(
 	// Apply comprehensive course fit adjustment if available
 	courseFitMultiplier := 1.0
 	if courseAnalytics != nil {
-		courseFitMultiplier = p.calculateCourseFitMultiplier(prediction, courseAnalytics, config)
+		courseFitMultiplier = p.calculateCourseFitMultiplier(ctx, prediction, courseAnalytics, config)
 	} else {
 		// Use basic course fit from prediction
 		courseFitMultiplier = 1.0 + (prediction.CourseFit * 0.1)
@@ -289,6 +364,7 @@ func (p *PositionOptimizer) calculatePlayerStrategyScore(
 
 // calculateCourseFitMultiplier calculates comprehensive course fit multiplier using DataGolf course analytics
 func (p *PositionOptimizer) calculateCourseFitMultiplier(
+	ctx context.Context,
 	prediction *providers.PlayerPrediction,
 	courseAnalytics *providers.CourseAnalytics,
 	config *StrategyConfig,
@@ -318,8 +394,9 @@ func (p *PositionOptimizer) calculateCourseFitMultiplier(
 	skillFitMultiplier := p.calculateSkillFitForCourse(prediction, courseAnalytics)
 	baseFit *= skillFitMultiplier
 	
-	// Use course fit score from prediction
-	baseFit *= (1.0 + prediction.CourseFit * 0.1)
+	// Use course fit score from the course model when the course has fitted weights, otherwise
+	// from the prediction
+	baseFit *= (1.0 + p.courseFitScore(ctx, prediction, courseAnalytics) * 0.1)
 	
 	// Course strategy fit - adjust based on whether course rewards the chosen strategy
 	strategyFitMultiplier := p.calculateCourseStrategyFit(courseAnalytics, config)
@@ -329,11 +406,72 @@ func (p *PositionOptimizer) calculateCourseFitMultiplier(
 	return math.Max(0.85, math.Min(1.25, baseFit))
 }
 
+// courseFitScore is the player's course fit on a -1 to 1 scale. Courses fitted from history are
+// scored by the course model with their fitted attribute weights; others use DataGolf's course fit.
+func (p *PositionOptimizer) courseFitScore(
+	ctx context.Context,
+	prediction *providers.PlayerPrediction,
+	courseAnalytics *providers.CourseAnalytics,
+) float64 {
+	if p.courseModel == nil || !p.courseModel.hasCourseSkillFit(courseAnalytics.CourseID) {
+		return prediction.CourseFit
+	}
+
+	result, err := p.courseModel.CalculateCourseFit(ctx, playerProfileFromPrediction(prediction), courseFeaturesFromAnalytics(courseAnalytics), nil)
+	if err != nil {
+		log.Printf("Course model fit failed for player %d: %v, using DataGolf course fit", prediction.PlayerID, err)
+		return prediction.CourseFit
+	}
+	return result.FitScore
+}
+
+// playerProfileFromPrediction rates a player's skills on the course model's 0-100 scale from
+// their strokes gained, with average at 50 and each stroke per round worth 25 points.
+// Off-the-tee strokes gained stands in for both driving distance and accuracy.
+func playerProfileFromPrediction(prediction *providers.PlayerPrediction) *PlayerProfile {
+	rating := func(strokesGained float64) float64 {
+		return math.Max(0, math.Min(100, 50+strokesGained*25))
+	}
+	return &PlayerProfile{
+		PlayerID:           fmt.Sprintf("%d", prediction.PlayerID),
+		PlayerName:         prediction.PlayerName,
+		DrivingDistance:    rating(prediction.SGOffTee),
+		DrivingAccuracy:    rating(prediction.SGOffTee),
+		ApproachPrecision:  rating(prediction.SGApproach),
+		ShortGameSkill:     rating(prediction.SGAroundGreen),
+		PuttingConsistency: rating(prediction.SGPutting),
+		ConsistencyRating:  (1 - prediction.VolatilityRating) * 100,
+		UpdatedAt:          time.Now(),
+	}
+}
+
+// courseFeaturesFromAnalytics maps DataGolf course analytics onto the course model's features
+func courseFeaturesFromAnalytics(courseAnalytics *providers.CourseAnalytics) *CourseFeatures {
+	return &CourseFeatures{
+		CourseID:                courseAnalytics.CourseID,
+		Length:                  courseAnalytics.Length,
+		Par:                     courseAnalytics.Par,
+		DifficultyRating:        courseAnalytics.DifficultyRating,
+		DrivingDistanceWeight:   courseAnalytics.SkillPremiums.DrivingDistance,
+		DrivingAccuracyWeight:   courseAnalytics.SkillPremiums.DrivingAccuracy,
+		ApproachPrecisionWeight: courseAnalytics.SkillPremiums.ApproachPrecision,
+		ShortGameWeight:         courseAnalytics.SkillPremiums.ShortGameSkill,
+		PuttingWeight:           courseAnalytics.SkillPremiums.PuttingConsistency,
+		WeatherSensitivity:      courseAnalytics.WeatherSensitivity,
+		KeyHoles:                courseAnalytics.KeyHoles,
+	}
+}
+
 // calculateSkillFitForCourse calculates how well a player's skills match course requirements
 func (p *PositionOptimizer) calculateSkillFitForCourse(
 	prediction *providers.PlayerPrediction,
 	courseAnalytics *providers.CourseAnalytics,
 ) float64 {
+	// Prefer premiums fitted from the course's own history when it has been fitted
+	if fit := lookupCourseSkillFit(p.courseSkillFits, courseAnalytics.CourseID); fit != nil {
+		return fit.skillFitMultiplier(prediction)
+	}
+	
 	skillFit := 1.0
 	
 	// Use available skill premiums from course analytics
@@ -18,6 +18,7 @@ type TournamentStateTracker struct {
 	tournamentID      string
 	currentState      *types.TournamentState
 	weatherService    WeatherServiceInterface
+	stateSource       TournamentStateSource
 	cutProbEngine     *CutProbabilityEngine
 	logger            *logrus.Logger
 	updateInterval    time.Duration
@@ -28,6 +29,11 @@ type TournamentStateTracker struct {
 	isTracking        bool
 }
 
+// TournamentStateSource supplies live tournament state, such as the live golf scoring pipeline
+type TournamentStateSource interface {
+	TournamentState(ctx context.Context) (*types.TournamentState, error)
+}
+
 // PlayerStateUpdate represents a live update for a player's state
 type PlayerStateUpdate struct {
 	PlayerID        string    `json:"player_id"`
@@ -87,21 +93,31 @@ func NewTournamentStateTracker(
 	}
 }
 
-// StartTracking begins live tournament tracking
-func (tst *TournamentStateTracker) StartTracking(ctx context.Context) error {
+// SetStateSource sets where live state comes from. Without a source the tracker reports a
+// placeholder state.
+func (tst *TournamentStateTracker) SetStateSource(source TournamentStateSource) {
 	tst.mu.Lock()
 	defer tst.mu.Unlock()
+	tst.stateSource = source
+}
 
+// StartTracking begins live tournament tracking
+func (tst *TournamentStateTracker) StartTracking(ctx context.Context) error {
+	tst.mu.Lock()
 	if tst.isTracking {
+		tst.mu.Unlock()
 		return fmt.Errorf("tournament tracking already active")
 	}
-
 	tst.isTracking = true
+	tst.mu.Unlock()
+
 	tst.logger.WithField("tournament_id", tst.tournamentID).Info("Starting tournament state tracking")
 
-	// Initialize current state
+	// Initialize current state; updateTournamentState takes the lock itself
 	if err := tst.updateTournamentState(ctx); err != nil {
+		tst.mu.Lock()
 		tst.isTracking = false
+		tst.mu.Unlock()
 		return fmt.Errorf("failed to initialize tournament state: %w", err)
 	}
 
@@ -272,16 +288,28 @@ func (tst *TournamentStateTracker) trackingLoop(ctx context.Context) {
 
 // updateTournamentState updates the current tournament state
 func (tst *TournamentStateTracker) updateTournamentState(ctx context.Context) error {
-	// This would fetch live tournament data from external APIs
-	// For now, create a mock update
-	newState := &types.TournamentState{
-		TournamentID:     tst.tournamentID,
-		CurrentRound:     2, // Example: Second round
-		CutLine:          2,
-		ProjectedCutLine: 1,
-		PlayersActive:    144,
-		PlayersWithdrawn: 6,
-		LastUpdate:       time.Now().Format(time.RFC3339),
+	tst.mu.RLock()
+	source := tst.stateSource
+	tst.mu.RUnlock()
+
+	var newState *types.TournamentState
+	if source != nil {
+		state, err := source.TournamentState(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to fetch live tournament state: %w", err)
+		}
+		newState = state
+	} else {
+		// No live source configured, so report a placeholder state
+		newState = &types.TournamentState{
+			TournamentID:     tst.tournamentID,
+			CurrentRound:     2, // Example: Second round
+			CutLine:          2,
+			ProjectedCutLine: 1,
+			PlayersActive:    144,
+			PlayersWithdrawn: 6,
+			LastUpdate:       time.Now().Format(time.RFC3339),
+		}
 	}
 
 	// Get weather update
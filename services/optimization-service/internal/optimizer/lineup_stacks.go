@@ -0,0 +1,149 @@
+package optimizer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/stitts-dev/dfs-sim/shared/types"
+)
+
+// Lineup stack types
+const (
+	StackTypeTeam      = "team"
+	StackTypeQB        = "qb"
+	StackTypeBringBack = "bring_back"
+)
+
+// noStackDescription groups lineups without a stack in portfolio summaries
+const noStackDescription = "no stack"
+
+// describeStacks finds a lineup's stacks and renders them. A quarterback with teammates is
+// described with its bring-back, e.g. "QB+2 / bring-back 1"; other teams with two or more
+// players are listed by size, e.g. "4-3".
+func describeStacks(players []types.Player) ([]types.LineupStack, string) {
+	byTeam := make(map[string][]types.Player)
+	opponents := make(map[string]string)
+	for _, player := range players {
+		team := getStringValue(player.Team)
+		if team == "" {
+			continue
+		}
+		byTeam[team] = append(byTeam[team], player)
+		if opponent := getStringValue(player.Opponent); opponent != "" {
+			opponents[team] = opponent
+		}
+	}
+
+	var stacks []types.LineupStack
+	var parts []string
+	described := make(map[string]bool)
+
+	if qb, ok := stackedQuarterback(players, byTeam); ok {
+		team, opponent := getStringValue(qb.Team), opponents[getStringValue(qb.Team)]
+		game := ""
+		if opponent != "" {
+			game = getGameKey(team, opponent)
+		}
+
+		stacks = append(stacks, newLineupStack(StackTypeQB, team, game, byTeam[team]))
+		described[team] = true
+		part := fmt.Sprintf("QB+%d", len(byTeam[team])-1)
+
+		if bringBack := byTeam[opponent]; opponent != "" && len(bringBack) > 0 {
+			stacks = append(stacks, newLineupStack(StackTypeBringBack, opponent, game, bringBack))
+			described[opponent] = true
+			part += fmt.Sprintf(" / bring-back %d", len(bringBack))
+		}
+		parts = append(parts, part)
+	}
+
+	var teamStacks []types.LineupStack
+	for team, teamPlayers := range byTeam {
+		if described[team] || len(teamPlayers) < 2 {
+			continue
+		}
+		game := ""
+		if opponent := opponents[team]; opponent != "" {
+			game = getGameKey(team, opponent)
+		}
+		teamStacks = append(teamStacks, newLineupStack(StackTypeTeam, team, game, teamPlayers))
+	}
+	sort.Slice(teamStacks, func(i, j int) bool {
+		if teamStacks[i].Size != teamStacks[j].Size {
+			return teamStacks[i].Size > teamStacks[j].Size
+		}
+		return teamStacks[i].Team < teamStacks[j].Team
+	})
+
+	if len(teamStacks) > 0 {
+		sizes := make([]string, len(teamStacks))
+		for i, stack := range teamStacks {
+			sizes[i] = fmt.Sprintf("%d", stack.Size)
+		}
+		sizePart := strings.Join(sizes, "-")
+		if len(parts) > 0 {
+			sizePart = "team " + sizePart
+		}
+		parts = append(parts, sizePart)
+		stacks = append(stacks, teamStacks...)
+	}
+
+	return stacks, strings.Join(parts, " / ")
+}
+
+// stackedQuarterback returns the lineup's quarterback if at least one teammate joins them
+func stackedQuarterback(players []types.Player, byTeam map[string][]types.Player) (types.Player, bool) {
+	for _, player := range players {
+		if getStringValue(player.Position) == "QB" && len(byTeam[getStringValue(player.Team)]) > 1 {
+			return player, true
+		}
+	}
+	return types.Player{}, false
+}
+
+func newLineupStack(stackType, team, game string, players []types.Player) types.LineupStack {
+	stack := types.LineupStack{
+		Type: stackType,
+		Team: team,
+		Game: game,
+		Size: len(players),
+	}
+	for _, player := range players {
+		stack.Positions = append(stack.Positions, getStringValue(player.Position))
+		stack.PlayerIDs = append(stack.PlayerIDs, player.ID)
+	}
+	return stack
+}
+
+// summarizeStacks counts a lineup set by stack description, most common first
+func summarizeStacks(lineups []types.GeneratedLineup) []types.StackSummary {
+	if len(lineups) == 0 {
+		return nil
+	}
+
+	counts := make(map[string]int)
+	for _, lineup := range lineups {
+		description := lineup.StackDescription
+		if description == "" {
+			description = noStackDescription
+		}
+		counts[description]++
+	}
+
+	summaries := make([]types.StackSummary, 0, len(counts))
+	for description, count := range counts {
+		summaries = append(summaries, types.StackSummary{
+			Description: description,
+			Lineups:     count,
+			Share:       float64(count) / float64(len(lineups)),
+		})
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].Lineups != summaries[j].Lineups {
+			return summaries[i].Lineups > summaries[j].Lineups
+		}
+		return summaries[i].Description < summaries[j].Description
+	})
+	return summaries
+}
@@ -0,0 +1,93 @@
+package optimizer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stitts-dev/dfs-sim/shared/types"
+)
+
+func stackPlayer(position, team, opponent string) types.Player {
+	return types.Player{ID: uuid.New(), Position: &position, Team: &team, Opponent: &opponent}
+}
+
+func TestDescribeStacks(t *testing.T) {
+	nfl := []types.Player{
+		stackPlayer("QB", "KC", "BUF"),
+		stackPlayer("WR", "KC", "BUF"),
+		stackPlayer("TE", "KC", "BUF"),
+		stackPlayer("WR", "BUF", "KC"),
+		stackPlayer("RB", "SF", "SEA"),
+		stackPlayer("DST", "SF", "SEA"),
+		stackPlayer("WR", "MIA", "NYJ"),
+	}
+	stacks, description := describeStacks(nfl)
+	assert.Equal(t, "QB+2 / bring-back 1 / team 2", description)
+	require.Len(t, stacks, 3)
+	assert.Equal(t, StackTypeQB, stacks[0].Type)
+	assert.Equal(t, []string{"QB", "WR", "TE"}, stacks[0].Positions)
+	assert.Equal(t, "BUF@KC", stacks[0].Game)
+	assert.Equal(t, StackTypeBringBack, stacks[1].Type)
+	assert.Equal(t, "BUF", stacks[1].Team)
+	assert.Equal(t, StackTypeTeam, stacks[2].Type)
+
+	var mlb []types.Player
+	for i := 0; i < 3; i++ {
+		mlb = append(mlb, stackPlayer("OF", "NYY", "BOS"))
+	}
+	for i := 0; i < 4; i++ {
+		mlb = append(mlb, stackPlayer("1B", "LAD", "SD"))
+	}
+	mlb = append(mlb, stackPlayer("P", "HOU", "TEX"))
+	_, description = describeStacks(mlb)
+	assert.Equal(t, "4-3", description)
+
+	stacks, description = describeStacks([]types.Player{stackPlayer("QB", "KC", "BUF"), stackPlayer("WR", "BUF", "KC")})
+	assert.Empty(t, stacks, "a quarterback without teammates isn't a stack")
+	assert.Empty(t, description)
+}
+
+func TestSummarizeStacks(t *testing.T) {
+	summaries := summarizeStacks([]types.GeneratedLineup{
+		{StackDescription: "4-3"}, {StackDescription: "4-3"}, {StackDescription: "5-2"}, {},
+	})
+	require.Len(t, summaries, 3)
+	assert.Equal(t, types.StackSummary{Description: "4-3", Lineups: 2, Share: 0.5}, summaries[0])
+	assert.Equal(t, noStackDescription, summaries[2].Description)
+}
+
+func TestAssignLineupSlots(t *testing.T) {
+	var players []types.Player
+	for _, position := range []string{"C", "PG", "SF", "PG", "PF", "SG", "SF", "C"} {
+		players = append(players, stackPlayer(position, "", ""))
+	}
+	slots := assignLineupSlots(players, getNBASlots("draftkings"))
+	require.Len(t, slots, 8)
+	assert.Equal(t, "C", slots[players[0].ID])
+	assert.Equal(t, "PG", slots[players[1].ID])
+	assert.Equal(t, "G", slots[players[3].ID])
+	assert.Equal(t, "F", slots[players[6].ID])
+	assert.Equal(t, "UTIL", slots[players[7].ID])
+
+	assert.Nil(t, assignLineupSlots(players[:7], getNBASlots("draftkings")))
+}
+
+func TestOptimizeLineups_ReportsSlots(t *testing.T) {
+	players, contest := testGolfPool(9)
+	result, err := OptimizeLineups(context.Background(), players, OptimizeConfig{SalaryCap: 50000, NumLineups: 2, MinDifferentPlayers: 1, Contest: contest})
+	require.NoError(t, err)
+	require.NotEmpty(t, result.Lineups)
+
+	for _, lineup := range result.Lineups {
+		assert.Empty(t, lineup.Stacks)
+		for _, player := range lineup.Players {
+			assert.Equal(t, "G", player.Slot)
+		}
+	}
+	require.Len(t, result.StackSummaries, 1)
+	assert.Equal(t, len(result.Lineups), result.StackSummaries[0].Lineups)
+}
@@ -0,0 +1,424 @@
+package optimizer
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/google/uuid"
+
+	"github.com/stitts-dev/dfs-sim/shared/types"
+)
+
+// Multi-lineup targets reported when a set falls short
+const (
+	TargetNumLineups          = "num_lineups"
+	TargetMinDifferentPlayers = "min_different_players"
+	TargetMinExposure         = "min_exposure"
+	TargetMaxExposure         = "max_exposure"
+)
+
+// repairCandidates is how many of the best candidates are repaired when none is admissible as is
+const repairCandidates = 50
+
+// lineupSetBuilder picks a set of lineups one at a time. Each new lineup must differ from every
+// lineup already picked by MinDifferentPlayers, keep every player under their maximum exposure and
+// leave enough lineups to reach every minimum exposure by the end of the set. When no candidate
+// qualifies, the best candidates are repaired by swapping players, and only then are targets
+// relaxed; every relaxation is reported.
+type lineupSetBuilder struct {
+	config OptimizeConfig
+	pool   []types.Player
+	// slotPositions is the positions each slot name accepts, for swapping players within a slot
+	slotPositions map[string][]string
+
+	target   int
+	minCount map[uuid.UUID]int
+	maxCount map[uuid.UUID]int
+	counts   map[uuid.UUID]int
+	lineups  []lineupCandidate
+
+	relaxedUniqueness int
+}
+
+func newLineupSetBuilder(config OptimizeConfig, slots []PositionSlot, pool []types.Player) *lineupSetBuilder {
+	b := &lineupSetBuilder{
+		config:        config,
+		pool:          pool,
+		slotPositions: make(map[string][]string, len(slots)),
+		target:        config.NumLineups,
+		minCount:      make(map[uuid.UUID]int, len(config.MinExposure)),
+		maxCount:      make(map[uuid.UUID]int, len(config.MaxExposure)),
+		counts:        make(map[uuid.UUID]int),
+	}
+	for _, slot := range slots {
+		b.slotPositions[slot.SlotName] = slot.AllowedPositions
+	}
+
+	// Exposures are fractions of the set; a minimum rounds up and a maximum rounds down to whole lineups
+	for id, exposure := range config.MinExposure {
+		if count := int(math.Ceil(exposure*float64(b.target) - 1e-9)); count > 0 {
+			b.minCount[id] = count
+		}
+	}
+	for id, exposure := range config.MaxExposure {
+		b.maxCount[id] = int(math.Floor(exposure*float64(b.target) + 1e-9))
+	}
+	return b
+}
+
+// build picks up to NumLineups lineups from candidates sorted best first
+func (b *lineupSetBuilder) build(candidates []lineupCandidate) []lineupCandidate {
+	b.lineups = make([]lineupCandidate, 0, b.target)
+	for len(b.lineups) < b.target {
+		next, ok := b.next(candidates)
+		if !ok {
+			break
+		}
+		b.add(next)
+	}
+	return b.lineups
+}
+
+// next solves for the next lineup, relaxing exposure and then uniqueness only when it has to
+func (b *lineupSetBuilder) next(candidates []lineupCandidate) (lineupCandidate, bool) {
+	if lineup, ok := b.nextStrict(candidates); ok {
+		return lineup, true
+	}
+	return b.nextRelaxed(candidates)
+}
+
+// nextStrict finds the best candidate that meets every target, repairing candidates if none does
+func (b *lineupSetBuilder) nextStrict(candidates []lineupCandidate) (lineupCandidate, bool) {
+	for _, candidate := range candidates {
+		if b.admissible(candidate) {
+			return candidate, true
+		}
+	}
+
+	for i := 0; i < len(candidates) && i < repairCandidates; i++ {
+		if repaired, ok := b.repair(candidates[i]); ok && b.admissible(repaired) {
+			return repaired, true
+		}
+	}
+	return lineupCandidate{}, false
+}
+
+// nextRelaxed gives up the minimum exposures, then uniqueness, to keep the set growing
+func (b *lineupSetBuilder) nextRelaxed(candidates []lineupCandidate) (lineupCandidate, bool) {
+	// Minimum exposures can no longer all be reached; keep uniqueness and the maximums
+	for _, candidate := range candidates {
+		if b.unique(candidate, b.config.MinDifferentPlayers) && b.withinMax(candidate) {
+			return candidate, true
+		}
+	}
+
+	// Fall back to any lineup that isn't a duplicate
+	for _, candidate := range candidates {
+		if b.unique(candidate, 1) && b.withinMax(candidate) {
+			b.relaxedUniqueness++
+			return candidate, true
+		}
+	}
+	return lineupCandidate{}, false
+}
+
+func (b *lineupSetBuilder) add(lineup lineupCandidate) {
+	b.lineups = append(b.lineups, lineup)
+	for _, player := range lineup.players {
+		b.counts[player.ID]++
+	}
+}
+
+func (b *lineupSetBuilder) admissible(lineup lineupCandidate) bool {
+	return b.unique(lineup, b.config.MinDifferentPlayers) && b.withinMax(lineup) && b.keepsMinimumsReachable(lineup)
+}
+
+// unique reports whether a lineup differs from every lineup already picked by at least minDifferent players
+func (b *lineupSetBuilder) unique(lineup lineupCandidate, minDifferent int) bool {
+	for _, prior := range b.lineups {
+		if countDifferentPlayers(prior, lineup) < max(minDifferent, 1) {
+			return false
+		}
+	}
+	return true
+}
+
+func (b *lineupSetBuilder) withinMax(lineup lineupCandidate) bool {
+	for _, player := range lineup.players {
+		if limit, ok := b.maxCount[player.ID]; ok && b.counts[player.ID]+1 > limit {
+			return false
+		}
+	}
+	return true
+}
+
+// keepsMinimumsReachable reports whether every player left out of the lineup can still reach their
+// minimum exposure in the lineups that remain after it
+func (b *lineupSetBuilder) keepsMinimumsReachable(lineup lineupCandidate) bool {
+	remaining := b.target - len(b.lineups) - 1
+	for id := range b.mustPlay(remaining) {
+		if !hasPlayer(lineup.players, id) {
+			return false
+		}
+	}
+	return true
+}
+
+// mustPlay is the players whose minimum exposure needs more lineups than the remaining ones
+func (b *lineupSetBuilder) mustPlay(remaining int) map[uuid.UUID]bool {
+	urgent := make(map[uuid.UUID]bool)
+	for id, count := range b.minCount {
+		if count-b.counts[id] > remaining {
+			urgent[id] = true
+		}
+	}
+	return urgent
+}
+
+// repair swaps players who must play into a lineup and players at their maximum out of it, each
+// time making the swap that costs the fewest projected points
+func (b *lineupSetBuilder) repair(lineup lineupCandidate) (lineupCandidate, bool) {
+	repaired := lineupCandidate{
+		players:         append([]types.Player(nil), lineup.players...),
+		playerPositions: make(map[uuid.UUID]string, len(lineup.playerPositions)),
+	}
+	for id, slot := range lineup.playerPositions {
+		repaired.playerPositions[id] = slot
+	}
+
+	urgent := b.mustPlay(b.target - len(b.lineups) - 1)
+	locked := make(map[uuid.UUID]bool, len(b.config.LockedPlayers)+len(urgent))
+	for _, id := range b.config.LockedPlayers {
+		locked[id] = true
+	}
+	for id := range urgent {
+		locked[id] = true
+	}
+
+	for _, player := range b.pool {
+		if !urgent[player.ID] || hasPlayer(repaired.players, player.ID) {
+			continue
+		}
+		if !b.swapIn(&repaired, player, locked) {
+			return lineupCandidate{}, false
+		}
+	}
+
+	for _, player := range append([]types.Player(nil), repaired.players...) {
+		if limit, ok := b.maxCount[player.ID]; !ok || b.counts[player.ID] < limit {
+			continue
+		}
+		if locked[player.ID] || !b.swapOut(&repaired, player, locked) {
+			return lineupCandidate{}, false
+		}
+	}
+
+	b.retotal(&repaired)
+	if !isValidLineup(&repaired, b.config) {
+		return lineupCandidate{}, false
+	}
+	return repaired, true
+}
+
+// swapIn replaces the unlocked player whose slot the incoming player can fill at the smallest
+// loss of projected points, keeping the lineup under the cap
+func (b *lineupSetBuilder) swapIn(lineup *lineupCandidate, incoming types.Player, locked map[uuid.UUID]bool) bool {
+	best, bestLoss := -1, math.Inf(1)
+	for i, current := range lineup.players {
+		if locked[current.ID] {
+			continue
+		}
+		slot := b.slotFor(*lineup, current)
+		if !b.canFill(incoming, slot) || !b.fitsCap(*lineup, current, incoming, slot) {
+			continue
+		}
+		if loss := slotPoints(current, slot) - slotPoints(incoming, slot); loss < bestLoss {
+			best, bestLoss = i, loss
+		}
+	}
+	if best < 0 {
+		return false
+	}
+	b.replace(lineup, best, incoming)
+	return true
+}
+
+// swapOut replaces a player with the best-projected pool player who can fill their slot
+func (b *lineupSetBuilder) swapOut(lineup *lineupCandidate, outgoing types.Player, locked map[uuid.UUID]bool) bool {
+	index := -1
+	for i, player := range lineup.players {
+		if player.ID == outgoing.ID {
+			index = i
+		}
+	}
+	slot := b.slotFor(*lineup, outgoing)
+
+	var replacement *types.Player
+	for i := range b.pool {
+		candidate := b.pool[i]
+		if hasPlayer(lineup.players, candidate.ID) || !b.canFill(candidate, slot) || !b.fitsCap(*lineup, outgoing, candidate, slot) {
+			continue
+		}
+		if limit, ok := b.maxCount[candidate.ID]; ok && b.counts[candidate.ID] >= limit {
+			continue
+		}
+		if replacement == nil || slotPoints(candidate, slot) > slotPoints(*replacement, slot) {
+			replacement = &b.pool[i]
+		}
+	}
+	if index < 0 || replacement == nil {
+		return false
+	}
+	b.replace(lineup, index, *replacement)
+	return true
+}
+
+func (b *lineupSetBuilder) replace(lineup *lineupCandidate, index int, incoming types.Player) {
+	outgoing := lineup.players[index]
+	slot := b.slotFor(*lineup, outgoing)
+	delete(lineup.playerPositions, outgoing.ID)
+	lineup.players[index] = incoming
+	lineup.playerPositions[incoming.ID] = slot
+	b.retotal(lineup)
+}
+
+func (b *lineupSetBuilder) slotFor(lineup lineupCandidate, player types.Player) string {
+	if slot, ok := lineup.playerPositions[player.ID]; ok {
+		return slot
+	}
+	if player.Position != nil {
+		return *player.Position
+	}
+	return ""
+}
+
+func (b *lineupSetBuilder) canFill(player types.Player, slot string) bool {
+	if player.Position == nil {
+		return false
+	}
+	allowed, ok := b.slotPositions[slot]
+	if !ok {
+		allowed = []string{slot}
+	}
+	for _, position := range allowed {
+		if *player.Position == position {
+			return true
+		}
+	}
+	return false
+}
+
+func (b *lineupSetBuilder) fitsCap(lineup lineupCandidate, outgoing, incoming types.Player, slot string) bool {
+	platform := ""
+	if b.config.Contest != nil {
+		platform = b.config.Contest.Platform
+	}
+	salary := lineup.totalSalary -
+		slotSalary(getSalaryForPlatform(outgoing, platform), slot) +
+		slotSalary(getSalaryForPlatform(incoming, platform), slot)
+	return salary <= b.config.SalaryCap
+}
+
+// retotal recomputes a lineup's salary, points and position groups after a swap
+func (b *lineupSetBuilder) retotal(lineup *lineupCandidate) {
+	platform := ""
+	if b.config.Contest != nil {
+		platform = b.config.Contest.Platform
+	}
+	lineup.totalSalary, lineup.projectedPoints = 0, 0
+	lineup.positions = make(map[string][]types.Player)
+	for _, player := range lineup.players {
+		slot := b.slotFor(*lineup, player)
+		lineup.totalSalary += slotSalary(getSalaryForPlatform(player, platform), slot)
+		lineup.projectedPoints += slotPoints(player, slot)
+		lineup.positions[slot] = append(lineup.positions[slot], player)
+	}
+}
+
+func slotPoints(player types.Player, slot string) float64 {
+	if player.ProjectedPoints == nil {
+		return 0
+	}
+	return *player.ProjectedPoints * slotMultiplier(slot)
+}
+
+// shortfalls reports every target the finished set missed
+func (b *lineupSetBuilder) shortfalls() []types.LineupTargetShortfall {
+	var unmet []types.LineupTargetShortfall
+	if len(b.lineups) < b.target {
+		unmet = append(unmet, types.LineupTargetShortfall{
+			Target:   TargetNumLineups,
+			Required: float64(b.target),
+			Achieved: float64(len(b.lineups)),
+			Message:  fmt.Sprintf("only %d of %d lineups could be built", len(b.lineups), b.target),
+		})
+	}
+	if b.relaxedUniqueness > 0 {
+		unmet = append(unmet, types.LineupTargetShortfall{
+			Target:   TargetMinDifferentPlayers,
+			Required: float64(b.config.MinDifferentPlayers),
+			Achieved: float64(b.minPairwiseDifference()),
+			Message:  fmt.Sprintf("%d lineups share more players with an earlier lineup than min_different_players allows", b.relaxedUniqueness),
+		})
+	}
+
+	exposure := func(id uuid.UUID) float64 {
+		if len(b.lineups) == 0 {
+			return 0
+		}
+		return float64(b.counts[id]) / float64(len(b.lineups))
+	}
+	var ids []uuid.UUID
+	for id := range b.minCount {
+		ids = append(ids, id)
+	}
+	sortIDs(ids)
+	for _, id := range ids {
+		if b.counts[id] < b.minCount[id] {
+			playerID := id
+			unmet = append(unmet, types.LineupTargetShortfall{
+				Target:   TargetMinExposure,
+				PlayerID: &playerID,
+				Required: b.config.MinExposure[id],
+				Achieved: exposure(id),
+				Message:  fmt.Sprintf("player %s is in %d lineups, needs %d", id, b.counts[id], b.minCount[id]),
+			})
+		}
+	}
+
+	ids = ids[:0]
+	for id := range b.maxCount {
+		ids = append(ids, id)
+	}
+	sortIDs(ids)
+	for _, id := range ids {
+		if len(b.lineups) > 0 && exposure(id) > b.config.MaxExposure[id]+1e-9 {
+			playerID := id
+			unmet = append(unmet, types.LineupTargetShortfall{
+				Target:   TargetMaxExposure,
+				PlayerID: &playerID,
+				Required: b.config.MaxExposure[id],
+				Achieved: exposure(id),
+				Message:  fmt.Sprintf("player %s is in %d of %d lineups, above the maximum", id, b.counts[id], len(b.lineups)),
+			})
+		}
+	}
+	return unmet
+}
+
+func (b *lineupSetBuilder) minPairwiseDifference() int {
+	minimum := -1
+	for i := range b.lineups {
+		for j := i + 1; j < len(b.lineups); j++ {
+			if different := countDifferentPlayers(b.lineups[i], b.lineups[j]); minimum < 0 || different < minimum {
+				minimum = different
+			}
+		}
+	}
+	return max(minimum, 0)
+}
+
+func sortIDs(ids []uuid.UUID) {
+	sort.Slice(ids, func(i, j int) bool { return ids[i].String() < ids[j].String() })
+}
@@ -0,0 +1,99 @@
+package optimizer
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stitts-dev/dfs-sim/shared/types"
+)
+
+func testGolfPool(size int) ([]types.Player, *types.Contest) {
+	tournamentID := uuid.New()
+	contest := &types.Contest{Platform: "draftkings", Name: "PGA TOUR Classic", SalaryCap: 50000, TournamentID: &tournamentID}
+
+	players := make([]types.Player, size)
+	for i := range players {
+		salary := 9000 - 200*i
+		points := 70.0 - 4*float64(i)
+		position, team := "G", fmt.Sprintf("T%d", i)
+		players[i] = types.Player{
+			ID:              uuid.New(),
+			Name:            fmt.Sprintf("Golfer %d", i),
+			Position:        &position,
+			Team:            &team,
+			SalaryDK:        &salary,
+			ProjectedPoints: &points,
+		}
+	}
+	return players, contest
+}
+
+func TestOptimizeLineups_UniquenessAndExposureTargets(t *testing.T) {
+	players, contest := testGolfPool(9)
+	best, worst := players[0], players[8]
+
+	result, err := OptimizeLineups(context.Background(), players, OptimizeConfig{
+		SalaryCap:           50000,
+		NumLineups:          4,
+		MinDifferentPlayers: 2,
+		MinExposure:         map[uuid.UUID]float64{worst.ID: 0.5},
+		MaxExposure:         map[uuid.UUID]float64{best.ID: 0.5},
+		Contest:             contest,
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Lineups, 4)
+	assert.Empty(t, result.UnmetTargets)
+
+	counts := make(map[uuid.UUID]int)
+	for i, lineup := range result.Lineups {
+		ids := make(map[uuid.UUID]bool)
+		for _, player := range lineup.Players {
+			ids[player.ID] = true
+			counts[player.ID]++
+		}
+		// Every pair differs, not just consecutive lineups
+		for _, prior := range result.Lineups[:i] {
+			shared := 0
+			for _, player := range prior.Players {
+				if ids[player.ID] {
+					shared++
+				}
+			}
+			assert.LessOrEqual(t, shared, 4)
+		}
+	}
+	assert.GreaterOrEqual(t, counts[worst.ID], 2)
+	assert.LessOrEqual(t, counts[best.ID], 2)
+}
+
+func TestOptimizeLineups_ReportsUnreachableTargets(t *testing.T) {
+	players, contest := testGolfPool(7)
+	missing := uuid.New()
+
+	result, err := OptimizeLineups(context.Background(), players, OptimizeConfig{
+		SalaryCap:           50000,
+		NumLineups:          5,
+		MinDifferentPlayers: 1,
+		MinExposure:         map[uuid.UUID]float64{missing: 0.2},
+		Contest:             contest,
+	})
+	require.NoError(t, err)
+
+	targets := make(map[string]types.LineupTargetShortfall)
+	for _, shortfall := range result.UnmetTargets {
+		targets[shortfall.Target] = shortfall
+	}
+	require.Contains(t, targets, TargetMinExposure)
+	assert.Equal(t, missing, *targets[TargetMinExposure].PlayerID)
+	assert.Zero(t, targets[TargetMinExposure].Achieved)
+
+	// Only the two lineups leaving out one of the two most expensive golfers fit under the cap
+	require.Len(t, result.Lineups, 2)
+	require.Contains(t, targets, TargetNumLineups)
+	assert.Equal(t, 2.0, targets[TargetNumLineups].Achieved)
+}
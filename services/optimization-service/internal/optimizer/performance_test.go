@@ -1,6 +1,7 @@
 package optimizer
 
 import (
+	"context"
 	"fmt"
 	"testing"
 	"time"
@@ -34,7 +35,7 @@ func BenchmarkDPOptimization_150Lineups(b *testing.B) {
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		start := time.Now()
-		result, err := optimizer.OptimizeWithDPV2(players, config)
+		result, err := optimizer.OptimizeWithDPV2(context.Background(), players, config)
 		duration := time.Since(start)
 
 		require.NoError(b, err)
@@ -67,7 +68,7 @@ func TestDPOptimization_PerformanceValidation(t *testing.T) {
 
 	// Measure optimization time
 	start := time.Now()
-	result, err := optimizer.OptimizeWithDPV2(players, config)
+	result, err := optimizer.OptimizeWithDPV2(context.Background(), players, config)
 	duration := time.Since(start)
 
 	// Validate results
@@ -112,7 +113,7 @@ func TestBackwardCompatibility(t *testing.T) {
 	optimizer := NewDPOptimizer()
 
 	start := time.Now()
-	result, err := optimizer.OptimizeWithDP(players, config, "draftkings")
+	result, err := optimizer.OptimizeWithDP(context.Background(), players, config, "draftkings")
 	duration := time.Since(start)
 
 	require.NoError(t, err)
@@ -131,15 +132,21 @@ func createTestPlayers(count int) []types.Player {
 	teams := []string{"LAL", "GSW", "BOS", "MIA", "DEN"}
 
 	for i := 0; i < count; i++ {
+		position := positions[i%len(positions)]
+		team := teams[i%len(teams)]
+		salary := 4000 + (i%12)*500 // Range: 4000-9500
+		projected := 20.0 + float64(i%30) // Range: 20-50
+		ceiling := 25.0 + float64(i%35) // Range: 25-60
+		floor := 15.0 + float64(i%20) // Range: 15-35
 		players[i] = types.Player{
 			ID:              uuid.New(),
 			Name:            fmt.Sprintf("Player_%d", i+1),
-			Position:        positions[i%len(positions)],
-			Team:            teams[i%len(teams)],
-			SalaryDK:        4000 + (i%12)*500, // Range: 4000-9500
-			ProjectedPoints: 20.0 + float64(i%30), // Range: 20-50
-			CeilingPoints:   25.0 + float64(i%35), // Range: 25-60
-			FloorPoints:     15.0 + float64(i%20), // Range: 15-35
+			Position:        &position,
+			Team:            &team,
+			SalaryDK:        &salary,
+			ProjectedPoints: &projected,
+			CeilingPoints:   &ceiling,
+			FloorPoints:     &floor,
 		}
 	}
 
@@ -0,0 +1,180 @@
+package optimizer
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"github.com/stitts-dev/dfs-sim/shared/types"
+)
+
+// Projection randomization modes
+const (
+	// RandomizeFloorCeiling perturbs each projection towards the player's ceiling or floor
+	RandomizeFloorCeiling = "floor_ceiling"
+	// RandomizeDistribution blends each projection with a draw from the player's outcome distribution
+	RandomizeDistribution = "distribution"
+)
+
+const (
+	// defaultVolatility is the projection spread assumed for players without a floor and ceiling
+	defaultVolatility = 0.25
+	// randomizedRedraws is how many fresh projection draws a lineup gets before targets are relaxed
+	randomizedRedraws = 5
+)
+
+// ProjectionSampler draws one fantasy score for a player; the simulator's player distributions
+// satisfy it
+type ProjectionSampler interface {
+	Sample(rng *rand.Rand) float64
+}
+
+// RandomizationConfig solves every lineup against its own perturbed projections so a large set
+// explores high-upside combinations instead of near-duplicates of the top projection
+type RandomizationConfig struct {
+	Mode string `json:"mode"`
+	// Level scales each perturbation: 0 leaves projections alone, 1 applies the full spread or draw
+	Level float64 `json:"level"`
+	// ExposurePenalty lowers a player's projection by this fraction of their exposure so far
+	ExposurePenalty float64 `json:"exposure_penalty"`
+	// VolatilityOverrides multiplies a player's spread, e.g. 1.5 for a boom-or-bust player
+	VolatilityOverrides map[uuid.UUID]float64 `json:"volatility_overrides,omitempty"`
+	// Samplers are the outcome distributions RandomizeDistribution draws from; players without one
+	// fall back to their floor and ceiling
+	Samplers map[uuid.UUID]ProjectionSampler `json:"-"`
+	Seed     int64                           `json:"seed,omitempty"`
+}
+
+func (r *RandomizationConfig) enabled() bool {
+	return r != nil && r.Level > 0
+}
+
+// perturb returns a copy of the pool with projections redrawn for one lineup
+func (r *RandomizationConfig) perturb(players []types.Player, exposure map[uuid.UUID]float64, rng *rand.Rand) []types.Player {
+	perturbed := make([]types.Player, len(players))
+	for i, player := range players {
+		perturbed[i] = player
+		if player.ProjectedPoints == nil {
+			continue
+		}
+
+		points := r.draw(player, rng) * (1 - r.ExposurePenalty*exposure[player.ID])
+		points = math.Max(0, points)
+		perturbed[i].ProjectedPoints = &points
+	}
+	return perturbed
+}
+
+func (r *RandomizationConfig) draw(player types.Player, rng *rand.Rand) float64 {
+	projection := *player.ProjectedPoints
+	volatility := 1.0
+	if override, ok := r.VolatilityOverrides[player.ID]; ok {
+		volatility = override
+	}
+
+	if sampler, ok := r.Samplers[player.ID]; ok && r.Mode == RandomizeDistribution {
+		return projection + r.Level*volatility*(sampler.Sample(rng)-projection)
+	}
+
+	// Half the distance to the ceiling or floor is one standard deviation on that side, so upside
+	// players are pushed up further than they are pulled down
+	upside, downside := defaultVolatility*projection, defaultVolatility*projection
+	if player.CeilingPoints != nil && *player.CeilingPoints > projection {
+		upside = (*player.CeilingPoints - projection) / 2
+	}
+	if player.FloorPoints != nil && *player.FloorPoints > 0 && *player.FloorPoints < projection {
+		downside = (projection - *player.FloorPoints) / 2
+	}
+
+	z := rng.NormFloat64()
+	spread := downside
+	if z > 0 {
+		spread = upside
+	}
+	return projection + r.Level*volatility*spread*z
+}
+
+// buildRandomizedLineups solves each lineup of the set against a fresh draw of projections, then
+// scores the picked lineups on the unperturbed projections. Cancelling ctx keeps the lineups
+// picked so far.
+func buildRandomizedLineups(ctx context.Context, players []types.Player, config OptimizeConfig, builder *lineupSetBuilder, logger *logrus.Entry) []lineupCandidate {
+	randomization := config.Randomization
+	seed := randomization.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	rng := rand.New(rand.NewSource(seed))
+
+	baseline := make(map[uuid.UUID]types.Player, len(players))
+	for _, player := range players {
+		baseline[player.ID] = player
+	}
+
+	// Each draw only needs a small candidate pool to pick one lineup from
+	drawConfig := config
+	drawConfig.NumLineups = 1
+
+	builder.lineups = make([]lineupCandidate, 0, builder.target)
+	var candidatesSeen int64
+	for len(builder.lineups) < builder.target && ctx.Err() == nil {
+		picked := false
+		var candidates []lineupCandidate
+		for attempt := 0; attempt < randomizedRedraws && !picked; attempt++ {
+			exposure := make(map[uuid.UUID]float64, len(builder.counts))
+			for id, count := range builder.counts {
+				exposure[id] = float64(count) / float64(builder.target)
+			}
+
+			drawn := randomization.perturb(players, exposure, rng)
+			candidates = generateValidLineups(ctx, organizeByPosition(drawn, logger), drawConfig, logger)
+			sort.Slice(candidates, func(i, j int) bool {
+				return candidates[i].projectedPoints > candidates[j].projectedPoints
+			})
+			candidatesSeen += int64(len(candidates))
+
+			var next lineupCandidate
+			if next, picked = builder.nextStrict(candidates); picked {
+				builder.add(builder.rescore(next, baseline))
+			}
+		}
+		if picked {
+			continue
+		}
+
+		// Redraws didn't find a lineup that meets every target; relax them on the last draw
+		next, ok := builder.nextRelaxed(candidates)
+		if !ok {
+			break
+		}
+		builder.add(builder.rescore(next, baseline))
+	}
+
+	logger.WithFields(logrus.Fields{
+		"lineups":    len(builder.lineups),
+		"candidates": candidatesSeen,
+		"mode":       randomization.Mode,
+		"level":      randomization.Level,
+	}).Debug("Randomized lineup generation completed")
+	return builder.lineups
+}
+
+// rescore swaps a lineup's perturbed players back for the originals and recomputes its totals
+func (b *lineupSetBuilder) rescore(lineup lineupCandidate, baseline map[uuid.UUID]types.Player) lineupCandidate {
+	rescored := lineupCandidate{
+		players:         make([]types.Player, len(lineup.players)),
+		playerPositions: lineup.playerPositions,
+	}
+	for i, player := range lineup.players {
+		if original, ok := baseline[player.ID]; ok {
+			player = original
+		}
+		rescored.players[i] = player
+	}
+	b.retotal(&rescored)
+	return rescored
+}
@@ -0,0 +1,111 @@
+package optimizer
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stitts-dev/dfs-sim/shared/types"
+)
+
+type fixedSampler float64
+
+func (s fixedSampler) Sample(*rand.Rand) float64 { return float64(s) }
+
+func lineupIDs(lineups []types.GeneratedLineup) [][]uuid.UUID {
+	ids := make([][]uuid.UUID, len(lineups))
+	for i, lineup := range lineups {
+		for _, player := range lineup.Players {
+			ids[i] = append(ids[i], player.ID)
+		}
+	}
+	return ids
+}
+
+func TestOptimizeLineups_RandomizedIsSeededAndScoredOnBaseline(t *testing.T) {
+	players, contest := testGolfPool(14)
+	projections := make(map[uuid.UUID]float64)
+	for _, player := range players {
+		projections[player.ID] = *player.ProjectedPoints
+	}
+
+	config := OptimizeConfig{
+		SalaryCap:           50000,
+		NumLineups:          6,
+		MinDifferentPlayers: 1,
+		Contest:             contest,
+		Randomization:       &RandomizationConfig{Mode: RandomizeFloorCeiling, Level: 1, Seed: 42},
+	}
+	first, err := OptimizeLineups(context.Background(), players, config)
+	require.NoError(t, err)
+	second, err := OptimizeLineups(context.Background(), players, config)
+	require.NoError(t, err)
+
+	require.Len(t, first.Lineups, 6)
+	assert.Equal(t, lineupIDs(first.Lineups), lineupIDs(second.Lineups))
+
+	for _, lineup := range first.Lineups {
+		total := 0.0
+		for _, player := range lineup.Players {
+			total += projections[player.ID]
+		}
+		assert.InDelta(t, total, lineup.ProjectedPoints, 0.01)
+	}
+}
+
+func TestOptimizeLineups_RandomizedSpreadsTopExposure(t *testing.T) {
+	players, contest := testGolfPool(14)
+	top := players[0].ID
+	config := OptimizeConfig{SalaryCap: 50000, NumLineups: 10, MinDifferentPlayers: 1, Contest: contest}
+
+	exposure := func(result *OptimizerResult) int {
+		count := 0
+		for _, lineup := range result.Lineups {
+			for _, player := range lineup.Players {
+				if player.ID == top {
+					count++
+				}
+			}
+		}
+		return count
+	}
+
+	plain, err := OptimizeLineups(context.Background(), players, config)
+	require.NoError(t, err)
+	require.Equal(t, len(plain.Lineups), exposure(plain))
+
+	config.Randomization = &RandomizationConfig{Mode: RandomizeFloorCeiling, Level: 1, ExposurePenalty: 0.5, Seed: 7}
+	randomized, err := OptimizeLineups(context.Background(), players, config)
+	require.NoError(t, err)
+	require.Len(t, randomized.Lineups, 10)
+
+	// Without randomization the top projection is in every lineup
+	assert.Less(t, exposure(randomized), len(randomized.Lineups))
+}
+
+func TestRandomizationConfig_Draw(t *testing.T) {
+	players, _ := testGolfPool(2)
+	rng := rand.New(rand.NewSource(1))
+
+	sampled := RandomizationConfig{
+		Mode:     RandomizeDistribution,
+		Level:    0.5,
+		Samplers: map[uuid.UUID]ProjectionSampler{players[0].ID: fixedSampler(100)},
+		Seed:     1,
+	}
+	// Halfway from the 70 point projection to the 100 point draw
+	assert.InDelta(t, 85.0, sampled.draw(players[0], rng), 0.001)
+
+	pinned := RandomizationConfig{
+		Mode:                RandomizeFloorCeiling,
+		Level:               1,
+		VolatilityOverrides: map[uuid.UUID]float64{players[1].ID: 0},
+	}
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, *players[1].ProjectedPoints, pinned.draw(players[1], rng))
+	}
+}
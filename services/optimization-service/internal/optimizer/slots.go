@@ -4,6 +4,10 @@ import (
 	"fmt"
 	"log"
 	"sort"
+
+	"github.com/google/uuid"
+
+	"github.com/stitts-dev/dfs-sim/shared/types"
 )
 
 // PositionSlot represents a position slot in a lineup
@@ -241,3 +245,37 @@ func GetSlotAssignmentsMap(assignments []SlotAssignment) map[uint]string {
 	}
 	return result
 }
+
+// assignLineupSlots places a lineup's players into slots in priority order, for lineups that
+// were built without tracking slots. It returns nil if the players don't fill every required
+// slot. A captain slot is never assigned since it changes the lineup's salary and points.
+func assignLineupSlots(players []types.Player, slots []PositionSlot) map[uuid.UUID]string {
+	sortedSlots := make([]PositionSlot, len(slots))
+	copy(sortedSlots, slots)
+	sort.SliceStable(sortedSlots, func(i, j int) bool {
+		return sortedSlots[i].Priority < sortedSlots[j].Priority
+	})
+
+	assigned := make(map[uuid.UUID]string, len(players))
+	for _, slot := range sortedSlots {
+		if slot.SlotName == captainSlot {
+			return nil
+		}
+
+		filled := false
+		for _, player := range players {
+			if _, taken := assigned[player.ID]; taken || player.Position == nil {
+				continue
+			}
+			if CanPlayerFillSlot(OptimizationPlayer{Position: *player.Position}, slot) {
+				assigned[player.ID] = slot.SlotName
+				filled = true
+				break
+			}
+		}
+		if !filled && slot.IsRequired {
+			return nil
+		}
+	}
+	return assigned
+}
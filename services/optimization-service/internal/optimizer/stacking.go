@@ -247,7 +247,7 @@ func (sb *StackBuilder) buildQBStacks() []Stack {
 				TotalSalary:     getPlayerSalary(qb) + getPlayerSalary(teammate),
 				ProjectedPoints: qb.ProjectedPoints + teammate.ProjectedPoints,
 			}
-			stack.CorrelationScore = sb.correlations.GetCorrelation(uint(qb.ID.ID()), uint(teammate.ID.ID()))
+			stack.CorrelationScore = sb.correlations.GetCorrelation(qb.ID, teammate.ID)
 			stacks = append(stacks, stack)
 		}
 
@@ -26,6 +26,7 @@ type OptimizationPlayer struct {
 	IsInjured       bool      `json:"is_injured"`
 	InjuryStatus    string    `json:"injury_status"`
 	ImageURL        string    `json:"image_url"`
+	BattingOrder    int       `json:"batting_order,omitempty"` // MLB only, 0 until lineups post
 	// Golf-specific fields
 	TeeTime         string    `json:"tee_time,omitempty"`
 	CutProbability  float64   `json:"cut_probability,omitempty"`
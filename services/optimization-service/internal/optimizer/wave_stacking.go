@@ -0,0 +1,87 @@
+package optimizer
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/stitts-dev/dfs-sim/shared/pkg/weather"
+	"github.com/stitts-dev/dfs-sim/shared/types"
+)
+
+// WaveStack is the stacking rule type that limits how many golfers come from one tee-time wave
+const WaveStack = "wave"
+
+// hasWaveRules reports whether any stacking rule needs players' waves
+func hasWaveRules(rules []types.StackingRule) bool {
+	for _, rule := range rules {
+		if rule.Type == WaveStack {
+			return true
+		}
+	}
+	return false
+}
+
+// assignPlayerWaves splits the pool into AM/PM waves by round 1 tee time, which golf pools carry
+// as the player's game time
+func assignPlayerWaves(players []types.Player) map[uuid.UUID]weather.Wave {
+	teeTimes := make(map[string]time.Time, len(players))
+	for _, player := range players {
+		if player.GameTime != nil && !player.GameTime.IsZero() {
+			teeTimes[player.ID.String()] = *player.GameTime
+		}
+	}
+
+	waves := make(map[uuid.UUID]weather.Wave, len(teeTimes))
+	for id, wave := range weather.AssignWaves(teeTimes) {
+		waves[uuid.MustParse(id)] = wave
+	}
+	return waves
+}
+
+// poolWaves splits a golf request's player pool into waves by round 1 tee time, keyed by the
+// players' DataGolf IDs so the field simulation can tee them off together
+func poolWaves(pool []types.OptimizationPlayer) map[int]weather.Wave {
+	teeTimes := make(map[string]time.Time, len(pool))
+	for _, player := range pool {
+		if _, err := strconv.Atoi(player.ExternalID); err != nil {
+			continue
+		}
+		if teeTime, ok := weather.ParseTeeTime(player.TeeTime); ok {
+			teeTimes[player.ExternalID] = teeTime
+		}
+	}
+
+	waves := make(map[int]weather.Wave, len(teeTimes))
+	for externalID, wave := range weather.AssignWaves(teeTimes) {
+		dataGolfID, _ := strconv.Atoi(externalID)
+		waves[dataGolfID] = wave
+	}
+	return waves
+}
+
+// validateWaveStacking checks a wave rule. With a wave named, the lineup must have between
+// MinPlayers and MaxPlayers golfers from it; otherwise the largest wave must reach MinPlayers and
+// no wave may exceed MaxPlayers. Players without a tee time count towards no wave.
+func validateWaveStacking(lineup *lineupCandidate, rule types.StackingRule, waves map[uuid.UUID]weather.Wave) bool {
+	counts := make(map[weather.Wave]int)
+	for _, player := range lineup.players {
+		if wave, ok := waves[player.ID]; ok {
+			counts[wave]++
+		}
+	}
+
+	if rule.Wave != "" {
+		count := counts[weather.Wave(rule.Wave)]
+		return count >= rule.MinPlayers && (rule.MaxPlayers == 0 || count <= rule.MaxPlayers)
+	}
+
+	largest := 0
+	for _, count := range counts {
+		if count > largest {
+			largest = count
+		}
+	}
+	return largest >= rule.MinPlayers && (rule.MaxPlayers == 0 || largest <= rule.MaxPlayers)
+}
@@ -0,0 +1,57 @@
+package optimizer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stitts-dev/dfs-sim/shared/pkg/weather"
+	"github.com/stitts-dev/dfs-sim/shared/types"
+)
+
+func TestWaveStacking(t *testing.T) {
+	teeTime := func(hour, minute int) *time.Time {
+		tee := time.Date(2024, 4, 11, hour, minute, 0, 0, time.UTC)
+		return &tee
+	}
+	players := []types.Player{
+		{ID: uuid.New(), GameTime: teeTime(7, 0)},
+		{ID: uuid.New(), GameTime: teeTime(7, 11)},
+		{ID: uuid.New(), GameTime: teeTime(8, 5)},
+		{ID: uuid.New(), GameTime: teeTime(12, 30)},
+		{ID: uuid.New(), GameTime: teeTime(13, 15)},
+		{ID: uuid.New()},
+	}
+
+	waves := assignPlayerWaves(players)
+	assert.Len(t, waves, 5)
+	assert.Equal(t, weather.WaveEarlyLate, waves[players[2].ID])
+	assert.Equal(t, weather.WaveLateEarly, waves[players[3].ID])
+
+	lineup := &lineupCandidate{players: players}
+	assert.True(t, validateWaveStacking(lineup, types.StackingRule{Type: WaveStack, MinPlayers: 3}, waves))
+	assert.False(t, validateWaveStacking(lineup, types.StackingRule{Type: WaveStack, MaxPlayers: 2}, waves))
+	assert.True(t, validateWaveStacking(lineup, types.StackingRule{Type: WaveStack, Wave: string(weather.WaveLateEarly), MinPlayers: 2, MaxPlayers: 2}, waves))
+	assert.False(t, validateWaveStacking(lineup, types.StackingRule{Type: WaveStack, Wave: string(weather.WaveLateEarly), MinPlayers: 3}, waves))
+}
+
+func TestPoolWaves(t *testing.T) {
+	pool := []types.OptimizationPlayer{
+		{ExternalID: "101", TeeTime: "7:05 AM"},
+		{ExternalID: "102", TeeTime: "2024-04-11 07:16"},
+		{ExternalID: "103", TeeTime: "12:40 PM"},
+		{ExternalID: "104", TeeTime: "13:02"},
+		{ExternalID: "105"},
+		{ExternalID: "dk-106", TeeTime: "7:27 AM"},
+	}
+
+	waves := poolWaves(pool)
+	assert.Equal(t, map[int]weather.Wave{
+		101: weather.WaveEarlyLate,
+		102: weather.WaveEarlyLate,
+		103: weather.WaveLateEarly,
+		104: weather.WaveLateEarly,
+	}, waves, "players without a tee time or a DataGolf ID get no wave")
+}
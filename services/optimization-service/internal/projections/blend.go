@@ -0,0 +1,267 @@
+package projections
+
+import (
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/stitts-dev/dfs-sim/shared/types"
+)
+
+// Blend is a projection set ready to apply: its weights, the entries of the sources it blends and
+// its manual overrides
+type Blend struct {
+	weights   types.ProjectionWeights
+	sources   map[uuid.UUID]map[string]types.ProjectionEntry
+	overrides map[string]types.ProjectionOverride
+}
+
+// Projection is a player's projection before or after blending
+type Projection struct {
+	ProjectedPoints float64 `json:"projected_points"`
+	FloorPoints     float64 `json:"floor_points,omitempty"`
+	CeilingPoints   float64 `json:"ceiling_points,omitempty"`
+	Ownership       float64 `json:"ownership,omitempty"`
+}
+
+// Player identifies a player to project. Provider holds the projections the player already
+// carries, which a blend can weight like any source and keeps when nothing covers the player.
+type Player struct {
+	ID         uuid.UUID
+	ExternalID string
+	Name       string
+	Team       string
+	Position   string
+	Provider   Projection
+}
+
+// NewBlend builds a blend from a set's weights, the entries of its sources and its overrides
+func NewBlend(weights types.ProjectionWeights, entries []types.ProjectionEntry, overrides []types.ProjectionOverride) *Blend {
+	b := &Blend{
+		weights:   make(types.ProjectionWeights, len(weights)),
+		sources:   make(map[uuid.UUID]map[string]types.ProjectionEntry),
+		overrides: make(map[string]types.ProjectionOverride, len(overrides)),
+	}
+	for i, sw := range weights {
+		positions := make(map[string]float64, len(sw.PositionWeights))
+		for position, weight := range sw.PositionWeights {
+			positions[strings.ToUpper(position)] = weight
+		}
+		sw.PositionWeights = positions
+		b.weights[i] = sw
+	}
+	for _, entry := range entries {
+		if b.sources[entry.SourceID] == nil {
+			b.sources[entry.SourceID] = make(map[string]types.ProjectionEntry)
+		}
+		b.sources[entry.SourceID][entry.PlayerKey] = entry
+	}
+	for _, override := range overrides {
+		b.overrides[override.PlayerKey] = override
+	}
+	return b
+}
+
+// SourceIDs returns the uploaded sources a set's weights blend, leaving out the provider
+func SourceIDs(weights types.ProjectionWeights) []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(weights))
+	for _, sw := range weights {
+		if sw.SourceID != nil {
+			ids = append(ids, *sw.SourceID)
+		}
+	}
+	return ids
+}
+
+// Project blends a player's projection. Each source covering the player contributes with its
+// weight for the player's position; floor, ceiling and ownership average over the sources that
+// supply them. A manual override then replaces the result. The second value is false when no
+// source or override covers the player, in which case the provider projection is returned as is.
+func (b *Blend) Project(p Player) (Projection, bool) {
+	keys := playerKeys(p)
+	result := p.Provider
+	covered := false
+
+	var points, floor, ceiling, ownership stat
+	for _, sw := range b.weights {
+		weight := weightFor(sw, p.Position)
+		if weight <= 0 {
+			continue
+		}
+
+		var value Projection
+		if sw.SourceID == nil {
+			value = p.Provider
+		} else {
+			entry, ok := find(b.sources[*sw.SourceID], keys)
+			if !ok {
+				continue
+			}
+			value = Projection{
+				ProjectedPoints: entry.ProjectedPoints,
+				FloorPoints:     entry.FloorPoints,
+				CeilingPoints:   entry.CeilingPoints,
+				Ownership:       entry.Ownership,
+			}
+			covered = true
+		}
+
+		points.add(value.ProjectedPoints, weight, true)
+		floor.add(value.FloorPoints, weight, value.FloorPoints != 0)
+		ceiling.add(value.CeilingPoints, weight, value.CeilingPoints != 0)
+		ownership.add(value.Ownership, weight, value.Ownership != 0)
+	}
+
+	if covered {
+		result.ProjectedPoints = points.mean(result.ProjectedPoints)
+		result.FloorPoints = floor.mean(result.FloorPoints)
+		result.CeilingPoints = ceiling.mean(result.CeilingPoints)
+		result.Ownership = ownership.mean(result.Ownership)
+	}
+
+	if override, ok := find(b.overrides, keys); ok {
+		result.ProjectedPoints = override.ProjectedPoints
+		if override.FloorPoints != nil {
+			result.FloorPoints = *override.FloorPoints
+		}
+		if override.CeilingPoints != nil {
+			result.CeilingPoints = *override.CeilingPoints
+		}
+		covered = true
+	}
+	return result, covered
+}
+
+// ApplyToPool replaces the pool's projections with the blend's and returns how many players it
+// covered
+func (b *Blend) ApplyToPool(pool []types.OptimizationPlayer) int {
+	applied := 0
+	for i := range pool {
+		player := &pool[i]
+		projection, ok := b.Project(Player{
+			ID:         player.ID,
+			ExternalID: player.ExternalID,
+			Name:       player.Name,
+			Team:       player.Team,
+			Position:   player.Position,
+			Provider: Projection{
+				ProjectedPoints: player.ProjectedPoints,
+				FloorPoints:     player.FloorPoints,
+				CeilingPoints:   player.CeilingPoints,
+				Ownership:       player.Ownership,
+			},
+		})
+		if !ok {
+			continue
+		}
+		player.ProjectedPoints = projection.ProjectedPoints
+		player.FloorPoints = projection.FloorPoints
+		player.CeilingPoints = projection.CeilingPoints
+		player.Ownership = projection.Ownership
+		applied++
+	}
+	return applied
+}
+
+// ApplyToLineups replaces the projections of the lineups' players with the blend's, moving each
+// lineup's total by the same amount, and returns how many players it covered
+func (b *Blend) ApplyToLineups(lineups []types.GeneratedLineup) int {
+	applied := 0
+	for i := range lineups {
+		lineup := &lineups[i]
+		for j := range lineup.Players {
+			player := &lineup.Players[j]
+			projection, ok := b.Project(Player{
+				ID:       player.ID,
+				Name:     player.Name,
+				Team:     player.Team,
+				Position: player.Position,
+				Provider: Projection{ProjectedPoints: player.ProjectedPoints},
+			})
+			if !ok {
+				continue
+			}
+			lineup.ProjectedPoints += projection.ProjectedPoints - player.ProjectedPoints
+			player.ProjectedPoints = projection.ProjectedPoints
+			applied++
+		}
+	}
+	return applied
+}
+
+// weightFor returns a source's weight for a position. Multi-position players such as "PG/SG" use
+// the first listed position that has its own weight.
+func weightFor(sw types.ProjectionSourceWeight, position string) float64 {
+	if len(sw.PositionWeights) == 0 || position == "" {
+		return sw.Weight
+	}
+	position = strings.ToUpper(position)
+	if weight, ok := sw.PositionWeights[position]; ok {
+		return weight
+	}
+	for _, part := range strings.Split(position, "/") {
+		if weight, ok := sw.PositionWeights[strings.TrimSpace(part)]; ok {
+			return weight
+		}
+	}
+	return sw.Weight
+}
+
+// playerKeys lists the keys a player may be uploaded under, most specific first. A row naming
+// the player may also give their team, their position (or one of a multi-position player's
+// positions), both or neither, but never a different team or position.
+func playerKeys(p Player) []string {
+	keys := make([]string, 0, 8)
+	if p.ID != uuid.Nil {
+		keys = append(keys, IDKey(p.ID))
+	}
+	if p.ExternalID != "" {
+		keys = append(keys, ExternalKey(p.ExternalID))
+	}
+
+	positions := []string{p.Position}
+	if parts := strings.Split(p.Position, "/"); len(parts) > 1 {
+		positions = append(positions, parts...)
+	}
+	positions = append(positions, "")
+	seen := make(map[string]bool)
+	for _, team := range []string{p.Team, ""} {
+		for _, position := range positions {
+			key := NameKey(p.Name, team, position)
+			if key != "" && !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+	return keys
+}
+
+func find[T any](byKey map[string]T, keys []string) (T, bool) {
+	for _, key := range keys {
+		if value, ok := byKey[key]; ok {
+			return value, true
+		}
+	}
+	var zero T
+	return zero, false
+}
+
+// stat accumulates a weighted mean
+type stat struct {
+	sum, weight float64
+}
+
+func (s *stat) add(value, weight float64, present bool) {
+	if present {
+		s.sum += value * weight
+		s.weight += weight
+	}
+}
+
+func (s stat) mean(fallback float64) float64 {
+	if s.weight == 0 {
+		return fallback
+	}
+	return s.sum / s.weight
+}
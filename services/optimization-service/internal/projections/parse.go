@@ -0,0 +1,331 @@
+// Package projections parses uploaded projection sources and blends them into the projections an
+// optimization or simulation runs on.
+package projections
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/google/uuid"
+
+	"github.com/stitts-dev/dfs-sim/shared/types"
+)
+
+// Upload formats
+const (
+	FormatCSV  = "csv"
+	FormatJSON = "json"
+)
+
+// MaxEntries bounds the players one uploaded source may hold
+const MaxEntries = 5000
+
+// Row is one player's projection as uploaded. A row names its player by ID, external (provider)
+// ID or name, in that order of preference.
+type Row struct {
+	PlayerID        string  `json:"player_id"`
+	ExternalID      string  `json:"external_id"`
+	Name            string  `json:"name"`
+	Position        string  `json:"position"`
+	Team            string  `json:"team"`
+	ProjectedPoints float64 `json:"projected_points"`
+	FloorPoints     float64 `json:"floor_points"`
+	CeilingPoints   float64 `json:"ceiling_points"`
+	Ownership       float64 `json:"ownership"`
+
+	// ownershipPercent is set when a CSV cell gave the ownership with a % sign
+	ownershipPercent bool
+}
+
+// csvColumns maps the header names accepted in CSV uploads to Row fields
+var csvColumns = map[string]string{
+	"player_id":        "player_id",
+	"id":               "player_id",
+	"external_id":      "external_id",
+	"name":             "name",
+	"player":           "name",
+	"player_name":      "name",
+	"position":         "position",
+	"pos":              "position",
+	"team":             "team",
+	"projected_points": "projected_points",
+	"projection":       "projected_points",
+	"points":           "projected_points",
+	"fpts":             "projected_points",
+	"floor":            "floor_points",
+	"floor_points":     "floor_points",
+	"ceiling":          "ceiling_points",
+	"ceiling_points":   "ceiling_points",
+	"ownership":        "ownership",
+	"own":              "ownership",
+}
+
+// DetectFormat picks the upload format from a file name, falling back to sniffing the content
+func DetectFormat(filename string, data []byte) string {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		return FormatCSV
+	case ".json":
+		return FormatJSON
+	}
+	trimmed := bytes.TrimLeftFunc(data, unicode.IsSpace)
+	if len(trimmed) > 0 && (trimmed[0] == '[' || trimmed[0] == '{') {
+		return FormatJSON
+	}
+	return FormatCSV
+}
+
+// Parse reads an uploaded source in the given format into entries keyed by player
+func Parse(r io.Reader, format string) ([]types.ProjectionEntry, error) {
+	var rows []Row
+	var err error
+	switch format {
+	case FormatCSV:
+		rows, err = ParseCSV(r)
+	case FormatJSON:
+		rows, err = ParseJSON(r)
+	default:
+		return nil, fmt.Errorf("unsupported projection format %q", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return Entries(rows)
+}
+
+// ParseCSV reads a CSV with a header row. A projection column and one of player_id, external_id
+// or name are required; floor, ceiling, ownership, position and team are optional.
+func ParseCSV(r io.Reader) ([]Row, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, errors.New("projection file is empty")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading header: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if field, ok := csvColumns[name]; ok {
+			if _, dup := columns[field]; !dup {
+				columns[field] = i
+			}
+		}
+	}
+	if _, ok := columns["projected_points"]; !ok {
+		return nil, errors.New("a projection column (projected_points, projection, points or fpts) is required")
+	}
+	_, hasID := columns["player_id"]
+	_, hasExternal := columns["external_id"]
+	_, hasName := columns["name"]
+	if !hasID && !hasExternal && !hasName {
+		return nil, errors.New("a player_id, external_id or name column is required")
+	}
+
+	var rows []Row
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		field := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		number := func(name string) (float64, error) {
+			value := strings.TrimSuffix(field(name), "%")
+			if value == "" {
+				return 0, nil
+			}
+			n, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return 0, fmt.Errorf("line %d: invalid %s %q", line, name, field(name))
+			}
+			return n, nil
+		}
+
+		row := Row{
+			PlayerID:   field("player_id"),
+			ExternalID: field("external_id"),
+			Name:       field("name"),
+			Position:   field("position"),
+			Team:       field("team"),
+		}
+		if row.PlayerID == "" && row.ExternalID == "" && row.Name == "" {
+			continue // blank line or a spreadsheet's trailing totals row
+		}
+		if row.ProjectedPoints, err = number("projected_points"); err != nil {
+			return nil, err
+		}
+		if row.FloorPoints, err = number("floor_points"); err != nil {
+			return nil, err
+		}
+		if row.CeilingPoints, err = number("ceiling_points"); err != nil {
+			return nil, err
+		}
+		if row.Ownership, err = number("ownership"); err != nil {
+			return nil, err
+		}
+		row.ownershipPercent = strings.HasSuffix(field("ownership"), "%")
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// ParseJSON reads a JSON array of rows, or an object holding one under "projections"
+func ParseJSON(r io.Reader) ([]Row, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	var rows []Row
+	if trimmed := bytes.TrimLeftFunc(data, unicode.IsSpace); len(trimmed) > 0 && trimmed[0] == '{' {
+		var wrapped struct {
+			Projections []Row `json:"projections"`
+		}
+		err = json.Unmarshal(data, &wrapped)
+		rows = wrapped.Projections
+	} else {
+		err = json.Unmarshal(data, &rows)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid projection JSON: %w", err)
+	}
+	return rows, nil
+}
+
+// Entries validates rows and keys them by player, rejecting duplicate players
+func Entries(rows []Row) ([]types.ProjectionEntry, error) {
+	if len(rows) == 0 {
+		return nil, errors.New("no projections found")
+	}
+	if len(rows) > MaxEntries {
+		return nil, fmt.Errorf("too many projections: %d (max %d)", len(rows), MaxEntries)
+	}
+
+	scale := ownershipScale(rows)
+	entries := make([]types.ProjectionEntry, 0, len(rows))
+	seen := make(map[string]int, len(rows))
+	for i, row := range rows {
+		key, err := rowKey(row)
+		if err != nil {
+			return nil, fmt.Errorf("projection %d: %w", i+1, err)
+		}
+		if first, dup := seen[key]; dup {
+			return nil, fmt.Errorf("projection %d: player %s already listed at projection %d", i+1, key, first)
+		}
+		seen[key] = i + 1
+
+		if row.CeilingPoints != 0 && row.FloorPoints > row.CeilingPoints {
+			return nil, fmt.Errorf("projection %d: floor %.2f is above ceiling %.2f", i+1, row.FloorPoints, row.CeilingPoints)
+		}
+		ownership := row.Ownership * scale
+		if ownership < 0 || ownership > 100 {
+			return nil, fmt.Errorf("projection %d: ownership %.2f is out of range", i+1, row.Ownership)
+		}
+
+		entries = append(entries, types.ProjectionEntry{
+			PlayerKey:       key,
+			PlayerName:      row.Name,
+			Position:        strings.ToUpper(row.Position),
+			Team:            strings.ToUpper(row.Team),
+			ProjectedPoints: row.ProjectedPoints,
+			FloorPoints:     row.FloorPoints,
+			CeilingPoints:   row.CeilingPoints,
+			Ownership:       ownership,
+		})
+	}
+	return entries, nil
+}
+
+// ownershipScale is what an upload's ownership values are multiplied by to give the provider's
+// percentages. The unit is decided once for the whole upload: percentages if any value has a %
+// sign or is above 1, otherwise fractions such as 0.12, which are scaled up.
+func ownershipScale(rows []Row) float64 {
+	for _, row := range rows {
+		if row.ownershipPercent || row.Ownership > 1 {
+			return 1
+		}
+	}
+	return 100
+}
+
+func rowKey(row Row) (string, error) {
+	switch {
+	case row.PlayerID != "":
+		id, err := uuid.Parse(row.PlayerID)
+		if err != nil {
+			return "", fmt.Errorf("invalid player_id %q", row.PlayerID)
+		}
+		return IDKey(id), nil
+	case row.ExternalID != "":
+		return ExternalKey(row.ExternalID), nil
+	case NameKey(row.Name, row.Team, row.Position) != "":
+		return NameKey(row.Name, row.Team, row.Position), nil
+	}
+	return "", errors.New("a player_id, external_id or name is required")
+}
+
+// IDKey is the player key for a player ID
+func IDKey(id uuid.UUID) string {
+	return "id:" + id.String()
+}
+
+// ExternalKey is the player key for a provider's player ID
+func ExternalKey(externalID string) string {
+	return "ext:" + strings.ToLower(strings.TrimSpace(externalID))
+}
+
+// NameKey is the player key for a name, qualified by team and position when either is known so
+// players who share a name stay apart. Case, punctuation and spacing in the name are ignored so
+// "D.J. Moore" and "DJ Moore" match; an empty name gives an empty key.
+func NameKey(name, team, position string) string {
+	normalized := normalizeName(name)
+	if normalized == "" {
+		return ""
+	}
+	team = strings.ToLower(strings.TrimSpace(team))
+	position = strings.ToLower(strings.TrimSpace(position))
+	if team == "" && position == "" {
+		return "name:" + normalized
+	}
+	return "name:" + normalized + "|" + team + "|" + position
+}
+
+func normalizeName(name string) string {
+	var b strings.Builder
+	space := false
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if space && b.Len() > 0 {
+				b.WriteByte(' ')
+			}
+			space = false
+			b.WriteRune(r)
+		case r == '.' || r == '\'':
+			// initials and apostrophes are dropped without splitting the word
+		default:
+			space = true
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,209 @@
+package projections
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stitts-dev/dfs-sim/shared/types"
+)
+
+func TestParseCSV(t *testing.T) {
+	id := uuid.New()
+	csv := "\ufeffName,Pos,Team,FPTS,Floor,Ceiling,Own\n" +
+		"D.J. Moore,wr,chi,14.5,8,24,12%\n" +
+		",,,,,,\n" +
+		"Josh Allen,QB,BUF,24.1,,,0.3\n"
+
+	entries, err := Parse(strings.NewReader(csv), FormatCSV)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	assert.Equal(t, "name:dj moore|chi|wr", entries[0].PlayerKey)
+	assert.Equal(t, "WR", entries[0].Position)
+	assert.Equal(t, "CHI", entries[0].Team)
+	assert.Equal(t, 14.5, entries[0].ProjectedPoints)
+	assert.Equal(t, 24.0, entries[0].CeilingPoints)
+	assert.InDelta(t, 12.0, entries[0].Ownership, 1e-9)
+	assert.InDelta(t, 0.3, entries[1].Ownership, 1e-9, "a file with percentages is read as percentages throughout")
+
+	entries, err = Parse(strings.NewReader("player_id,projection\n"+id.String()+",10\n"), FormatCSV)
+	require.NoError(t, err)
+	assert.Equal(t, IDKey(id), entries[0].PlayerKey)
+}
+
+func TestParseOwnershipUnit(t *testing.T) {
+	tests := []struct {
+		name     string
+		csv      string
+		expected []float64
+	}{
+		{"fractions", "name,fpts,own\nA,10,0.12\nB,10,0.3\nC,10,1\n", []float64{12, 30, 100}},
+		{"percentages", "name,fpts,own\nA,10,12\nB,10,0.3\nC,10,1\n", []float64{12, 0.3, 1}},
+		{"percent signs", "name,fpts,own\nA,10,0.5%\nB,10,0.3\n", []float64{0.5, 0.3}},
+		{"no ownership", "name,fpts,own\nA,10,\nB,10,0\n", []float64{0, 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries, err := Parse(strings.NewReader(tt.csv), FormatCSV)
+			require.NoError(t, err)
+			require.Len(t, entries, len(tt.expected))
+			for i, expected := range tt.expected {
+				assert.InDelta(t, expected, entries[i].Ownership, 1e-9, entries[i].PlayerName)
+			}
+		})
+	}
+
+	_, err := Parse(strings.NewReader("name,fpts,own\nA,10,120\n"), FormatCSV)
+	assert.Error(t, err, "ownership above 100% is rejected")
+}
+
+func TestParseRejectsBadUploads(t *testing.T) {
+	cases := map[string]string{
+		"no projection column": "name,team\nJosh Allen,BUF\n",
+		"no player column":     "team,fpts\nBUF,20\n",
+		"bad number":           "name,fpts\nJosh Allen,lots\n",
+		"duplicate player":     "name,fpts\nJosh Allen,20\njosh  allen,21\n",
+		"duplicate on a team":  "name,team,fpts\nJosh Allen,BUF,20\njosh allen,buf,21\n",
+		"floor above ceiling":  "name,fpts,floor,ceiling\nJosh Allen,20,30,25\n",
+		"empty":                "",
+	}
+	for name, csv := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := Parse(strings.NewReader(csv), FormatCSV)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestParseJSON(t *testing.T) {
+	body := `{"projections": [{"external_id": "DK-123", "projected_points": 18.2, "position": "RB"}]}`
+	assert.Equal(t, FormatJSON, DetectFormat("upload", []byte(body)))
+
+	entries, err := Parse(strings.NewReader(body), FormatJSON)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "ext:dk-123", entries[0].PlayerKey)
+
+	_, err = Parse(strings.NewReader(`[{"projected_points": 3}]`), FormatJSON)
+	assert.Error(t, err, "a row without a player is rejected")
+}
+
+func TestBlendWeightsByPosition(t *testing.T) {
+	modelA, modelB := uuid.New(), uuid.New()
+	blend := NewBlend(
+		types.ProjectionWeights{
+			{SourceID: &modelA, Weight: 1},
+			{SourceID: &modelB, Weight: 1, PositionWeights: map[string]float64{"qb": 3}},
+		},
+		[]types.ProjectionEntry{
+			{SourceID: modelA, PlayerKey: "name:josh allen", ProjectedPoints: 20, CeilingPoints: 30},
+			{SourceID: modelB, PlayerKey: "name:josh allen", ProjectedPoints: 24},
+			{SourceID: modelA, PlayerKey: "name:dj moore", ProjectedPoints: 10},
+			{SourceID: modelB, PlayerKey: "name:dj moore", ProjectedPoints: 14},
+		},
+		nil,
+	)
+
+	qb, ok := blend.Project(Player{Name: "Josh Allen", Position: "QB", Provider: Projection{ProjectedPoints: 5, CeilingPoints: 9}})
+	require.True(t, ok)
+	assert.InDelta(t, 23.0, qb.ProjectedPoints, 1e-9, "model B counts three times at QB")
+	assert.InDelta(t, 30.0, qb.CeilingPoints, 1e-9, "only model A supplies a ceiling")
+
+	wr, ok := blend.Project(Player{Name: "DJ Moore", Position: "WR"})
+	require.True(t, ok)
+	assert.InDelta(t, 12.0, wr.ProjectedPoints, 1e-9)
+
+	uncovered, ok := blend.Project(Player{Name: "Nobody", Position: "TE", Provider: Projection{ProjectedPoints: 7}})
+	assert.False(t, ok)
+	assert.Equal(t, 7.0, uncovered.ProjectedPoints, "players no source covers keep the provider projection")
+}
+
+func TestBlendKeepsNamesakesApart(t *testing.T) {
+	model := uuid.New()
+	entries, err := Entries([]Row{
+		{Name: "Josh Allen", Team: "BUF", Position: "QB", ProjectedPoints: 24},
+		{Name: "Josh Allen", Team: "JAX", Position: "LB", ProjectedPoints: 6},
+		{Name: "Mike Williams", Position: "WR", ProjectedPoints: 11},
+	})
+	require.NoError(t, err, "namesakes on different teams are different players")
+	for i := range entries {
+		entries[i].SourceID = model
+	}
+	blend := NewBlend(types.ProjectionWeights{{SourceID: &model, Weight: 1}}, entries, nil)
+
+	tests := []struct {
+		name     string
+		player   Player
+		covered  bool
+		expected float64
+	}{
+		{"team and position", Player{Name: "Josh Allen", Team: "BUF", Position: "QB"}, true, 24},
+		{"namesake", Player{Name: "Josh Allen", Team: "JAX", Position: "LB"}, true, 6},
+		{"multi-position player", Player{Name: "Mike Williams", Team: "NYJ", Position: "WR/TE"}, true, 11},
+		{"same name on another team", Player{Name: "Josh Allen", Team: "NYJ", Position: "QB", Provider: Projection{ProjectedPoints: 3}}, false, 3},
+		{"same name at another position", Player{Name: "Mike Williams", Position: "TE", Provider: Projection{ProjectedPoints: 4}}, false, 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			projection, ok := blend.Project(tt.player)
+			assert.Equal(t, tt.covered, ok)
+			assert.InDelta(t, tt.expected, projection.ProjectedPoints, 1e-9)
+		})
+	}
+}
+
+func TestBlendWithProviderAndOverrides(t *testing.T) {
+	model := uuid.New()
+	playerID := uuid.New()
+	floor := 2.0
+	blend := NewBlend(
+		types.ProjectionWeights{
+			{Weight: 1},
+			{SourceID: &model, Weight: 3},
+		},
+		[]types.ProjectionEntry{
+			{SourceID: model, PlayerKey: IDKey(playerID), ProjectedPoints: 20},
+			{SourceID: model, PlayerKey: "ext:dk-9", ProjectedPoints: 30},
+		},
+		[]types.ProjectionOverride{
+			{PlayerKey: "name:injured guy", ProjectedPoints: 0, FloorPoints: &floor},
+		},
+	)
+
+	pool := []types.OptimizationPlayer{
+		{ID: playerID, Name: "Renamed In Upload", ProjectedPoints: 12},
+		{ID: uuid.New(), ExternalID: "DK-9", ProjectedPoints: 10},
+		{ID: uuid.New(), Name: "Injured Guy", ProjectedPoints: 15, FloorPoints: 9},
+		{ID: uuid.New(), Name: "Untouched", ProjectedPoints: 8},
+	}
+	assert.Equal(t, 3, blend.ApplyToPool(pool))
+	assert.InDelta(t, 18.0, pool[0].ProjectedPoints, 1e-9, "provider 12 at weight 1, model 20 at weight 3")
+	assert.InDelta(t, 25.0, pool[1].ProjectedPoints, 1e-9)
+	assert.Equal(t, 0.0, pool[2].ProjectedPoints)
+	assert.Equal(t, 2.0, pool[2].FloorPoints)
+	assert.Equal(t, 8.0, pool[3].ProjectedPoints)
+
+	lineups := []types.GeneratedLineup{{
+		ProjectedPoints: 27,
+		Players: []types.LineupPlayer{
+			{ID: playerID, ProjectedPoints: 12},
+			{ID: uuid.New(), Name: "Injured Guy", ProjectedPoints: 15},
+		},
+	}}
+	assert.Equal(t, 2, blend.ApplyToLineups(lineups))
+	assert.InDelta(t, 18.0, lineups[0].ProjectedPoints, 1e-9)
+}
+
+func TestWeightsValidate(t *testing.T) {
+	source := uuid.New()
+	assert.NoError(t, types.ProjectionWeights{{Weight: 1}, {SourceID: &source, Weight: 2}}.Validate())
+	assert.NoError(t, types.ProjectionWeights{{SourceID: &source, PositionWeights: map[string]float64{"QB": 1}}}.Validate())
+	assert.Error(t, types.ProjectionWeights{}.Validate())
+	assert.Error(t, types.ProjectionWeights{{SourceID: &source, Weight: 1}, {SourceID: &source, Weight: 1}}.Validate())
+	assert.Error(t, types.ProjectionWeights{{SourceID: &source, Weight: -1}}.Validate())
+	assert.Error(t, types.ProjectionWeights{{SourceID: &source}}.Validate())
+}
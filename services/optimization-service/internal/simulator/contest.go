@@ -1,11 +1,16 @@
 package simulator
 
 import (
+	"fmt"
 	"math"
 	"math/rand"
 	"sort"
+	"strings"
+	"sync"
 
 	"github.com/google/uuid"
+	"github.com/stitts-dev/dfs-sim/services/optimization-service/internal/optimizer"
+	sharedsim "github.com/stitts-dev/dfs-sim/shared/pkg/simulator"
 	"github.com/stitts-dev/dfs-sim/shared/types"
 )
 
@@ -22,6 +27,13 @@ type ContestSimulator struct {
 	fieldSize       int
 	payoutStructure []PayoutTier
 	ownershipModel  *OwnershipModel
+	correlations    *optimizer.CorrelationMatrix
+
+	// The joint sampler is built for one player pool and rebuilt when the pool changes
+	samplerMu  sync.Mutex
+	samplerKey string
+	sampler    *sharedsim.CopulaSampler
+	samplerErr error
 }
 
 // NewContestSimulator creates a new contest simulator
@@ -43,15 +55,7 @@ func (cs *ContestSimulator) SimulateFullContest(userLineups []types.GeneratedLin
 	allLineups := append(userLineups, fieldLineups...)
 
 	// Generate player outcomes
-	playerDists := make(map[uuid.UUID]*PlayerDistribution)
-	for _, player := range players {
-		playerDists[player.ID] = NewPlayerDistribution(player)
-	}
-
-	playerOutcomes := make(map[uuid.UUID]float64)
-	for _, player := range players {
-		playerOutcomes[player.ID] = playerDists[player.ID].Sample(rng)
-	}
+	playerOutcomes := cs.generatePlayerOutcomes(players, rng)
 
 	// Calculate all lineup scores
 	lineupScores := make([]LineupScore, len(allLineups))
@@ -85,6 +89,77 @@ func (cs *ContestSimulator) SimulateFullContest(userLineups []types.GeneratedLin
 	}
 }
 
+// SetCorrelations enables joint sampling of player outcomes from the slate correlation matrix
+func (cs *ContestSimulator) SetCorrelations(correlations *optimizer.CorrelationMatrix) {
+	cs.samplerMu.Lock()
+	defer cs.samplerMu.Unlock()
+	cs.correlations = correlations
+	cs.sampler, cs.samplerErr, cs.samplerKey = nil, nil, ""
+}
+
+// generatePlayerOutcomes samples every player's score, jointly when correlations are set
+func (cs *ContestSimulator) generatePlayerOutcomes(players []types.Player, rng *rand.Rand) map[uuid.UUID]float64 {
+	playerOutcomes := make(map[uuid.UUID]float64, len(players))
+
+	if sampler := cs.samplerFor(players, rng); sampler != nil {
+		scores := make([]float64, len(players))
+		sampler.Sample(rng, scores)
+		for i, player := range players {
+			playerOutcomes[player.ID] = scores[i]
+		}
+		return playerOutcomes
+	}
+
+	for _, player := range players {
+		playerOutcomes[player.ID] = NewPlayerDistribution(player).Sample(rng)
+	}
+	return playerOutcomes
+}
+
+// samplerFor returns the joint sampler for the player pool, building it when the pool differs
+// from the one the cached sampler was built for. It is nil without correlations or when the
+// sampler cannot be built, and outcomes are then sampled independently.
+func (cs *ContestSimulator) samplerFor(players []types.Player, rng *rand.Rand) *sharedsim.CopulaSampler {
+	cs.samplerMu.Lock()
+	defer cs.samplerMu.Unlock()
+	if cs.correlations == nil {
+		return nil
+	}
+	key := playerPoolKey(players)
+	if cs.samplerKey != key {
+		cs.sampler, cs.samplerErr = cs.buildSampler(players, rng)
+		cs.samplerKey = key
+	}
+	if cs.samplerErr != nil || cs.sampler.Size() != len(players) {
+		return nil
+	}
+	return cs.sampler
+}
+
+// playerPoolKey identifies a player pool by its players, in order, and their projections
+func playerPoolKey(players []types.Player) string {
+	var key strings.Builder
+	for _, player := range players {
+		key.WriteString(player.ID.String())
+		fmt.Fprintf(&key, ":%g:%g:%g;", getFloatValueSim(player.ProjectedPoints), getFloatValueSim(player.FloorPoints), getFloatValueSim(player.CeilingPoints))
+	}
+	return key.String()
+}
+
+// buildSampler tabulates each player's PlayerDistribution as a copula marginal
+func (cs *ContestSimulator) buildSampler(players []types.Player, rng *rand.Rand) (*sharedsim.CopulaSampler, error) {
+	marginals := make([]sharedsim.Marginal, len(players))
+	for i, player := range players {
+		marginals[i] = NewPlayerDistribution(player).Marginal(rng)
+	}
+
+	correlation := sharedsim.BuildCorrelationMatrix(len(players), func(i, j int) float64 {
+		return cs.correlations.GetCorrelation(players[i].ID, players[j].ID)
+	})
+
+	return sharedsim.NewCopulaSampler(correlation, marginals)
+}
+
 func (cs *ContestSimulator) generateFieldLineups(players []types.Player, count int, rng *rand.Rand) []types.GeneratedLineup {
 	fieldLineups := make([]types.GeneratedLineup, 0, count)
 
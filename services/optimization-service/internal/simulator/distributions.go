@@ -4,9 +4,13 @@ import (
 	"math"
 	"math/rand"
 
+	sharedsim "github.com/stitts-dev/dfs-sim/shared/pkg/simulator"
 	"github.com/stitts-dev/dfs-sim/shared/types"
 )
 
+// marginalSampleSize is the number of draws used to tabulate a player's marginal quantile function
+const marginalSampleSize = 2000
+
 // Helper functions to safely extract values from pointers
 func getStringValueDist(ptr *string) string {
 	if ptr != nil {
@@ -217,6 +221,16 @@ func (pd *PlayerDistribution) Sample(rng *rand.Rand) float64 {
 	return math.Max(0, score)
 }
 
+// Marginal tabulates the player's outcome distribution, including injury risk, so it can be
+// sampled jointly with other players through a copula
+func (pd *PlayerDistribution) Marginal(rng *rand.Rand) *sharedsim.EmpiricalMarginal {
+	samples := make([]float64, marginalSampleSize)
+	for i := range samples {
+		samples[i] = pd.Sample(rng)
+	}
+	return sharedsim.NewEmpiricalMarginal(samples)
+}
+
 func (pd *PlayerDistribution) applyPositionAdjustments(baseScore float64, rng *rand.Rand) float64 {
 	score := baseScore
 
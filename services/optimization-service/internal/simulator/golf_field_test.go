@@ -0,0 +1,65 @@
+package simulator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stitts-dev/dfs-sim/services/optimization-service/internal/optimizer"
+	"github.com/stitts-dev/dfs-sim/services/sports-data-service/pkg/providers"
+	"github.com/stitts-dev/dfs-sim/shared/pkg/weather"
+)
+
+func TestGolfFieldSimulator_SimulatesCutAndFinishOdds(t *testing.T) {
+	predictions := make([]providers.PlayerPrediction, 120)
+	for i := range predictions {
+		predictions[i] = providers.PlayerPrediction{
+			PlayerID: 1000 + i,
+			// Best player first, falling from +2.5 to -1.5 strokes gained per round
+			SGApproach:       2.5 - 4*float64(i)/float64(len(predictions)-1),
+			VolatilityRating: 0.5,
+			// DataGolf's own odds are replaced by the simulation
+			MakeCutProbability: 0.01,
+		}
+	}
+
+	var _ optimizer.FieldSimulator = (*GolfFieldSimulator)(nil)
+	odds, err := NewGolfFieldSimulator(DefaultGolfTournamentConfig(), 1000, 17).SimulateField(predictions, nil)
+	require.NoError(t, err)
+	require.Len(t, odds, len(predictions))
+
+	best, worst := odds[1000], odds[1119]
+	assert.Greater(t, best.MakeCut, 0.8)
+	assert.Less(t, worst.MakeCut, 0.35)
+	assert.Greater(t, best.Win, worst.Win)
+	assert.GreaterOrEqual(t, best.Top5, best.Win)
+	assert.GreaterOrEqual(t, best.Top10, best.Top5)
+	assert.GreaterOrEqual(t, best.Top20, best.Top10)
+
+	wins := 0.0
+	for _, player := range odds {
+		wins += player.Win
+	}
+	assert.InDelta(t, 1.0, wins, 1e-9, "exactly one winner per tournament")
+
+	_, err = NewGolfFieldSimulator(DefaultGolfTournamentConfig(), 10, 1).SimulateField(nil, nil)
+	assert.Error(t, err)
+}
+
+func TestGolfFieldSimulator_TeesOffInRealWaves(t *testing.T) {
+	predictions := []providers.PlayerPrediction{{PlayerID: 1}, {PlayerID: 2}, {PlayerID: 3}, {PlayerID: 4}, {PlayerID: 5}}
+	waves := map[int]weather.Wave{
+		1: weather.WaveLateEarly,
+		2: weather.WaveLateEarly,
+		3: weather.WaveEarlyLate,
+	}
+
+	entrants := fieldEntrants(predictions, waves)
+	assert.Equal(t, GolfWaveLate, entrants[0].Wave)
+	assert.Equal(t, GolfWaveLate, entrants[1].Wave)
+	assert.Equal(t, GolfWaveEarly, entrants[2].Wave)
+	// Players without a tee time split between the waves
+	assert.Equal(t, GolfWaveEarly, entrants[3].Wave)
+	assert.Equal(t, GolfWaveLate, entrants[4].Wave)
+}
@@ -0,0 +1,122 @@
+package simulator
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/stitts-dev/dfs-sim/shared/pkg/scoring"
+	"github.com/stitts-dev/dfs-sim/shared/types"
+)
+
+// GolfWindowPlayerProjection is a player's fantasy points over one contest format's scoring window
+type GolfWindowPlayerProjection struct {
+	PlayerID        string  `json:"player_id"`
+	ProjectedPoints float64 `json:"projected_points"`
+	StdDev          float64 `json:"std_dev"`
+	Floor           float64 `json:"floor"`   // 10th percentile
+	Ceiling         float64 `json:"ceiling"` // 90th percentile
+	// PlaysWindow is the probability the player is still in the field for every round the window scores
+	PlaysWindow float64 `json:"plays_window"`
+}
+
+// GolfWindowProjection projects a showdown, weekend or classic slate from the live tournament state
+type GolfWindowProjection struct {
+	Window      scoring.GolfScoringWindow    `json:"window"`
+	Iterations  int                          `json:"iterations"`
+	Players     []GolfWindowPlayerProjection `json:"players"`
+	ProjectedAt time.Time                    `json:"projected_at"`
+}
+
+// Player returns the projection for a player ID
+func (p *GolfWindowProjection) Player(playerID string) (*GolfWindowPlayerProjection, bool) {
+	for i := range p.Players {
+		if p.Players[i].PlayerID == playerID {
+			return &p.Players[i], true
+		}
+	}
+	return nil, false
+}
+
+// ProjectWindow simulates the rest of the tournament and scores only the rounds in a contest
+// format's window. Holes already played inside the window count towards the projection, so a
+// showdown can be re-projected mid-round; with nothing recorded it is a pre-tournament projection.
+func (l *LiveGolfTournament) ProjectWindow(window scoring.GolfScoringWindow, iterations int, rng *rand.Rand) *GolfWindowProjection {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	n := len(l.sim.entrants)
+	projection := &GolfWindowProjection{
+		Window:      window,
+		Iterations:  iterations,
+		Players:     make([]GolfWindowPlayerProjection, n),
+		ProjectedAt: time.Now(),
+	}
+	for i, entrant := range l.sim.entrants {
+		projection.Players[i].PlayerID = entrant.PlayerID
+	}
+	if iterations <= 0 {
+		return projection
+	}
+
+	recorded := l.recordedHoles()
+	samples := make([][]float64, n)
+	for iter := 0; iter < iterations; iter++ {
+		outcome, cards := l.simulateRemaining(recorded, rng)
+		for i := range outcome.Players {
+			player := &outcome.Players[i]
+			position := player.Position
+			if !player.MadeCut {
+				position = 0
+			}
+			points := l.rules.ScoreWindow(scoring.GolfScoringInput{
+				Rounds:   cards[i],
+				Position: position,
+				Final:    true,
+			}, window).Total
+			samples[i] = append(samples[i], points)
+
+			if len(cards[i]) >= window.LastRound && len(cards[i][window.LastRound-1]) == holesPerRound {
+				projection.Players[i].PlaysWindow++
+			}
+		}
+	}
+
+	count := float64(iterations)
+	for i := range projection.Players {
+		stats := &projection.Players[i]
+		sorted := samples[i]
+		sort.Float64s(sorted)
+
+		mean := 0.0
+		for _, points := range sorted {
+			mean += points
+		}
+		mean /= count
+		variance := 0.0
+		for _, points := range sorted {
+			variance += (points - mean) * (points - mean)
+		}
+
+		stats.ProjectedPoints = mean
+		stats.StdDev = math.Sqrt(variance / count)
+		stats.Floor = calculatePercentile(sorted, 10)
+		stats.Ceiling = calculatePercentile(sorted, 90)
+		stats.PlaysWindow /= count
+	}
+
+	return projection
+}
+
+// recordedHoles copies every player's recorded holes by round; callers hold the read lock
+func (l *LiveGolfTournament) recordedHoles() [][][]types.HoleScore {
+	recorded := make([][][]types.HoleScore, len(l.sim.entrants))
+	for i := range l.sim.entrants {
+		recorded[i] = make([][]types.HoleScore, l.sim.config.Rounds)
+		for r := 1; r <= l.sim.config.Rounds; r++ {
+			recorded[i][r-1] = l.roundHoles(i, r)
+		}
+	}
+	return recorded
+}
@@ -0,0 +1,45 @@
+package simulator
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stitts-dev/dfs-sim/shared/pkg/scoring"
+)
+
+func TestLiveGolfTournament_ProjectWindow(t *testing.T) {
+	field := testGolfField(60)
+	config := DefaultGolfTournamentConfig()
+	config.CutRule = GolfCutRule{AfterRound: 2, TopN: 30, IncludeTies: true}
+	live, err := NewLiveGolfTournament(field, config, livePars, scoring.DraftKingsGolf)
+	require.NoError(t, err)
+
+	// The best player has finished round one: three birdies and fifteen pars
+	best := field[0].PlayerID
+	playRound(t, live, best, 1, map[int]int{2: -1, 5: -1, 11: -1})
+
+	roundOne, err := scoring.GolfWindowForFormat(scoring.GolfFormatShowdown, 1)
+	require.NoError(t, err)
+	showdown := live.ProjectWindow(roundOne, 200, rand.New(rand.NewSource(5)))
+	finished, ok := showdown.Player(best)
+	require.True(t, ok)
+	assert.InDelta(t, 3*3+15*0.5+3, finished.ProjectedPoints, 1e-9, "a finished round scores exactly, bogey-free bonus included")
+	assert.Zero(t, finished.StdDev)
+	assert.Equal(t, 1.0, finished.PlaysWindow)
+
+	weekendWindow, err := scoring.GolfWindowForFormat(scoring.GolfFormatWeekend, 0)
+	require.NoError(t, err)
+	weekend := live.ProjectWindow(weekendWindow, 200, rand.New(rand.NewSource(5)))
+	classic := live.Project(200, rand.New(rand.NewSource(5)))
+
+	worst := field[len(field)-1].PlayerID
+	weekendWorst, _ := weekend.Player(worst)
+	classicWorst, _ := classic.Player(worst)
+	assert.Less(t, weekendWorst.PlaysWindow, 1.0, "weak players miss the cut and score nothing on the weekend")
+	assert.InDelta(t, classicWorst.CutProbability, weekendWorst.PlaysWindow, 0.15)
+	assert.Less(t, weekendWorst.ProjectedPoints, classicWorst.ProjectedPoints)
+	assert.LessOrEqual(t, weekendWorst.Floor, weekendWorst.Ceiling)
+}
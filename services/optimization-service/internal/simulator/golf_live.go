@@ -0,0 +1,530 @@
+package simulator
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/stitts-dev/dfs-sim/shared/pkg/scoring"
+	"github.com/stitts-dev/dfs-sim/shared/types"
+)
+
+const (
+	holesPerRound = 18
+	// tourHoleVariance is the per-round variance the hole distribution already produces on its own,
+	// so only the rest of a player's round-to-round variance is added as a form shock
+	tourHoleVariance = 9.3
+)
+
+// Live player statuses
+const (
+	GolfStatusActive    = "active"
+	GolfStatusCut       = "cut"
+	GolfStatusWithdrawn = "wd"
+)
+
+// GolfHoleUpdate is one completed hole from a live scoring feed. A repeated update for the same
+// player, round and hole replaces the earlier score, which is how feeds publish corrections.
+type GolfHoleUpdate struct {
+	PlayerID  string          `json:"player_id"`
+	Round     int             `json:"round"`
+	Hole      types.HoleScore `json:"hole"`
+	Withdrawn bool            `json:"withdrawn,omitempty"`
+	Timestamp time.Time       `json:"timestamp"`
+}
+
+// GolfLiveStanding is a player's place on the current leaderboard
+type GolfLiveStanding struct {
+	PlayerID      string  `json:"player_id"`
+	Position      int     `json:"position"`
+	Tied          bool    `json:"tied"`
+	ToPar         int     `json:"to_par"`
+	Today         int     `json:"today"`
+	Round         int     `json:"round"`
+	Thru          int     `json:"thru"`
+	HolesPlayed   int     `json:"holes_played"`
+	FantasyPoints float64 `json:"fantasy_points"`
+	Status        string  `json:"status"`
+}
+
+// GolfLivePlayerProjection is a player's projected finish given the holes already played
+type GolfLivePlayerProjection struct {
+	PlayerID        string  `json:"player_id"`
+	CurrentPoints   float64 `json:"current_points"`
+	ProjectedPoints float64 `json:"projected_points"`
+	ProjectedToPar  float64 `json:"projected_to_par"`
+	AverageFinish   float64 `json:"average_finish"`
+	WinProbability  float64 `json:"win_probability"`
+	Top10           float64 `json:"top_10_probability"`
+	CutProbability  float64 `json:"cut_probability"`
+}
+
+// GolfLiveProjection is the result of re-simulating the rest of a tournament
+type GolfLiveProjection struct {
+	Iterations int                        `json:"iterations"`
+	Players    []GolfLivePlayerProjection `json:"players"`
+	// ProjectedCutLine is the average simulated cut line to par, nil when there is no cut
+	ProjectedCutLine *float64    `json:"projected_cut_line,omitempty"`
+	CutLineHistogram map[int]int `json:"cut_line_histogram,omitempty"`
+	HolesRemaining   int         `json:"holes_remaining"`
+	ProjectedAt      time.Time   `json:"projected_at"`
+}
+
+// Player returns the projection for a player ID
+func (p *GolfLiveProjection) Player(playerID string) (*GolfLivePlayerProjection, bool) {
+	for i := range p.Players {
+		if p.Players[i].PlayerID == playerID {
+			return &p.Players[i], true
+		}
+	}
+	return nil, false
+}
+
+// LiveGolfTournament holds the scorecards of a tournament in progress and projects the finish by
+// simulating each player's remaining holes
+type LiveGolfTournament struct {
+	sim   *GolfTournamentSimulator
+	pars  []int
+	rules scoring.GolfScoringRules
+
+	mu        sync.RWMutex
+	cards     [][]map[int]types.HoleScore // player -> round -> hole number -> score
+	withdrawn []bool
+	updatedAt time.Time
+}
+
+// NewLiveGolfTournament creates live state for a field on a course. The course par in the config is
+// replaced by the sum of the hole pars.
+func NewLiveGolfTournament(entrants []GolfEntrant, config GolfTournamentConfig, pars []int, rules scoring.GolfScoringRules) (*LiveGolfTournament, error) {
+	if len(pars) != holesPerRound {
+		return nil, fmt.Errorf("course needs %d hole pars, got %d", holesPerRound, len(pars))
+	}
+	config.Par = 0
+	for _, par := range pars {
+		config.Par += par
+	}
+
+	sim, err := NewGolfTournamentSimulator(entrants, config)
+	if err != nil {
+		return nil, err
+	}
+
+	cards := make([][]map[int]types.HoleScore, len(entrants))
+	for i := range cards {
+		cards[i] = make([]map[int]types.HoleScore, config.Rounds)
+		for r := range cards[i] {
+			cards[i][r] = make(map[int]types.HoleScore)
+		}
+	}
+
+	return &LiveGolfTournament{
+		sim:       sim,
+		pars:      pars,
+		rules:     rules,
+		cards:     cards,
+		withdrawn: make([]bool, len(entrants)),
+	}, nil
+}
+
+// Apply records a hole score or withdrawal
+func (l *LiveGolfTournament) Apply(update GolfHoleUpdate) error {
+	i, ok := l.sim.index[update.PlayerID]
+	if !ok {
+		return fmt.Errorf("player %s is not in the field", update.PlayerID)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if update.Withdrawn {
+		l.withdrawn[i] = true
+		l.touch(update.Timestamp)
+		return nil
+	}
+
+	if update.Round < 1 || update.Round > l.sim.config.Rounds {
+		return fmt.Errorf("round %d is outside a %d round event", update.Round, l.sim.config.Rounds)
+	}
+	hole := update.Hole
+	if hole.Hole < 1 || hole.Hole > holesPerRound {
+		return fmt.Errorf("hole %d is not on the course", hole.Hole)
+	}
+	if hole.Score < 1 {
+		return fmt.Errorf("player %s has an invalid score of %d on hole %d", update.PlayerID, hole.Score, hole.Hole)
+	}
+	if hole.Par == 0 {
+		hole.Par = l.pars[hole.Hole-1]
+	}
+
+	l.cards[i][update.Round-1][hole.Hole] = hole
+	l.touch(update.Timestamp)
+	return nil
+}
+
+func (l *LiveGolfTournament) touch(at time.Time) {
+	if at.IsZero() {
+		at = time.Now()
+	}
+	if at.After(l.updatedAt) {
+		l.updatedAt = at
+	}
+}
+
+// UpdatedAt is the time of the latest applied update
+func (l *LiveGolfTournament) UpdatedAt() time.Time {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.updatedAt
+}
+
+// CurrentRound is the latest round anyone has started, or 1 before play begins
+func (l *LiveGolfTournament) CurrentRound() int {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.currentRound()
+}
+
+func (l *LiveGolfTournament) currentRound() int {
+	current := 1
+	for _, rounds := range l.cards {
+		for r := len(rounds) - 1; r >= 0; r-- {
+			if len(rounds[r]) > 0 {
+				if r+1 > current {
+					current = r + 1
+				}
+				break
+			}
+		}
+	}
+	return current
+}
+
+// roundHoles returns a player's holes for a round ordered by hole number
+func (l *LiveGolfTournament) roundHoles(i, round int) []types.HoleScore {
+	card := l.cards[i][round-1]
+	holes := make([]types.HoleScore, 0, len(card))
+	for _, hole := range card {
+		holes = append(holes, hole)
+	}
+	sort.Slice(holes, func(a, b int) bool { return holes[a].Hole < holes[b].Hole })
+	return holes
+}
+
+// RecentHoles returns up to n of a player's most recently played holes, oldest first
+func (l *LiveGolfTournament) RecentHoles(playerID string, n int) []types.HoleScore {
+	i, ok := l.sim.index[playerID]
+	if !ok || n <= 0 {
+		return nil
+	}
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	var recent []types.HoleScore
+	for round := len(l.cards[i]); round >= 1 && len(recent) < n; round-- {
+		holes := l.roundHoles(i, round)
+		for h := len(holes) - 1; h >= 0 && len(recent) < n; h-- {
+			recent = append(recent, holes[h])
+		}
+	}
+	for a, b := 0, len(recent)-1; a < b; a, b = a+1, b-1 {
+		recent[a], recent[b] = recent[b], recent[a]
+	}
+	return recent
+}
+
+// madeCut returns which players survived the cut once every active player has finished the cut
+// round, or nil while the cut is still open
+func (l *LiveGolfTournament) madeCut() []bool {
+	rule := l.sim.config.CutRule
+	if !rule.Active() {
+		return nil
+	}
+
+	outcome := &GolfTournamentOutcome{Players: make([]GolfPlayerOutcome, len(l.cards)), index: l.sim.index}
+	active := make([]int, 0, len(l.cards))
+	for i := range l.cards {
+		outcome.Players[i].MadeCut = !l.withdrawn[i]
+		if l.withdrawn[i] {
+			continue
+		}
+		for r := 1; r <= rule.AfterRound; r++ {
+			if len(l.cards[i][r-1]) < holesPerRound {
+				return nil
+			}
+			for _, hole := range l.cards[i][r-1] {
+				outcome.Players[i].TotalStrokes += hole.Score
+			}
+		}
+		active = append(active, i)
+	}
+
+	// Ties at the line are settled the same way every time so the leaderboard doesn't flicker
+	l.sim.applyCut(active, outcome, rand.New(rand.NewSource(1)))
+	made := make([]bool, len(l.cards))
+	for i := range outcome.Players {
+		made[i] = outcome.Players[i].MadeCut
+	}
+	return made
+}
+
+// Standings returns the current leaderboard, best score first. Fantasy points include hole and
+// bonus points earned so far but no placement points until the event is final.
+func (l *LiveGolfTournament) Standings() []GolfLiveStanding {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	current := l.currentRound()
+	made := l.madeCut()
+	standings := make([]GolfLiveStanding, len(l.cards))
+	for i, entrant := range l.sim.entrants {
+		standing := GolfLiveStanding{PlayerID: entrant.PlayerID, Round: current, Status: GolfStatusActive}
+		rounds := make([][]types.HoleScore, 0, current)
+		for r := 1; r <= l.sim.config.Rounds; r++ {
+			holes := l.roundHoles(i, r)
+			if len(holes) == 0 && r > current {
+				continue
+			}
+			rounds = append(rounds, holes)
+			for _, hole := range holes {
+				standing.ToPar += hole.Score - hole.Par
+				standing.HolesPlayed++
+				if r == current {
+					standing.Today += hole.Score - hole.Par
+					standing.Thru++
+				}
+			}
+		}
+		standing.FantasyPoints = l.rules.ScoreTournament(scoring.GolfScoringInput{Rounds: rounds}).Total
+
+		switch {
+		case l.withdrawn[i]:
+			standing.Status = GolfStatusWithdrawn
+		case made != nil && !made[i]:
+			standing.Status = GolfStatusCut
+		}
+		standings[i] = standing
+	}
+
+	// Active players rank ahead of cuts and withdrawals; ties share a position
+	rank := func(s GolfLiveStanding) int {
+		switch s.Status {
+		case GolfStatusCut:
+			return 1
+		case GolfStatusWithdrawn:
+			return 2
+		default:
+			return 0
+		}
+	}
+	sort.SliceStable(standings, func(a, b int) bool {
+		if rank(standings[a]) != rank(standings[b]) {
+			return rank(standings[a]) < rank(standings[b])
+		}
+		return standings[a].ToPar < standings[b].ToPar
+	})
+	for k := range standings {
+		standings[k].Position = k + 1
+		if k > 0 && rank(standings[k]) == rank(standings[k-1]) && standings[k].ToPar == standings[k-1].ToPar {
+			standings[k].Position = standings[k-1].Position
+			standings[k].Tied = true
+			standings[k-1].Tied = true
+		}
+	}
+
+	return standings
+}
+
+// Project simulates the remaining holes for every player still in the event, applies the cut when
+// its round completes, and aggregates finishing positions and final fantasy points
+func (l *LiveGolfTournament) Project(iterations int, rng *rand.Rand) *GolfLiveProjection {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	config := l.sim.config
+	n := len(l.sim.entrants)
+	projection := &GolfLiveProjection{
+		Iterations:       iterations,
+		Players:          make([]GolfLivePlayerProjection, n),
+		CutLineHistogram: make(map[int]int),
+		ProjectedAt:      time.Now(),
+	}
+
+	recorded := make([][][]types.HoleScore, n)
+	for i, entrant := range l.sim.entrants {
+		recorded[i] = make([][]types.HoleScore, config.Rounds)
+		rounds := make([][]types.HoleScore, 0, config.Rounds)
+		for r := 1; r <= config.Rounds; r++ {
+			recorded[i][r-1] = l.roundHoles(i, r)
+			if len(recorded[i][r-1]) > 0 {
+				rounds = append(rounds, recorded[i][r-1])
+			}
+			if !l.withdrawn[i] {
+				projection.HolesRemaining += holesPerRound - len(recorded[i][r-1])
+			}
+		}
+		projection.Players[i] = GolfLivePlayerProjection{
+			PlayerID:      entrant.PlayerID,
+			CurrentPoints: l.rules.ScoreTournament(scoring.GolfScoringInput{Rounds: rounds}).Total,
+		}
+	}
+	if iterations <= 0 {
+		return projection
+	}
+
+	cutLineTotal := 0
+	for iter := 0; iter < iterations; iter++ {
+		outcome, cards := l.simulateRemaining(recorded, rng)
+		if outcome.CutLine != nil {
+			cutLineTotal += *outcome.CutLine
+			projection.CutLineHistogram[*outcome.CutLine]++
+		}
+
+		for i := range outcome.Players {
+			player := &outcome.Players[i]
+			stats := &projection.Players[i]
+
+			position := player.Position
+			if !player.MadeCut {
+				position = 0
+			}
+			stats.ProjectedPoints += l.rules.ScoreTournament(scoring.GolfScoringInput{
+				Rounds:   cards[i],
+				Position: position,
+				Final:    true,
+			}).Total
+			stats.ProjectedToPar += float64(player.ToPar)
+			stats.AverageFinish += float64(player.Position)
+			if player.MadeCut {
+				stats.CutProbability++
+			}
+			if player.Position == 1 {
+				stats.WinProbability++
+			}
+			if player.Position <= 10 {
+				stats.Top10++
+			}
+		}
+	}
+
+	count := float64(iterations)
+	for i := range projection.Players {
+		stats := &projection.Players[i]
+		stats.ProjectedPoints /= count
+		stats.ProjectedToPar /= count
+		stats.AverageFinish /= count
+		stats.CutProbability /= count
+		stats.WinProbability /= count
+		stats.Top10 /= count
+	}
+	if len(projection.CutLineHistogram) > 0 {
+		average := float64(cutLineTotal) / count
+		projection.ProjectedCutLine = &average
+	}
+
+	return projection
+}
+
+// simulateRemaining plays out one tournament from the recorded scorecards
+func (l *LiveGolfTournament) simulateRemaining(recorded [][][]types.HoleScore, rng *rand.Rand) (*GolfTournamentOutcome, [][][]types.HoleScore) {
+	config := l.sim.config
+	n := len(l.sim.entrants)
+	outcome := &GolfTournamentOutcome{
+		Players: make([]GolfPlayerOutcome, n),
+		index:   l.sim.index,
+	}
+	cards := make([][][]types.HoleScore, n)
+
+	active := make([]int, 0, n)
+	for i, entrant := range l.sim.entrants {
+		outcome.Players[i] = GolfPlayerOutcome{PlayerID: entrant.PlayerID, MadeCut: !l.withdrawn[i]}
+		cards[i] = make([][]types.HoleScore, 0, config.Rounds)
+		if l.withdrawn[i] {
+			// A withdrawal keeps the holes already played and ranks behind the cut
+			for _, holes := range recorded[i] {
+				if len(holes) > 0 {
+					cards[i] = append(cards[i], holes)
+					for _, hole := range holes {
+						outcome.Players[i].TotalStrokes += hole.Score
+						outcome.Players[i].ToPar += hole.Score - hole.Par
+					}
+				}
+			}
+			continue
+		}
+		active = append(active, i)
+	}
+
+	for round := 1; round <= config.Rounds; round++ {
+		dayShock := rng.NormFloat64() * config.DayShockStdDev
+		for _, i := range active {
+			entrant := l.sim.entrants[i]
+			holes := recorded[i][round-1]
+
+			if len(holes) < holesPerRound {
+				played := make(map[int]bool, len(holes))
+				for _, hole := range holes {
+					played[hole.Hole] = true
+				}
+				var remaining, numbers []int
+				for h := 1; h <= holesPerRound; h++ {
+					if !played[h] {
+						remaining = append(remaining, l.pars[h-1])
+						numbers = append(numbers, h)
+					}
+				}
+
+				form := math.Sqrt(math.Max(0, entrant.RoundStdDev*entrant.RoundStdDev-tourHoleVariance)) * rng.NormFloat64()
+				expected := config.FieldScoringAverage - float64(config.Par) - entrant.StrokesGained + dayShock + form
+				sampled := scoring.SampleRound(rng, remaining, scoring.TourHoleDistribution(expected))
+				for k := range sampled {
+					sampled[k].Hole = numbers[k]
+				}
+
+				full := make([]types.HoleScore, 0, holesPerRound)
+				full = append(full, holes...)
+				full = append(full, sampled...)
+				holes = full
+			}
+
+			player := &outcome.Players[i]
+			strokes := 0
+			for _, hole := range holes {
+				strokes += hole.Score
+			}
+			player.RoundScores = append(player.RoundScores, strokes)
+			player.TotalStrokes += strokes
+			cards[i] = append(cards[i], holes)
+		}
+
+		if config.CutRule.Active() && round == config.CutRule.AfterRound && len(active) > 0 {
+			active = l.sim.applyCut(active, outcome, rng)
+			cutLine := outcome.Players[active[len(active)-1]].TotalStrokes - config.Par*round
+			outcome.CutLine = &cutLine
+		}
+	}
+
+	for _, i := range active {
+		player := &outcome.Players[i]
+		player.ToPar = player.TotalStrokes - config.Par*len(player.RoundScores)
+	}
+	for i := range outcome.Players {
+		player := &outcome.Players[i]
+		if !player.MadeCut && !l.withdrawn[i] {
+			player.ToPar = player.TotalStrokes - config.Par*len(player.RoundScores)
+		}
+	}
+	l.sim.assignPositions(outcome, rng)
+
+	// Withdrawals finish last whatever they shot before leaving
+	for i := range outcome.Players {
+		if l.withdrawn[i] {
+			outcome.Players[i].Position = n
+		}
+	}
+
+	return outcome, cards
+}
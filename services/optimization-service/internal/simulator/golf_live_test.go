@@ -0,0 +1,126 @@
+package simulator
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stitts-dev/dfs-sim/shared/pkg/scoring"
+	"github.com/stitts-dev/dfs-sim/shared/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var livePars = []int{4, 4, 3, 5, 4, 4, 3, 4, 5, 4, 4, 3, 5, 4, 4, 3, 4, 5}
+
+// playRound records a full round for a player with every hole at par plus the given offsets
+func playRound(t *testing.T, live *LiveGolfTournament, playerID string, round int, diffs map[int]int) {
+	t.Helper()
+	for h := 1; h <= 18; h++ {
+		score := livePars[h-1] + diffs[h]
+		require.NoError(t, live.Apply(GolfHoleUpdate{
+			PlayerID: playerID,
+			Round:    round,
+			Hole:     types.HoleScore{Hole: h, Score: score},
+		}))
+	}
+}
+
+func TestLiveGolfTournament_StandingsAndCorrections(t *testing.T) {
+	field := testGolfField(3)
+	live, err := NewLiveGolfTournament(field, DefaultGolfTournamentConfig(), livePars, scoring.DraftKingsGolf)
+	require.NoError(t, err)
+
+	// p000 birdies 1 and 2, p001 bogeys 1, p002 matches p000 through two holes
+	require.NoError(t, live.Apply(GolfHoleUpdate{PlayerID: "p000", Round: 1, Hole: types.HoleScore{Hole: 1, Score: 3}}))
+	require.NoError(t, live.Apply(GolfHoleUpdate{PlayerID: "p000", Round: 1, Hole: types.HoleScore{Hole: 2, Score: 3}}))
+	require.NoError(t, live.Apply(GolfHoleUpdate{PlayerID: "p001", Round: 1, Hole: types.HoleScore{Hole: 1, Score: 5}}))
+	require.NoError(t, live.Apply(GolfHoleUpdate{PlayerID: "p002", Round: 1, Hole: types.HoleScore{Hole: 1, Score: 3}}))
+	require.NoError(t, live.Apply(GolfHoleUpdate{PlayerID: "p002", Round: 1, Hole: types.HoleScore{Hole: 2, Score: 3}}))
+
+	standings := live.Standings()
+	require.Len(t, standings, 3)
+	assert.Equal(t, -2, standings[0].ToPar)
+	assert.Equal(t, 1, standings[0].Position)
+	assert.Equal(t, 1, standings[1].Position)
+	assert.True(t, standings[0].Tied)
+	assert.Equal(t, "p001", standings[2].PlayerID)
+	assert.Equal(t, 3, standings[2].Position)
+	assert.Equal(t, 2, standings[0].Thru)
+	assert.Equal(t, 6.0, standings[0].FantasyPoints)
+
+	// A correction replaces the hole rather than adding to it
+	require.NoError(t, live.Apply(GolfHoleUpdate{PlayerID: "p002", Round: 1, Hole: types.HoleScore{Hole: 2, Score: 4}}))
+	standings = live.Standings()
+	assert.Equal(t, "p000", standings[0].PlayerID)
+	assert.False(t, standings[0].Tied)
+
+	assert.Error(t, live.Apply(GolfHoleUpdate{PlayerID: "nobody", Round: 1, Hole: types.HoleScore{Hole: 1, Score: 4}}))
+	assert.Error(t, live.Apply(GolfHoleUpdate{PlayerID: "p000", Round: 5, Hole: types.HoleScore{Hole: 1, Score: 4}}))
+}
+
+func TestLiveGolfTournament_CutAppliesWhenRoundTwoCompletes(t *testing.T) {
+	field := testGolfField(4)
+	config := DefaultGolfTournamentConfig()
+	config.CutRule = GolfCutRule{AfterRound: 2, TopN: 2, IncludeTies: true}
+	live, err := NewLiveGolfTournament(field, config, livePars, scoring.DraftKingsGolf)
+	require.NoError(t, err)
+
+	offsets := map[string]map[int]int{
+		"p000": {1: -1, 2: -1},
+		"p001": {1: -1},
+		"p002": {1: -1},
+		"p003": {1: 1},
+	}
+	for round := 1; round <= 2; round++ {
+		for id, diffs := range offsets {
+			if round == 2 && id == "p003" {
+				continue
+			}
+			playRound(t, live, id, round, diffs)
+		}
+	}
+
+	// p003 hasn't finished round two, so nobody is cut yet
+	for _, standing := range live.Standings() {
+		assert.Equal(t, GolfStatusActive, standing.Status)
+	}
+
+	playRound(t, live, "p003", 2, offsets["p003"])
+	status := make(map[string]string)
+	for _, standing := range live.Standings() {
+		status[standing.PlayerID] = standing.Status
+	}
+	// p001 and p002 tie for second at -2 and both make it
+	assert.Equal(t, map[string]string{"p000": "active", "p001": "active", "p002": "active", "p003": "cut"}, status)
+}
+
+func TestLiveGolfTournament_ProjectionRespectsPlayedHoles(t *testing.T) {
+	field := testGolfField(60)
+	live, err := NewLiveGolfTournament(field, DefaultGolfTournamentConfig(), livePars, scoring.DraftKingsGolf)
+	require.NoError(t, err)
+
+	// The worst player in the field opens with 63 and 64
+	worst := field[len(field)-1].PlayerID
+	playRound(t, live, worst, 1, map[int]int{1: -1, 2: -1, 4: -2, 6: -1, 9: -1, 13: -1, 15: -1, 18: -1})
+	playRound(t, live, worst, 2, map[int]int{2: -1, 4: -1, 7: -1, 9: -1, 12: -1, 14: -1, 17: -1, 18: -1})
+	require.NoError(t, live.Apply(GolfHoleUpdate{PlayerID: field[1].PlayerID, Withdrawn: true}))
+
+	projection := live.Project(300, rand.New(rand.NewSource(3)))
+	require.NotNil(t, projection.ProjectedCutLine)
+
+	leader, ok := projection.Player(worst)
+	require.True(t, ok)
+	assert.Equal(t, 1.0, leader.CutProbability, "a 36-hole total of -17 can't miss the cut")
+	peer, _ := projection.Player(field[len(field)-2].PlayerID)
+	assert.Less(t, leader.AverageFinish, 10.0)
+	assert.Greater(t, leader.WinProbability, 10*peer.WinProbability)
+	assert.Greater(t, leader.ProjectedPoints, leader.CurrentPoints)
+
+	withdrawn, ok := projection.Player(field[1].PlayerID)
+	require.True(t, ok)
+	assert.Equal(t, 0.0, withdrawn.CutProbability)
+	assert.Equal(t, float64(len(field)), withdrawn.AverageFinish)
+
+	// Four rounds for 58 players, two for the leader and none for the withdrawal remain
+	assert.Equal(t, 58*72+36, projection.HolesRemaining)
+}
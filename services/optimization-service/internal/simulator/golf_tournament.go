@@ -0,0 +1,417 @@
+package simulator
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// Tee time waves for the first two rounds; players swap waves for round two
+const (
+	GolfWaveEarly = 0
+	GolfWaveLate  = 1
+)
+
+// GolfCutRule is a tour's cut: after AfterRound, the top N (and ties) play the remaining rounds.
+// Some events also keep anyone within a number of strokes of the lead.
+type GolfCutRule struct {
+	AfterRound          int  `json:"after_round"`
+	TopN                int  `json:"top_n"`
+	IncludeTies         bool `json:"include_ties"`
+	WithinStrokesOfLead int  `json:"within_strokes_of_lead"`
+}
+
+// Cut rules used by the tours and majors
+var (
+	PGATourCutRule       = GolfCutRule{AfterRound: 2, TopN: 65, IncludeTies: true}
+	MastersCutRule       = GolfCutRule{AfterRound: 2, TopN: 50, IncludeTies: true}
+	USOpenCutRule        = GolfCutRule{AfterRound: 2, TopN: 60, IncludeTies: true}
+	OpenChampionshipRule = GolfCutRule{AfterRound: 2, TopN: 70, IncludeTies: true}
+	NoCutRule            = GolfCutRule{}
+)
+
+// Active reports whether the rule removes anyone from the field
+func (r GolfCutRule) Active() bool {
+	return r.AfterRound > 0 && r.TopN > 0
+}
+
+// GolfEntrant is one player in the simulated field
+type GolfEntrant struct {
+	PlayerID string `json:"player_id"`
+	// StrokesGained is the expected strokes gained per round against the field
+	StrokesGained float64 `json:"strokes_gained"`
+	// RoundStdDev is the standard deviation of a single round in strokes
+	RoundStdDev float64 `json:"round_std_dev"`
+	// Wave is the round one tee time wave (GolfWaveEarly or GolfWaveLate)
+	Wave int `json:"wave"`
+}
+
+// GolfTournamentConfig controls the shape of a simulated tournament
+type GolfTournamentConfig struct {
+	Rounds              int         `json:"rounds"`
+	Par                 int         `json:"par"`
+	FieldScoringAverage float64     `json:"field_scoring_average"`
+	CutRule             GolfCutRule `json:"cut_rule"`
+	// DayShockStdDev moves every score in a round (setup, overall conditions)
+	DayShockStdDev float64 `json:"day_shock_std_dev"`
+	// WaveShockStdDev is shared by every player in the same wave, like the wind picking up after lunch
+	WaveShockStdDev float64 `json:"wave_shock_std_dev"`
+	// WaveAdjustments adds expected strokes per round to the [early, late] wave, e.g. from a forecast
+	WaveAdjustments [][2]float64 `json:"wave_adjustments,omitempty"`
+}
+
+// DefaultGolfTournamentConfig returns a standard 72-hole PGA Tour event
+func DefaultGolfTournamentConfig() GolfTournamentConfig {
+	return GolfTournamentConfig{
+		Rounds:              4,
+		Par:                 72,
+		FieldScoringAverage: 71.2,
+		CutRule:             PGATourCutRule,
+		DayShockStdDev:      1.0,
+		WaveShockStdDev:     0.6,
+	}
+}
+
+// GolfPlayerOutcome is one player's result in a simulated tournament
+type GolfPlayerOutcome struct {
+	PlayerID     string `json:"player_id"`
+	RoundScores  []int  `json:"round_scores"`
+	TotalStrokes int    `json:"total_strokes"`
+	ToPar        int    `json:"to_par"`
+	MadeCut      bool   `json:"made_cut"`
+	// Position is the finishing position, shared by tied players; missed cuts rank after the weekend field
+	Position int  `json:"position"`
+	Tied     bool `json:"tied"`
+	// WaveStrokes is the total of the wave shocks the player was exposed to
+	WaveStrokes float64 `json:"wave_strokes"`
+}
+
+// GolfTournamentOutcome is one simulated tournament for the whole field
+type GolfTournamentOutcome struct {
+	Players []GolfPlayerOutcome `json:"players"`
+	// CutLine is the worst score to par that made the cut, or nil when there was no cut
+	CutLine *int `json:"cut_line,omitempty"`
+	// WinningScore is the winner's score to par
+	WinningScore int `json:"winning_score"`
+
+	index map[string]int
+}
+
+// Player returns the outcome for a player ID
+func (o *GolfTournamentOutcome) Player(playerID string) (*GolfPlayerOutcome, bool) {
+	i, ok := o.index[playerID]
+	if !ok {
+		return nil, false
+	}
+	return &o.Players[i], true
+}
+
+// GolfPlayerSummary aggregates a player's results across simulated tournaments
+type GolfPlayerSummary struct {
+	PlayerID       string  `json:"player_id"`
+	CutProbability float64 `json:"cut_probability"`
+	WinProbability float64 `json:"win_probability"`
+	Top5           float64 `json:"top_5_probability"`
+	Top10          float64 `json:"top_10_probability"`
+	Top20          float64 `json:"top_20_probability"`
+	AverageFinish  float64 `json:"average_finish"`
+	AverageToPar   float64 `json:"average_to_par"`
+}
+
+// GolfTournamentSummary aggregates many simulated tournaments
+type GolfTournamentSummary struct {
+	Iterations       int                 `json:"iterations"`
+	Players          []GolfPlayerSummary `json:"players"`
+	AverageCutLine   float64             `json:"average_cut_line"`
+	CutLineHistogram map[int]int         `json:"cut_line_histogram"`
+}
+
+// GolfTournamentSimulator plays every round for the entire field so cut odds and finishing
+// positions come from the simulated leaderboard rather than from independent player draws
+type GolfTournamentSimulator struct {
+	entrants []GolfEntrant
+	config   GolfTournamentConfig
+	index    map[string]int
+}
+
+// NewGolfTournamentSimulator creates a simulator for a field
+func NewGolfTournamentSimulator(entrants []GolfEntrant, config GolfTournamentConfig) (*GolfTournamentSimulator, error) {
+	if len(entrants) == 0 {
+		return nil, fmt.Errorf("tournament field is empty")
+	}
+	if config.Rounds <= 0 {
+		return nil, fmt.Errorf("tournament must have at least one round")
+	}
+	if config.CutRule.Active() && config.CutRule.AfterRound >= config.Rounds {
+		return nil, fmt.Errorf("cut after round %d leaves no rounds to play in a %d round event", config.CutRule.AfterRound, config.Rounds)
+	}
+
+	index := make(map[string]int, len(entrants))
+	for i, entrant := range entrants {
+		if _, exists := index[entrant.PlayerID]; exists {
+			return nil, fmt.Errorf("player %s is entered twice", entrant.PlayerID)
+		}
+		index[entrant.PlayerID] = i
+	}
+
+	return &GolfTournamentSimulator{
+		entrants: entrants,
+		config:   config,
+		index:    index,
+	}, nil
+}
+
+// SimulateTournament plays one tournament round by round
+func (s *GolfTournamentSimulator) SimulateTournament(rng *rand.Rand) *GolfTournamentOutcome {
+	n := len(s.entrants)
+	outcome := &GolfTournamentOutcome{
+		Players: make([]GolfPlayerOutcome, n),
+		index:   s.index,
+	}
+
+	active := make([]int, n)
+	for i, entrant := range s.entrants {
+		active[i] = i
+		outcome.Players[i] = GolfPlayerOutcome{
+			PlayerID:    entrant.PlayerID,
+			RoundScores: make([]int, 0, s.config.Rounds),
+			MadeCut:     true,
+		}
+	}
+
+	for round := 1; round <= s.config.Rounds; round++ {
+		waves := s.assignWaves(round, active, outcome)
+
+		dayShock := rng.NormFloat64() * s.config.DayShockStdDev
+		waveShocks := [2]float64{
+			rng.NormFloat64() * s.config.WaveShockStdDev,
+			rng.NormFloat64() * s.config.WaveShockStdDev,
+		}
+		if round <= len(s.config.WaveAdjustments) {
+			waveShocks[GolfWaveEarly] += s.config.WaveAdjustments[round-1][GolfWaveEarly]
+			waveShocks[GolfWaveLate] += s.config.WaveAdjustments[round-1][GolfWaveLate]
+		}
+
+		for _, i := range active {
+			entrant := s.entrants[i]
+			wave := waves[i]
+			performance := entrant.StrokesGained + entrant.RoundStdDev*rng.NormFloat64()
+			strokes := int(math.Round(s.config.FieldScoringAverage + dayShock + waveShocks[wave] - performance))
+
+			player := &outcome.Players[i]
+			player.RoundScores = append(player.RoundScores, strokes)
+			player.TotalStrokes += strokes
+			player.WaveStrokes += waveShocks[wave]
+		}
+
+		if s.config.CutRule.Active() && round == s.config.CutRule.AfterRound {
+			active = s.applyCut(active, outcome, rng)
+			cutLine := outcome.Players[active[len(active)-1]].TotalStrokes - s.config.Par*round
+			outcome.CutLine = &cutLine
+		}
+	}
+
+	for i := range outcome.Players {
+		player := &outcome.Players[i]
+		player.ToPar = player.TotalStrokes - s.config.Par*len(player.RoundScores)
+	}
+	s.assignPositions(outcome, rng)
+
+	return outcome
+}
+
+// Run simulates many tournaments, passing each outcome to observe (if set) before aggregating
+func (s *GolfTournamentSimulator) Run(iterations int, rng *rand.Rand, observe func(*GolfTournamentOutcome)) *GolfTournamentSummary {
+	n := len(s.entrants)
+	summary := &GolfTournamentSummary{
+		Iterations:       iterations,
+		Players:          make([]GolfPlayerSummary, n),
+		CutLineHistogram: make(map[int]int),
+	}
+	for i, entrant := range s.entrants {
+		summary.Players[i].PlayerID = entrant.PlayerID
+	}
+	if iterations <= 0 {
+		return summary
+	}
+
+	cutLineTotal := 0
+	for iter := 0; iter < iterations; iter++ {
+		outcome := s.SimulateTournament(rng)
+		if observe != nil {
+			observe(outcome)
+		}
+
+		if outcome.CutLine != nil {
+			cutLineTotal += *outcome.CutLine
+			summary.CutLineHistogram[*outcome.CutLine]++
+		}
+
+		for i := range outcome.Players {
+			player := &outcome.Players[i]
+			stats := &summary.Players[i]
+			if player.MadeCut {
+				stats.CutProbability++
+			}
+			if player.Position == 1 {
+				stats.WinProbability++
+			}
+			if player.Position <= 5 {
+				stats.Top5++
+			}
+			if player.Position <= 10 {
+				stats.Top10++
+			}
+			if player.Position <= 20 {
+				stats.Top20++
+			}
+			stats.AverageFinish += float64(player.Position)
+			stats.AverageToPar += float64(player.ToPar)
+		}
+	}
+
+	count := float64(iterations)
+	for i := range summary.Players {
+		stats := &summary.Players[i]
+		stats.CutProbability /= count
+		stats.WinProbability /= count
+		stats.Top5 /= count
+		stats.Top10 /= count
+		stats.Top20 /= count
+		stats.AverageFinish /= count
+		stats.AverageToPar /= count
+	}
+	if len(summary.CutLineHistogram) > 0 {
+		summary.AverageCutLine = float64(cutLineTotal) / count
+	}
+
+	return summary
+}
+
+// assignWaves returns each active player's wave for a round. Rounds one and two use the draw with
+// waves swapped for round two; weekend tee times go off in reverse order of the leaderboard, so the
+// leaders share the late wave.
+func (s *GolfTournamentSimulator) assignWaves(round int, active []int, outcome *GolfTournamentOutcome) map[int]int {
+	waves := make(map[int]int, len(active))
+
+	if round <= 2 {
+		for _, i := range active {
+			wave := s.entrants[i].Wave
+			if round == 2 {
+				wave = 1 - wave
+			}
+			waves[i] = wave
+		}
+		return waves
+	}
+
+	order := make([]int, len(active))
+	copy(order, active)
+	sort.SliceStable(order, func(a, b int) bool {
+		return outcome.Players[order[a]].TotalStrokes > outcome.Players[order[b]].TotalStrokes
+	})
+	for rank, i := range order {
+		if rank < len(order)/2 {
+			waves[i] = GolfWaveEarly
+		} else {
+			waves[i] = GolfWaveLate
+		}
+	}
+	return waves
+}
+
+// applyCut marks players outside the cut and returns the remaining field, best score first
+func (s *GolfTournamentSimulator) applyCut(active []int, outcome *GolfTournamentOutcome, rng *rand.Rand) []int {
+	rule := s.config.CutRule
+
+	// Shuffle before the stable sort so ties at the line are broken at random when ties don't carry
+	order := make([]int, len(active))
+	copy(order, active)
+	rng.Shuffle(len(order), func(a, b int) { order[a], order[b] = order[b], order[a] })
+	sort.SliceStable(order, func(a, b int) bool {
+		return outcome.Players[order[a]].TotalStrokes < outcome.Players[order[b]].TotalStrokes
+	})
+
+	if len(order) <= rule.TopN {
+		return order
+	}
+
+	cutScore := outcome.Players[order[rule.TopN-1]].TotalStrokes
+	leadScore := outcome.Players[order[0]].TotalStrokes
+
+	survivors := make([]int, 0, len(order))
+	for rank, i := range order {
+		total := outcome.Players[i].TotalStrokes
+		made := rank < rule.TopN ||
+			(rule.IncludeTies && total == cutScore) ||
+			(rule.WithinStrokesOfLead > 0 && total-leadScore <= rule.WithinStrokesOfLead)
+
+		if made {
+			survivors = append(survivors, i)
+		} else {
+			outcome.Players[i].MadeCut = false
+		}
+	}
+	return survivors
+}
+
+// assignPositions ranks the weekend field by total strokes with ties sharing a position, settles a
+// tie for the lead with a playoff, and ranks missed cuts by their 36-hole total behind the weekend field
+func (s *GolfTournamentSimulator) assignPositions(outcome *GolfTournamentOutcome, rng *rand.Rand) {
+	var made, missed []int
+	for i := range outcome.Players {
+		if outcome.Players[i].MadeCut {
+			made = append(made, i)
+		} else {
+			missed = append(missed, i)
+		}
+	}
+
+	rank := func(group []int, offset int) {
+		sort.SliceStable(group, func(a, b int) bool {
+			return outcome.Players[group[a]].TotalStrokes < outcome.Players[group[b]].TotalStrokes
+		})
+		for k, i := range group {
+			position := offset + k + 1
+			if k > 0 && outcome.Players[group[k-1]].TotalStrokes == outcome.Players[i].TotalStrokes {
+				position = outcome.Players[group[k-1]].Position
+			}
+			outcome.Players[i].Position = position
+		}
+		for k, i := range group {
+			tiedBefore := k > 0 && outcome.Players[group[k-1]].Position == outcome.Players[i].Position
+			tiedAfter := k < len(group)-1 && outcome.Players[group[k+1]].Position == outcome.Players[i].Position
+			outcome.Players[i].Tied = tiedBefore || tiedAfter
+		}
+	}
+
+	rank(made, 0)
+	rank(missed, len(made))
+
+	if len(made) == 0 {
+		return
+	}
+	outcome.WinningScore = outcome.Players[made[0]].ToPar
+
+	// Playoff: one of the co-leaders wins outright and the rest finish tied for second
+	var coLeaders []int
+	for _, i := range made {
+		if outcome.Players[i].Position != 1 {
+			break
+		}
+		coLeaders = append(coLeaders, i)
+	}
+	if len(coLeaders) > 1 {
+		winner := coLeaders[rng.Intn(len(coLeaders))]
+		for _, i := range coLeaders {
+			if i == winner {
+				outcome.Players[i].Position = 1
+				outcome.Players[i].Tied = false
+			} else {
+				outcome.Players[i].Position = 2
+				outcome.Players[i].Tied = len(coLeaders) > 2
+			}
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package simulator
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testGolfField(size int) []GolfEntrant {
+	field := make([]GolfEntrant, size)
+	for i := range field {
+		field[i] = GolfEntrant{
+			PlayerID: fmt.Sprintf("p%03d", i),
+			// Best player first, falling from +2.5 to -1.5 strokes gained per round
+			StrokesGained: 2.5 - 4*float64(i)/float64(size-1),
+			RoundStdDev:   2.8,
+			Wave:          i % 2,
+		}
+	}
+	return field
+}
+
+func TestGolfTournamentSimulator_AppliesCutWithTies(t *testing.T) {
+	sim, err := NewGolfTournamentSimulator(testGolfField(144), DefaultGolfTournamentConfig())
+	require.NoError(t, err)
+
+	rng := rand.New(rand.NewSource(11))
+	for iter := 0; iter < 200; iter++ {
+		outcome := sim.SimulateTournament(rng)
+		require.NotNil(t, outcome.CutLine)
+
+		made := 0
+		for _, player := range outcome.Players {
+			thirtySix := player.RoundScores[0] + player.RoundScores[1] - 2*72
+			if player.MadeCut {
+				made++
+				assert.Len(t, player.RoundScores, 4)
+				assert.LessOrEqual(t, thirtySix, *outcome.CutLine)
+			} else {
+				assert.Len(t, player.RoundScores, 2)
+				assert.Greater(t, thirtySix, *outcome.CutLine)
+				assert.Greater(t, player.Position, 65)
+			}
+		}
+		// Top 65 and ties
+		assert.GreaterOrEqual(t, made, 65)
+	}
+}
+
+func TestGolfTournamentSimulator_PositionsFollowLeaderboard(t *testing.T) {
+	sim, err := NewGolfTournamentSimulator(testGolfField(120), DefaultGolfTournamentConfig())
+	require.NoError(t, err)
+
+	outcome := sim.SimulateTournament(rand.New(rand.NewSource(3)))
+
+	winners := 0
+	for _, a := range outcome.Players {
+		if a.Position == 1 {
+			winners++
+			assert.Equal(t, outcome.WinningScore, a.ToPar)
+		}
+		if !a.MadeCut {
+			continue
+		}
+		for _, b := range outcome.Players {
+			if b.MadeCut && a.TotalStrokes < b.TotalStrokes {
+				assert.Less(t, a.Position, b.Position, "%s beat %s", a.PlayerID, b.PlayerID)
+			}
+		}
+	}
+	assert.Equal(t, 1, winners, "a playoff leaves exactly one winner")
+
+	player, ok := outcome.Player("p000")
+	require.True(t, ok)
+	assert.Equal(t, "p000", player.PlayerID)
+}
+
+func TestGolfTournamentSimulator_RunProducesOdds(t *testing.T) {
+	field := testGolfField(144)
+	sim, err := NewGolfTournamentSimulator(field, DefaultGolfTournamentConfig())
+	require.NoError(t, err)
+
+	observed := 0
+	summary := sim.Run(500, rand.New(rand.NewSource(5)), func(*GolfTournamentOutcome) { observed++ })
+	assert.Equal(t, 500, observed)
+
+	best, worst := summary.Players[0], summary.Players[len(field)-1]
+	assert.Greater(t, best.CutProbability, 0.75)
+	assert.Less(t, worst.CutProbability, 0.35)
+	assert.Greater(t, best.WinProbability, worst.WinProbability)
+	assert.Less(t, best.AverageFinish, worst.AverageFinish)
+
+	totalWins := 0.0
+	expectedCuts := 0.0
+	for _, player := range summary.Players {
+		totalWins += player.WinProbability
+		expectedCuts += player.CutProbability
+		assert.LessOrEqual(t, player.Top5, player.Top10)
+		assert.LessOrEqual(t, player.Top10, player.Top20)
+	}
+	assert.InDelta(t, 1.0, totalWins, 1e-9)
+	assert.GreaterOrEqual(t, expectedCuts, 65.0)
+	assert.Less(t, expectedCuts, 75.0)
+}
+
+func TestGolfTournamentSimulator_WaveShocksAreShared(t *testing.T) {
+	config := DefaultGolfTournamentConfig()
+	config.CutRule = NoCutRule
+	// A forecast that costs the round one afternoon wave two strokes
+	config.WaveAdjustments = [][2]float64{{0, 2}}
+
+	field := testGolfField(40)
+	for i := range field {
+		field[i].StrokesGained = 0
+	}
+	sim, err := NewGolfTournamentSimulator(field, config)
+	require.NoError(t, err)
+
+	var early, late float64
+	rng := rand.New(rand.NewSource(9))
+	for iter := 0; iter < 300; iter++ {
+		outcome := sim.SimulateTournament(rng)
+		for i, player := range outcome.Players {
+			if field[i].Wave == GolfWaveEarly {
+				early += float64(player.RoundScores[0])
+			} else {
+				late += float64(player.RoundScores[0])
+			}
+		}
+	}
+	perRound := float64(300 * 20)
+	assert.InDelta(t, 2.0, late/perRound-early/perRound, 0.3)
+}
+
+func TestNewGolfTournamentSimulator_RejectsInvalidConfig(t *testing.T) {
+	_, err := NewGolfTournamentSimulator(nil, DefaultGolfTournamentConfig())
+	assert.Error(t, err)
+
+	config := DefaultGolfTournamentConfig()
+	config.Rounds = 2
+	_, err = NewGolfTournamentSimulator(testGolfField(10), config)
+	assert.Error(t, err)
+
+	duplicate := testGolfField(2)
+	duplicate[1].PlayerID = duplicate[0].PlayerID
+	_, err = NewGolfTournamentSimulator(duplicate, DefaultGolfTournamentConfig())
+	assert.Error(t, err)
+}
@@ -1,7 +1,7 @@
 package simulator
 
 import (
-	"hash/crc32"
+	"fmt"
 	"math"
 	"math/rand"
 	"runtime"
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	sharedsim "github.com/stitts-dev/dfs-sim/shared/pkg/simulator"
 	"github.com/stitts-dev/dfs-sim/shared/types"
 	"github.com/stitts-dev/dfs-sim/services/optimization-service/internal/optimizer"
 )
@@ -89,6 +90,16 @@ func (s *Simulator) SimulateContest(lineups []types.GeneratedLineup, progressCha
 		numWorkers = s.config.SimulationWorkers
 	}
 
+	// Build the joint sampler once; every simulation draws from the same slate distribution
+	var sampler *sharedsim.CopulaSampler
+	if s.config.UseCorrelations {
+		var err error
+		sampler, err = s.buildCopulaSampler(lineups[0].Players)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build correlated sampler: %w", err)
+		}
+	}
+
 	// Create channels for work distribution
 	simulationsChan := make(chan int, s.config.NumSimulations)
 	resultsChan := make(chan SimulationRun, s.config.NumSimulations)
@@ -102,7 +113,7 @@ func (s *Simulator) SimulateContest(lineups []types.GeneratedLineup, progressCha
 	var wg sync.WaitGroup
 	for w := 0; w < numWorkers; w++ {
 		wg.Add(1)
-		go s.simulationWorker(lineups, simulationsChan, resultsChan, &wg)
+		go s.simulationWorker(lineups, sampler, simulationsChan, resultsChan, &wg)
 	}
 
 	// Queue simulations
@@ -119,7 +130,7 @@ func (s *Simulator) SimulateContest(lineups []types.GeneratedLineup, progressCha
 	return s.aggregateResults(lineups[0], resultsChan), nil
 }
 
-func (s *Simulator) simulationWorker(lineups []types.GeneratedLineup, simChan <-chan int, resultsChan chan<- SimulationRun, wg *sync.WaitGroup) {
+func (s *Simulator) simulationWorker(lineups []types.GeneratedLineup, sampler *sharedsim.CopulaSampler, simChan <-chan int, resultsChan chan<- SimulationRun, wg *sync.WaitGroup) {
 	defer wg.Done()
 
 	// Create local RNG for this worker
@@ -128,7 +139,7 @@ func (s *Simulator) simulationWorker(lineups []types.GeneratedLineup, simChan <-
 	for simNum := range simChan {
 		// Generate player outcomes for this simulation
 		_ = simNum // Mark as used
-		playerOutcomes := s.generatePlayerOutcomes(lineups[0].Players, localRng)
+		playerOutcomes := s.generatePlayerOutcomes(lineups[0].Players, sampler, localRng)
 
 		// Calculate lineup scores
 		lineupScores := make([]float64, len(lineups))
@@ -156,12 +167,12 @@ func (s *Simulator) simulationWorker(lineups []types.GeneratedLineup, simChan <-
 	}
 }
 
-func (s *Simulator) generatePlayerOutcomes(players []types.LineupPlayer, rng *rand.Rand) map[uuid.UUID]float64 {
+func (s *Simulator) generatePlayerOutcomes(players []types.LineupPlayer, sampler *sharedsim.CopulaSampler, rng *rand.Rand) map[uuid.UUID]float64 {
 	outcomes := make(map[uuid.UUID]float64)
 
-	if s.config.UseCorrelations {
+	if sampler != nil {
 		// Generate correlated outcomes
-		outcomes = s.generateCorrelatedOutcomes(players, rng)
+		outcomes = s.generateCorrelatedOutcomes(players, sampler, rng)
 	} else {
 		// Generate independent outcomes
 		for _, player := range players {
@@ -172,46 +183,42 @@ func (s *Simulator) generatePlayerOutcomes(players []types.LineupPlayer, rng *ra
 	return outcomes
 }
 
-func (s *Simulator) generateCorrelatedOutcomes(players []types.LineupPlayer, rng *rand.Rand) map[uuid.UUID]float64 {
-	n := len(players)
-	outcomes := make(map[uuid.UUID]float64)
+// generateCorrelatedOutcomes draws one joint sample of player scores from the copula sampler
+func (s *Simulator) generateCorrelatedOutcomes(players []types.LineupPlayer, sampler *sharedsim.CopulaSampler, rng *rand.Rand) map[uuid.UUID]float64 {
+	scores := make([]float64, len(players))
+	sampler.Sample(rng, scores)
 
-	// Generate base scores
-	baseScores := make([]float64, n)
+	outcomes := make(map[uuid.UUID]float64, len(players))
 	for i, player := range players {
-		baseScores[i] = s.generatePlayerScore(player, rng)
-	}
-
-	// Apply correlations using Cholesky decomposition approximation
-	// For simplicity, we'll use a simpler approach here
-	for i, player1 := range players {
-		adjustedScore := baseScores[i]
-
-		// Apply correlation adjustments
-		for j, player2 := range players {
-			if i != j {
-				// Convert UUID to uint for correlation lookup
-				// TODO: This is a temporary solution - ideally correlation matrix should use UUIDs
-				player1Hash := hashUUID(player1.ID)
-				player2Hash := hashUUID(player2.ID)
-				correlation := s.correlations.GetCorrelation(player1Hash, player2Hash)
-				if correlation != 0 {
-					// Adjust score based on correlation and other player's performance
-					deviation := (baseScores[j] - player2.ProjectedPoints) / player2.ProjectedPoints
-					adjustment := correlation * deviation * player1.ProjectedPoints * 0.1
-					adjustedScore += adjustment
-				}
-			}
-		}
-
-		// Ensure score stays within reasonable bounds
-		adjustedScore = math.Max(0, adjustedScore)
-		adjustedScore = math.Min(player1.ProjectedPoints*1.8, adjustedScore)
+		outcomes[player.ID] = scores[i]
+	}
+	return outcomes
+}
 
-		outcomes[player1.ID] = adjustedScore
+// buildCopulaSampler pairs each player's marginal score distribution with the slate correlation
+// matrix, repairing the matrix to the nearest positive semidefinite one when needed
+func (s *Simulator) buildCopulaSampler(players []types.LineupPlayer) (*sharedsim.CopulaSampler, error) {
+	marginals := make([]sharedsim.Marginal, len(players))
+	for i, player := range players {
+		marginals[i] = playerMarginal(player)
 	}
 
-	return outcomes
+	correlation := sharedsim.BuildCorrelationMatrix(len(players), func(i, j int) float64 {
+		return s.correlations.GetCorrelation(players[i].ID, players[j].ID)
+	})
+
+	return sharedsim.NewCopulaSampler(correlation, marginals)
+}
+
+// playerMarginal describes the same distribution generatePlayerScore samples from
+func playerMarginal(player types.LineupPlayer) sharedsim.NormalMarginal {
+	return sharedsim.NormalMarginal{
+		Mean:            player.ProjectedPoints,
+		StdDev:          player.ProjectedPoints * 0.25,
+		Floor:           player.ProjectedPoints * 0.3,
+		Ceiling:         player.ProjectedPoints * 1.8,
+		ZeroProbability: 0.02,
+	}
 }
 
 func (s *Simulator) generatePlayerScore(player types.LineupPlayer, rng *rand.Rand) float64 {
@@ -264,20 +271,6 @@ func (s *Simulator) calculateRanks(scores []float64) []int {
 	return ranks
 }
 
-// hashUUID converts a UUID to a uint32 for compatibility with correlation matrix
-// TODO: CRITICAL - This is a temporary hash-based solution with significant limitations:
-// 1. Hash collisions can cause incorrect correlations between different players
-// 2. Correlation matrix should be refactored to use UUID keys instead of uint
-// 3. This breaks historical correlation data that used sequential uint IDs
-// 4. Performance impact from hash computation on every correlation lookup
-// 
-// PROPER SOLUTION: Update CorrelationMatrix struct to use map[uuid.UUID]map[uuid.UUID]float64
-// and migrate existing correlation data to use UUID keys
-// TEMP FIX: Using CRC32 hash for compilation compatibility only
-func hashUUID(id uuid.UUID) uint {
-	return uint(crc32.ChecksumIEEE(id[:]))
-}
-
 func (s *Simulator) aggregateResults(lineup types.GeneratedLineup, resultsChan <-chan SimulationRun) *SimulationResult {
 	scores := make([]float64, 0, s.config.NumSimulations)
 	ranks := make([]int, 0, s.config.NumSimulations)
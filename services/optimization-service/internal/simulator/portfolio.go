@@ -0,0 +1,96 @@
+package simulator
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"sort"
+
+	"github.com/google/uuid"
+
+	"github.com/stitts-dev/dfs-sim/services/optimization-service/internal/optimizer"
+	"github.com/stitts-dev/dfs-sim/shared/types"
+)
+
+// FieldOutcomes are a lineup pool's simulated finishes against one sampled field. FieldAbove[i][k]
+// is the fraction of field lineups that outscored lineup i in iteration k, which places the lineup
+// in a contest of any size on the slate. DuplicationRates[i] is the chance a random field entry
+// is an exact copy of lineup i.
+type FieldOutcomes struct {
+	LineupIDs        []string
+	FieldAbove       [][]float64
+	DuplicationRates []float64
+}
+
+// SimulateFieldOutcomes samples a field of fieldSize ownership-weighted lineups for the slate,
+// then scores the pool and the field together for each iteration
+func SimulateFieldOutcomes(ctx context.Context, lineups []types.GeneratedLineup, players []types.Player, contest *types.Contest, fieldSize, iterations int, rng *rand.Rand) (*FieldOutcomes, error) {
+	if len(lineups) == 0 || iterations <= 0 || fieldSize <= 0 {
+		return nil, errors.New("lineups, iterations and a field size are required")
+	}
+
+	cs := NewContestSimulator(contest)
+	// Teammates and opponents finish together, so pool and field scores are sampled jointly
+	cs.SetCorrelations(optimizer.NewCorrelationMatrix(convertPlayersToOptimizationMC(players)))
+	ownership := cs.ownershipModel.GenerateOwnership(players, rng)
+	field := make([]types.GeneratedLineup, 0, fieldSize)
+	pool := cs.createWeightedPool(players, ownership)
+	for attempts := 0; len(field) < fieldSize && attempts < fieldSize*2; attempts++ {
+		if lineup := cs.generateSingleLineup(pool, players, rng); lineup != nil {
+			field = append(field, *lineup)
+		}
+	}
+	if len(field) == 0 {
+		return nil, errors.New("could not build field lineups from the player pool")
+	}
+
+	outcomes := &FieldOutcomes{
+		LineupIDs:        make([]string, len(lineups)),
+		FieldAbove:       make([][]float64, len(lineups)),
+		DuplicationRates: make([]float64, len(lineups)),
+	}
+	for i, lineup := range lineups {
+		outcomes.LineupIDs[i] = lineup.ID
+		outcomes.FieldAbove[i] = make([]float64, iterations)
+		rate := 1.0
+		for _, player := range lineup.Players {
+			rate *= ownership[player.ID]
+		}
+		outcomes.DuplicationRates[i] = rate
+	}
+
+	fieldScores := make([]float64, len(field))
+	for k := 0; k < iterations; k++ {
+		if k%100 == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
+
+		playerOutcomes := cs.generatePlayerOutcomes(players, rng)
+		for j, lineup := range field {
+			fieldScores[j] = lineupScore(lineup, playerOutcomes)
+		}
+		sort.Float64s(fieldScores)
+
+		for i, lineup := range lineups {
+			score := lineupScore(lineup, playerOutcomes)
+			below := sort.Search(len(fieldScores), func(j int) bool { return fieldScores[j] > score })
+			outcomes.FieldAbove[i][k] = float64(len(fieldScores)-below) / float64(len(fieldScores))
+		}
+	}
+	return outcomes, nil
+}
+
+// lineupScore totals a lineup's sampled points; players outside the pool score their projection
+func lineupScore(lineup types.GeneratedLineup, playerOutcomes map[uuid.UUID]float64) float64 {
+	score := 0.0
+	for _, player := range lineup.Players {
+		if points, ok := playerOutcomes[player.ID]; ok {
+			score += points
+		} else {
+			score += player.ProjectedPoints
+		}
+	}
+	return score
+}
@@ -0,0 +1,101 @@
+package simulator
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stitts-dev/dfs-sim/services/optimization-service/internal/optimizer"
+	"github.com/stitts-dev/dfs-sim/shared/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func slatePlayer(position string, projected float64, salary int) types.Player {
+	floor, ceiling := projected*0.6, projected*1.4
+	return types.Player{
+		ID:              uuid.New(),
+		Name:            fmt.Sprintf("%s %.0f", position, projected),
+		Position:        &position,
+		SalaryDK:        &salary,
+		ProjectedPoints: &projected,
+		FloorPoints:     &floor,
+		CeilingPoints:   &ceiling,
+	}
+}
+
+func toLineup(id string, players ...types.Player) types.GeneratedLineup {
+	lineup := types.GeneratedLineup{ID: id}
+	for _, p := range players {
+		lineup.Players = append(lineup.Players, types.LineupPlayer{ID: p.ID, ProjectedPoints: *p.ProjectedPoints})
+		lineup.ProjectedPoints += *p.ProjectedPoints
+	}
+	return lineup
+}
+
+func TestSimulateFieldOutcomes(t *testing.T) {
+	var guards, forwards []types.Player
+	for i := 0; i < 6; i++ {
+		guards = append(guards, slatePlayer("G", float64(10+5*i), 5000))
+		forwards = append(forwards, slatePlayer("F", float64(10+5*i), 5000))
+	}
+	players := append(append([]types.Player{}, guards...), forwards...)
+	contest := &types.Contest{
+		ContestType:          "gpp",
+		SalaryCap:            50000,
+		PositionRequirements: types.PositionRequirements{"G": 1, "F": 1},
+	}
+	lineups := []types.GeneratedLineup{
+		toLineup("best", guards[5], forwards[5]),
+		toLineup("worst", guards[0], forwards[0]),
+	}
+
+	outcomes, err := SimulateFieldOutcomes(context.Background(), lineups, players, contest, 200, 300, rand.New(rand.NewSource(7)))
+	require.NoError(t, err)
+	require.Len(t, outcomes.FieldAbove, 2)
+	assert.Equal(t, []string{"best", "worst"}, outcomes.LineupIDs)
+
+	mean := func(values []float64) float64 {
+		total := 0.0
+		for _, v := range values {
+			assert.True(t, v >= 0 && v <= 1)
+			total += v
+		}
+		return total / float64(len(values))
+	}
+	assert.Len(t, outcomes.FieldAbove[0], 300)
+	assert.Less(t, mean(outcomes.FieldAbove[0]), mean(outcomes.FieldAbove[1]), "the higher projected lineup finishes ahead more often")
+	assert.Greater(t, outcomes.DuplicationRates[0], 0.0)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = SimulateFieldOutcomes(ctx, lineups, players, contest, 50, 300, rand.New(rand.NewSource(7)))
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestContestSimulatorRebuildsSamplerForNewPool(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+	players := []types.Player{slatePlayer("G", 20, 5000), slatePlayer("G", 30, 6000), slatePlayer("F", 25, 5500)}
+	cs := NewContestSimulator(&types.Contest{ContestType: "gpp", PositionRequirements: types.PositionRequirements{"G": 1, "F": 1}})
+	assert.Nil(t, cs.samplerFor(players, rng), "outcomes are independent without correlations")
+
+	cs.SetCorrelations(optimizer.NewCorrelationMatrix(convertPlayersToOptimizationMC(players)))
+	first := cs.samplerFor(players, rng)
+	require.NotNil(t, first)
+	assert.Same(t, first, cs.samplerFor(players, rng), "the sampler is reused for the same pool")
+
+	larger := append(append([]types.Player{}, players...), slatePlayer("F", 15, 4000))
+	second := cs.samplerFor(larger, rng)
+	require.NotNil(t, second)
+	assert.NotSame(t, first, second)
+	assert.Equal(t, len(larger), second.Size())
+	assert.Len(t, cs.generatePlayerOutcomes(larger, rng), len(larger))
+
+	// Changing a projection in the same pool also rebuilds the marginals
+	boosted := append([]types.Player{}, larger...)
+	projected := 45.0
+	boosted[0].ProjectedPoints = &projected
+	assert.NotSame(t, second, cs.samplerFor(boosted, rng))
+}
@@ -0,0 +1,26 @@
+-- 014_add_player_game_results.sql
+-- Historical fantasy results by role, used to estimate empirical player correlations
+
+CREATE TABLE IF NOT EXISTS player_game_results (
+    id UUID DEFAULT gen_random_uuid() PRIMARY KEY,
+    sport VARCHAR(20) NOT NULL,
+    game_id VARCHAR(255) NOT NULL,
+    game_date TIMESTAMP NOT NULL,
+    player_id UUID NOT NULL,
+    team VARCHAR(50) NOT NULL,
+    opponent VARCHAR(50) NOT NULL,
+    role VARCHAR(20) NOT NULL, -- 'QB1', 'WR2', 'DST', 'SP', 'BAT3', ...
+    fantasy_points DECIMAL(8,2) NOT NULL,
+    projected_points DECIMAL(8,2),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    -- Constraints
+    CONSTRAINT uq_player_game_results_game_player UNIQUE (game_id, player_id)
+);
+
+-- Indexes for player_game_results
+CREATE INDEX idx_player_game_results_sport_date ON player_game_results (sport, game_date);
+CREATE INDEX idx_player_game_results_game_id ON player_game_results (game_id);
+CREATE INDEX idx_player_game_results_player_id ON player_game_results (player_id);
+
+COMMENT ON TABLE player_game_results IS 'Per-game fantasy results tagged by team role for empirical correlation estimates';
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_lineups_workspace;
+ALTER TABLE lineups DROP COLUMN IF EXISTS workspace_id;
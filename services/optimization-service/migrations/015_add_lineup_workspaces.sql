@@ -0,0 +1,9 @@
+-- Migration: Let team workspaces own saved lineups
+-- workspaces is created by the user-service migrations, which may run after these, so the
+-- column is not a foreign key
+
+ALTER TABLE lineups ADD COLUMN IF NOT EXISTS workspace_id UUID;
+
+CREATE INDEX IF NOT EXISTS idx_lineups_workspace ON lineups(workspace_id, created_at DESC);
+
+COMMENT ON COLUMN lineups.workspace_id IS 'Team workspace sharing the lineup; NULL keeps it private to user_id';
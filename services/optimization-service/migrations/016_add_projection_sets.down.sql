@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS projection_overrides;
+DROP TABLE IF EXISTS projection_sets;
+DROP TABLE IF EXISTS projection_entries;
+DROP TABLE IF EXISTS projection_sources;
@@ -0,0 +1,65 @@
+-- Migration: Add uploaded projection sources and blended projection sets
+-- users and workspaces are created by the user-service migrations, which may run after these, so
+-- owner and workspace columns are not foreign keys
+
+-- Named projection uploads (CSV or JSON), one row per player in projection_entries
+CREATE TABLE IF NOT EXISTS projection_sources (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    owner_id UUID NOT NULL,
+    workspace_id UUID,
+    name VARCHAR(100) NOT NULL,
+    description TEXT,
+    sport VARCHAR(20),
+    entry_count INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS projection_entries (
+    source_id UUID NOT NULL REFERENCES projection_sources(id) ON DELETE CASCADE,
+    player_key VARCHAR(150) NOT NULL,
+    player_name VARCHAR(150),
+    position VARCHAR(20),
+    team VARCHAR(10),
+    projected_points DECIMAL(8,2) NOT NULL,
+    floor_points DECIMAL(8,2),
+    ceiling_points DECIMAL(8,2),
+    ownership DECIMAL(5,2),
+    PRIMARY KEY (source_id, player_key)
+);
+
+-- Weighted blends of sources that optimize and simulate requests reference by ID
+CREATE TABLE IF NOT EXISTS projection_sets (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    owner_id UUID NOT NULL,
+    workspace_id UUID,
+    name VARCHAR(100) NOT NULL,
+    description TEXT,
+    sport VARCHAR(20),
+    weights JSONB NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Manual per-player projections applied after blending
+CREATE TABLE IF NOT EXISTS projection_overrides (
+    set_id UUID NOT NULL REFERENCES projection_sets(id) ON DELETE CASCADE,
+    player_key VARCHAR(150) NOT NULL,
+    projected_points DECIMAL(8,2) NOT NULL,
+    floor_points DECIMAL(8,2),
+    ceiling_points DECIMAL(8,2),
+    note TEXT,
+    updated_by UUID NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (set_id, player_key)
+);
+
+CREATE INDEX IF NOT EXISTS idx_projection_sources_owner ON projection_sources(owner_id);
+CREATE INDEX IF NOT EXISTS idx_projection_sources_workspace ON projection_sources(workspace_id);
+CREATE INDEX IF NOT EXISTS idx_projection_sets_owner ON projection_sets(owner_id);
+CREATE INDEX IF NOT EXISTS idx_projection_sets_workspace ON projection_sets(workspace_id);
+
+COMMENT ON TABLE projection_sources IS 'Named projection uploads; workspace_id NULL keeps a source private to owner_id';
+COMMENT ON TABLE projection_entries IS 'Per-player projections of a source, keyed by normalized player ID, external ID or name';
+COMMENT ON TABLE projection_sets IS 'Per-source and per-position weights blending sources into one projection set';
+COMMENT ON TABLE projection_overrides IS 'Manual per-player projections applied after a set is blended';
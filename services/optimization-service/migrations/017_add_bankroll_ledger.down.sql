@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS bankroll_settings;
+DROP TABLE IF EXISTS bankroll_transactions;
@@ -0,0 +1,35 @@
+-- Migration: Add per-user bankroll ledger and sizing settings
+-- users are created by the user-service migrations, which may run after these, so user_id
+-- columns are not foreign keys
+
+-- Deposits, withdrawals, entry fees, winnings and adjustments; amount is the signed balance change
+CREATE TABLE IF NOT EXISTS bankroll_transactions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL,
+    kind VARCHAR(20) NOT NULL CHECK (kind IN ('deposit', 'withdrawal', 'entry_fee', 'winnings', 'adjustment')),
+    amount DECIMAL(12,2) NOT NULL,
+    contest_id UUID REFERENCES contests(id) ON DELETE SET NULL,
+    lineup_id UUID,
+    entries INTEGER NOT NULL DEFAULT 0,
+    note TEXT,
+    occurred_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Sizing defaults and drawdown alert state, one row per user
+CREATE TABLE IF NOT EXISTS bankroll_settings (
+    user_id UUID PRIMARY KEY,
+    drawdown_alert_threshold DECIMAL(5,4) NOT NULL DEFAULT 0.2,
+    kelly_multiplier DECIMAL(5,4) NOT NULL DEFAULT 0.25,
+    fixed_fraction DECIMAL(5,4) NOT NULL DEFAULT 0.02,
+    max_contest_fraction DECIMAL(5,4) NOT NULL DEFAULT 0.1,
+    drawdown_alerted BOOLEAN NOT NULL DEFAULT false,
+    drawdown_alerted_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_bankroll_transactions_user ON bankroll_transactions(user_id, occurred_at);
+CREATE INDEX IF NOT EXISTS idx_bankroll_transactions_contest ON bankroll_transactions(contest_id);
+
+COMMENT ON TABLE bankroll_transactions IS 'Per-user bankroll ledger; amount is positive for deposits and winnings, negative for withdrawals and entry fees';
+COMMENT ON TABLE bankroll_settings IS 'Per-user Kelly and fixed-fraction sizing defaults and drawdown alert threshold';
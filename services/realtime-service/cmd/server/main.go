@@ -55,6 +55,13 @@ func main() {
 	}
 	defer db.Close()
 
+	// Apply pending schema migrations when enabled
+	if cfg.RunMigrations {
+		if err := db.Migrate(context.Background(), "realtime-service", cfg.MigrationsDir, logger.WithField("service", "realtime-service")); err != nil {
+			logger.WithError(err).Fatal("Failed to migrate database")
+		}
+	}
+
 	// Initialize Redis connection
 	redisClient, err := initRedis(cfg, logger)
 	if err != nil {
@@ -70,6 +77,7 @@ func main() {
 
 	// Initialize alert engine
 	alertEngine := alerts.NewAlertEngine(db.DB, redisClient, logger)
+	eventProcessor.RegisterHandler(events.NewBankrollDrawdownHandler(db.DB, alertEngine, logger))
 
 	// Initialize late swap engine
 	lateSwapEngine := lateswap.NewRecommendationEngine(db.DB, redisClient, logger)
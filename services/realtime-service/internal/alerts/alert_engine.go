@@ -7,6 +7,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/redis/go-redis/v9"
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
@@ -23,7 +24,7 @@ type AlertEngine struct {
 	rateLimiter     *AlertRateLimiter
 	
 	// Alert rules cache
-	rulesCache      map[int][]*models.AlertRule // user_id -> rules
+	rulesCache      map[uuid.UUID][]*models.AlertRule // user_id -> rules
 	rulesMutex      sync.RWMutex
 	cacheExpiry     time.Time
 	
@@ -52,7 +53,7 @@ type AlertRequest struct {
 // DeliveryRequest represents a request to deliver an alert
 type DeliveryRequest struct {
 	Alert    models.Alert
-	UserID   int
+	UserID   uuid.UUID
 	Channels []models.DeliveryChannel
 	Priority string
 }
@@ -108,7 +109,7 @@ func NewAlertEngine(db *gorm.DB, redisClient *redis.Client, logger *logrus.Logge
 		db:              db,
 		redisClient:     redisClient,
 		logger:          logger,
-		rulesCache:      make(map[int][]*models.AlertRule),
+		rulesCache:      make(map[uuid.UUID][]*models.AlertRule),
 		alertQueue:      make(chan AlertRequest, config.MaxQueueSize),
 		deliveryQueue:   make(chan DeliveryRequest, config.MaxQueueSize*2),
 		alertStats:      &AlertStats{},
@@ -264,6 +265,11 @@ func (ae *AlertEngine) shouldTriggerAlert(event models.RealTimeEvent, rule *mode
 		}
 	}
 	
+	// User-scoped events only reach the rules of the user they name
+	if targetUserID, scoped := eventUserID(event); scoped {
+		return targetUserID != uuid.Nil && targetUserID == rule.UserID
+	}
+	
 	// Check sports filter
 	if len(rule.Sports) > 0 {
 		// This would need sport information from the event
@@ -284,7 +290,7 @@ func (ae *AlertEngine) shouldTriggerAlert(event models.RealTimeEvent, rule *mode
 }
 
 // generateAlert creates an alert from an event and rule
-func (ae *AlertEngine) generateAlert(event models.RealTimeEvent, rule *models.AlertRule, userID int) models.Alert {
+func (ae *AlertEngine) generateAlert(event models.RealTimeEvent, rule *models.AlertRule, userID uuid.UUID) models.Alert {
 	alert := models.Alert{
 		UserID:    userID,
 		RuleID:    rule.RuleID,
@@ -314,6 +320,8 @@ func (ae *AlertEngine) generateAlertTitle(event models.RealTimeEvent) string {
 		return "Price Change Alert"
 	case models.EventTypeNewsUpdate:
 		return "Breaking News"
+	case models.EventTypeBankrollDrawdown:
+		return "Bankroll Drawdown Alert"
 	default:
 		return "DFS Alert"
 	}
@@ -336,6 +344,8 @@ func (ae *AlertEngine) generateAlertMessage(event models.RealTimeEvent, rule *mo
 		baseMessage = ae.generatePriceMessage(event)
 	case models.EventTypeNewsUpdate:
 		baseMessage = ae.generateNewsMessage(event)
+	case models.EventTypeBankrollDrawdown:
+		baseMessage = ae.generateDrawdownMessage(event)
 	default:
 		baseMessage = fmt.Sprintf("Event: %s", event.EventType)
 	}
@@ -439,6 +449,34 @@ func (ae *AlertEngine) generateNewsMessage(event models.RealTimeEvent) string {
 	return "Breaking news update"
 }
 
+func (ae *AlertEngine) generateDrawdownMessage(event models.RealTimeEvent) string {
+	var drawdownData map[string]interface{}
+	if err := json.Unmarshal(event.Data, &drawdownData); err == nil {
+		drawdown := getFloat64(drawdownData, "drawdown")
+		balance := getFloat64(drawdownData, "balance")
+		peak := getFloat64(drawdownData, "peak")
+		
+		return fmt.Sprintf("Bankroll is down %.0f%% from its peak: $%.2f of $%.2f", drawdown*100, balance, peak)
+	}
+	
+	return "Bankroll drawdown threshold reached"
+}
+
+// eventUserID returns the user a user-scoped event is for; a scoped event without a valid
+// user ID returns uuid.Nil, which matches no rule
+func eventUserID(event models.RealTimeEvent) (uuid.UUID, bool) {
+	if event.EventType != models.EventTypeBankrollDrawdown {
+		return uuid.Nil, false
+	}
+	var data struct {
+		UserID uuid.UUID `json:"user_id"`
+	}
+	if err := json.Unmarshal(event.Data, &data); err != nil {
+		return uuid.Nil, true
+	}
+	return data.UserID, true
+}
+
 // calculatePriority calculates alert priority based on event and rule
 func (ae *AlertEngine) calculatePriority(event models.RealTimeEvent, rule *models.AlertRule) string {
 	// High impact events get high priority
@@ -498,11 +536,11 @@ func (ae *AlertEngine) deliverAlert(request DeliveryRequest) {
 }
 
 // getAllActiveRules retrieves all active alert rules with caching
-func (ae *AlertEngine) getAllActiveRules() map[int][]*models.AlertRule {
+func (ae *AlertEngine) getAllActiveRules() map[uuid.UUID][]*models.AlertRule {
 	ae.rulesMutex.RLock()
 	if time.Now().Before(ae.cacheExpiry) && len(ae.rulesCache) > 0 {
 		// Return cached rules
-		result := make(map[int][]*models.AlertRule)
+		result := make(map[uuid.UUID][]*models.AlertRule)
 		for userID, rules := range ae.rulesCache {
 			result[userID] = rules
 		}
@@ -515,8 +553,16 @@ func (ae *AlertEngine) getAllActiveRules() map[int][]*models.AlertRule {
 	return ae.refreshRulesCache()
 }
 
+// InvalidateRules drops the cached rules so the next event reloads them, letting a rule that
+// was just created apply right away
+func (ae *AlertEngine) InvalidateRules() {
+	ae.rulesMutex.Lock()
+	defer ae.rulesMutex.Unlock()
+	ae.cacheExpiry = time.Time{}
+}
+
 // refreshRulesCache refreshes the alert rules cache
-func (ae *AlertEngine) refreshRulesCache() map[int][]*models.AlertRule {
+func (ae *AlertEngine) refreshRulesCache() map[uuid.UUID][]*models.AlertRule {
 	ae.rulesMutex.Lock()
 	defer ae.rulesMutex.Unlock()
 	
@@ -527,7 +573,7 @@ func (ae *AlertEngine) refreshRulesCache() map[int][]*models.AlertRule {
 	}
 	
 	// Group rules by user ID
-	newCache := make(map[int][]*models.AlertRule)
+	newCache := make(map[uuid.UUID][]*models.AlertRule)
 	for i := range allRules {
 		rule := &allRules[i]
 		newCache[rule.UserID] = append(newCache[rule.UserID], rule)
@@ -0,0 +1,129 @@
+package alerts
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/stitts-dev/dfs-sim/services/realtime-service/internal/models"
+)
+
+// newTestAlertEngine serves rules from its cache; Redis is unreachable, which the rate limiter
+// treats as allowing the alert
+func newTestAlertEngine(t *testing.T, rules ...*models.AlertRule) *AlertEngine {
+	t.Helper()
+	redisClient := redis.NewClient(&redis.Options{Addr: "127.0.0.1:1", MaxRetries: -1, DialTimeout: 100 * time.Millisecond})
+	t.Cleanup(func() { redisClient.Close() })
+	logger := logrus.New()
+	logger.SetLevel(logrus.PanicLevel)
+
+	engine := NewAlertEngine(nil, redisClient, logger)
+	for _, rule := range rules {
+		engine.rulesCache[rule.UserID] = append(engine.rulesCache[rule.UserID], rule)
+	}
+	engine.cacheExpiry = time.Now().Add(time.Hour)
+	return engine
+}
+
+func drawdownRule(userID uuid.UUID) *models.AlertRule {
+	return &models.AlertRule{
+		UserID:           userID,
+		RuleID:           "drawdown-" + userID.String(),
+		EventTypes:       []string{string(models.EventTypeBankrollDrawdown)},
+		DeliveryChannels: []string{"websocket"},
+		IsActive:         true,
+	}
+}
+
+// drawdownEvent is shaped like the optimization service's bankroll_drawdown event
+func drawdownEvent(t *testing.T, userID string) models.RealTimeEvent {
+	t.Helper()
+	data, err := json.Marshal(map[string]interface{}{
+		"user_id":   userID,
+		"balance":   600.0,
+		"peak":      1000.0,
+		"drawdown":  0.4,
+		"threshold": 0.25,
+	})
+	require.NoError(t, err)
+	return models.RealTimeEvent{
+		EventID:      uuid.New(),
+		EventType:    models.EventTypeBankrollDrawdown,
+		Data:         data,
+		ImpactRating: 8,
+	}
+}
+
+func TestProcessAlertRequest_DrawdownReachesItsUser(t *testing.T) {
+	owner := uuid.New()
+	other := uuid.New()
+	engine := newTestAlertEngine(t, drawdownRule(owner), drawdownRule(other))
+
+	engine.processAlertRequest(AlertRequest{Event: drawdownEvent(t, owner.String()), Timestamp: time.Now()})
+
+	require.Len(t, engine.deliveryQueue, 1)
+	delivery := <-engine.deliveryQueue
+	assert.Equal(t, owner, delivery.UserID)
+	assert.Equal(t, owner, delivery.Alert.UserID)
+	assert.Equal(t, "drawdown-"+owner.String(), delivery.Alert.RuleID)
+	assert.Equal(t, "Bankroll Drawdown Alert", delivery.Alert.Title)
+	assert.Contains(t, delivery.Alert.Message, "down 40% from its peak")
+	assert.Equal(t, []models.DeliveryChannel{models.DeliveryChannelWebSocket}, delivery.Channels)
+}
+
+func TestShouldTriggerAlert_DrawdownScopedToUser(t *testing.T) {
+	owner := uuid.New()
+	engine := newTestAlertEngine(t)
+	rule := drawdownRule(owner)
+
+	tests := []struct {
+		name     string
+		userID   string
+		expected bool
+	}{
+		{"rule owner", owner.String(), true},
+		{"another user", uuid.New().String(), false},
+		{"no user", "", false},
+		{"legacy integer ID", "42", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, engine.shouldTriggerAlert(drawdownEvent(t, tt.userID), rule))
+		})
+	}
+}
+
+func TestInvalidateRules_ReloadsNewRules(t *testing.T) {
+	owner := uuid.New()
+	engine := newTestAlertEngine(t)
+	sqlDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer sqlDB.Close()
+	engine.db, err = gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+	engine.rulesCache[uuid.New()] = []*models.AlertRule{drawdownRule(uuid.New())}
+
+	// A rule created after the cache was filled only shows up once the cache is dropped
+	assert.NotContains(t, engine.getAllActiveRules(), owner)
+
+	mock.ExpectQuery(`SELECT \* FROM "alert_rules" WHERE is_active = \$1`).
+		WithArgs(true).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "rule_id", "event_types", "is_active"}).
+			AddRow(1, owner, "drawdown-"+owner.String(), "{bankroll_drawdown}", true))
+	engine.InvalidateRules()
+
+	rules := engine.getAllActiveRules()
+	require.Len(t, rules[owner], 1)
+	assert.Equal(t, "drawdown-"+owner.String(), rules[owner][0].RuleID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
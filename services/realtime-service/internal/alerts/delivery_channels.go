@@ -8,6 +8,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/redis/go-redis/v9"
 	"github.com/sirupsen/logrus"
 
@@ -33,7 +34,7 @@ type DeliveryManager struct {
 
 // DeliveryChannel represents different alert delivery methods
 type ChannelHandler interface {
-	DeliverAlert(alert models.Alert, userID int) error
+	DeliverAlert(alert models.Alert, userID uuid.UUID) error
 	GetChannelType() models.DeliveryChannel
 	IsAvailable() bool
 	GetDeliveryStats() ChannelStats
@@ -70,7 +71,7 @@ func NewDeliveryManager(redisClient *redis.Client, logger *logrus.Logger) *Deliv
 }
 
 // DeliverAlert delivers an alert through the specified channel
-func (dm *DeliveryManager) DeliverAlert(alert models.Alert, channel models.DeliveryChannel, userID int) error {
+func (dm *DeliveryManager) DeliverAlert(alert models.Alert, channel models.DeliveryChannel, userID uuid.UUID) error {
 	handler := dm.getChannelHandler(channel)
 	if handler == nil {
 		return fmt.Errorf("no handler available for channel: %s", channel)
@@ -114,7 +115,7 @@ func (dm *DeliveryManager) DeliverAlert(alert models.Alert, channel models.Deliv
 }
 
 // deliverWithTimeout delivers an alert with timeout context
-func (dm *DeliveryManager) deliverWithTimeout(ctx context.Context, handler ChannelHandler, alert models.Alert, userID int) error {
+func (dm *DeliveryManager) deliverWithTimeout(ctx context.Context, handler ChannelHandler, alert models.Alert, userID uuid.UUID) error {
 	done := make(chan error, 1)
 	
 	go func() {
@@ -183,7 +184,7 @@ func NewWebSocketHandler(redisClient *redis.Client, logger *logrus.Logger) *WebS
 	}
 }
 
-func (wh *WebSocketHandler) DeliverAlert(alert models.Alert, userID int) error {
+func (wh *WebSocketHandler) DeliverAlert(alert models.Alert, userID uuid.UUID) error {
 	startTime := time.Now()
 	
 	// Publish alert to Redis channel for WebSocket delivery
@@ -201,7 +202,7 @@ func (wh *WebSocketHandler) DeliverAlert(alert models.Alert, userID int) error {
 	}
 	
 	// Publish to user-specific channel
-	channel := fmt.Sprintf("alerts:user:%d", userID)
+	channel := fmt.Sprintf("alerts:user:%s", userID)
 	if err := wh.redisClient.Publish(context.Background(), channel, messageBytes).Err(); err != nil {
 		wh.updateStats(false, time.Since(startTime))
 		return fmt.Errorf("failed to publish WebSocket alert: %w", err)
@@ -277,7 +278,7 @@ func NewEmailHandler(logger *logrus.Logger) *EmailHandler {
 	}
 }
 
-func (eh *EmailHandler) DeliverAlert(alert models.Alert, userID int) error {
+func (eh *EmailHandler) DeliverAlert(alert models.Alert, userID uuid.UUID) error {
 	if !eh.isConfigured {
 		return fmt.Errorf("email delivery not configured")
 	}
@@ -367,7 +368,7 @@ func NewPushHandler(logger *logrus.Logger) *PushHandler {
 	}
 }
 
-func (ph *PushHandler) DeliverAlert(alert models.Alert, userID int) error {
+func (ph *PushHandler) DeliverAlert(alert models.Alert, userID uuid.UUID) error {
 	if !ph.isConfigured {
 		return fmt.Errorf("push notifications not configured")
 	}
@@ -450,7 +451,7 @@ func NewSMSHandler(logger *logrus.Logger) *SMSHandler {
 	}
 }
 
-func (sh *SMSHandler) DeliverAlert(alert models.Alert, userID int) error {
+func (sh *SMSHandler) DeliverAlert(alert models.Alert, userID uuid.UUID) error {
 	if !sh.isConfigured {
 		return fmt.Errorf("SMS delivery not configured")
 	}
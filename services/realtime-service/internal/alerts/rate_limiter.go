@@ -5,9 +5,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/redis/go-redis/v9"
 	"github.com/sirupsen/logrus"
 )
@@ -20,7 +22,7 @@ type AlertRateLimiter struct {
 	windowDuration  time.Duration // Rate limiting window duration
 	
 	// User-specific limits
-	userLimits      map[int]int   // user_id -> custom limit
+	userLimits      map[uuid.UUID]int // user_id -> custom limit
 	userLimitsMutex sync.RWMutex
 	
 	// Rate limiting statistics
@@ -41,7 +43,7 @@ type RateLimitStats struct {
 
 // UserRateLimit represents a user's current rate limit status
 type UserRateLimit struct {
-	UserID       int       `json:"user_id"`
+	UserID       uuid.UUID `json:"user_id"`
 	AlertCount   int       `json:"alert_count"`
 	Limit        int       `json:"limit"`
 	WindowStart  time.Time `json:"window_start"`
@@ -53,7 +55,7 @@ type UserRateLimit struct {
 
 // RateLimitRule represents a rate limiting rule
 type RateLimitRule struct {
-	UserID       int           `json:"user_id"`
+	UserID       uuid.UUID     `json:"user_id"`
 	RuleID       string        `json:"rule_id"`
 	AlertType    string        `json:"alert_type"`
 	Limit        int           `json:"limit"`
@@ -69,13 +71,13 @@ func NewAlertRateLimiter(redisClient *redis.Client, defaultLimit int, logger *lo
 		logger:         logger,
 		defaultLimit:   defaultLimit,
 		windowDuration: time.Hour, // 1-hour windows by default
-		userLimits:     make(map[int]int),
+		userLimits:     make(map[uuid.UUID]int),
 		stats:          &RateLimitStats{},
 	}
 }
 
 // CanSendAlert checks if an alert can be sent to a user without exceeding rate limits
-func (rl *AlertRateLimiter) CanSendAlert(userID int, ruleID string) bool {
+func (rl *AlertRateLimiter) CanSendAlert(userID uuid.UUID, ruleID string) bool {
 	// Get user's rate limit
 	limit := rl.getUserLimit(userID)
 	
@@ -109,7 +111,7 @@ func (rl *AlertRateLimiter) CanSendAlert(userID int, ruleID string) bool {
 }
 
 // CanSendAlertWithPriority checks rate limits with priority consideration
-func (rl *AlertRateLimiter) CanSendAlertWithPriority(userID int, ruleID string, priority string) bool {
+func (rl *AlertRateLimiter) CanSendAlertWithPriority(userID uuid.UUID, ruleID string, priority string) bool {
 	// High priority alerts may have higher limits or bypass certain restrictions
 	if priority == "critical" || priority == "high" {
 		// Use a higher limit for high-priority alerts
@@ -131,7 +133,7 @@ func (rl *AlertRateLimiter) CanSendAlertWithPriority(userID int, ruleID string,
 }
 
 // GetUserRateLimit returns the current rate limit status for a user
-func (rl *AlertRateLimiter) GetUserRateLimit(userID int) (*UserRateLimit, error) {
+func (rl *AlertRateLimiter) GetUserRateLimit(userID uuid.UUID) (*UserRateLimit, error) {
 	limit := rl.getUserLimit(userID)
 	usage, err := rl.getCurrentUsage(userID)
 	if err != nil {
@@ -154,7 +156,7 @@ func (rl *AlertRateLimiter) GetUserRateLimit(userID int) (*UserRateLimit, error)
 }
 
 // SetUserLimit sets a custom rate limit for a specific user
-func (rl *AlertRateLimiter) SetUserLimit(userID int, limit int) {
+func (rl *AlertRateLimiter) SetUserLimit(userID uuid.UUID, limit int) {
 	rl.userLimitsMutex.Lock()
 	rl.userLimits[userID] = limit
 	rl.userLimitsMutex.Unlock()
@@ -166,7 +168,7 @@ func (rl *AlertRateLimiter) SetUserLimit(userID int, limit int) {
 }
 
 // RemoveUserLimit removes a custom rate limit for a user (reverts to default)
-func (rl *AlertRateLimiter) RemoveUserLimit(userID int) {
+func (rl *AlertRateLimiter) RemoveUserLimit(userID uuid.UUID) {
 	rl.userLimitsMutex.Lock()
 	delete(rl.userLimits, userID)
 	rl.userLimitsMutex.Unlock()
@@ -175,7 +177,7 @@ func (rl *AlertRateLimiter) RemoveUserLimit(userID int) {
 }
 
 // getUserLimit returns the rate limit for a specific user
-func (rl *AlertRateLimiter) getUserLimit(userID int) int {
+func (rl *AlertRateLimiter) getUserLimit(userID uuid.UUID) int {
 	rl.userLimitsMutex.RLock()
 	defer rl.userLimitsMutex.RUnlock()
 	
@@ -187,7 +189,7 @@ func (rl *AlertRateLimiter) getUserLimit(userID int) int {
 }
 
 // getCurrentUsage returns the current alert count for a user in the current window
-func (rl *AlertRateLimiter) getCurrentUsage(userID int) (int, error) {
+func (rl *AlertRateLimiter) getCurrentUsage(userID uuid.UUID) (int, error) {
 	ctx := context.Background()
 	key := rl.getUserKey(userID)
 	
@@ -208,7 +210,7 @@ func (rl *AlertRateLimiter) getCurrentUsage(userID int) (int, error) {
 }
 
 // incrementUsage increments the usage counter for a user
-func (rl *AlertRateLimiter) incrementUsage(userID int) error {
+func (rl *AlertRateLimiter) incrementUsage(userID uuid.UUID) error {
 	ctx := context.Background()
 	key := rl.getUserKey(userID)
 	
@@ -226,10 +228,27 @@ func (rl *AlertRateLimiter) incrementUsage(userID int) error {
 }
 
 // getUserKey generates a Redis key for a user's rate limit counter
-func (rl *AlertRateLimiter) getUserKey(userID int) string {
+func (rl *AlertRateLimiter) getUserKey(userID uuid.UUID) string {
 	windowStart := rl.getCurrentWindowStart()
 	windowID := windowStart.Unix() / int64(rl.windowDuration.Seconds())
-	return fmt.Sprintf("alert_rate_limit:user:%d:window:%d", userID, windowID)
+	return fmt.Sprintf("alert_rate_limit:user:%s:window:%d", userID, windowID)
+}
+
+// parseUserKey splits a key made by getUserKey into its user and window IDs
+func parseUserKey(key string) (uuid.UUID, int64, bool) {
+	parts := strings.Split(key, ":")
+	if len(parts) != 5 || parts[0] != "alert_rate_limit" || parts[1] != "user" || parts[3] != "window" {
+		return uuid.Nil, 0, false
+	}
+	userID, err := uuid.Parse(parts[2])
+	if err != nil {
+		return uuid.Nil, 0, false
+	}
+	windowID, err := strconv.ParseInt(parts[4], 10, 64)
+	if err != nil {
+		return uuid.Nil, 0, false
+	}
+	return userID, windowID, true
 }
 
 // getCurrentWindowStart returns the start time of the current rate limiting window
@@ -241,7 +260,7 @@ func (rl *AlertRateLimiter) getCurrentWindowStart() time.Time {
 }
 
 // ResetUserLimit resets the rate limit counter for a specific user
-func (rl *AlertRateLimiter) ResetUserLimit(userID int) error {
+func (rl *AlertRateLimiter) ResetUserLimit(userID uuid.UUID) error {
 	ctx := context.Background()
 	key := rl.getUserKey(userID)
 	
@@ -288,8 +307,8 @@ func (rl *AlertRateLimiter) GetActiveUsers() ([]UserRateLimit, error) {
 	
 	for _, key := range keys {
 		// Extract user ID from key
-		var userID int
-		if _, err := fmt.Sscanf(key, "alert_rate_limit:user:%d:window:%d", &userID, &windowID); err != nil {
+		userID, _, ok := parseUserKey(key)
+		if !ok {
 			continue
 		}
 		
@@ -321,8 +340,8 @@ func (rl *AlertRateLimiter) CleanupExpiredWindows() error {
 	
 	for _, key := range keys {
 		// Extract window ID from key
-		var userID, windowID int64
-		if _, err := fmt.Sscanf(key, "alert_rate_limit:user:%d:window:%d", &userID, &windowID); err != nil {
+		_, windowID, ok := parseUserKey(key)
+		if !ok {
 			continue
 		}
 		
@@ -348,7 +367,7 @@ func (rl *AlertRateLimiter) CleanupExpiredWindows() error {
 func (rl *AlertRateLimiter) SetRateLimitRule(rule RateLimitRule) error {
 	// Store rule in Redis for persistence
 	ctx := context.Background()
-	ruleKey := fmt.Sprintf("rate_limit_rules:user:%d:rule:%s", rule.UserID, rule.RuleID)
+	ruleKey := fmt.Sprintf("rate_limit_rules:user:%s:rule:%s", rule.UserID, rule.RuleID)
 	
 	ruleJSON, err := json.Marshal(rule)
 	if err != nil {
@@ -2,17 +2,22 @@ package handlers
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"github.com/redis/go-redis/v9"
 	"github.com/sirupsen/logrus"
+	"gorm.io/datatypes"
 	"gorm.io/gorm"
 
 	"github.com/stitts-dev/dfs-sim/services/realtime-service/internal/alerts"
 	"github.com/stitts-dev/dfs-sim/services/realtime-service/internal/events"
 	"github.com/stitts-dev/dfs-sim/services/realtime-service/internal/lateswap"
+	"github.com/stitts-dev/dfs-sim/services/realtime-service/internal/models"
 	"github.com/stitts-dev/dfs-sim/services/realtime-service/internal/ownership"
 )
 
@@ -82,11 +87,61 @@ func (h *Handlers) ReadinessCheck(c *gin.Context) {
 	})
 }
 
+// createEventRequest is the body other services post to publish a real-time event
+type createEventRequest struct {
+	EventType      models.EventType `json:"event_type" binding:"required"`
+	Source         string           `json:"source" binding:"required"`
+	Data           json.RawMessage  `json:"data" binding:"required"`
+	PlayerID       *uint            `json:"player_id"`
+	GameID         *string          `json:"game_id"`
+	TournamentID   *string          `json:"tournament_id"`
+	ImpactRating   float64          `json:"impact_rating"`
+	Confidence     *float64         `json:"confidence"`
+	ExpirationTime *time.Time       `json:"expiration_time"`
+}
+
+// CreateEvent publishes an event to the stream, where the event processor stores it and runs
+// its handler
 func (h *Handlers) CreateEvent(c *gin.Context) {
-	// TODO: Implement event creation
-	c.JSON(http.StatusNotImplemented, gin.H{
-		"error": "CreateEvent not implemented",
-	})
+	var req createEventRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid event",
+			"details": err.Error(),
+		})
+		return
+	}
+	if !json.Valid(req.Data) || req.ImpactRating < -10 || req.ImpactRating > 10 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid event: data must be JSON and impact_rating between -10 and 10",
+		})
+		return
+	}
+
+	event := &models.RealTimeEvent{
+		EventID:        uuid.New(),
+		EventType:      req.EventType,
+		PlayerID:       req.PlayerID,
+		GameID:         req.GameID,
+		TournamentID:   req.TournamentID,
+		Timestamp:      time.Now().UTC(),
+		Source:         req.Source,
+		Data:           datatypes.JSON(req.Data),
+		ImpactRating:   req.ImpactRating,
+		Confidence:     1.0,
+		ExpirationTime: req.ExpirationTime,
+	}
+	if req.Confidence != nil {
+		event.Confidence = *req.Confidence
+	}
+
+	if err := h.eventProcessor.PublishEvent(c.Request.Context(), event); err != nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"error": "Failed to publish event",
+		})
+		return
+	}
+	c.JSON(http.StatusAccepted, event)
 }
 
 func (h *Handlers) GetEvents(c *gin.Context) {
@@ -117,18 +172,127 @@ func (h *Handlers) GetOwnershipTrends(c *gin.Context) {
 	})
 }
 
+// GetAlertRules lists the authenticated user's alert rules
 func (h *Handlers) GetAlertRules(c *gin.Context) {
-	// TODO: Implement get alert rules
-	c.JSON(http.StatusNotImplemented, gin.H{
-		"error": "GetAlertRules not implemented",
-	})
+	userID, ok := contextUserID(c)
+	if !ok {
+		return
+	}
+
+	var rules []models.AlertRule
+	if err := h.db.WithContext(c.Request.Context()).
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Find(&rules).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to load alert rules",
+			"details": err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"rules": rules})
+}
+
+// createAlertRuleRequest is the body for adding one of the user's alert rules
+type createAlertRuleRequest struct {
+	EventTypes       []models.EventType       `json:"event_types" binding:"required,min=1"`
+	ImpactThreshold  float64                  `json:"impact_threshold"`
+	Sports           []string                 `json:"sports"`
+	DeliveryChannels []models.DeliveryChannel `json:"delivery_channels"`
+	IsActive         *bool                    `json:"is_active"`
+}
+
+var alertEventTypes = map[models.EventType]bool{
+	models.EventTypePlayerInjury:     true,
+	models.EventTypePlayerStatus:     true,
+	models.EventTypeWeatherUpdate:    true,
+	models.EventTypeOwnershipChange:  true,
+	models.EventTypeContestUpdate:    true,
+	models.EventTypeLineupChange:     true,
+	models.EventTypePriceChange:      true,
+	models.EventTypeNewsUpdate:       true,
+	models.EventTypeBankrollDrawdown: true,
 }
 
+var alertDeliveryChannels = map[models.DeliveryChannel]bool{
+	models.DeliveryChannelWebSocket: true,
+	models.DeliveryChannelEmail:     true,
+	models.DeliveryChannelPush:      true,
+	models.DeliveryChannelSMS:       true,
+}
+
+// CreateAlertRule adds an alert rule owned by the authenticated user. Rules deliver over the
+// websocket unless other channels are named.
 func (h *Handlers) CreateAlertRule(c *gin.Context) {
-	// TODO: Implement create alert rule
-	c.JSON(http.StatusNotImplemented, gin.H{
-		"error": "CreateAlertRule not implemented",
+	userID, ok := contextUserID(c)
+	if !ok {
+		return
+	}
+
+	var req createAlertRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid alert rule",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	rule := models.AlertRule{
+		UserID:           userID,
+		RuleID:           uuid.New().String(),
+		ImpactThreshold:  req.ImpactThreshold,
+		Sports:           pq.StringArray(req.Sports),
+		DeliveryChannels: pq.StringArray{string(models.DeliveryChannelWebSocket)},
+		IsActive:         true,
+	}
+	for _, eventType := range req.EventTypes {
+		if !alertEventTypes[eventType] {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid alert rule: unknown event type " + string(eventType),
+			})
+			return
+		}
+		rule.EventTypes = append(rule.EventTypes, string(eventType))
+	}
+	if len(req.DeliveryChannels) > 0 {
+		rule.DeliveryChannels = nil
+		for _, channel := range req.DeliveryChannels {
+			if !alertDeliveryChannels[channel] {
+				c.JSON(http.StatusBadRequest, gin.H{
+					"error": "Invalid alert rule: unknown delivery channel " + string(channel),
+				})
+				return
+			}
+			rule.DeliveryChannels = append(rule.DeliveryChannels, string(channel))
+		}
+	}
+	if req.IsActive != nil {
+		rule.IsActive = *req.IsActive
+	}
+
+	// gorm writes the column default in place of a false is_active, so a paused rule is turned
+	// off once it's stored
+	err := h.db.WithContext(c.Request.Context()).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&rule).Error; err != nil {
+			return err
+		}
+		if req.IsActive != nil && !*req.IsActive {
+			return tx.Model(&rule).Update("is_active", false).Error
+		}
+		return nil
 	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to create alert rule",
+			"details": err.Error(),
+		})
+		return
+	}
+	if h.alertEngine != nil {
+		h.alertEngine.InvalidateRules()
+	}
+	c.JSON(http.StatusCreated, rule)
 }
 
 func (h *Handlers) UpdateAlertRule(c *gin.Context) {
@@ -193,4 +357,24 @@ func (h *Handlers) SimulateEvent(c *gin.Context) {
 	c.JSON(http.StatusNotImplemented, gin.H{
 		"error": "SimulateEvent not implemented",
 	})
-}
\ No newline at end of file
+}
+// contextUserID reads the authenticated user set by the auth middleware, writing the error
+// response when it's missing or malformed
+func contextUserID(c *gin.Context) (uuid.UUID, bool) {
+	userIDInterface, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User ID not found in context"})
+		return uuid.Nil, false
+	}
+	userIDStr, ok := userIDInterface.(string)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID format"})
+		return uuid.Nil, false
+	}
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID format"})
+		return uuid.Nil, false
+	}
+	return userID, true
+}
@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/stitts-dev/dfs-sim/services/realtime-service/internal/models"
+)
+
+// newTestAlertRouter serves the alert rule routes as userID, backed by sqlmock. An empty
+// userID leaves the request unauthenticated
+func newTestAlertRouter(t *testing.T, userID string) (*gin.Engine, sqlmock.Sqlmock) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	sqlDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { sqlDB.Close() })
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+
+	log := logrus.New()
+	log.SetLevel(logrus.PanicLevel)
+	handler := NewHandlers(gormDB, nil, nil, nil, nil, nil, log)
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		if userID != "" {
+			c.Set("user_id", userID)
+		}
+	})
+	router.GET("/alerts/rules", handler.GetAlertRules)
+	router.POST("/alerts/rules", handler.CreateAlertRule)
+	return router, mock
+}
+
+func postAlertRule(router *gin.Engine, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/alerts/rules", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestGetAlertRules_ListsTheUsersRules(t *testing.T) {
+	userID := uuid.New()
+	router, mock := newTestAlertRouter(t, userID.String())
+
+	mock.ExpectQuery(`SELECT \* FROM "alert_rules" WHERE user_id = \$1 ORDER BY created_at DESC`).
+		WithArgs(userID.String()).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "rule_id", "event_types", "is_active"}).
+			AddRow(1, userID, "drawdown", "{bankroll_drawdown}", true))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/alerts/rules", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var response struct {
+		Rules []models.AlertRule `json:"rules"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.Len(t, response.Rules, 1)
+	assert.Equal(t, userID, response.Rules[0].UserID)
+	assert.Equal(t, []string{"bankroll_drawdown"}, []string(response.Rules[0].EventTypes))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateAlertRule_OwnedByTheUser(t *testing.T) {
+	userID := uuid.New()
+	router, mock := newTestAlertRouter(t, userID.String())
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO "alert_rules" \("user_id","rule_id","event_types","impact_threshold","is_active","delivery_channels"\)`).
+		WithArgs(userID.String(), sqlmock.AnyArg(), `{"bankroll_drawdown"}`, 20.0, true, `{"websocket"}`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+	mock.ExpectCommit()
+
+	w := postAlertRule(router, `{"event_types":["bankroll_drawdown"],"impact_threshold":20}`)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	var rule models.AlertRule
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &rule))
+	assert.Equal(t, uint(7), rule.ID)
+	assert.Equal(t, userID, rule.UserID)
+	assert.NotEmpty(t, rule.RuleID)
+	assert.True(t, rule.IsActive)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateAlertRule_KeepsPausedRulePaused(t *testing.T) {
+	userID := uuid.New()
+	router, mock := newTestAlertRouter(t, userID.String())
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO "alert_rules" \("user_id","rule_id","event_types","impact_threshold","is_active","sports","delivery_channels"\)`).
+		WithArgs(userID.String(), sqlmock.AnyArg(), `{"player_injury"}`, 0.0, true, `{"nba"}`, `{"email","push"}`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(8))
+	mock.ExpectExec(`UPDATE "alert_rules" SET "is_active"=\$1,"updated_at"=\$2 WHERE "id" = \$3`).
+		WithArgs(false, sqlmock.AnyArg(), 8).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	w := postAlertRule(router, `{"event_types":["player_injury"],"sports":["nba"],"delivery_channels":["email","push"],"is_active":false}`)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	var rule models.AlertRule
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &rule))
+	assert.False(t, rule.IsActive)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateAlertRule_RejectsUnknownTypes(t *testing.T) {
+	router, mock := newTestAlertRouter(t, uuid.New().String())
+
+	for name, body := range map[string]string{
+		"no event types":   `{"event_types":[]}`,
+		"unknown event":    `{"event_types":["meteor_strike"]}`,
+		"unknown delivery": `{"event_types":["bankroll_drawdown"],"delivery_channels":["pager"]}`,
+	} {
+		w := postAlertRule(router, body)
+		assert.Equal(t, http.StatusBadRequest, w.Code, name)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet(), "nothing is written")
+}
+
+func TestAlertRules_NeedAuthenticatedUser(t *testing.T) {
+	router, mock := newTestAlertRouter(t, "")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/alerts/rules", nil))
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	w = postAlertRule(router, `{"event_types":["bankroll_drawdown"]}`)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
 
@@ -505,4 +506,61 @@ func abs(x float64) float64 {
 		return -x
 	}
 	return x
-}
\ No newline at end of file
+}
+// AlertProcessor receives events that should be evaluated against users' alert rules
+type AlertProcessor interface {
+	ProcessEvent(event models.RealTimeEvent) error
+}
+
+// BankrollDrawdownHandler forwards bankroll drawdown events to the alert engine
+type BankrollDrawdownHandler struct {
+	db     *gorm.DB
+	alerts AlertProcessor
+	logger *logrus.Logger
+}
+
+func NewBankrollDrawdownHandler(db *gorm.DB, alerts AlertProcessor, logger *logrus.Logger) *BankrollDrawdownHandler {
+	return &BankrollDrawdownHandler{
+		db:     db,
+		alerts: alerts,
+		logger: logger,
+	}
+}
+
+func (h *BankrollDrawdownHandler) GetEventType() models.EventType {
+	return models.EventTypeBankrollDrawdown
+}
+
+func (h *BankrollDrawdownHandler) GetPriority() int {
+	return 8 // A user's own money, so ahead of slate-wide updates
+}
+
+func (h *BankrollDrawdownHandler) HandleEvent(ctx context.Context, event *models.RealTimeEvent) error {
+	var drawdownData struct {
+		UserID    uuid.UUID `json:"user_id"`
+		Drawdown  float64   `json:"drawdown"`
+		Threshold float64   `json:"threshold"`
+	}
+	if err := json.Unmarshal(event.Data, &drawdownData); err != nil {
+		return fmt.Errorf("failed to parse bankroll drawdown data: %w", err)
+	}
+	if drawdownData.UserID == uuid.Nil {
+		return fmt.Errorf("bankroll drawdown event has no user_id")
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"event_id":  event.EventID,
+		"user_id":   drawdownData.UserID,
+		"drawdown":  drawdownData.Drawdown,
+		"threshold": drawdownData.Threshold,
+	}).Info("Processing bankroll drawdown event")
+
+	eventLog := models.EventLog{
+		EventID: event.EventID,
+		Action:  "bankroll_drawdown_processed",
+		Details: event.Data,
+	}
+	h.db.Create(&eventLog)
+
+	return h.alerts.ProcessEvent(*event)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"sync"
 	"time"
 
@@ -144,9 +145,9 @@ func (ep *EventProcessor) PublishEvent(ctx context.Context, event *models.RealTi
 	eventData := map[string]interface{}{
 		"event_id":      event.EventID.String(),
 		"event_type":    string(event.EventType),
-		"player_id":     event.PlayerID,
-		"game_id":       event.GameID,
-		"tournament_id": event.TournamentID,
+		"player_id":     formatUintPtr(event.PlayerID),
+		"game_id":       formatStringPtr(event.GameID),
+		"tournament_id": formatStringPtr(event.TournamentID),
 		"timestamp":     event.Timestamp.Format(time.RFC3339),
 		"source":        event.Source,
 		"data":          string(event.Data),
@@ -489,3 +490,18 @@ func formatTimePtr(t *time.Time) string {
 	return t.Format(time.RFC3339)
 }
 
+// Stream values must be plain strings or numbers, so optional fields are written as "" when unset
+func formatUintPtr(v *uint) string {
+	if v == nil {
+		return ""
+	}
+	return strconv.FormatUint(uint64(*v), 10)
+}
+
+func formatStringPtr(v *string) string {
+	if v == nil {
+		return ""
+	}
+	return *v
+}
+
@@ -21,6 +21,8 @@ const (
 	EventTypeLineupChange     EventType = "lineup_change"
 	EventTypePriceChange      EventType = "price_change"
 	EventTypeNewsUpdate       EventType = "news_update"
+	// EventTypeBankrollDrawdown is published for one user, named by "user_id" in the event data
+	EventTypeBankrollDrawdown EventType = "bankroll_drawdown"
 )
 
 // DeliveryChannel represents alert delivery methods
@@ -108,7 +110,7 @@ func (os *OwnershipSnapshot) GetPlayerOwnership(playerID uint) float64 {
 // AlertRule represents user-specific alert configuration
 type AlertRule struct {
 	ID               uint                    `json:"id" gorm:"primaryKey"`
-	UserID           int                     `json:"user_id" gorm:"index:idx_user_alerts;not null"`
+	UserID           uuid.UUID               `json:"user_id" gorm:"type:uuid;index:idx_user_alerts;not null"`
 	RuleID           string                  `json:"rule_id" gorm:"uniqueIndex;size:100;not null"`
 	EventTypes       pq.StringArray          `json:"event_types" gorm:"type:text[];not null"`
 	ImpactThreshold  float64                 `json:"impact_threshold" gorm:"default:0.0"`
@@ -232,7 +234,7 @@ type OwnershipTrend struct {
 // Alert represents a generated alert for delivery
 type Alert struct {
 	ID          uuid.UUID       `json:"id"`
-	UserID      int             `json:"user_id"`
+	UserID      uuid.UUID       `json:"user_id"`
 	RuleID      string          `json:"rule_id"`
 	EventID     uuid.UUID       `json:"event_id"`
 	Title       string          `json:"title"`
@@ -1,4 +1,5 @@
 -- Migration: Add real-time data integration schema
+-- migrate:no-transaction
 -- This migration creates tables for real-time event processing, ownership tracking, and alert management
 
 -- Real-time events table for event sourcing pattern
@@ -0,0 +1,22 @@
+-- Restore the integer owner column. Rules created since the up migration have no legacy owner
+-- unless legacy_user_mapping names one; those keep their UUID owner in user_uuid and are
+-- deactivated rather than dropped.
+
+DROP INDEX IF EXISTS idx_alert_rules_user_active;
+
+ALTER TABLE alert_rules RENAME COLUMN user_id TO user_uuid;
+ALTER TABLE alert_rules RENAME COLUMN legacy_user_id TO user_id;
+
+DO $$
+BEGIN
+    IF to_regclass('legacy_user_mapping') IS NOT NULL THEN
+        UPDATE alert_rules r SET user_id = m.legacy_id
+        FROM legacy_user_mapping m
+        WHERE m.supabase_uuid = r.user_uuid AND r.user_id IS NULL;
+    END IF;
+END $$;
+
+UPDATE alert_rules SET is_active = false WHERE user_id IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_alert_rules_user_active
+    ON alert_rules(user_id) WHERE is_active = true;
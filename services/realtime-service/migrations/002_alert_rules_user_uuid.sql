@@ -0,0 +1,28 @@
+-- Migration: Key alert rules by the platform's UUID user IDs
+-- Every other service identifies users by UUID, and user-scoped events such as bankroll_drawdown
+-- name that UUID. Integer owners are carried over through user-service's legacy_user_mapping.
+-- Rules whose owner has no mapping yet are kept, deactivated, with the integer owner in
+-- legacy_user_id so they can be reassigned once the mapping exists.
+
+ALTER TABLE alert_rules ADD COLUMN user_uuid UUID;
+
+DO $$
+BEGIN
+    IF to_regclass('legacy_user_mapping') IS NOT NULL THEN
+        UPDATE alert_rules r SET user_uuid = m.supabase_uuid
+        FROM legacy_user_mapping m
+        WHERE m.legacy_id = r.user_id;
+    END IF;
+END $$;
+
+UPDATE alert_rules SET is_active = false WHERE user_uuid IS NULL;
+
+-- The partial index is keyed on the integer column and is rebuilt below
+DROP INDEX IF EXISTS idx_alert_rules_user_active;
+
+ALTER TABLE alert_rules RENAME COLUMN user_id TO legacy_user_id;
+ALTER TABLE alert_rules ALTER COLUMN legacy_user_id DROP NOT NULL;
+ALTER TABLE alert_rules RENAME COLUMN user_uuid TO user_id;
+
+CREATE INDEX IF NOT EXISTS idx_alert_rules_user_active
+    ON alert_rules(user_id) WHERE is_active = true;
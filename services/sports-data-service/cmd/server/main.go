@@ -57,6 +57,13 @@ func main() {
 	}
 	defer db.Close()
 
+	// Apply pending schema migrations when enabled
+	if cfg.RunMigrations {
+		if err := db.Migrate(context.Background(), "sports-data-service", cfg.MigrationsDir, logger.WithService("sports-data-service")); err != nil {
+			logger.WithService("sports-data-service").Fatalf("Failed to migrate database: %v", err)
+		}
+	}
+
 	// Connect to Redis
 	opt, err := redis.ParseURL(cfg.RedisURL)
 	if err != nil {
@@ -90,6 +97,16 @@ func main() {
 
 	// Initialize golf business services
 	golfProjectionService := services.NewGolfProjectionService(db, cacheService, structuredLogger, cfg.DataGolfAPIKey)
+	if weatherService, err := services.NewWeatherService(redisClient); err == nil {
+		golfProjectionService.SetWeatherService(weatherService)
+	} else {
+		logger.WithService("sports-data-service").WithError(err).Warn("Weather service disabled, projections will not include tee time waves")
+	}
+	golfProjectionService.SetOddsBlendWeights(services.GolfOddsBlendWeights{
+		Win:     cfg.GolfOddsWinWeight,
+		Top10:   cfg.GolfOddsTop10Weight,
+		MakeCut: cfg.GolfOddsMakeCutWeight,
+	})
 
 	// Use DataGolf as the only provider
 	var primaryGolfProvider interface {
@@ -152,6 +169,8 @@ func main() {
 		apiV1.GET("/golf/tournaments/:id", golfHandler.GetTournament)
 		apiV1.GET("/golf/tournaments/:id/leaderboard", golfHandler.GetTournamentLeaderboard)
 		apiV1.GET("/golf/tournaments/:id/players", golfHandler.GetTournamentPlayers)
+		apiV1.GET("/golf/tournaments/:id/odds", golfHandler.GetTournamentOdds)
+		apiV1.GET("/golf/tournaments/:id/waves", golfHandler.GetTournamentWaves)
 		apiV1.POST("/golf/tournaments/sync", golfHandler.SyncTournamentData)
 
 		// Golf player endpoints
@@ -3,6 +3,7 @@ module github.com/stitts-dev/dfs-sim/services/sports-data-service
 go 1.21
 
 require (
+	github.com/DATA-DOG/go-sqlmock v1.5.2
 	github.com/gin-gonic/gin v1.9.1
 	github.com/google/uuid v1.3.0
 	github.com/lib/pq v1.10.9
@@ -12,6 +13,7 @@ require (
 	github.com/sony/gobreaker v0.5.0
 	github.com/stitts-dev/dfs-sim/shared v0.0.0
 	github.com/stretchr/testify v1.8.3
+	gorm.io/driver/postgres v1.5.0
 	gorm.io/gorm v1.25.0
 )
 
@@ -62,5 +64,4 @@ require (
 	google.golang.org/protobuf v1.30.0 // indirect
 	gopkg.in/ini.v1 v1.67.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
-	gorm.io/driver/postgres v1.5.0 // indirect
 )
@@ -558,6 +558,83 @@ func (h *GolfHandler) GetGolfProjections(c *gin.Context) {
 	})
 }
 
+// GetTournamentOdds returns the no-vig betting market for a tournament alongside our model's
+// probabilities, plus head-to-head matchup prices
+func (h *GolfHandler) GetTournamentOdds(c *gin.Context) {
+	tournamentID := c.Param("id")
+	market := c.Query("market")
+
+	var tournament models.GolfTournament
+	if err := h.db.First(&tournament, "id = ? OR external_id = ?", tournamentID, tournamentID).Error; err != nil {
+		utils.SendNotFound(c, "Tournament not found")
+		return
+	}
+
+	var entries []models.GolfPlayerEntry
+	if err := h.db.Where("tournament_id = ?", tournament.ID).
+		Preload("Player").
+		Find(&entries).Error; err != nil {
+		h.logger.Error("Failed to fetch player entries", "error", err)
+		utils.SendInternalError(c, "Failed to fetch players")
+		return
+	}
+
+	playerInterfaces := make([]types.PlayerInterface, 0, len(entries))
+	for _, entry := range entries {
+		if entry.Player != nil {
+			playerInterfaces = append(playerInterfaces, entry.Player)
+		}
+	}
+
+	comparisons, err := h.projectionService.CompareWithMarket(c.Request.Context(), playerInterfaces, tournament.ID.String())
+	if err != nil {
+		h.logger.Error("Failed to compare projections with market", "error", err)
+		utils.SendInternalError(c, "Failed to load odds")
+		return
+	}
+	if market != "" {
+		filtered := comparisons[:0]
+		for _, comparison := range comparisons {
+			if comparison.Market == market {
+				filtered = append(filtered, comparison)
+			}
+		}
+		comparisons = filtered
+	}
+
+	var matchups []models.GolfMatchupOdds
+	if err := h.db.Where("tournament_id = ?", tournament.ID).
+		Order("matchup_id, player").
+		Find(&matchups).Error; err != nil {
+		h.logger.Error("Failed to fetch matchup odds", "error", err)
+		utils.SendInternalError(c, "Failed to load odds")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"tournament": tournament,
+		"outrights":  comparisons,
+		"matchups":   matchups,
+	})
+}
+
+// GetTournamentWaves returns the AM/PM wave split for a tournament and the strokes advantage of
+// each draw under the course forecast
+func (h *GolfHandler) GetTournamentWaves(c *gin.Context) {
+	report, err := h.projectionService.WaveReport(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.logger.WithError(err).Warn("Failed to analyze tee time waves")
+		utils.SendNotFound(c, "Wave analysis not available for this tournament")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"tournament_id": c.Param("id"),
+		"waves":         report.Waves,
+		"players":       report.Players,
+	})
+}
+
 // GetTournamentSchedule returns the upcoming tournament schedule
 func (h *GolfHandler) GetTournamentSchedule(c *gin.Context) {
 	// Get year parameter, default to current year
@@ -169,8 +169,8 @@ type GolfCourseHistory struct {
 	WorstFinish        int        `json:"worst_finish"`
 	CutsMade           int        `json:"cuts_made"`
 	MissedCuts         int        `json:"missed_cuts"`
-	Top10s             int        `json:"top_10s"`
-	Top25s             int        `json:"top_25s"`
+	Top10s             int        `gorm:"column:top_10s" json:"top_10s"`
+	Top25s             int        `gorm:"column:top_25s" json:"top_25s"`
 	Wins               int        `json:"wins"`
 	StrokesGainedTotal float64    `json:"strokes_gained_total"`
 	SGTeeToGreen       float64    `json:"sg_tee_to_green"`
@@ -0,0 +1,65 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Skills regressed in the course fit model
+const (
+	CourseFitSkillOffTee          = "sg_ott"
+	CourseFitSkillApproach        = "sg_app"
+	CourseFitSkillAroundGreen     = "sg_arg"
+	CourseFitSkillPutting         = "sg_putt"
+	CourseFitSkillDrivingDistance = "driving_distance"
+	CourseFitSkillDrivingAccuracy = "driving_accuracy"
+)
+
+// GolfCourseFit holds the diagnostics of the latest skill regression for one course
+type GolfCourseFit struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	CourseKey string    `gorm:"not null;uniqueIndex" json:"course_key"`
+	Course    string    `json:"course"`
+	Events    int       `json:"events"`
+	// Observations is the number of player-events with a pre-event skill baseline
+	Observations int     `json:"observations"`
+	Intercept    float64 `json:"intercept"`
+	RSquared     float64 `gorm:"column:r_squared" json:"r_squared"`
+	RMSE         float64 `gorm:"column:rmse" json:"rmse"`
+	// TourRSquared is the fit of the tour-wide coefficients on this course's observations, so
+	// RSquared above it is what the course-specific premiums explain
+	TourRSquared float64                  `gorm:"column:tour_r_squared" json:"tour_r_squared"`
+	Shrinkage    float64                  `json:"shrinkage"`
+	FittedAt     time.Time                `json:"fitted_at"`
+	Skills       []GolfCourseSkillPremium `gorm:"foreignKey:CourseKey;references:CourseKey" json:"skills"`
+	CreatedAt    time.Time                `json:"created_at"`
+	UpdatedAt    time.Time                `json:"updated_at"`
+}
+
+// TableName specifies the table name for GORM
+func (GolfCourseFit) TableName() string {
+	return "golf_course_fits"
+}
+
+// GolfCourseSkillPremium is one skill's fitted effect at a course
+type GolfCourseSkillPremium struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	CourseKey string    `gorm:"not null;uniqueIndex:idx_golf_course_skill" json:"course_key"`
+	Skill     string    `gorm:"not null;uniqueIndex:idx_golf_course_skill" json:"skill"`
+	// Coefficient is strokes gained per round at the course for one unit of the skill: a stroke
+	// of baseline strokes gained, ten yards of distance or ten points of fairway percentage
+	Coefficient float64 `json:"coefficient"`
+	StdError    float64 `json:"std_error"`
+	// Premium is the coefficient less the tour-wide coefficient; positive means the skill is
+	// worth more here than at an average course
+	Premium float64 `json:"premium"`
+	// Importance is the skill's share of the spread in predicted scores at the course
+	Importance float64   `json:"importance"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// TableName specifies the table name for GORM
+func (GolfCourseSkillPremium) TableName() string {
+	return "golf_course_skill_premiums"
+}
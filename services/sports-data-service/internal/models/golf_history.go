@@ -0,0 +1,110 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Backfill progress states
+const (
+	BackfillStatusComplete = "complete"
+	BackfillStatusFailed   = "failed"
+)
+
+// GolfHistoricalEvent is one edition of a tournament in the DataGolf historical archive
+type GolfHistoricalEvent struct {
+	ID        uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	Tour      string     `gorm:"not null;uniqueIndex:idx_golf_hist_event" json:"tour"`
+	EventID   string     `gorm:"not null;uniqueIndex:idx_golf_hist_event" json:"event_id"`
+	Year      int        `gorm:"not null;uniqueIndex:idx_golf_hist_event" json:"year"`
+	EventName string     `json:"event_name"`
+	Course    string     `json:"course"`
+	CourseKey string     `gorm:"index" json:"course_key"`
+	StartDate *time.Time `json:"start_date"`
+	EndDate   *time.Time `json:"end_date"`
+	// CoursePar, CutLine and FieldSize are derived from the rounds once they are loaded.
+	// CutLine is the 36-hole score to par of the last player through, nil for no-cut events.
+	CoursePar int       `json:"course_par"`
+	CutLine   *int      `json:"cut_line"`
+	FieldSize int       `json:"field_size"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName specifies the table name for GORM
+func (GolfHistoricalEvent) TableName() string {
+	return "golf_historical_events"
+}
+
+// GolfHistoricalRound is a single round by a player at a historical event
+type GolfHistoricalRound struct {
+	ID         uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	Tour       string    `gorm:"not null;uniqueIndex:idx_golf_hist_round" json:"tour"`
+	EventID    string    `gorm:"not null;uniqueIndex:idx_golf_hist_round" json:"event_id"`
+	Year       int       `gorm:"not null;uniqueIndex:idx_golf_hist_round" json:"year"`
+	Round      int       `gorm:"not null;uniqueIndex:idx_golf_hist_round" json:"round"`
+	DGID       int       `gorm:"column:dg_id;not null;uniqueIndex:idx_golf_hist_round;index" json:"dg_id"`
+	PlayerName string    `json:"player_name"`
+	Score      int       `json:"score"`
+	CoursePar  int       `json:"course_par"`
+	SGOffTee   float64   `gorm:"column:sg_ott" json:"sg_ott"`
+	SGApproach float64   `gorm:"column:sg_app" json:"sg_app"`
+	SGAround   float64   `gorm:"column:sg_arg" json:"sg_arg"`
+	SGPutting  float64   `gorm:"column:sg_putt" json:"sg_putt"`
+	SGTotal    float64   `gorm:"column:sg_total" json:"sg_total"`
+	// DrivingDistance is yards per measured drive and DrivingAccuracy the share of fairways hit,
+	// zero where the round has no driving stats
+	DrivingDistance float64   `gorm:"column:driving_dist" json:"driving_dist"`
+	DrivingAccuracy float64   `gorm:"column:driving_acc" json:"driving_acc"`
+	TeeTime         string    `json:"tee_time"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+// TableName specifies the table name for GORM
+func (GolfHistoricalRound) TableName() string {
+	return "golf_historical_rounds"
+}
+
+// GolfHistoricalDFSResult is a player's salary, ownership and fantasy points at an event on one site
+type GolfHistoricalDFSResult struct {
+	ID         uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	Tour       string    `gorm:"not null;uniqueIndex:idx_golf_hist_dfs" json:"tour"`
+	Site       string    `gorm:"not null;uniqueIndex:idx_golf_hist_dfs" json:"site"`
+	EventID    string    `gorm:"not null;uniqueIndex:idx_golf_hist_dfs" json:"event_id"`
+	Year       int       `gorm:"not null;uniqueIndex:idx_golf_hist_dfs" json:"year"`
+	DGID       int       `gorm:"column:dg_id;not null;uniqueIndex:idx_golf_hist_dfs;index" json:"dg_id"`
+	PlayerName string    `json:"player_name"`
+	Salary     int       `json:"salary"`
+	Ownership  float64   `json:"ownership"`
+	Points     float64   `json:"points"`
+	Finish     int       `json:"finish"`
+	MadeCut    bool      `json:"made_cut"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// TableName specifies the table name for GORM
+func (GolfHistoricalDFSResult) TableName() string {
+	return "golf_historical_dfs_results"
+}
+
+// GolfBackfillProgress records which event downloads have finished so an interrupted backfill
+// can resume where it stopped
+type GolfBackfillProgress struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	Dataset   string    `gorm:"not null;uniqueIndex:idx_golf_backfill" json:"dataset"` // "rounds" or "dfs:<site>"
+	Tour      string    `gorm:"not null;uniqueIndex:idx_golf_backfill" json:"tour"`
+	EventID   string    `gorm:"not null;uniqueIndex:idx_golf_backfill" json:"event_id"`
+	Year      int       `gorm:"not null;uniqueIndex:idx_golf_backfill" json:"year"`
+	Status    string    `gorm:"not null" json:"status"`
+	Rows      int       `json:"rows"`
+	Attempts  int       `json:"attempts"`
+	LastError string    `json:"last_error,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName specifies the table name for GORM
+func (GolfBackfillProgress) TableName() string {
+	return "golf_backfill_progress"
+}
@@ -0,0 +1,56 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Outright betting markets ingested from DataGolf
+const (
+	GolfMarketWin     = "win"
+	GolfMarketTop5    = "top_5"
+	GolfMarketTop10   = "top_10"
+	GolfMarketTop20   = "top_20"
+	GolfMarketMakeCut = "make_cut"
+)
+
+// GolfMarketOdds is the sportsbook consensus for one player in one outright market, with the
+// bookmakers' margin removed
+type GolfMarketOdds struct {
+	ID           uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	TournamentID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_golf_market_odds" json:"tournament_id"`
+	Market       string    `gorm:"not null;uniqueIndex:idx_golf_market_odds" json:"market"`
+	DGID         int       `gorm:"column:dg_id;not null;uniqueIndex:idx_golf_market_odds" json:"dg_id"`
+	PlayerName   string    `json:"player_name"`
+	// ImpliedProb is the average no-vig probability across the books pricing the player
+	ImpliedProb  float64   `json:"implied_prob"`
+	DataGolfProb float64   `json:"datagolf_prob"`
+	Books        int       `json:"books"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// TableName specifies the table name for GORM
+func (GolfMarketOdds) TableName() string {
+	return "golf_market_odds"
+}
+
+// GolfMatchupOdds is the no-vig consensus price for one side of a head-to-head or three-ball
+type GolfMatchupOdds struct {
+	ID           uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	TournamentID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_golf_matchup_odds" json:"tournament_id"`
+	Market       string    `gorm:"not null;uniqueIndex:idx_golf_matchup_odds" json:"market"`
+	MatchupID    string    `gorm:"not null;uniqueIndex:idx_golf_matchup_odds" json:"matchup_id"`
+	Player       string    `gorm:"not null;uniqueIndex:idx_golf_matchup_odds" json:"player"`
+	ImpliedProb  float64   `json:"implied_prob"`
+	DataGolfProb float64   `json:"datagolf_prob"`
+	Books        int       `json:"books"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// TableName specifies the table name for GORM
+func (GolfMatchupOdds) TableName() string {
+	return "golf_matchup_odds"
+}
@@ -1180,6 +1180,177 @@ func (c *DataGolfClient) GetLiveTournamentData(tournamentID string) (*LiveTourna
 	return &response, nil
 }
 
+// GetHistoricalEventList fetches the events with round-level historical data for a tour
+func (c *DataGolfClient) GetHistoricalEventList(tour string) (*HistoricalEventListResponse, error) {
+	cacheKey := fmt.Sprintf("datagolf:historical:events:%s", tour)
+
+	var cached HistoricalEventListResponse
+	if err := c.cache.GetSimple(cacheKey, &cached); err == nil {
+		return &cached, nil
+	}
+
+	url := fmt.Sprintf("%s/historical-raw-data/event-list?tour=%s&file_format=json&key=%s",
+		c.baseURL, tour, c.apiKey)
+
+	var response HistoricalEventListResponse
+	if err := c.makeRequest(url, &response); err != nil {
+		return nil, fmt.Errorf("failed to fetch historical event list: %w", err)
+	}
+
+	// New events are only added weekly
+	c.cache.SetSimple(cacheKey, response, 24*time.Hour)
+
+	return &response, nil
+}
+
+// GetHistoricalRounds fetches every round played at one event. Callers store the result, so it
+// is not cached.
+func (c *DataGolfClient) GetHistoricalRounds(tour, eventID string, year int) (*HistoricalRoundsResponse, error) {
+	url := fmt.Sprintf("%s/historical-raw-data/rounds?tour=%s&event_id=%s&year=%d&file_format=json&key=%s",
+		c.baseURL, tour, eventID, year, c.apiKey)
+
+	var response HistoricalRoundsResponse
+	if err := c.makeRequest(url, &response); err != nil {
+		return nil, fmt.Errorf("failed to fetch historical rounds for event %s/%d: %w", eventID, year, err)
+	}
+	return &response, nil
+}
+
+// GetHistoricalDFSEventList fetches the events with historical DFS salaries and points
+func (c *DataGolfClient) GetHistoricalDFSEventList() (*HistoricalDFSEventListResponse, error) {
+	cacheKey := "datagolf:historical:dfs_events"
+
+	var cached HistoricalDFSEventListResponse
+	if err := c.cache.GetSimple(cacheKey, &cached); err == nil {
+		return &cached, nil
+	}
+
+	url := fmt.Sprintf("%s/historical-dfs-data/event-list?file_format=json&key=%s", c.baseURL, c.apiKey)
+
+	var response HistoricalDFSEventListResponse
+	if err := c.makeRequest(url, &response); err != nil {
+		return nil, fmt.Errorf("failed to fetch historical DFS event list: %w", err)
+	}
+
+	c.cache.SetSimple(cacheKey, response, 24*time.Hour)
+
+	return &response, nil
+}
+
+// GetHistoricalDFSPoints fetches salaries, ownership and fantasy points for one event on one site
+func (c *DataGolfClient) GetHistoricalDFSPoints(tour, site, eventID string, year int) (*HistoricalDFSResponse, error) {
+	url := fmt.Sprintf("%s/historical-dfs-data/points?tour=%s&site=%s&event_id=%s&year=%d&file_format=json&key=%s",
+		c.baseURL, tour, site, eventID, year, c.apiKey)
+
+	var response HistoricalDFSResponse
+	if err := c.makeRequest(url, &response); err != nil {
+		return nil, fmt.Errorf("failed to fetch historical DFS points for event %s/%d on %s: %w", eventID, year, site, err)
+	}
+	return &response, nil
+}
+
+// GetOutrights fetches sportsbook and DataGolf odds for an outright market on the current event:
+// win, top_5, top_10, top_20 or make_cut
+func (c *DataGolfClient) GetOutrights(market string) (*OutrightsResponse, error) {
+	cacheKey := fmt.Sprintf("datagolf:outrights:%s", market)
+
+	var cached OutrightsResponse
+	if err := c.cache.GetSimple(cacheKey, &cached); err == nil {
+		return &cached, nil
+	}
+
+	url := fmt.Sprintf("%s/betting-tools/outrights?tour=pga&market=%s&odds_format=decimal&file_format=json&key=%s",
+		c.baseURL, market, c.apiKey)
+
+	var response OutrightsResponse
+	if err := c.makeRequest(url, &response); err != nil {
+		return nil, fmt.Errorf("failed to fetch %s outrights: %w", market, err)
+	}
+
+	c.cache.SetSimple(cacheKey, response, 30*time.Minute)
+
+	return &response, nil
+}
+
+// GetMatchups fetches head-to-head or three-ball odds for the current event
+func (c *DataGolfClient) GetMatchups(market string) (*MatchupsResponse, error) {
+	cacheKey := fmt.Sprintf("datagolf:matchups:%s", market)
+
+	var cached MatchupsResponse
+	if err := c.cache.GetSimple(cacheKey, &cached); err == nil {
+		return &cached, nil
+	}
+
+	url := fmt.Sprintf("%s/betting-tools/matchups?tour=pga&market=%s&odds_format=decimal&file_format=json&key=%s",
+		c.baseURL, market, c.apiKey)
+
+	var response MatchupsResponse
+	if err := c.makeRequest(url, &response); err != nil {
+		return nil, fmt.Errorf("failed to fetch %s matchups: %w", market, err)
+	}
+
+	c.cache.SetSimple(cacheKey, response, 30*time.Minute)
+
+	return &response, nil
+}
+
+// GetFieldUpdates fetches the current event's field with its tee times and DFS salaries
+func (c *DataGolfClient) GetFieldUpdates() (*FieldUpdatesResponse, error) {
+	cacheKey := "datagolf:field_updates"
+
+	var cached FieldUpdatesResponse
+	if err := c.cache.GetSimple(cacheKey, &cached); err == nil {
+		return &cached, nil
+	}
+
+	url := fmt.Sprintf("%s/field-updates?tour=pga&file_format=json&key=%s", c.baseURL, c.apiKey)
+
+	var response FieldUpdatesResponse
+	if err := c.makeRequest(url, &response); err != nil {
+		return nil, fmt.Errorf("failed to fetch field updates: %w", err)
+	}
+
+	c.cache.SetSimple(cacheKey, response, 30*time.Minute)
+
+	return &response, nil
+}
+
+// GetInPlayPredictions fetches the raw in-play leaderboard. It is never cached, since live
+// scoring feeds diff consecutive snapshots to recover hole-by-hole scores.
+func (c *DataGolfClient) GetInPlayPredictions() (*LivePredictionsResponse, error) {
+	url := fmt.Sprintf("%s/preds/in-play?tour=pga&dead_heat=no&odds_format=percent&file_format=json&key=%s",
+		c.baseURL, c.apiKey)
+
+	var response LivePredictionsResponse
+	if err := c.makeRequest(url, &response); err != nil {
+		return nil, fmt.Errorf("failed to fetch in-play predictions: %w", err)
+	}
+	return &response, nil
+}
+
+// GetLiveHoleStats fetches per-hole scoring for the current event, including each hole's par
+func (c *DataGolfClient) GetLiveHoleStats() (*LiveHoleStatsResponse, error) {
+	cacheKey := "datagolf:live_hole_stats"
+
+	var cached LiveHoleStatsResponse
+	if err := c.cache.GetSimple(cacheKey, &cached); err == nil {
+		return &cached, nil
+	}
+
+	url := fmt.Sprintf("%s/preds/live-hole-stats?tour=pga&file_format=json&key=%s",
+		c.baseURL, c.apiKey)
+
+	var response LiveHoleStatsResponse
+	if err := c.makeRequest(url, &response); err != nil {
+		return nil, fmt.Errorf("failed to fetch live hole stats: %w", err)
+	}
+
+	// Hole pars don't change during an event; the scoring averages are only a few minutes stale
+	c.cache.SetSimple(cacheKey, response, 5*time.Minute)
+
+	return &response, nil
+}
+
 // GetPlayerCourseHistory fetches historical performance data for a player at a specific course
 func (c *DataGolfClient) GetPlayerCourseHistory(playerID, courseID string) (*PlayerCourseHistory, error) {
 	cacheKey := fmt.Sprintf("datagolf:course_history:%s:%s", playerID, courseID)
@@ -63,6 +63,9 @@ type DataGolfFieldUpdateItem struct {
 	PlayerName  string  `json:"player_name"`
 	Status      string  `json:"status,omitempty"`
 	TeeTime     string  `json:"tee_time,omitempty"`
+	// R1TeeTime and R2TeeTime are the first two rounds' tee times in course local time
+	R1TeeTime   string  `json:"r1_teetime,omitempty"`
+	R2TeeTime   string  `json:"r2_teetime,omitempty"`
 	YahooID     string  `json:"yahoo_id,omitempty"`
 	YahooSalary int     `json:"yahoo_salary,omitempty"`
 }
@@ -204,6 +207,8 @@ type DataGolfLivePrediction struct {
 	PlayerName      string  `json:"player_name"`
 	Position        string  `json:"position"`
 	Score           string  `json:"score"`
+	Today           string  `json:"today"`
+	Round           int     `json:"round"`
 	Thru            string  `json:"thru"`
 	WinProb         float64 `json:"win"`
 	Top5Prob        float64 `json:"top_5"`
@@ -304,11 +309,14 @@ type DataGolfHistoricalRound struct {
 	Year               int     `json:"year"`
 	Round              int     `json:"round"`
 	Score              int     `json:"score"`
+	CoursePar          int     `json:"course_par,omitempty"`
 	SGApproach         float64 `json:"sg_app,omitempty"`
 	SGAroundTheGreen   float64 `json:"sg_arg,omitempty"`
 	SGOffTheTee        float64 `json:"sg_ott,omitempty"`
 	SGPutting          float64 `json:"sg_putt,omitempty"`
 	SGTotal            float64 `json:"sg_total,omitempty"`
+	DrivingDistance    float64 `json:"driving_dist,omitempty"`
+	DrivingAccuracy    float64 `json:"driving_acc,omitempty"`
 	TeeTime            string  `json:"tee_time,omitempty"`
 }
 
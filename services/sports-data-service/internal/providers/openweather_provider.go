@@ -12,6 +12,7 @@ import (
 
 	"github.com/redis/go-redis/v9"
 
+	"github.com/stitts-dev/dfs-sim/shared/pkg/weather"
 	"github.com/stitts-dev/dfs-sim/shared/types"
 )
 
@@ -49,6 +50,8 @@ type WeatherLocation struct {
 	City      string  `json:"city"`
 	State     string  `json:"state"`
 	Country   string  `json:"country"`
+	// TimeZone is the course's IANA zone, which its tee sheet is published in
+	TimeZone string `json:"time_zone"`
 }
 
 // NewOpenWeatherProvider creates a new OpenWeather API client
@@ -127,6 +130,30 @@ func (p *OpenWeatherProvider) GetWeatherConditions(ctx context.Context, location
 	return conditions, nil
 }
 
+// openWeatherForecastResponse is the 5-day forecast in 3-hour steps
+type openWeatherForecastResponse struct {
+	List []struct {
+		Dt   int64 `json:"dt"`
+		Main struct {
+			Temp     float64 `json:"temp"`
+			Humidity int     `json:"humidity"`
+		} `json:"main"`
+		Wind struct {
+			Speed float64 `json:"speed"`
+			Deg   int     `json:"deg"`
+			Gust  float64 `json:"gust"`
+		} `json:"wind"`
+		Weather []struct {
+			Main        string `json:"main"`
+			Description string `json:"description"`
+		} `json:"weather"`
+		Pop  float64 `json:"pop"`
+		Rain struct {
+			ThreeHour float64 `json:"3h"`
+		} `json:"rain"`
+	} `json:"list"`
+}
+
 // GetWeatherForecast retrieves 5-day weather forecast for a location
 func (p *OpenWeatherProvider) GetWeatherForecast(ctx context.Context, location *WeatherLocation) ([]types.WeatherConditions, error) {
 	// Check cache first
@@ -135,6 +162,76 @@ func (p *OpenWeatherProvider) GetWeatherForecast(ctx context.Context, location *
 		return cached, nil
 	}
 
+	forecastResp, err := p.fetchForecast(ctx, location)
+	if err != nil {
+		return nil, err
+	}
+
+	forecast := make([]types.WeatherConditions, 0, len(forecastResp.List))
+	for _, entry := range forecastResp.List {
+		current := OpenWeatherResponse{Weather: entry.Weather}
+		current.Main.Temp = entry.Main.Temp
+		current.Main.Humidity = entry.Main.Humidity
+		current.Wind.Speed = entry.Wind.Speed
+		current.Wind.Deg = entry.Wind.Deg
+		forecast = append(forecast, *p.convertToWeatherConditions(&current))
+	}
+
+	// Cache the result
+	if err := p.cacheForecast(ctx, cacheKey, forecast); err != nil {
+		fmt.Printf("Failed to cache forecast data: %v\n", err)
+	}
+
+	return forecast, nil
+}
+
+// GetHourlyForecast retrieves the timed forecast used for tee-time wave analysis. OpenWeather's
+// forecast comes in 3-hour steps, which the wave model interpolates between.
+func (p *OpenWeatherProvider) GetHourlyForecast(ctx context.Context, location *WeatherLocation) ([]weather.HourlyForecast, error) {
+	if location == nil {
+		return nil, fmt.Errorf("location is required")
+	}
+
+	cacheKey := fmt.Sprintf("forecast_hourly:%f,%f", location.Latitude, location.Longitude)
+	if p.redisClient != nil {
+		if data, err := p.redisClient.Get(ctx, cacheKey).Result(); err == nil {
+			var cached []weather.HourlyForecast
+			if err := json.Unmarshal([]byte(data), &cached); err == nil {
+				return cached, nil
+			}
+		}
+	}
+
+	forecastResp, err := p.fetchForecast(ctx, location)
+	if err != nil {
+		return nil, err
+	}
+
+	forecast := make([]weather.HourlyForecast, 0, len(forecastResp.List))
+	for _, entry := range forecastResp.List {
+		forecast = append(forecast, weather.HourlyForecast{
+			Time:              time.Unix(entry.Dt, 0).UTC(),
+			Temperature:       entry.Main.Temp,
+			WindSpeed:         entry.Wind.Speed,
+			WindGust:          entry.Wind.Gust,
+			Precipitation:     entry.Rain.ThreeHour / 3,
+			PrecipProbability: entry.Pop,
+		})
+	}
+
+	if p.redisClient != nil {
+		if data, err := json.Marshal(forecast); err == nil {
+			if err := p.redisClient.Set(ctx, cacheKey, data, p.cacheTTL).Err(); err != nil {
+				fmt.Printf("Failed to cache hourly forecast: %v\n", err)
+			}
+		}
+	}
+
+	return forecast, nil
+}
+
+// fetchForecast calls the forecast API
+func (p *OpenWeatherProvider) fetchForecast(ctx context.Context, location *WeatherLocation) (*openWeatherForecastResponse, error) {
 	// Check rate limit
 	if !p.rateLimit.Allow() {
 		return nil, fmt.Errorf("weather API rate limit exceeded")
@@ -162,17 +259,12 @@ func (p *OpenWeatherProvider) GetWeatherForecast(ctx context.Context, location *
 		return nil, fmt.Errorf("forecast API returned status %d", resp.StatusCode)
 	}
 
-	// Parse forecast response (simplified - would need full implementation)
-	var forecast []types.WeatherConditions
-	// Implementation would parse the 5-day forecast response
-	// For now, return empty slice
-	
-	// Cache the result
-	if err := p.cacheForecast(ctx, cacheKey, forecast); err != nil {
-		fmt.Printf("Failed to cache forecast data: %v\n", err)
+	var forecastResp openWeatherForecastResponse
+	if err := json.NewDecoder(resp.Body).Decode(&forecastResp); err != nil {
+		return nil, fmt.Errorf("failed to parse forecast response: %w", err)
 	}
 
-	return forecast, nil
+	return &forecastResp, nil
 }
 
 // buildWeatherURL constructs the OpenWeatherMap current weather API URL
@@ -138,6 +138,26 @@ func (dfs *DataFetcherService) scheduleJobs() error {
 		return fmt.Errorf("failed to schedule weekly tournament discovery job: %w", err)
 	}
 
+	// Golf history backfill - Every day at 3:30 AM, resuming where the last run stopped
+	if err := dfs.addJob("golf_history_backfill", "30 3 * * *", "Golf historical backfill", dfs.backfillGolfHistory); err != nil {
+		return fmt.Errorf("failed to schedule golf history backfill job: %w", err)
+	}
+
+	// Golf odds sync - Every hour, a quarter past, for the current event's betting markets
+	if err := dfs.addJob("golf_odds_sync", "15 * * * *", "Golf betting odds sync", dfs.syncGolfOdds); err != nil {
+		return fmt.Errorf("failed to schedule golf odds sync job: %w", err)
+	}
+
+	// Golf tee times - Every hour at a quarter to, picking up the draw once it's published
+	if err := dfs.addJob("golf_tee_times_sync", "45 * * * *", "Golf tee time sync", dfs.syncGolfTeeTimes); err != nil {
+		return fmt.Errorf("failed to schedule golf tee time sync job: %w", err)
+	}
+
+	// Golf course fit - Every Monday at 5 AM, once the weekend's rounds are in the warehouse
+	if err := dfs.addJob("golf_course_fit", "0 5 * * 1", "Golf course fit regression", dfs.fitGolfCourses); err != nil {
+		return fmt.Errorf("failed to schedule golf course fit job: %w", err)
+	}
+
 	return nil
 }
 
@@ -626,6 +646,9 @@ func (dfs *DataFetcherService) TriggerJob(id string) error {
 		"cache_warming":               dfs.warmCache,
 		"daily_cleanup":               dfs.dailyCleanup,
 		"weekly_tournament_discovery": dfs.discoverNewTournaments,
+		"golf_history_backfill":       dfs.backfillGolfHistory,
+		"golf_odds_sync":              dfs.syncGolfOdds,
+		"golf_course_fit":             dfs.fitGolfCourses,
 	}
 
 	jobFunc, exists := jobFunctions[id]
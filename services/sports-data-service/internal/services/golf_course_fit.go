@@ -0,0 +1,533 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/stitts-dev/dfs-sim/services/sports-data-service/internal/models"
+)
+
+const (
+	// courseFitWindowEvents is how many of a player's previous events make up the skill baseline
+	// an event's result is regressed on
+	courseFitWindowEvents = 20
+	// courseFitMinBaselineRounds is the fewest prior rounds a player needs before their baseline
+	// is trusted
+	courseFitMinBaselineRounds = 12
+	// courseFitMinObservations and courseFitMinEvents gate which courses get their own fit
+	courseFitMinObservations = 120
+	courseFitMinEvents       = 2
+	// courseFitShrinkage is the ridge penalty pulling course coefficients towards the tour-wide
+	// ones; it weighs like that many observations sitting exactly on the tour fit
+	courseFitShrinkage = 250.0
+	// drivingDistanceUnit and drivingAccuracyUnit scale driving stats so their coefficients read
+	// per ten yards and per ten points of fairway percentage
+	drivingDistanceUnit = 10.0
+	drivingAccuracyUnit = 0.1
+)
+
+// courseFitSkills are the regressors in column order
+var courseFitSkills = []string{
+	models.CourseFitSkillOffTee,
+	models.CourseFitSkillApproach,
+	models.CourseFitSkillAroundGreen,
+	models.CourseFitSkillPutting,
+	models.CourseFitSkillDrivingDistance,
+	models.CourseFitSkillDrivingAccuracy,
+}
+
+// CourseFitRound is one historical round with the course it was played on
+type CourseFitRound struct {
+	Tour            string
+	EventID         string
+	Year            int
+	DGID            int
+	CourseKey       string
+	Course          string
+	EndDate         *time.Time
+	SGOffTee        float64
+	SGApproach      float64
+	SGAround        float64
+	SGPutting       float64
+	SGTotal         float64
+	DrivingDistance float64
+	DrivingAccuracy float64
+}
+
+// CourseFitObservation is a player's strokes gained per round at one event alongside their skill
+// baseline going into it
+type CourseFitObservation struct {
+	CourseKey string
+	EventKey  string
+	Skills    []float64
+	SGTotal   float64
+}
+
+// CourseSkillFit is the result of regressing one course's observations
+type CourseSkillFit struct {
+	Coefficients []float64
+	StdErrors    []float64
+	Importance   []float64
+	Intercept    float64
+	RSquared     float64
+	RMSE         float64
+	Observations int
+}
+
+// GolfCourseFitResult summarises a course fit run
+type GolfCourseFitResult struct {
+	Observations int
+	Courses      int
+	Skipped      int
+	TourRSquared float64
+}
+
+// fitGolfCourses is the scheduled wrapper around FitGolfCourseModels
+func (dfs *DataFetcherService) fitGolfCourses() {
+	logger := dfs.logger.WithField("component", "data_fetcher").WithField("job", "golf_course_fit")
+
+	result, err := dfs.FitGolfCourseModels(dfs.ctx)
+	if err != nil {
+		logger.WithError(err).Error("Golf course fit failed")
+		return
+	}
+
+	logger.WithFields(logrus.Fields{
+		"observations":   result.Observations,
+		"courses":        result.Courses,
+		"skipped":        result.Skipped,
+		"tour_r_squared": result.TourRSquared,
+	}).Info("Golf course fit completed")
+}
+
+// FitGolfCourseModels regresses each course's historical strokes gained on the players'
+// pre-event skills and stores the coefficients, their premium over the tour-wide fit and the fit
+// diagnostics. Courses with too little history are left with their previous fit.
+func (dfs *DataFetcherService) FitGolfCourseModels(ctx context.Context) (*GolfCourseFitResult, error) {
+	var rounds []CourseFitRound
+	if err := dfs.db.WithContext(ctx).Table("golf_historical_rounds AS r").
+		Select(`r.tour, r.event_id, r.year, r.dg_id AS dg_id, e.course_key, e.course, e.end_date,
+			r.sg_ott AS sg_off_tee, r.sg_app AS sg_approach, r.sg_arg AS sg_around, r.sg_putt AS sg_putting,
+			r.sg_total, COALESCE(r.driving_dist, 0) AS driving_distance, COALESCE(r.driving_acc, 0) AS driving_accuracy`).
+		Joins("JOIN golf_historical_events e ON e.tour = r.tour AND e.event_id = r.event_id AND e.year = r.year").
+		Where("e.course_key <> '' AND e.end_date IS NOT NULL").
+		Scan(&rounds).Error; err != nil {
+		return nil, fmt.Errorf("failed to load historical rounds: %w", err)
+	}
+
+	observations := BuildCourseFitObservations(rounds)
+	result := &GolfCourseFitResult{Observations: len(observations)}
+	if len(observations) < courseFitMinObservations {
+		return result, nil
+	}
+
+	// The tour-wide fit is lightly regularised only to keep columns without data solvable
+	zero := make([]float64, len(courseFitSkills))
+	tour, err := FitCourseSkills(observations, zero, 1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fit tour-wide model: %w", err)
+	}
+	result.TourRSquared = tour.RSquared
+
+	byCourse := make(map[string][]CourseFitObservation)
+	for _, obs := range observations {
+		byCourse[obs.CourseKey] = append(byCourse[obs.CourseKey], obs)
+	}
+	courseNames := make(map[string]string)
+	for _, r := range rounds {
+		courseNames[r.CourseKey] = r.Course
+	}
+
+	fittedAt := time.Now()
+	for courseKey, courseObs := range byCourse {
+		events := make(map[string]bool)
+		for _, obs := range courseObs {
+			events[obs.EventKey] = true
+		}
+		if len(courseObs) < courseFitMinObservations || len(events) < courseFitMinEvents {
+			result.Skipped++
+			continue
+		}
+
+		fit, err := FitCourseSkills(courseObs, tour.Coefficients, courseFitShrinkage)
+		if err != nil {
+			dfs.logger.WithError(err).WithField("course_key", courseKey).Warn("Failed to fit course model")
+			result.Skipped++
+			continue
+		}
+
+		row := models.GolfCourseFit{
+			CourseKey:    courseKey,
+			Course:       courseNames[courseKey],
+			Events:       len(events),
+			Observations: fit.Observations,
+			Intercept:    fit.Intercept,
+			RSquared:     fit.RSquared,
+			RMSE:         fit.RMSE,
+			TourRSquared: centeredRSquared(courseObs, tour.Coefficients),
+			Shrinkage:    courseFitShrinkage,
+			FittedAt:     fittedAt,
+		}
+		skills := make([]models.GolfCourseSkillPremium, len(courseFitSkills))
+		for i, skill := range courseFitSkills {
+			skills[i] = models.GolfCourseSkillPremium{
+				CourseKey:   courseKey,
+				Skill:       skill,
+				Coefficient: fit.Coefficients[i],
+				StdError:    fit.StdErrors[i],
+				Premium:     fit.Coefficients[i] - tour.Coefficients[i],
+				Importance:  fit.Importance[i],
+			}
+		}
+
+		if err := dfs.storeCourseFit(ctx, row, skills); err != nil {
+			return result, err
+		}
+		result.Courses++
+	}
+
+	return result, nil
+}
+
+func (dfs *DataFetcherService) storeCourseFit(ctx context.Context, row models.GolfCourseFit, skills []models.GolfCourseSkillPremium) error {
+	err := dfs.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Skills").Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "course_key"}},
+			DoUpdates: clause.AssignmentColumns([]string{
+				"course", "events", "observations", "intercept", "r_squared", "rmse",
+				"tour_r_squared", "shrinkage", "fitted_at", "updated_at",
+			}),
+		}).Create(&row).Error; err != nil {
+			return err
+		}
+		return tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "course_key"}, {Name: "skill"}},
+			DoUpdates: clause.AssignmentColumns([]string{"coefficient", "std_error", "premium", "importance", "updated_at"}),
+		}).Create(&skills).Error
+	})
+	if err != nil {
+		return fmt.Errorf("failed to store course fit for %s: %w", row.CourseKey, err)
+	}
+	return nil
+}
+
+// BuildCourseFitObservations pairs each player-event with the player's average skills over their
+// previous events, so the regressors never include the result being explained. Driving stats
+// are taken relative to the field at each event, which removes course and conditions effects.
+func BuildCourseFitObservations(rounds []CourseFitRound) []CourseFitObservation {
+	type fieldDriving struct {
+		distance, accuracy         float64
+		distanceCount, accuracyCnt int
+	}
+	fields := make(map[string]*fieldDriving)
+	for _, r := range rounds {
+		key := historicalEventKey(r.Tour, r.EventID, r.Year)
+		field, ok := fields[key]
+		if !ok {
+			field = &fieldDriving{}
+			fields[key] = field
+		}
+		if r.DrivingDistance > 0 {
+			field.distance += r.DrivingDistance
+			field.distanceCount++
+		}
+		if r.DrivingAccuracy > 0 {
+			field.accuracy += r.DrivingAccuracy
+			field.accuracyCnt++
+		}
+	}
+
+	// Sums per player-event; driving sums are deviations from the field average
+	type playerEvent struct {
+		dgID          int
+		eventKey      string
+		courseKey     string
+		date          time.Time
+		rounds        int
+		sg            [4]float64
+		sgTotal       float64
+		distance      float64
+		distanceCount int
+		accuracy      float64
+		accuracyCount int
+	}
+	events := make(map[string]*playerEvent)
+	for _, r := range rounds {
+		if r.SGOffTee == 0 && r.SGApproach == 0 && r.SGAround == 0 && r.SGPutting == 0 {
+			continue // no strokes gained breakdown for this round
+		}
+		eventKey := historicalEventKey(r.Tour, r.EventID, r.Year)
+		key := fmt.Sprintf("%s:%d", eventKey, r.DGID)
+		pe, ok := events[key]
+		if !ok {
+			pe = &playerEvent{dgID: r.DGID, eventKey: eventKey, courseKey: r.CourseKey}
+			if r.EndDate != nil {
+				pe.date = *r.EndDate
+			}
+			events[key] = pe
+		}
+		pe.rounds++
+		pe.sg[0] += r.SGOffTee
+		pe.sg[1] += r.SGApproach
+		pe.sg[2] += r.SGAround
+		pe.sg[3] += r.SGPutting
+		pe.sgTotal += r.SGTotal
+
+		field := fields[eventKey]
+		if r.DrivingDistance > 0 && field.distanceCount > 0 {
+			pe.distance += r.DrivingDistance - field.distance/float64(field.distanceCount)
+			pe.distanceCount++
+		}
+		if r.DrivingAccuracy > 0 && field.accuracyCnt > 0 {
+			pe.accuracy += r.DrivingAccuracy - field.accuracy/float64(field.accuracyCnt)
+			pe.accuracyCount++
+		}
+	}
+
+	byPlayer := make(map[int][]*playerEvent)
+	for _, pe := range events {
+		byPlayer[pe.dgID] = append(byPlayer[pe.dgID], pe)
+	}
+
+	var observations []CourseFitObservation
+	for _, history := range byPlayer {
+		sort.Slice(history, func(i, j int) bool { return history[i].date.Before(history[j].date) })
+
+		for i, current := range history {
+			start := i - courseFitWindowEvents
+			if start < 0 {
+				start = 0
+			}
+
+			var sg [4]float64
+			rounds, distance, distanceCount, accuracy, accuracyCount := 0, 0.0, 0, 0.0, 0
+			for _, prior := range history[start:i] {
+				if !prior.date.Before(current.date) {
+					continue
+				}
+				rounds += prior.rounds
+				for k := range sg {
+					sg[k] += prior.sg[k]
+				}
+				distance += prior.distance
+				distanceCount += prior.distanceCount
+				accuracy += prior.accuracy
+				accuracyCount += prior.accuracyCount
+			}
+			if rounds < courseFitMinBaselineRounds {
+				continue
+			}
+
+			skills := make([]float64, len(courseFitSkills))
+			for k := range sg {
+				skills[k] = sg[k] / float64(rounds)
+			}
+			if distanceCount > 0 {
+				skills[4] = distance / float64(distanceCount) / drivingDistanceUnit
+			}
+			if accuracyCount > 0 {
+				skills[5] = accuracy / float64(accuracyCount) / drivingAccuracyUnit
+			}
+
+			observations = append(observations, CourseFitObservation{
+				CourseKey: current.courseKey,
+				EventKey:  current.eventKey,
+				Skills:    skills,
+				SGTotal:   current.sgTotal / float64(current.rounds),
+			})
+		}
+	}
+
+	return observations
+}
+
+// FitCourseSkills is a ridge regression of strokes gained on skills that shrinks the
+// coefficients towards prior rather than zero. The intercept is not penalised. Standard errors
+// use the ridge sandwich estimator.
+func FitCourseSkills(observations []CourseFitObservation, prior []float64, shrinkage float64) (*CourseSkillFit, error) {
+	n := len(observations)
+	p := len(prior)
+	if n <= p+1 {
+		return nil, fmt.Errorf("need more than %d observations, have %d", p+1, n)
+	}
+
+	meanX := make([]float64, p)
+	meanY := 0.0
+	for _, obs := range observations {
+		for k := 0; k < p; k++ {
+			meanX[k] += obs.Skills[k]
+		}
+		meanY += obs.SGTotal
+	}
+	for k := range meanX {
+		meanX[k] /= float64(n)
+	}
+	meanY /= float64(n)
+
+	xtx := make([][]float64, p)
+	for k := range xtx {
+		xtx[k] = make([]float64, p)
+	}
+	xty := make([]float64, p)
+	sst := 0.0
+	for _, obs := range observations {
+		y := obs.SGTotal - meanY
+		sst += y * y
+		for a := 0; a < p; a++ {
+			xa := obs.Skills[a] - meanX[a]
+			xty[a] += xa * y
+			for b := 0; b < p; b++ {
+				xtx[a][b] += xa * (obs.Skills[b] - meanX[b])
+			}
+		}
+	}
+
+	penalised := make([][]float64, p)
+	rhs := make([]float64, p)
+	for a := 0; a < p; a++ {
+		penalised[a] = append([]float64(nil), xtx[a]...)
+		penalised[a][a] += shrinkage
+		rhs[a] = xty[a] + shrinkage*prior[a]
+	}
+	inverse, err := invertMatrix(penalised)
+	if err != nil {
+		return nil, err
+	}
+
+	fit := &CourseSkillFit{
+		Coefficients: make([]float64, p),
+		StdErrors:    make([]float64, p),
+		Importance:   make([]float64, p),
+		Observations: n,
+	}
+	for a := 0; a < p; a++ {
+		for b := 0; b < p; b++ {
+			fit.Coefficients[a] += inverse[a][b] * rhs[b]
+		}
+	}
+	fit.Intercept = meanY
+	for k := 0; k < p; k++ {
+		fit.Intercept -= meanX[k] * fit.Coefficients[k]
+	}
+
+	sse := 0.0
+	for _, obs := range observations {
+		residual := obs.SGTotal - fit.Intercept
+		for k := 0; k < p; k++ {
+			residual -= obs.Skills[k] * fit.Coefficients[k]
+		}
+		sse += residual * residual
+	}
+	fit.RMSE = math.Sqrt(sse / float64(n))
+	if sst > 0 {
+		fit.RSquared = 1 - sse/sst
+	}
+
+	// Var(beta) = sigma^2 * A^-1 X'X A^-1 with A = X'X + shrinkage*I
+	sigma2 := sse / float64(n-p-1)
+	for k := 0; k < p; k++ {
+		variance := 0.0
+		for a := 0; a < p; a++ {
+			for b := 0; b < p; b++ {
+				variance += inverse[k][a] * xtx[a][b] * inverse[b][k]
+			}
+		}
+		fit.StdErrors[k] = math.Sqrt(math.Max(0, sigma2*variance))
+	}
+
+	// Importance is each skill's share of the spread in predictions: |beta| times the skill's
+	// standard deviation across the observations
+	total := 0.0
+	for k := 0; k < p; k++ {
+		fit.Importance[k] = math.Abs(fit.Coefficients[k]) * math.Sqrt(xtx[k][k]/float64(n))
+		total += fit.Importance[k]
+	}
+	if total > 0 {
+		for k := range fit.Importance {
+			fit.Importance[k] /= total
+		}
+	}
+
+	return fit, nil
+}
+
+// centeredRSquared is how much of the spread in observations the given coefficients explain once
+// each is measured against the observations' own means
+func centeredRSquared(observations []CourseFitObservation, coefficients []float64) float64 {
+	if len(observations) == 0 {
+		return 0
+	}
+	meanY, meanPred := 0.0, 0.0
+	predictions := make([]float64, len(observations))
+	for i, obs := range observations {
+		for k, coefficient := range coefficients {
+			predictions[i] += obs.Skills[k] * coefficient
+		}
+		meanY += obs.SGTotal
+		meanPred += predictions[i]
+	}
+	meanY /= float64(len(observations))
+	meanPred /= float64(len(observations))
+
+	sse, sst := 0.0, 0.0
+	for i, obs := range observations {
+		residual := (obs.SGTotal - meanY) - (predictions[i] - meanPred)
+		sse += residual * residual
+		sst += (obs.SGTotal - meanY) * (obs.SGTotal - meanY)
+	}
+	if sst == 0 {
+		return 0
+	}
+	return 1 - sse/sst
+}
+
+// invertMatrix inverts a square matrix by Gauss-Jordan elimination with partial pivoting
+func invertMatrix(m [][]float64) ([][]float64, error) {
+	n := len(m)
+	a := make([][]float64, n)
+	for i := range m {
+		a[i] = make([]float64, 2*n)
+		copy(a[i], m[i])
+		a[i][n+i] = 1
+	}
+
+	for col := 0; col < n; col++ {
+		pivot := col
+		for row := col + 1; row < n; row++ {
+			if math.Abs(a[row][col]) > math.Abs(a[pivot][col]) {
+				pivot = row
+			}
+		}
+		if math.Abs(a[pivot][col]) < 1e-12 {
+			return nil, fmt.Errorf("matrix is singular")
+		}
+		a[col], a[pivot] = a[pivot], a[col]
+
+		scale := a[col][col]
+		for j := range a[col] {
+			a[col][j] /= scale
+		}
+		for row := 0; row < n; row++ {
+			if row == col || a[row][col] == 0 {
+				continue
+			}
+			factor := a[row][col]
+			for j := range a[row] {
+				a[row][j] -= factor * a[col][j]
+			}
+		}
+	}
+
+	inverse := make([][]float64, n)
+	for i := range a {
+		inverse[i] = a[i][n:]
+	}
+	return inverse, nil
+}
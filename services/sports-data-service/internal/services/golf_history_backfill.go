@@ -0,0 +1,737 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/sony/gobreaker"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/stitts-dev/dfs-sim/services/sports-data-service/internal/models"
+	"github.com/stitts-dev/dfs-sim/services/sports-data-service/internal/providers"
+)
+
+const (
+	golfBackfillRounds = "rounds"
+	golfBackfillBatch  = 500
+)
+
+// golfHistorySource is the part of the DataGolf client the backfill downloads from
+type golfHistorySource interface {
+	GetHistoricalEventList(tour string) (*providers.HistoricalEventListResponse, error)
+	GetHistoricalRounds(tour, eventID string, year int) (*providers.HistoricalRoundsResponse, error)
+	GetHistoricalDFSEventList() (*providers.HistoricalDFSEventListResponse, error)
+	GetHistoricalDFSPoints(tour, site, eventID string, year int) (*providers.HistoricalDFSResponse, error)
+}
+
+// GolfBackfillOptions limits what a historical backfill run downloads
+type GolfBackfillOptions struct {
+	Tours    []string `json:"tours"`
+	Sites    []string `json:"sites"`
+	FromYear int      `json:"from_year"`
+	// MaxRequests caps DataGolf calls per run. The client allows one request per second, so the
+	// default keeps a nightly run to roughly ten minutes; later runs pick up where this one stopped.
+	MaxRequests int `json:"max_requests"`
+}
+
+// DefaultGolfBackfillOptions covers five seasons of PGA Tour rounds and DraftKings and FanDuel results
+func DefaultGolfBackfillOptions() GolfBackfillOptions {
+	return GolfBackfillOptions{
+		Tours:       []string{"pga"},
+		Sites:       []string{"draftkings", "fanduel"},
+		FromYear:    time.Now().Year() - 5,
+		MaxRequests: 600,
+	}
+}
+
+// GolfBackfillResult summarises a backfill run
+type GolfBackfillResult struct {
+	Events          int  `json:"events"`
+	Requests        int  `json:"requests"`
+	Completed       int  `json:"completed"`
+	Failed          int  `json:"failed"`
+	RoundsStored    int  `json:"rounds_stored"`
+	DFSRowsStored   int  `json:"dfs_rows_stored"`
+	CourseHistories int  `json:"course_histories"`
+	Remaining       int  `json:"remaining"`
+	BudgetExhausted bool `json:"budget_exhausted"`
+}
+
+// backfillGolfHistory is the scheduled wrapper around RunGolfHistoryBackfill
+func (dfs *DataFetcherService) backfillGolfHistory() {
+	logger := dfs.logger.WithField("component", "data_fetcher").WithField("job", "golf_history_backfill")
+
+	result, err := dfs.RunGolfHistoryBackfill(dfs.ctx, DefaultGolfBackfillOptions())
+	if err != nil {
+		logger.WithError(err).Error("Golf history backfill failed")
+		return
+	}
+
+	logger.WithFields(logrus.Fields{
+		"events":           result.Events,
+		"requests":         result.Requests,
+		"completed":        result.Completed,
+		"failed":           result.Failed,
+		"rounds_stored":    result.RoundsStored,
+		"dfs_rows_stored":  result.DFSRowsStored,
+		"course_histories": result.CourseHistories,
+		"remaining":        result.Remaining,
+	}).Info("Golf history backfill completed")
+}
+
+// RunGolfHistoryBackfill downloads historical rounds and DFS results for every finished event
+// that hasn't been stored yet, newest first. Each event and dataset is written in one transaction
+// and recorded in golf_backfill_progress, so re-running is safe and resumes after a failure or
+// when the request budget runs out.
+func (dfs *DataFetcherService) RunGolfHistoryBackfill(ctx context.Context, opts GolfBackfillOptions) (*GolfBackfillResult, error) {
+	if dfs.dataGolfProvider == nil {
+		return nil, fmt.Errorf("DataGolf provider is not available")
+	}
+	if dfs.circuitBreaker.GetState("datagolf") == gobreaker.StateOpen {
+		return nil, fmt.Errorf("DataGolf provider is unavailable")
+	}
+	return dfs.runGolfHistoryBackfill(ctx, dfs.dataGolfProvider, opts)
+}
+
+func (dfs *DataFetcherService) runGolfHistoryBackfill(ctx context.Context, source golfHistorySource, opts GolfBackfillOptions) (*GolfBackfillResult, error) {
+	result := &GolfBackfillResult{}
+	budget := func() bool {
+		if opts.MaxRequests > 0 && result.Requests >= opts.MaxRequests {
+			result.BudgetExhausted = true
+			return false
+		}
+		return true
+	}
+
+	// Which events have DFS data at all, so we don't spend requests on the ones that don't
+	dfsEvents := make(map[string]bool)
+	if len(opts.Sites) > 0 && budget() {
+		result.Requests++
+		list, err := source.GetHistoricalDFSEventList()
+		if err != nil {
+			return nil, err
+		}
+		for _, event := range list.Events {
+			tour := event.Tour
+			if tour == "" {
+				tour = "pga"
+			}
+			dfsEvents[historicalEventKey(tour, event.EventID, event.Year)] = true
+		}
+	}
+
+	for _, tour := range opts.Tours {
+		if !budget() {
+			break
+		}
+		result.Requests++
+		list, err := source.GetHistoricalEventList(tour)
+		if err != nil {
+			return nil, err
+		}
+
+		events, err := dfs.storeHistoricalEvents(ctx, tour, list.Events, opts.FromYear)
+		if err != nil {
+			return nil, err
+		}
+		result.Events += len(events)
+
+		done, err := dfs.completedBackfills(ctx, tour)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, event := range events {
+			datasets := []string{golfBackfillRounds}
+			if dfsEvents[historicalEventKey(tour, event.EventID, event.Year)] {
+				for _, site := range opts.Sites {
+					datasets = append(datasets, "dfs:"+site)
+				}
+			}
+
+			for _, dataset := range datasets {
+				if done[backfillKey(dataset, event.EventID, event.Year)] {
+					continue
+				}
+				if err := ctx.Err(); err != nil {
+					return result, err
+				}
+				if !budget() {
+					result.Remaining++
+					continue
+				}
+
+				result.Requests++
+				rows, err := dfs.backfillEvent(ctx, source, dataset, event)
+				dfs.recordBackfill(ctx, dataset, event, rows, err)
+				if err != nil {
+					result.Failed++
+					dfs.logger.WithError(err).WithFields(logrus.Fields{
+						"dataset":  dataset,
+						"event_id": event.EventID,
+						"year":     event.Year,
+					}).Warn("Golf history backfill step failed")
+					continue
+				}
+
+				result.Completed++
+				if dataset == golfBackfillRounds {
+					result.RoundsStored += rows
+				} else {
+					result.DFSRowsStored += rows
+				}
+			}
+		}
+	}
+
+	histories, err := dfs.RebuildGolfCourseHistory(ctx)
+	if err != nil {
+		return result, fmt.Errorf("failed to rebuild course history: %w", err)
+	}
+	result.CourseHistories = histories
+
+	return result, nil
+}
+
+// storeHistoricalEvents upserts the event list and returns finished events from fromYear on,
+// newest first
+func (dfs *DataFetcherService) storeHistoricalEvents(ctx context.Context, tour string, events []providers.DataGolfHistoricalEvent, fromYear int) ([]models.GolfHistoricalEvent, error) {
+	now := time.Now()
+	var stored []models.GolfHistoricalEvent
+	for _, event := range events {
+		if event.Year < fromYear {
+			continue
+		}
+		row := models.GolfHistoricalEvent{
+			Tour:      tour,
+			EventID:   event.EventID,
+			Year:      event.Year,
+			EventName: event.EventName,
+			Course:    event.Course,
+			CourseKey: CourseKey(event.Course),
+			StartDate: parseHistoricalDate(event.StartDate),
+			EndDate:   parseHistoricalDate(event.EndDate),
+		}
+		// Events still in progress are left for a later run
+		if row.EndDate != nil && row.EndDate.After(now) {
+			continue
+		}
+		stored = append(stored, row)
+	}
+	if len(stored) == 0 {
+		return nil, nil
+	}
+
+	err := dfs.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "tour"}, {Name: "event_id"}, {Name: "year"}},
+		DoUpdates: clause.AssignmentColumns([]string{"event_name", "course", "course_key", "start_date", "end_date", "updated_at"}),
+	}).CreateInBatches(&stored, golfBackfillBatch).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to store historical events: %w", err)
+	}
+
+	sort.SliceStable(stored, func(i, j int) bool {
+		if stored[i].Year != stored[j].Year {
+			return stored[i].Year > stored[j].Year
+		}
+		if stored[i].StartDate != nil && stored[j].StartDate != nil {
+			return stored[i].StartDate.After(*stored[j].StartDate)
+		}
+		return false
+	})
+	return stored, nil
+}
+
+// completedBackfills returns the dataset/event pairs already stored for a tour
+func (dfs *DataFetcherService) completedBackfills(ctx context.Context, tour string) (map[string]bool, error) {
+	var progress []models.GolfBackfillProgress
+	if err := dfs.db.WithContext(ctx).
+		Where("tour = ? AND status = ?", tour, models.BackfillStatusComplete).
+		Find(&progress).Error; err != nil {
+		return nil, fmt.Errorf("failed to load backfill progress: %w", err)
+	}
+
+	done := make(map[string]bool, len(progress))
+	for _, p := range progress {
+		done[backfillKey(p.Dataset, p.EventID, p.Year)] = true
+	}
+	return done, nil
+}
+
+// backfillEvent downloads and stores one dataset for one event, returning the rows written
+func (dfs *DataFetcherService) backfillEvent(ctx context.Context, source golfHistorySource, dataset string, event models.GolfHistoricalEvent) (int, error) {
+	if dataset == golfBackfillRounds {
+		response, err := source.GetHistoricalRounds(event.Tour, event.EventID, event.Year)
+		if err != nil {
+			return 0, err
+		}
+		return dfs.storeHistoricalRounds(ctx, event, response.Rounds)
+	}
+
+	site := strings.TrimPrefix(dataset, "dfs:")
+	response, err := source.GetHistoricalDFSPoints(event.Tour, site, event.EventID, event.Year)
+	if err != nil {
+		return 0, err
+	}
+	return dfs.storeHistoricalDFS(ctx, event, site, response.Results)
+}
+
+func (dfs *DataFetcherService) storeHistoricalRounds(ctx context.Context, event models.GolfHistoricalEvent, rounds []providers.DataGolfHistoricalRound) (int, error) {
+	rows := make([]models.GolfHistoricalRound, 0, len(rounds))
+	for _, r := range rounds {
+		if r.Score <= 0 || r.Round <= 0 {
+			continue
+		}
+		rows = append(rows, models.GolfHistoricalRound{
+			Tour:            event.Tour,
+			EventID:         event.EventID,
+			Year:            event.Year,
+			Round:           r.Round,
+			DGID:            r.DGID,
+			PlayerName:      r.PlayerName,
+			Score:           r.Score,
+			CoursePar:       r.CoursePar,
+			SGOffTee:        r.SGOffTheTee,
+			SGApproach:      r.SGApproach,
+			SGAround:        r.SGAroundTheGreen,
+			SGPutting:       r.SGPutting,
+			SGTotal:         r.SGTotal,
+			DrivingDistance: r.DrivingDistance,
+			DrivingAccuracy: r.DrivingAccuracy,
+			TeeTime:         r.TeeTime,
+		})
+	}
+	summary := SummarizeHistoricalEvent(rows)
+
+	err := dfs.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if len(rows) > 0 {
+			if err := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "tour"}, {Name: "event_id"}, {Name: "year"}, {Name: "round"}, {Name: "dg_id"}},
+				DoUpdates: clause.AssignmentColumns([]string{"player_name", "score", "course_par", "sg_ott", "sg_app", "sg_arg", "sg_putt", "sg_total", "driving_dist", "driving_acc", "tee_time"}),
+			}).CreateInBatches(&rows, golfBackfillBatch).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Model(&models.GolfHistoricalEvent{}).
+			Where("tour = ? AND event_id = ? AND year = ?", event.Tour, event.EventID, event.Year).
+			Updates(map[string]interface{}{
+				"course_par": summary.CoursePar,
+				"cut_line":   summary.CutLine,
+				"field_size": summary.FieldSize,
+			}).Error
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to store rounds: %w", err)
+	}
+	return len(rows), nil
+}
+
+func (dfs *DataFetcherService) storeHistoricalDFS(ctx context.Context, event models.GolfHistoricalEvent, site string, results []providers.DataGolfDFSResult) (int, error) {
+	rows := make([]models.GolfHistoricalDFSResult, 0, len(results))
+	for _, r := range results {
+		rows = append(rows, models.GolfHistoricalDFSResult{
+			Tour:       event.Tour,
+			Site:       site,
+			EventID:    event.EventID,
+			Year:       event.Year,
+			DGID:       r.DGID,
+			PlayerName: r.PlayerName,
+			Salary:     r.Salary,
+			Ownership:  r.Ownership,
+			Points:     r.Points,
+			Finish:     r.Position,
+			MadeCut:    r.MadeCut,
+		})
+	}
+	if len(rows) == 0 {
+		return 0, nil
+	}
+
+	err := dfs.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "tour"}, {Name: "site"}, {Name: "event_id"}, {Name: "year"}, {Name: "dg_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"player_name", "salary", "ownership", "points", "finish", "made_cut"}),
+	}).CreateInBatches(&rows, golfBackfillBatch).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to store DFS results: %w", err)
+	}
+	return len(rows), nil
+}
+
+// recordBackfill marks a dataset as complete, or failed with the error for the next run to retry
+func (dfs *DataFetcherService) recordBackfill(ctx context.Context, dataset string, event models.GolfHistoricalEvent, rows int, stepErr error) {
+	progress := models.GolfBackfillProgress{
+		Dataset:  dataset,
+		Tour:     event.Tour,
+		EventID:  event.EventID,
+		Year:     event.Year,
+		Status:   models.BackfillStatusComplete,
+		Rows:     rows,
+		Attempts: 1,
+	}
+	if stepErr != nil {
+		progress.Status = models.BackfillStatusFailed
+		progress.LastError = stepErr.Error()
+	}
+
+	err := dfs.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "dataset"}, {Name: "tour"}, {Name: "event_id"}, {Name: "year"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"status":     progress.Status,
+			"rows":       progress.Rows,
+			"last_error": progress.LastError,
+			"attempts":   gorm.Expr("golf_backfill_progress.attempts + 1"),
+			"updated_at": time.Now(),
+		}),
+	}).Create(&progress).Error
+	if err != nil {
+		dfs.logger.WithError(err).WithField("event_id", event.EventID).Error("Failed to record golf backfill progress")
+	}
+}
+
+// HistoricalEventSummary is derived from the stored rounds of one event
+type HistoricalEventSummary struct {
+	CoursePar int
+	CutLine   *int
+	FieldSize int
+}
+
+// SummarizeHistoricalEvent works out course par, field size and the cut line. An event has a cut
+// when some players stopped after two rounds while others played on; the line is the worst
+// 36-hole score to par among those who continued.
+func SummarizeHistoricalEvent(rounds []models.GolfHistoricalRound) HistoricalEventSummary {
+	parCounts := make(map[int]int)
+	type card struct {
+		first36   int
+		lastRound int
+	}
+	cards := make(map[int]*card)
+	for _, r := range rounds {
+		if r.CoursePar > 0 {
+			parCounts[r.CoursePar]++
+		}
+		c, ok := cards[r.DGID]
+		if !ok {
+			c = &card{}
+			cards[r.DGID] = c
+		}
+		if r.Round <= 2 {
+			c.first36 += r.Score
+		}
+		if r.Round > c.lastRound {
+			c.lastRound = r.Round
+		}
+	}
+
+	summary := HistoricalEventSummary{FieldSize: len(cards)}
+	for par, count := range parCounts {
+		if count > parCounts[summary.CoursePar] || (count == parCounts[summary.CoursePar] && par < summary.CoursePar) {
+			summary.CoursePar = par
+		}
+	}
+	if summary.CoursePar == 0 {
+		return summary
+	}
+
+	missed, worstMade := 0, 0
+	for _, c := range cards {
+		switch {
+		case c.lastRound == 2:
+			missed++
+		case c.lastRound > 2 && c.first36 > worstMade:
+			worstMade = c.first36
+		}
+	}
+	if missed > 0 && worstMade > 0 {
+		cutLine := worstMade - 2*summary.CoursePar
+		summary.CutLine = &cutLine
+	}
+	return summary
+}
+
+// courseAggregate accumulates a player's rounds and finishes at one course
+type courseAggregate struct {
+	events                    map[string]bool
+	rounds, strokes           int
+	relativeToField           float64
+	sgTotal, sgT2G, sgPutting float64
+	sgRounds                  int
+	cutsMade, missedCuts      int
+	bestFinish, worstFinish   int
+	top10s, top25s, wins      int
+	lastPlayed                *time.Time
+}
+
+// RebuildGolfCourseHistory recomputes golf_course_history from the warehouse for every DataGolf
+// player on file. course_id is the normalized course key (see CourseKey). The adjusted scoring
+// average is the course's overall average plus the player's strokes against the field in the
+// same rounds, which removes the effect of easy and hard weeks.
+func (dfs *DataFetcherService) RebuildGolfCourseHistory(ctx context.Context) (int, error) {
+	db := dfs.db.WithContext(ctx)
+
+	var events []models.GolfHistoricalEvent
+	if err := db.Where("course_key <> ''").Find(&events).Error; err != nil {
+		return 0, err
+	}
+	eventByKey := make(map[string]*models.GolfHistoricalEvent, len(events))
+	for i := range events {
+		e := &events[i]
+		eventByKey[historicalEventKey(e.Tour, e.EventID, e.Year)] = e
+	}
+
+	var rounds []models.GolfHistoricalRound
+	if err := db.Find(&rounds).Error; err != nil {
+		return 0, err
+	}
+
+	// Field average per event round, and each course's average over all rounds
+	type avg struct {
+		total float64
+		count int
+	}
+	fieldAvg := make(map[string]*avg)
+	courseAvg := make(map[string]*avg)
+	for _, r := range rounds {
+		event, ok := eventByKey[historicalEventKey(r.Tour, r.EventID, r.Year)]
+		if !ok {
+			continue
+		}
+		key := fmt.Sprintf("%s:%d", historicalEventKey(r.Tour, r.EventID, r.Year), r.Round)
+		if fieldAvg[key] == nil {
+			fieldAvg[key] = &avg{}
+		}
+		fieldAvg[key].total += float64(r.Score)
+		fieldAvg[key].count++
+		if courseAvg[event.CourseKey] == nil {
+			courseAvg[event.CourseKey] = &avg{}
+		}
+		courseAvg[event.CourseKey].total += float64(r.Score)
+		courseAvg[event.CourseKey].count++
+	}
+
+	aggregates := make(map[int]map[string]*courseAggregate)
+	lastRound := make(map[string]int) // event key + dg_id -> last round played
+	for _, r := range rounds {
+		eventKey := historicalEventKey(r.Tour, r.EventID, r.Year)
+		event, ok := eventByKey[eventKey]
+		if !ok {
+			continue
+		}
+		agg := courseAggregateFor(aggregates, r.DGID, event.CourseKey)
+		agg.events[eventKey] = true
+		agg.rounds++
+		agg.strokes += r.Score
+		field := fieldAvg[fmt.Sprintf("%s:%d", eventKey, r.Round)]
+		agg.relativeToField += float64(r.Score) - field.total/float64(field.count)
+		if r.SGTotal != 0 {
+			agg.sgTotal += r.SGTotal
+			agg.sgT2G += r.SGOffTee + r.SGApproach + r.SGAround
+			agg.sgPutting += r.SGPutting
+			agg.sgRounds++
+		}
+		if event.EndDate != nil && (agg.lastPlayed == nil || event.EndDate.After(*agg.lastPlayed)) {
+			agg.lastPlayed = event.EndDate
+		}
+		playerEvent := fmt.Sprintf("%s:%d", eventKey, r.DGID)
+		if r.Round > lastRound[playerEvent] {
+			lastRound[playerEvent] = r.Round
+		}
+	}
+
+	// Cuts come from the rounds, finishing positions from the DFS archive
+	for playerEvent, round := range lastRound {
+		sep := strings.LastIndex(playerEvent, ":")
+		eventKey := playerEvent[:sep]
+		event := eventByKey[eventKey]
+		if event.CutLine == nil {
+			continue
+		}
+		var dgID int
+		fmt.Sscanf(playerEvent[sep+1:], "%d", &dgID)
+		agg := aggregates[dgID][event.CourseKey]
+		if round > 2 {
+			agg.cutsMade++
+		} else {
+			agg.missedCuts++
+		}
+	}
+
+	var finishes []models.GolfHistoricalDFSResult
+	if err := db.Where("finish > 0").Find(&finishes).Error; err != nil {
+		return 0, err
+	}
+	seen := make(map[string]bool)
+	for _, f := range finishes {
+		eventKey := historicalEventKey(f.Tour, f.EventID, f.Year)
+		event, ok := eventByKey[eventKey]
+		if !ok {
+			continue
+		}
+		// Every site reports the same finish, so count each player-event once
+		playerEvent := fmt.Sprintf("%s:%d", eventKey, f.DGID)
+		if seen[playerEvent] {
+			continue
+		}
+		seen[playerEvent] = true
+
+		agg := courseAggregateFor(aggregates, f.DGID, event.CourseKey)
+		if agg.bestFinish == 0 || f.Finish < agg.bestFinish {
+			agg.bestFinish = f.Finish
+		}
+		if f.Finish > agg.worstFinish {
+			agg.worstFinish = f.Finish
+		}
+		if f.Finish == 1 {
+			agg.wins++
+		}
+		if f.Finish <= 10 {
+			agg.top10s++
+		}
+		if f.Finish <= 25 {
+			agg.top25s++
+		}
+	}
+
+	players, err := dfs.datagolfPlayerIDs(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var histories []models.GolfCourseHistory
+	for dgID, courses := range aggregates {
+		for _, playerID := range players[dgID] {
+			for courseKey, agg := range courses {
+				if agg.rounds == 0 {
+					continue
+				}
+				history := models.GolfCourseHistory{
+					PlayerID:          playerID,
+					CourseID:          courseKey,
+					TournamentsPlayed: len(agg.events),
+					RoundsPlayed:      agg.rounds,
+					TotalStrokes:      agg.strokes,
+					ScoringAvg:        float64(agg.strokes) / float64(agg.rounds),
+					BestFinish:        agg.bestFinish,
+					WorstFinish:       agg.worstFinish,
+					CutsMade:          agg.cutsMade,
+					MissedCuts:        agg.missedCuts,
+					Top10s:            agg.top10s,
+					Top25s:            agg.top25s,
+					Wins:              agg.wins,
+					LastPlayed:        agg.lastPlayed,
+				}
+				if course := courseAvg[courseKey]; course != nil && course.count > 0 {
+					history.AdjScoringAvg = course.total/float64(course.count) + agg.relativeToField/float64(agg.rounds)
+				}
+				if agg.sgRounds > 0 {
+					history.StrokesGainedTotal = agg.sgTotal / float64(agg.sgRounds)
+					history.SGTeeToGreen = agg.sgT2G / float64(agg.sgRounds)
+					history.SGPutting = agg.sgPutting / float64(agg.sgRounds)
+				}
+				histories = append(histories, history)
+			}
+		}
+	}
+	if len(histories) == 0 {
+		return 0, nil
+	}
+
+	err = db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "player_id"}, {Name: "course_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"tournaments_played", "rounds_played", "total_strokes", "scoring_avg", "adj_scoring_avg",
+			"best_finish", "worst_finish", "cuts_made", "missed_cuts", "top_10s", "top_25s", "wins",
+			"strokes_gained_total", "sg_tee_to_green", "sg_putting", "last_played", "updated_at",
+		}),
+	}).CreateInBatches(&histories, golfBackfillBatch).Error
+	if err != nil {
+		return 0, err
+	}
+	return len(histories), nil
+}
+
+func courseAggregateFor(aggregates map[int]map[string]*courseAggregate, dgID int, courseKey string) *courseAggregate {
+	courses, ok := aggregates[dgID]
+	if !ok {
+		courses = make(map[string]*courseAggregate)
+		aggregates[dgID] = courses
+	}
+	agg, ok := courses[courseKey]
+	if !ok {
+		agg = &courseAggregate{events: make(map[string]bool)}
+		courses[courseKey] = agg
+	}
+	return agg
+}
+
+// datagolfPlayerIDs maps DataGolf IDs to player rows. Golf players are stored per contest, so
+// history is written for each row from the last week rather than only the newest.
+func (dfs *DataFetcherService) datagolfPlayerIDs(ctx context.Context) (map[int][]uuid.UUID, error) {
+	var rows []struct {
+		ID         uuid.UUID
+		ExternalID string
+	}
+	err := dfs.db.WithContext(ctx).
+		Table("players").
+		Select("id, external_id").
+		Where("external_id LIKE ? AND (game_time IS NULL OR game_time >= ?)", "datagolf_%", time.Now().AddDate(0, 0, -7)).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to load DataGolf players: %w", err)
+	}
+
+	players := make(map[int][]uuid.UUID)
+	for _, row := range rows {
+		var dgID int
+		if _, err := fmt.Sscanf(row.ExternalID, "datagolf_%d", &dgID); err != nil {
+			continue
+		}
+		players[dgID] = append(players[dgID], row.ID)
+	}
+	return players, nil
+}
+
+// CourseKey normalizes a course name into the identifier used for course history, e.g.
+// "TPC Sawgrass (Stadium)" becomes "tpc_sawgrass_stadium"
+func CourseKey(course string) string {
+	var b strings.Builder
+	underscore := false
+	for _, r := range strings.ToLower(strings.TrimSpace(course)) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+			underscore = false
+			continue
+		}
+		if !underscore && b.Len() > 0 {
+			b.WriteByte('_')
+			underscore = true
+		}
+	}
+	return strings.TrimSuffix(b.String(), "_")
+}
+
+func historicalEventKey(tour, eventID string, year int) string {
+	return fmt.Sprintf("%s:%s:%d", tour, eventID, year)
+}
+
+func backfillKey(dataset, eventID string, year int) string {
+	return fmt.Sprintf("%s:%s:%d", dataset, eventID, year)
+}
+
+func parseHistoricalDate(value string) *time.Time {
+	if value == "" {
+		return nil
+	}
+	for _, layout := range []string{"2006-01-02", time.RFC3339} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return &t
+		}
+	}
+	return nil
+}
@@ -0,0 +1,242 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/stitts-dev/dfs-sim/services/sports-data-service/internal/models"
+	"github.com/stitts-dev/dfs-sim/services/sports-data-service/internal/providers"
+	"github.com/stitts-dev/dfs-sim/shared/pkg/database"
+)
+
+func TestCourseKey(t *testing.T) {
+	tests := []struct {
+		course   string
+		expected string
+	}{
+		{"TPC Sawgrass (Stadium)", "tpc_sawgrass_stadium"},
+		{"  Pebble Beach Golf Links ", "pebble_beach_golf_links"},
+		{"Muirfield Village G.C.", "muirfield_village_g_c"},
+		{"Club de Golf Chapultepec", "club_de_golf_chapultepec"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.course, func(t *testing.T) {
+			assert.Equal(t, tt.expected, CourseKey(tt.course))
+		})
+	}
+}
+
+func TestSummarizeHistoricalEvent(t *testing.T) {
+	round := func(dgID, round, score, par int) models.GolfHistoricalRound {
+		return models.GolfHistoricalRound{DGID: dgID, Round: round, Score: score, CoursePar: par}
+	}
+	fullEvent := func(dgID int, scores ...int) []models.GolfHistoricalRound {
+		var rounds []models.GolfHistoricalRound
+		for i, score := range scores {
+			rounds = append(rounds, round(dgID, i+1, score, 72))
+		}
+		return rounds
+	}
+	concat := func(parts ...[]models.GolfHistoricalRound) []models.GolfHistoricalRound {
+		var rounds []models.GolfHistoricalRound
+		for _, part := range parts {
+			rounds = append(rounds, part...)
+		}
+		return rounds
+	}
+	intPtr := func(v int) *int { return &v }
+
+	tests := []struct {
+		name     string
+		rounds   []models.GolfHistoricalRound
+		expected HistoricalEventSummary
+	}{
+		{
+			name: "cut at the worst 36 holes that played on",
+			rounds: concat(
+				fullEvent(1, 70, 71, 69, 70),
+				fullEvent(2, 73, 72, 70, 71),
+				fullEvent(3, 75, 74),
+			),
+			expected: HistoricalEventSummary{CoursePar: 72, CutLine: intPtr(1), FieldSize: 3},
+		},
+		{
+			name: "no-cut event",
+			rounds: concat(
+				fullEvent(1, 70, 71, 69, 70),
+				fullEvent(2, 75, 74, 73, 72),
+			),
+			expected: HistoricalEventSummary{CoursePar: 72, FieldSize: 2},
+		},
+		{
+			name: "par is the most common value",
+			rounds: concat(
+				fullEvent(1, 70, 71, 69),
+				[]models.GolfHistoricalRound{round(2, 1, 71, 71)},
+			),
+			expected: HistoricalEventSummary{CoursePar: 72, FieldSize: 2},
+		},
+		{
+			name:     "no par reported",
+			rounds:   []models.GolfHistoricalRound{round(1, 1, 70, 0), round(1, 2, 71, 0)},
+			expected: HistoricalEventSummary{FieldSize: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, SummarizeHistoricalEvent(tt.rounds))
+		})
+	}
+}
+
+// newTestBackfillService is a data fetcher backed by sqlmock
+func newTestBackfillService(t *testing.T) (*DataFetcherService, sqlmock.Sqlmock) {
+	t.Helper()
+	sqlDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { sqlDB.Close() })
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+	log := logrus.New()
+	log.SetLevel(logrus.PanicLevel)
+	return &DataFetcherService{db: &database.DB{DB: gormDB}, logger: log}, mock
+}
+
+// expectEmptyCourseHistoryRebuild answers the course history rebuild that ends every run
+func expectEmptyCourseHistoryRebuild(mock sqlmock.Sqlmock) {
+	mock.ExpectQuery(`SELECT \* FROM "golf_historical_events"`).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectQuery(`SELECT \* FROM "golf_historical_rounds"`).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectQuery(`SELECT \* FROM "golf_historical_dfs_results"`).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectQuery(`SELECT id, external_id FROM "players"`).WillReturnRows(sqlmock.NewRows([]string{"id", "external_id"}))
+}
+
+// fakeGolfHistory serves a fixed archive and counts what was downloaded
+type fakeGolfHistory struct {
+	events    []providers.DataGolfHistoricalEvent
+	dfsEvents []providers.DataGolfDFSEvent
+	results   []providers.DataGolfDFSResult
+	rounds    []string
+	dfs       []string
+}
+
+func (f *fakeGolfHistory) GetHistoricalEventList(tour string) (*providers.HistoricalEventListResponse, error) {
+	return &providers.HistoricalEventListResponse{Events: f.events}, nil
+}
+
+func (f *fakeGolfHistory) GetHistoricalRounds(tour, eventID string, year int) (*providers.HistoricalRoundsResponse, error) {
+	f.rounds = append(f.rounds, backfillKey(tour, eventID, year))
+	return &providers.HistoricalRoundsResponse{}, nil
+}
+
+func (f *fakeGolfHistory) GetHistoricalDFSEventList() (*providers.HistoricalDFSEventListResponse, error) {
+	return &providers.HistoricalDFSEventListResponse{Events: f.dfsEvents}, nil
+}
+
+func (f *fakeGolfHistory) GetHistoricalDFSPoints(tour, site, eventID string, year int) (*providers.HistoricalDFSResponse, error) {
+	f.dfs = append(f.dfs, backfillKey(site, eventID, year))
+	return &providers.HistoricalDFSResponse{Results: f.results}, nil
+}
+
+func TestRunGolfHistoryBackfill_ResumesFromProgress(t *testing.T) {
+	dfs, mock := newTestBackfillService(t)
+
+	source := &fakeGolfHistory{
+		events: []providers.DataGolfHistoricalEvent{
+			{EventID: "14", Year: 2024, EventName: "The Masters", Course: "Augusta National Golf Club", EndDate: "2024-04-14"},
+		},
+		dfsEvents: []providers.DataGolfDFSEvent{{EventID: "14", Year: 2024, Tour: "pga"}},
+		results: []providers.DataGolfDFSResult{
+			{DGID: 18417, PlayerName: "Scheffler, Scottie", Salary: 11000, Ownership: 0.31, Points: 121.5, Position: 1, MadeCut: true},
+		},
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO "golf_historical_events" .* ON CONFLICT \("tour","event_id","year"\) DO UPDATE`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("5f1b8f3e-9a56-4c4e-8e55-1f3a9c3f7d10"))
+	mock.ExpectCommit()
+	// An earlier run stored the rounds before it stopped
+	mock.ExpectQuery(`SELECT \* FROM "golf_backfill_progress" WHERE tour = \$1 AND status = \$2`).
+		WithArgs("pga", models.BackfillStatusComplete).
+		WillReturnRows(sqlmock.NewRows([]string{"dataset", "tour", "event_id", "year", "status"}).
+			AddRow(golfBackfillRounds, "pga", "14", 2024, models.BackfillStatusComplete))
+	// Stored results are upserted on their natural key, so a retried event doesn't duplicate rows
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO "golf_historical_dfs_results" .* ON CONFLICT \("tour","site","event_id","year","dg_id"\) DO UPDATE`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("0c0e7f43-2f62-4a0b-bc1a-6f0f6d0c7e21"))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO "golf_backfill_progress" .* ON CONFLICT \("dataset","tour","event_id","year"\) DO UPDATE SET .*"attempts"=golf_backfill_progress.attempts \+ 1`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("3d9a3c52-81c4-4bd4-9a7e-2a0b5d7c6e34"))
+	mock.ExpectCommit()
+	expectEmptyCourseHistoryRebuild(mock)
+
+	result, err := dfs.runGolfHistoryBackfill(context.Background(), source, GolfBackfillOptions{
+		Tours:    []string{"pga"},
+		Sites:    []string{"draftkings"},
+		FromYear: time.Now().Year() - 5,
+	})
+	require.NoError(t, err)
+
+	assert.Empty(t, source.rounds, "completed datasets aren't downloaded again")
+	assert.Equal(t, []string{backfillKey("draftkings", "14", 2024)}, source.dfs)
+	assert.Equal(t, 1, result.Events)
+	assert.Equal(t, 1, result.Completed)
+	assert.Equal(t, 1, result.DFSRowsStored)
+	assert.Equal(t, 0, result.RoundsStored)
+	assert.Equal(t, 3, result.Requests)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRunGolfHistoryBackfill_BudgetLeavesRemainingForNextRun(t *testing.T) {
+	dfs, mock := newTestBackfillService(t)
+
+	source := &fakeGolfHistory{
+		events: []providers.DataGolfHistoricalEvent{
+			{EventID: "14", Year: 2024, Course: "Augusta National Golf Club", EndDate: "2024-04-14"},
+			{EventID: "14", Year: 2023, Course: "Augusta National Golf Club", EndDate: "2023-04-09"},
+		},
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO "golf_historical_events"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).
+			AddRow("5f1b8f3e-9a56-4c4e-8e55-1f3a9c3f7d10").
+			AddRow("7a2c9e41-3b8d-4f6a-9c5e-0d1b2a3c4e5f"))
+	mock.ExpectCommit()
+	mock.ExpectQuery(`SELECT \* FROM "golf_backfill_progress"`).
+		WillReturnRows(sqlmock.NewRows([]string{"dataset", "tour", "event_id", "year", "status"}))
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE "golf_historical_events" SET`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO "golf_backfill_progress"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("3d9a3c52-81c4-4bd4-9a7e-2a0b5d7c6e34"))
+	mock.ExpectCommit()
+	expectEmptyCourseHistoryRebuild(mock)
+
+	// The event list and one event's rounds use the whole budget
+	result, err := dfs.runGolfHistoryBackfill(context.Background(), source, GolfBackfillOptions{
+		Tours:       []string{"pga"},
+		FromYear:    2020,
+		MaxRequests: 2,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{backfillKey("pga", "14", 2024)}, source.rounds, "newest event first")
+	assert.Equal(t, 1, result.Completed)
+	assert.Equal(t, 1, result.Remaining)
+	assert.True(t, result.BudgetExhausted)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,314 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sony/gobreaker"
+	"gorm.io/gorm/clause"
+
+	"github.com/stitts-dev/dfs-sim/services/sports-data-service/internal/models"
+	"github.com/stitts-dev/dfs-sim/services/sports-data-service/internal/providers"
+)
+
+const (
+	// golfMatchupMarket is DataGolf's tournament-long head-to-head market
+	golfMatchupMarket = "tournament_matchups"
+	// minBookCoverage is the share of the priced field a book must cover for its outright prices
+	// to be normalized; below it the book's slice of the market is too thin to estimate
+	minBookCoverage = 0.5
+	// maxImpliedProb keeps normalized top-N and make-cut prices below certainty
+	maxImpliedProb = 0.995
+)
+
+// golfOutrightMarkets are the outright markets ingested each run, in request order
+var golfOutrightMarkets = []string{
+	models.GolfMarketWin,
+	models.GolfMarketTop5,
+	models.GolfMarketTop10,
+	models.GolfMarketTop20,
+	models.GolfMarketMakeCut,
+}
+
+// ConsensusProbability is a player's no-vig probability averaged across books
+type ConsensusProbability struct {
+	Prob  float64
+	Books int
+}
+
+// ImpliedProbability converts decimal odds to the probability the price implies, margin included
+func ImpliedProbability(decimalOdds float64) float64 {
+	if decimalOdds <= 1 {
+		return 0
+	}
+	return 1 / decimalOdds
+}
+
+// RemoveVig scales one book's implied probabilities so they sum to the number of winning
+// outcomes: 1 for a win market or matchup, N for a top-N market, the cut size for make-cut
+func RemoveVig(implied []float64, outcomes float64) []float64 {
+	total := 0.0
+	for _, p := range implied {
+		total += p
+	}
+
+	fair := make([]float64, len(implied))
+	if total <= 0 {
+		return fair
+	}
+	for i, p := range implied {
+		fair[i] = math.Min(maxImpliedProb, p*outcomes/total)
+	}
+	return fair
+}
+
+// marketOutcomes is how many players win a bet in an outright market
+func marketOutcomes(market string, fieldSize int) float64 {
+	outcomes := 1
+	switch market {
+	case models.GolfMarketTop5:
+		outcomes = 5
+	case models.GolfMarketTop10:
+		outcomes = 10
+	case models.GolfMarketTop20:
+		outcomes = 20
+	case models.GolfMarketMakeCut:
+		outcomes = tourCutSize
+	}
+	if fieldSize > 0 && outcomes > fieldSize {
+		outcomes = fieldSize
+	}
+	return float64(outcomes)
+}
+
+// OutrightConsensus removes each book's margin and averages the fair prices per DataGolf ID.
+// A book that skips part of the field is normalized over the outcomes its players account for:
+// the market's outcomes scaled by the share of DataGolf's model probability they hold. Without
+// model probabilities to measure that share, only books pricing the whole field are used.
+func OutrightConsensus(market string, odds []providers.DataGolfOutrightOdd) map[int]ConsensusProbability {
+	type price struct {
+		dgID    int
+		implied float64
+	}
+	books := make(map[string][]price)
+	modelProbs := make(map[int]float64, len(odds))
+	modelTotal := 0.0
+	for _, player := range odds {
+		if player.ModelProb > 0 {
+			modelProbs[player.DGID] = player.ModelProb
+			modelTotal += player.ModelProb
+		}
+		for book, quote := range player.BookOdds {
+			implied := quote.ImpliedProb
+			if implied <= 0 {
+				implied = ImpliedProbability(quote.Odds)
+			}
+			if implied > 0 {
+				books[book] = append(books[book], price{dgID: player.DGID, implied: implied})
+			}
+		}
+	}
+
+	totals := make(map[int]float64)
+	counts := make(map[int]int)
+	outcomes := marketOutcomes(market, len(odds))
+	for _, prices := range books {
+		if float64(len(prices)) < minBookCoverage*float64(len(odds)) {
+			continue
+		}
+		bookOutcomes := outcomes
+		if len(prices) < len(odds) {
+			if modelTotal <= 0 {
+				continue
+			}
+			covered := 0.0
+			for _, p := range prices {
+				covered += modelProbs[p.dgID]
+			}
+			bookOutcomes = outcomes * covered / modelTotal
+		}
+		implied := make([]float64, len(prices))
+		for i, p := range prices {
+			implied[i] = p.implied
+		}
+		for i, fair := range RemoveVig(implied, bookOutcomes) {
+			totals[prices[i].dgID] += fair
+			counts[prices[i].dgID]++
+		}
+	}
+
+	consensus := make(map[int]ConsensusProbability, len(totals))
+	for dgID, total := range totals {
+		consensus[dgID] = ConsensusProbability{Prob: total / float64(counts[dgID]), Books: counts[dgID]}
+	}
+	return consensus
+}
+
+// MatchupConsensus removes each book's margin from a matchup and averages the fair prices,
+// returned in the same order as the matchup's players
+func MatchupConsensus(matchup providers.DataGolfMatchup) []ConsensusProbability {
+	consensus := make([]ConsensusProbability, len(matchup.Players))
+	for _, quotes := range matchup.BookOdds {
+		if len(quotes) != len(matchup.Players) {
+			continue
+		}
+		implied := make([]float64, len(quotes))
+		priced := true
+		for i, quote := range quotes {
+			implied[i] = quote.ImpliedProb
+			if implied[i] <= 0 {
+				implied[i] = ImpliedProbability(quote.Odds)
+			}
+			priced = priced && implied[i] > 0
+		}
+		if !priced {
+			continue
+		}
+		for i, fair := range RemoveVig(implied, 1) {
+			consensus[i].Prob += fair
+			consensus[i].Books++
+		}
+	}
+
+	for i := range consensus {
+		if consensus[i].Books > 0 {
+			consensus[i].Prob /= float64(consensus[i].Books)
+		}
+	}
+	return consensus
+}
+
+// syncGolfOdds is the scheduled wrapper around SyncGolfOdds
+func (dfs *DataFetcherService) syncGolfOdds() {
+	logger := dfs.logger.WithField("component", "data_fetcher").WithField("job", "golf_odds_sync")
+
+	if dfs.dataGolfProvider == nil {
+		logger.Debug("DataGolf provider not available, skipping odds sync")
+		return
+	}
+	if dfs.circuitBreaker.GetState("datagolf") == gobreaker.StateOpen {
+		logger.Warn("DataGolf circuit breaker is open, skipping odds sync")
+		return
+	}
+
+	outrights, matchups, err := dfs.SyncGolfOdds(dfs.ctx)
+	if err != nil {
+		logger.WithError(err).Error("Golf odds sync failed")
+		return
+	}
+
+	logger.WithFields(logrus.Fields{
+		"outright_rows": outrights,
+		"matchup_rows":  matchups,
+	}).Info("Golf odds sync completed")
+}
+
+// SyncGolfOdds fetches the outright and matchup markets for the current event and stores the
+// no-vig consensus against its tournament. It returns the number of outright and matchup rows.
+func (dfs *DataFetcherService) SyncGolfOdds(ctx context.Context) (int, int, error) {
+	outrightRows := 0
+	for _, market := range golfOutrightMarkets {
+		response, err := dfs.dataGolfProvider.GetOutrights(market)
+		if err != nil {
+			// Not every market is offered every week
+			dfs.logger.WithError(err).WithField("market", market).Warn("Failed to fetch golf outrights")
+			continue
+		}
+
+		tournament, err := dfs.oddsTournament(ctx, response.Event)
+		if err != nil {
+			return outrightRows, 0, err
+		}
+
+		consensus := OutrightConsensus(market, response.Odds)
+		rows := make([]models.GolfMarketOdds, 0, len(consensus))
+		for _, player := range response.Odds {
+			price, ok := consensus[player.DGID]
+			if !ok {
+				continue
+			}
+			rows = append(rows, models.GolfMarketOdds{
+				TournamentID: tournament.ID,
+				Market:       market,
+				DGID:         player.DGID,
+				PlayerName:   player.PlayerName,
+				ImpliedProb:  price.Prob,
+				DataGolfProb: player.ModelProb,
+				Books:        price.Books,
+			})
+		}
+		if len(rows) == 0 {
+			continue
+		}
+
+		if err := dfs.db.WithContext(ctx).Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "tournament_id"}, {Name: "market"}, {Name: "dg_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"player_name", "implied_prob", "datagolf_prob", "books", "updated_at"}),
+		}).CreateInBatches(&rows, golfBackfillBatch).Error; err != nil {
+			return outrightRows, 0, fmt.Errorf("failed to store %s odds: %w", market, err)
+		}
+		outrightRows += len(rows)
+	}
+
+	matchupRows, err := dfs.syncGolfMatchups(ctx)
+	return outrightRows, matchupRows, err
+}
+
+func (dfs *DataFetcherService) syncGolfMatchups(ctx context.Context) (int, error) {
+	response, err := dfs.dataGolfProvider.GetMatchups(golfMatchupMarket)
+	if err != nil {
+		dfs.logger.WithError(err).Warn("Failed to fetch golf matchups")
+		return 0, nil
+	}
+
+	tournament, err := dfs.oddsTournament(ctx, response.Event)
+	if err != nil {
+		return 0, err
+	}
+
+	var rows []models.GolfMatchupOdds
+	for _, matchup := range response.Matchups {
+		for i, price := range MatchupConsensus(matchup) {
+			if price.Books == 0 {
+				continue
+			}
+			row := models.GolfMatchupOdds{
+				TournamentID: tournament.ID,
+				Market:       golfMatchupMarket,
+				MatchupID:    matchup.MatchupID,
+				Player:       matchup.Players[i],
+				ImpliedProb:  price.Prob,
+				Books:        price.Books,
+			}
+			if i < len(matchup.ModelProbs) {
+				row.DataGolfProb = matchup.ModelProbs[i]
+			}
+			rows = append(rows, row)
+		}
+	}
+	if len(rows) == 0 {
+		return 0, nil
+	}
+
+	if err := dfs.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "tournament_id"}, {Name: "market"}, {Name: "matchup_id"}, {Name: "player"}},
+		DoUpdates: clause.AssignmentColumns([]string{"implied_prob", "datagolf_prob", "books", "updated_at"}),
+	}).CreateInBatches(&rows, golfBackfillBatch).Error; err != nil {
+		return 0, fmt.Errorf("failed to store matchup odds: %w", err)
+	}
+	return len(rows), nil
+}
+
+// oddsTournament finds the stored tournament a betting response belongs to
+func (dfs *DataFetcherService) oddsTournament(ctx context.Context, event providers.DataGolfEventInfo) (*models.GolfTournament, error) {
+	var tournament models.GolfTournament
+	if err := dfs.db.WithContext(ctx).
+		Where("external_id = ?", strconv.Itoa(event.EventID)).
+		First(&tournament).Error; err != nil {
+		return nil, fmt.Errorf("tournament for event %d (%s) not found: %w", event.EventID, event.EventName, err)
+	}
+	return &tournament, nil
+}
@@ -0,0 +1,199 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stitts-dev/dfs-sim/services/sports-data-service/internal/models"
+	"github.com/stitts-dev/dfs-sim/services/sports-data-service/internal/providers"
+	"github.com/stitts-dev/dfs-sim/shared/types"
+)
+
+func TestRemoveVig(t *testing.T) {
+	tests := []struct {
+		name     string
+		implied  []float64
+		outcomes float64
+		expected []float64
+	}{
+		{"two-way market", []float64{0.5, 0.6}, 1, []float64{0.5 / 1.1, 0.6 / 1.1}},
+		{"top-N market", []float64{0.6, 0.6, 0.6, 0.6}, 2, []float64{0.5, 0.5, 0.5, 0.5}},
+		{"capped below certainty", []float64{1.0, 0.5, 0.5}, 2, []float64{maxImpliedProb, 0.5, 0.5}},
+		{"nothing priced", []float64{0, 0}, 1, []float64{0, 0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fair := RemoveVig(tt.implied, tt.outcomes)
+			require.Len(t, fair, len(tt.expected))
+			for i := range tt.expected {
+				assert.InDelta(t, tt.expected[i], fair[i], 1e-9)
+			}
+		})
+	}
+}
+
+func TestOutrightConsensus(t *testing.T) {
+	quote := func(implied float64) providers.DataGolfOdds { return providers.DataGolfOdds{ImpliedProb: implied} }
+	field := func(modelProbs ...float64) []providers.DataGolfOutrightOdd {
+		odds := make([]providers.DataGolfOutrightOdd, len(modelProbs))
+		for i, prob := range modelProbs {
+			odds[i] = providers.DataGolfOutrightOdd{DGID: i + 1, ModelProb: prob, BookOdds: map[string]providers.DataGolfOdds{}}
+		}
+		// "full" prices the whole field with a 20% margin
+		for i, implied := range []float64{0.5, 0.3, 0.25, 0.15} {
+			odds[i].BookOdds["full"] = quote(implied)
+		}
+		// "half" only prices the favourites, which DataGolf gives 70% of the win probability
+		odds[0].BookOdds["half"] = quote(0.44)
+		odds[1].BookOdds["half"] = quote(0.33)
+		// "thin" is under the coverage threshold
+		odds[0].BookOdds["thin"] = quote(0.9)
+		return odds
+	}
+
+	tests := []struct {
+		name     string
+		odds     []providers.DataGolfOutrightOdd
+		expected map[int]ConsensusProbability
+	}{
+		{
+			name: "partial book normalized over its share of the field",
+			odds: field(0.4, 0.3, 0.2, 0.1),
+			expected: map[int]ConsensusProbability{
+				1: {Prob: (0.5/1.2 + 0.4) / 2, Books: 2},
+				2: {Prob: (0.3/1.2 + 0.3) / 2, Books: 2},
+				3: {Prob: 0.25 / 1.2, Books: 1},
+				4: {Prob: 0.15 / 1.2, Books: 1},
+			},
+		},
+		{
+			name: "partial book dropped without model probabilities",
+			odds: field(0, 0, 0, 0),
+			expected: map[int]ConsensusProbability{
+				1: {Prob: 0.5 / 1.2, Books: 1},
+				2: {Prob: 0.3 / 1.2, Books: 1},
+				3: {Prob: 0.25 / 1.2, Books: 1},
+				4: {Prob: 0.15 / 1.2, Books: 1},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			consensus := OutrightConsensus(models.GolfMarketWin, tt.odds)
+			require.Len(t, consensus, len(tt.expected))
+			for dgID, expected := range tt.expected {
+				assert.InDelta(t, expected.Prob, consensus[dgID].Prob, 1e-9, "dg_id %d", dgID)
+				assert.Equal(t, expected.Books, consensus[dgID].Books, "dg_id %d", dgID)
+			}
+		})
+	}
+}
+
+func TestMatchupConsensus(t *testing.T) {
+	tests := []struct {
+		name     string
+		bookOdds map[string][]providers.DataGolfOdds
+		expected []ConsensusProbability
+	}{
+		{
+			name: "implied probabilities and decimal odds averaged",
+			bookOdds: map[string][]providers.DataGolfOdds{
+				"even":  {{ImpliedProb: 0.55}, {ImpliedProb: 0.55}},
+				"fav":   {{Odds: 1.8}, {Odds: 2.1}},
+				"short": {{ImpliedProb: 0.6}},
+				"void":  {{Odds: 1.9}, {Odds: 0}},
+			},
+			expected: []ConsensusProbability{
+				{Prob: (0.5 + 2.1/3.9) / 2, Books: 2},
+				{Prob: (0.5 + 1.8/3.9) / 2, Books: 2},
+			},
+		},
+		{
+			name:     "unpriced matchup",
+			bookOdds: map[string][]providers.DataGolfOdds{},
+			expected: []ConsensusProbability{{}, {}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			consensus := MatchupConsensus(providers.DataGolfMatchup{Players: []string{"A", "B"}, BookOdds: tt.bookOdds})
+			require.Len(t, consensus, len(tt.expected))
+			for i, expected := range tt.expected {
+				assert.InDelta(t, expected.Prob, consensus[i].Prob, 1e-9)
+				assert.Equal(t, expected.Books, consensus[i].Books)
+			}
+		})
+	}
+}
+
+func TestBlendProbability(t *testing.T) {
+	tests := []struct {
+		name                  string
+		model, market, weight float64
+		expected              float64
+	}{
+		{"unpriced market", 0.2, 0, 0.5, 0.2},
+		{"no weight", 0.2, 0.3, 0, 0.2},
+		{"even blend", 0.2, 0.3, 0.5, 0.25},
+		{"weight capped at the market", 0.2, 0.3, 1.5, 0.3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.expected, blendProbability(tt.model, tt.market, tt.weight), 1e-9)
+		})
+	}
+}
+
+func TestCompareWithMarket(t *testing.T) {
+	player := types.Player{ID: uuid.New(), ExternalID: "datagolf_10"}
+	projections := map[uuid.UUID]*models.GolfProjection{
+		player.ID: {WinProbability: 0.1, FinalCutProbability: 0.8},
+	}
+	odds := []models.GolfMarketOdds{
+		{DGID: 10, Market: models.GolfMarketWin, ImpliedProb: 0.08, Books: 3},
+		{DGID: 10, Market: models.GolfMarketMakeCut, ImpliedProb: 0.85, Books: 2},
+		{DGID: 10, Market: models.GolfMarketTop20, ImpliedProb: 0.4, Books: 2},
+		{DGID: 99, Market: models.GolfMarketWin, ImpliedProb: 0.01, Books: 1},
+	}
+
+	comparisons := compareWithMarket(odds, projections, []types.PlayerInterface{player})
+	require.Len(t, comparisons, len(odds))
+
+	tests := []struct {
+		name     string
+		playerID uuid.UUID
+		model    *float64
+		edge     float64
+	}{
+		{"model above market", player.ID, floatPtr(0.1), 0.02},
+		{"model below market", player.ID, floatPtr(0.8), -0.05},
+		{"market the model doesn't price", player.ID, nil, 0},
+		{"player outside the pool", uuid.Nil, nil, 0},
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			comparison := comparisons[i]
+			assert.Equal(t, odds[i].ImpliedProb, comparison.MarketProb)
+			assert.Equal(t, odds[i].Books, comparison.Books)
+			assert.Equal(t, tt.playerID, comparison.PlayerID)
+			if tt.model == nil {
+				assert.Nil(t, comparison.ModelProb)
+				assert.Nil(t, comparison.Edge)
+				return
+			}
+			require.NotNil(t, comparison.ModelProb)
+			require.NotNil(t, comparison.Edge)
+			assert.InDelta(t, *tt.model, *comparison.ModelProb, 1e-9)
+			assert.InDelta(t, tt.edge, *comparison.Edge, 1e-9)
+		})
+	}
+}
+
+func floatPtr(v float64) *float64 { return &v }
@@ -11,9 +11,20 @@ import (
 	"github.com/stitts-dev/dfs-sim/services/sports-data-service/internal/models"
 	"github.com/stitts-dev/dfs-sim/services/sports-data-service/internal/providers"
 	"github.com/stitts-dev/dfs-sim/shared/pkg/database"
+	"github.com/stitts-dev/dfs-sim/shared/pkg/scoring"
+	"github.com/stitts-dev/dfs-sim/shared/pkg/weather"
 	"github.com/stitts-dev/dfs-sim/shared/types"
 )
 
+const (
+	// defaultCoursePar is used when a tournament has no par recorded
+	defaultCoursePar = 72
+	// golfRoundStdDev is the typical standard deviation of a tour player's round in strokes
+	golfRoundStdDev = 2.8
+	// tourCutSize is the number of players who make a standard tour cut
+	tourCutSize = 65
+)
+
 // CutProbabilityEngineInterface defines the interface for cut probability calculations
 type CutProbabilityEngineInterface interface {
 	CalculateCutProbability(ctx context.Context, playerID, tournamentID, courseID string, fieldStrength float64) (*CutProbabilityResult, error)
@@ -42,6 +53,36 @@ type GolfProjectionService struct {
 	golfProvider         *providers.DataGolfClient
 	weatherService       *WeatherService
 	cutProbabilityEngine CutProbabilityEngineInterface
+	oddsWeights          GolfOddsBlendWeights
+}
+
+// GolfOddsBlendWeights is how much the no-vig betting market counts against the model when
+// blending probabilities. A weight of zero keeps the model's value.
+type GolfOddsBlendWeights struct {
+	Win     float64 `json:"win"`
+	Top10   float64 `json:"top10"`
+	MakeCut float64 `json:"make_cut"`
+}
+
+// DefaultGolfOddsBlendWeights leans on the market for outright winners, where books price the
+// tails better than the model, and less for the cut
+func DefaultGolfOddsBlendWeights() GolfOddsBlendWeights {
+	return GolfOddsBlendWeights{Win: 0.5, Top10: 0.5, MakeCut: 0.4}
+}
+
+// GolfOddsComparison sets the market's price for a player next to our model's and DataGolf's
+type GolfOddsComparison struct {
+	PlayerID     uuid.UUID `json:"player_id"`
+	PlayerName   string    `json:"player_name"`
+	DGID         int       `json:"dg_id"`
+	Market       string    `json:"market"`
+	MarketProb   float64   `json:"market_prob"`
+	Books        int       `json:"books"`
+	DataGolfProb float64   `json:"datagolf_prob"`
+	// ModelProb is our unblended projection, nil for markets the model doesn't price
+	ModelProb *float64 `json:"model_prob,omitempty"`
+	// Edge is the model's probability minus the market's
+	Edge *float64 `json:"edge,omitempty"`
 }
 
 // NewGolfProjectionService creates a new golf projection service
@@ -56,6 +97,7 @@ func NewGolfProjectionService(
 		cache:        cache,
 		logger:       logger,
 		golfProvider: providers.NewDataGolfClient(apiKey, db.DB, cache, logger),
+		oddsWeights:  DefaultGolfOddsBlendWeights(),
 	}
 }
 
@@ -64,6 +106,11 @@ func (gps *GolfProjectionService) SetWeatherService(ws *WeatherService) {
 	gps.weatherService = ws
 }
 
+// SetOddsBlendWeights sets how much betting odds count in blended win, top-10 and cut probabilities
+func (gps *GolfProjectionService) SetOddsBlendWeights(weights GolfOddsBlendWeights) {
+	gps.oddsWeights = weights
+}
+
 // SetCutProbabilityEngine sets the cut probability engine for advanced cut predictions
 func (gps *GolfProjectionService) SetCutProbabilityEngine(engine CutProbabilityEngineInterface) {
 	gps.cutProbabilityEngine = engine
@@ -74,6 +121,15 @@ func (gps *GolfProjectionService) GenerateProjections(
 	ctx context.Context,
 	players []types.PlayerInterface,
 	tournamentID string,
+) (map[uuid.UUID]*models.GolfProjection, map[uuid.UUID]map[uuid.UUID]float64, error) {
+	return gps.generateProjections(ctx, players, tournamentID, gps.oddsWeights)
+}
+
+func (gps *GolfProjectionService) generateProjections(
+	ctx context.Context,
+	players []types.PlayerInterface,
+	tournamentID string,
+	weights GolfOddsBlendWeights,
 ) (map[uuid.UUID]*models.GolfProjection, map[uuid.UUID]map[uuid.UUID]float64, error) {
 	projections := make(map[uuid.UUID]*models.GolfProjection)
 
@@ -95,13 +151,46 @@ func (gps *GolfProjectionService) GenerateProjections(
 		gps.logger.Warn("Failed to get course history", "error", err)
 	}
 
+	// Wave advantage from the tee sheet and hourly forecast
+	var waves *weather.WaveReport
+	if gps.weatherService != nil && len(entries) > 0 {
+		if waves, err = gps.waveReport(ctx, tournament, entries); err != nil {
+			gps.logger.Warn("Failed to analyze tee time waves", "error", err)
+		}
+	}
+
+	// Betting markets are only needed when they carry weight
+	var marketOdds map[int]map[string]float64
+	if weights != (GolfOddsBlendWeights{}) {
+		if odds, err := gps.getMarketOdds(ctx, tournament.ID); err != nil {
+			gps.logger.Warn("Failed to get market odds", "error", err)
+		} else {
+			marketOdds = make(map[int]map[string]float64)
+			for _, o := range odds {
+				if marketOdds[o.DGID] == nil {
+					marketOdds[o.DGID] = make(map[string]float64)
+				}
+				marketOdds[o.DGID][o.Market] = o.ImpliedProb
+			}
+		}
+	}
+
 	// Generate projections for each player
 	for _, player := range players {
 		playerID := player.GetID()
 		entry := entries[playerID]
 		history := courseHistory[playerID]
+		var market map[string]float64
+		if dgID, ok := datagolfID(player.GetExternalID()); ok {
+			market = marketOdds[dgID]
+		}
+
+		var wave *weather.PlayerWaveAdvantage
+		if waves != nil {
+			wave = waves.Players[playerID.String()]
+		}
 
-		projection := gps.generatePlayerProjection(player, tournament, entry, history)
+		projection := gps.generatePlayerProjection(player, tournament, entry, history, wave, market, weights)
 		projections[playerID] = projection
 	}
 
@@ -117,6 +206,9 @@ func (gps *GolfProjectionService) generatePlayerProjection(
 	tournament *models.GolfTournament,
 	entry *models.GolfPlayerEntry,
 	history *models.GolfCourseHistory,
+	wave *weather.PlayerWaveAdvantage,
+	market map[string]float64,
+	weights GolfOddsBlendWeights,
 ) *models.GolfProjection {
 	projection := &models.GolfProjection{
 		PlayerID:     player.GetID().String(),
@@ -163,6 +255,12 @@ func (gps *GolfProjectionService) generatePlayerProjection(
 		}
 	}
 
+	// A good draw is strokes off the first two rounds, which is what decides the cut
+	if wave != nil {
+		projection.ExpectedScore -= wave.StrokesAdvantage
+		projection.TeeTimeAdvantage = wave.StrokesAdvantage
+	}
+
 	// Enhanced cut probability calculation using cut probability engine
 	if gps.cutProbabilityEngine != nil {
 		if cutResult, err := gps.cutProbabilityEngine.CalculateCutProbability(
@@ -189,12 +287,19 @@ func (gps *GolfProjectionService) generatePlayerProjection(
 		// Fallback to simple calculation if engine not available
 		projection.FinalCutProbability = gps.calculateSimpleCutProbability(player, tournament)
 	}
+	if wave != nil {
+		projection.FinalCutProbability = weather.AdjustCutProbability(projection.FinalCutProbability, wave.StrokesAdvantage, golfRoundStdDev)
+		projection.WeatherAdjustedCut = projection.FinalCutProbability
+	}
+	projection.FinalCutProbability = blendProbability(projection.FinalCutProbability, market[models.GolfMarketMakeCut], weights.MakeCut)
 
 	// Calculate position probabilities based on cut probability and skill level
 	projection.Top5Probability = gps.calculatePositionProbability(player, tournament, projection.FinalCutProbability, 5)
 	projection.Top10Probability = gps.calculatePositionProbability(player, tournament, projection.FinalCutProbability, 10)
 	projection.Top25Probability = gps.calculatePositionProbability(player, tournament, projection.FinalCutProbability, 25)
 	projection.WinProbability = gps.calculatePositionProbability(player, tournament, projection.FinalCutProbability, 1)
+	projection.Top10Probability = blendProbability(projection.Top10Probability, market[models.GolfMarketTop10], weights.Top10)
+	projection.WinProbability = blendProbability(projection.WinProbability, market[models.GolfMarketWin], weights.Win)
 
 	// Calculate expected finish position
 	projection.ExpectedFinishPosition = gps.calculateExpectedFinish(player, tournament, projection.FinalCutProbability)
@@ -214,10 +319,18 @@ func (gps *GolfProjectionService) generatePlayerProjection(
 	return projection
 }
 
+// tournamentPar returns the course par, or a standard par 72 when none is recorded
+func tournamentPar(tournament *models.GolfTournament) int {
+	if tournament.CoursePar == 0 {
+		return defaultCoursePar
+	}
+	return tournament.CoursePar
+}
+
 // calculateBaseScore calculates base expected 4-round score
 func (gps *GolfProjectionService) calculateBaseScore(player types.PlayerInterface, tournament *models.GolfTournament) float64 {
 	// Base score relative to par (4 rounds)
-	coursePar := float64(tournament.CoursePar * 4) // 4-round total par
+	coursePar := float64(tournamentPar(tournament) * 4) // 4-round total par
 
 	// Use player's projected points as a proxy for skill level
 	// Assuming projected points correlate with expected finish position
@@ -413,66 +526,215 @@ func (gps *GolfProjectionService) calculateWinProbability(
 // DFS Points Calculations
 
 func (gps *GolfProjectionService) calculateDKPoints(projection *models.GolfProjection, tournament *models.GolfTournament) float64 {
-	points := 0.0
+	return gps.calculateExpectedFantasyPoints(scoring.DraftKingsGolf, projection, tournament)
+}
+
+func (gps *GolfProjectionService) calculateFDPoints(projection *models.GolfProjection, tournament *models.GolfTournament) float64 {
+	return gps.calculateExpectedFantasyPoints(scoring.FanDuelGolf, projection, tournament)
+}
 
-	// DraftKings scoring (simplified)
-	// Placement points
-	if projection.WinProbability > 0.01 {
-		points += 30 * projection.WinProbability // 30 pts for win
+// calculateExpectedFantasyPoints applies a site's scoring tables to the projection: per-hole
+// points from the expected hole distribution for every round the player is expected to play, the
+// round and four-round bonuses, and placement points weighted by the finish probabilities
+func (gps *GolfProjectionService) calculateExpectedFantasyPoints(
+	rules scoring.GolfScoringRules,
+	projection *models.GolfProjection,
+	tournament *models.GolfTournament,
+) float64 {
+	par := tournamentPar(tournament)
+	roundToPar := (projection.ExpectedScore - float64(par*4)) / 4
+	cutProbability := math.Max(0, math.Min(1, projection.FinalCutProbability))
+
+	// Everyone plays two rounds; the weekend only counts when the cut is made
+	roundsPlayed := 2 + 2*cutProbability
+	points := roundsPlayed * rules.ExpectedRoundPoints(scoring.TourHoleDistribution(roundToPar))
+
+	if rules.Bonuses.AllRoundsUnderStrokes > 0 {
+		// Probability a round finishes under the threshold (strokes are whole numbers)
+		threshold := float64(rules.Bonuses.AllRoundsUnderStrokes) - 0.5
+		underProbability := 0.5 * math.Erfc(-(threshold-(float64(par)+roundToPar))/(golfRoundStdDev*math.Sqrt2))
+		points += rules.Bonuses.AllRoundsUnder * cutProbability * math.Pow(underProbability, 4)
 	}
-	if projection.Top10Probability > 0.1 {
-		points += 10 * projection.Top10Probability // 10 pts for top 10
+
+	// Finish probabilities are cumulative, so each band is the gap to the one above it
+	win := projection.WinProbability
+	top5 := math.Max(projection.Top5Probability, win)
+	top10 := math.Max(projection.Top10Probability, top5)
+	top25 := math.Max(projection.Top25Probability, top10)
+	madeCut := math.Max(cutProbability, top25)
+
+	points += win * rules.PlacementPoints(1)
+	points += (top5 - win) * rules.AveragePlacementPoints(2, 5)
+	points += (top10 - top5) * rules.AveragePlacementPoints(6, 10)
+	points += (top25 - top10) * rules.AveragePlacementPoints(11, 25)
+	points += (madeCut - top25) * rules.AveragePlacementPoints(26, tourCutSize)
+
+	return math.Max(0, points)
+}
+
+// CompareWithMarket lines up the stored no-vig market prices for a tournament against our
+// unblended projections, so the model can be judged against the books
+func (gps *GolfProjectionService) CompareWithMarket(
+	ctx context.Context,
+	players []types.PlayerInterface,
+	tournamentID string,
+) ([]GolfOddsComparison, error) {
+	tournament, err := gps.getTournament(ctx, tournamentID)
+	if err != nil {
+		return nil, fmt.Errorf("getting tournament: %w", err)
 	}
-	if projection.Top25Probability > 0.2 {
-		points += 6 * projection.Top25Probability // 6 pts for top 25
+
+	odds, err := gps.getMarketOdds(ctx, tournament.ID)
+	if err != nil {
+		return nil, fmt.Errorf("getting market odds: %w", err)
 	}
 
-	// Birdie/eagle points (estimated based on expected score)
-	expectedBirdies := (float64(tournament.CoursePar*4) - projection.ExpectedScore) * 0.8
-	points += expectedBirdies * 3 // 3 pts per birdie
+	projections, _, err := gps.generateProjections(ctx, players, tournament.ID.String(), GolfOddsBlendWeights{})
+	if err != nil {
+		return nil, err
+	}
 
-	// Bogey penalty
-	expectedBogeys := math.Max(0, (projection.ExpectedScore-float64(tournament.CoursePar*4))*0.3)
-	points -= expectedBogeys * 0.5 // -0.5 per bogey
+	return compareWithMarket(odds, projections, players), nil
+}
 
-	// Cut penalty
-	if projection.CutProbability < 0.5 {
-		points *= projection.CutProbability // Heavily penalize likely missed cuts
+// compareWithMarket pairs each stored market price with the projection of the player it belongs to
+func compareWithMarket(
+	odds []models.GolfMarketOdds,
+	projections map[uuid.UUID]*models.GolfProjection,
+	players []types.PlayerInterface,
+) []GolfOddsComparison {
+	byDGID := make(map[int]types.PlayerInterface, len(players))
+	for _, player := range players {
+		if dgID, ok := datagolfID(player.GetExternalID()); ok {
+			byDGID[dgID] = player
+		}
 	}
 
-	return math.Max(0, points)
+	comparisons := make([]GolfOddsComparison, 0, len(odds))
+	for _, o := range odds {
+		comparison := GolfOddsComparison{
+			PlayerName:   o.PlayerName,
+			DGID:         o.DGID,
+			Market:       o.Market,
+			MarketProb:   o.ImpliedProb,
+			Books:        o.Books,
+			DataGolfProb: o.DataGolfProb,
+		}
+		if player, ok := byDGID[o.DGID]; ok {
+			comparison.PlayerID = player.GetID()
+			if model, ok := modelMarketProbability(projections[player.GetID()], o.Market); ok {
+				edge := model - o.ImpliedProb
+				comparison.ModelProb = &model
+				comparison.Edge = &edge
+			}
+		}
+		comparisons = append(comparisons, comparison)
+	}
+	return comparisons
 }
 
-func (gps *GolfProjectionService) calculateFDPoints(projection *models.GolfProjection, tournament *models.GolfTournament) float64 {
-	points := 0.0
+// WaveReport analyzes a tournament's tee-time waves against the course forecast
+func (gps *GolfProjectionService) WaveReport(ctx context.Context, tournamentID string) (*weather.WaveReport, error) {
+	if gps.weatherService == nil {
+		return nil, fmt.Errorf("weather service is not configured")
+	}
 
-	// FanDuel scoring (simplified, similar but different values)
-	if projection.WinProbability > 0.01 {
-		points += 35 * projection.WinProbability
+	tournament, err := gps.getTournament(ctx, tournamentID)
+	if err != nil {
+		return nil, fmt.Errorf("getting tournament: %w", err)
 	}
-	if projection.Top10Probability > 0.1 {
-		points += 12 * projection.Top10Probability
+
+	entries, err := gps.getPlayerEntries(ctx, tournament.ID)
+	if err != nil {
+		return nil, fmt.Errorf("getting player entries: %w", err)
 	}
-	if projection.Top25Probability > 0.2 {
-		points += 8 * projection.Top25Probability
+
+	return gps.waveReport(ctx, tournament, entries)
+}
+
+// waveReport reads the round 1 and 2 tee times from the entries, keyed by player ID. Entries
+// hold the tee sheet's local times, which are placed in the course's zone before they are matched
+// against the forecast.
+func (gps *GolfProjectionService) waveReport(
+	ctx context.Context,
+	tournament *models.GolfTournament,
+	entries map[uuid.UUID]*models.GolfPlayerEntry,
+) (*weather.WaveReport, error) {
+	loc, err := gps.weatherService.CourseTimeZone(tournament.CourseID)
+	if err != nil {
+		return nil, err
+	}
+
+	teeTimes := make([]weather.PlayerTeeTimes, 0, len(entries))
+	for playerID, entry := range entries {
+		if len(entry.TeeTimes) == 0 {
+			continue
+		}
+		round1, ok := weather.ParseTeeTimeIn(entry.TeeTimes[0], loc)
+		if !ok {
+			continue
+		}
+		times := weather.PlayerTeeTimes{PlayerID: playerID.String(), Round1: round1}
+		if len(entry.TeeTimes) > 1 {
+			times.Round2, _ = weather.ParseTeeTimeIn(entry.TeeTimes[1], loc)
+		}
+		teeTimes = append(teeTimes, times)
+	}
+	if len(teeTimes) == 0 {
+		return nil, fmt.Errorf("no tee times for tournament %s", tournament.ID)
 	}
 
-	// Score-based points
-	expectedBirdies := (float64(tournament.CoursePar*4) - projection.ExpectedScore) * 0.8
-	points += expectedBirdies * 2.5
+	return gps.weatherService.GetWaveAdvantage(ctx, tournament.CourseID, teeTimes)
+}
 
-	expectedBogeys := math.Max(0, (projection.ExpectedScore-float64(tournament.CoursePar*4))*0.3)
-	points -= expectedBogeys * 0.5
+// modelMarketProbability is the projection's probability for an outright market
+func modelMarketProbability(projection *models.GolfProjection, market string) (float64, bool) {
+	if projection == nil {
+		return 0, false
+	}
+	switch market {
+	case models.GolfMarketWin:
+		return projection.WinProbability, true
+	case models.GolfMarketTop5:
+		return projection.Top5Probability, true
+	case models.GolfMarketTop10:
+		return projection.Top10Probability, true
+	case models.GolfMarketMakeCut:
+		return projection.FinalCutProbability, true
+	}
+	return 0, false
+}
 
-	if projection.CutProbability < 0.5 {
-		points *= projection.CutProbability
+// blendProbability mixes the market's probability into the model's; markets that weren't
+// priced leave the model alone
+func blendProbability(model, market, weight float64) float64 {
+	if market <= 0 || weight <= 0 {
+		return model
 	}
+	weight = math.Min(weight, 1)
+	return weight*market + (1-weight)*model
+}
 
-	return math.Max(0, points)
+// datagolfID reads the DataGolf ID from a player's external ID
+func datagolfID(externalID string) (int, bool) {
+	var dgID int
+	if _, err := fmt.Sscanf(externalID, "datagolf_%d", &dgID); err != nil {
+		return 0, false
+	}
+	return dgID, true
 }
 
 // Helper methods
 
+func (gps *GolfProjectionService) getMarketOdds(ctx context.Context, tournamentID uuid.UUID) ([]models.GolfMarketOdds, error) {
+	var odds []models.GolfMarketOdds
+	err := gps.db.WithContext(ctx).
+		Where("tournament_id = ?", tournamentID).
+		Order("market, implied_prob DESC").
+		Find(&odds).Error
+	return odds, err
+}
+
 func (gps *GolfProjectionService) getTournament(ctx context.Context, tournamentID string) (*models.GolfTournament, error) {
 	var tournament models.GolfTournament
 
@@ -0,0 +1,124 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/sirupsen/logrus"
+	"github.com/sony/gobreaker"
+
+	"github.com/stitts-dev/dfs-sim/services/sports-data-service/internal/models"
+	"github.com/stitts-dev/dfs-sim/services/sports-data-service/internal/providers"
+	"github.com/stitts-dev/dfs-sim/shared/pkg/weather"
+)
+
+// golfTeeTimeLayout is how tee times are stored on player entries: the tee sheet's local clock
+// time, since the column has no zone
+const golfTeeTimeLayout = "2006-01-02 15:04:05"
+
+// syncGolfTeeTimes is the scheduled wrapper around SyncGolfTeeTimes
+func (dfs *DataFetcherService) syncGolfTeeTimes() {
+	logger := dfs.logger.WithField("component", "data_fetcher").WithField("job", "golf_tee_times_sync")
+
+	if dfs.dataGolfProvider == nil {
+		logger.Debug("DataGolf provider not available, skipping tee time sync")
+		return
+	}
+	if dfs.circuitBreaker.GetState("datagolf") == gobreaker.StateOpen {
+		logger.Warn("DataGolf circuit breaker is open, skipping tee time sync")
+		return
+	}
+
+	updated, err := dfs.SyncGolfTeeTimes(dfs.ctx)
+	if err != nil {
+		logger.WithError(err).Error("Golf tee time sync failed")
+		return
+	}
+
+	logger.WithField("entries", updated).Info("Golf tee time sync completed")
+}
+
+// SyncGolfTeeTimes copies the round 1 and 2 tee times from DataGolf's field updates onto the
+// current event's player entries, where the wave report reads them. It returns the number of
+// entries updated.
+func (dfs *DataFetcherService) SyncGolfTeeTimes(ctx context.Context) (int, error) {
+	response, err := dfs.dataGolfProvider.GetFieldUpdates()
+	if err != nil {
+		return 0, err
+	}
+
+	tournament, err := dfs.oddsTournament(ctx, providers.DataGolfEventInfo{EventID: response.EventID, EventName: response.EventName})
+	if err != nil {
+		return 0, err
+	}
+
+	teeTimes := make(map[string]pq.StringArray, len(response.Field))
+	externalIDs := make([]string, 0, len(response.Field))
+	for _, item := range response.Field {
+		times := fieldTeeTimes(item, response.CurrentRound, tournament.StartDate)
+		if len(times) == 0 {
+			continue
+		}
+		externalID := fmt.Sprintf("datagolf_%d", item.DGID)
+		teeTimes[externalID] = times
+		externalIDs = append(externalIDs, externalID)
+	}
+	if len(externalIDs) == 0 {
+		return 0, nil
+	}
+
+	var players []models.Player
+	if err := dfs.db.WithContext(ctx).
+		Select("id", "external_id").
+		Where("external_id IN ?", externalIDs).
+		Find(&players).Error; err != nil {
+		return 0, fmt.Errorf("failed to load players for tee times: %w", err)
+	}
+
+	updated := 0
+	for _, player := range players {
+		result := dfs.db.WithContext(ctx).
+			Model(&models.GolfPlayerEntry{}).
+			Where("player_id = ? AND tournament_id = ?", player.ID, tournament.ID).
+			Update("tee_times", teeTimes[player.ExternalID])
+		if result.Error != nil {
+			return updated, fmt.Errorf("failed to store tee times for player %s: %w", player.ID, result.Error)
+		}
+		updated += int(result.RowsAffected)
+	}
+
+	if skipped := len(externalIDs) - len(players); skipped > 0 {
+		dfs.logger.WithFields(logrus.Fields{
+			"tournament_id": tournament.ID,
+			"skipped":       skipped,
+		}).Debug("Tee times for players not yet in the database were skipped")
+	}
+	return updated, nil
+}
+
+// fieldTeeTimes is a player's round 1 and 2 tee times in the stored layout. The current round's
+// tee time fills in for its round-specific field, and times without a date are placed on the
+// round's day of the tournament. Nothing is returned without a round 1 tee time.
+func fieldTeeTimes(item providers.DataGolfFieldUpdateItem, currentRound int, startDate time.Time) pq.StringArray {
+	rounds := []string{item.R1TeeTime, item.R2TeeTime}
+	if currentRound >= 1 && currentRound <= len(rounds) && rounds[currentRound-1] == "" {
+		rounds[currentRound-1] = item.TeeTime
+	}
+
+	var times pq.StringArray
+	for i, value := range rounds {
+		// Read without the course's zone, so the clock time is kept exactly as published
+		t, ok := weather.ParseTeeTime(value)
+		if !ok {
+			break
+		}
+		if t.Year() == 0 {
+			day := startDate.AddDate(0, 0, i)
+			t = time.Date(day.Year(), day.Month(), day.Day(), t.Hour(), t.Minute(), 0, 0, time.UTC)
+		}
+		times = append(times, t.Format(golfTeeTimeLayout))
+	}
+	return times
+}
@@ -0,0 +1,59 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stitts-dev/dfs-sim/services/sports-data-service/internal/providers"
+)
+
+func TestFieldTeeTimes(t *testing.T) {
+	start := time.Date(2025, 4, 10, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		item         providers.DataGolfFieldUpdateItem
+		currentRound int
+		expected     []string
+	}{
+		{
+			name:         "both rounds published",
+			item:         providers.DataGolfFieldUpdateItem{R1TeeTime: "2025-04-10 07:40", R2TeeTime: "2025-04-11 12:52"},
+			currentRound: 1,
+			expected:     []string{"2025-04-10 07:40:00", "2025-04-11 12:52:00"},
+		},
+		{
+			name:         "clock times placed on each round's day",
+			item:         providers.DataGolfFieldUpdateItem{R1TeeTime: "7:40am", R2TeeTime: "12:52"},
+			currentRound: 1,
+			expected:     []string{"2025-04-10 07:40:00", "2025-04-11 12:52:00"},
+		},
+		{
+			name:         "current round tee time fills round 1",
+			item:         providers.DataGolfFieldUpdateItem{TeeTime: "13:15"},
+			currentRound: 1,
+			expected:     []string{"2025-04-10 13:15:00"},
+		},
+		{
+			name:         "published offset kept as local time",
+			item:         providers.DataGolfFieldUpdateItem{R1TeeTime: "2025-04-10T07:40:00-04:00"},
+			currentRound: 2,
+			expected:     []string{"2025-04-10 07:40:00"},
+		},
+		{
+			name:         "no draw yet",
+			item:         providers.DataGolfFieldUpdateItem{TeeTime: "13:15"},
+			currentRound: 3,
+			expected:     nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			times := fieldTeeTimes(tt.item, tt.currentRound, start)
+			assert.Equal(t, tt.expected, []string(times))
+		})
+	}
+}
@@ -5,10 +5,12 @@ import (
 	"fmt"
 	"math"
 	"os"
+	"time"
 
 	"github.com/redis/go-redis/v9"
 
 	"github.com/stitts-dev/dfs-sim/services/sports-data-service/internal/providers"
+	"github.com/stitts-dev/dfs-sim/shared/pkg/weather"
 	"github.com/stitts-dev/dfs-sim/shared/types"
 )
 
@@ -17,6 +19,7 @@ type WeatherService struct {
 	openWeatherProvider *providers.OpenWeatherProvider
 	redisClient        *redis.Client
 	courseLocations    map[string]*providers.WeatherLocation
+	waveModel          weather.WaveModel
 }
 
 // WeatherImpactConfig represents configuration for weather impact calculations
@@ -43,6 +46,7 @@ func NewWeatherService(redisClient *redis.Client) (*WeatherService, error) {
 		openWeatherProvider: openWeatherProvider,
 		redisClient:        redisClient,
 		courseLocations:    courseLocations,
+		waveModel:          weather.DefaultWaveModel(),
 	}, nil
 }
 
@@ -56,6 +60,37 @@ func (w *WeatherService) GetWeatherConditions(ctx context.Context, courseID stri
 	return w.openWeatherProvider.GetWeatherConditions(ctx, location)
 }
 
+// GetWaveAdvantage maps each player's round 1 and 2 tee times onto the course's forecast and
+// works out the strokes advantage of their draw over the field's
+func (w *WeatherService) GetWaveAdvantage(ctx context.Context, courseID string, teeTimes []weather.PlayerTeeTimes) (*weather.WaveReport, error) {
+	location, exists := w.courseLocations[courseID]
+	if !exists {
+		return nil, fmt.Errorf("course location not found for course ID: %s", courseID)
+	}
+
+	forecast, err := w.openWeatherProvider.GetHourlyForecast(ctx, location)
+	if err != nil {
+		return nil, err
+	}
+	if len(forecast) == 0 {
+		return nil, fmt.Errorf("no forecast available for course ID: %s", courseID)
+	}
+
+	return w.waveModel.Analyze(forecast, teeTimes), nil
+}
+
+// CourseTimeZone is the time zone a course's tee times are published in
+func (w *WeatherService) CourseTimeZone(courseID string) (*time.Location, error) {
+	location, exists := w.courseLocations[courseID]
+	if !exists {
+		return nil, fmt.Errorf("course location not found for course ID: %s", courseID)
+	}
+	if location.TimeZone == "" {
+		return nil, fmt.Errorf("no time zone for course ID: %s", courseID)
+	}
+	return time.LoadLocation(location.TimeZone)
+}
+
 // CalculateGolfImpact calculates weather impact on golf performance based on research
 func (w *WeatherService) CalculateGolfImpact(conditions *types.WeatherConditions) *types.WeatherImpact {
 	if conditions == nil {
@@ -232,6 +267,7 @@ func initializeCourseLocations() map[string]*providers.WeatherLocation {
 			City:      "Augusta",
 			State:     "GA",
 			Country:   "US",
+			TimeZone:  "America/New_York",
 		},
 		"pebble_beach": {
 			Latitude:  36.5684,
@@ -239,6 +275,7 @@ func initializeCourseLocations() map[string]*providers.WeatherLocation {
 			City:      "Pebble Beach",
 			State:     "CA",
 			Country:   "US",
+			TimeZone:  "America/Los_Angeles",
 		},
 		"pinehurst_no2": {
 			Latitude:  35.1827,
@@ -246,6 +283,7 @@ func initializeCourseLocations() map[string]*providers.WeatherLocation {
 			City:      "Pinehurst",
 			State:     "NC",
 			Country:   "US",
+			TimeZone:  "America/New_York",
 		},
 		"st_andrews": {
 			Latitude:  56.3398,
@@ -253,6 +291,7 @@ func initializeCourseLocations() map[string]*providers.WeatherLocation {
 			City:      "St Andrews",
 			State:     "Scotland",
 			Country:   "UK",
+			TimeZone:  "Europe/London",
 		},
 	}
 }
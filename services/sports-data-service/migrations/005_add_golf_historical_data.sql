@@ -0,0 +1,86 @@
+-- 005_add_golf_historical_data.sql
+-- Warehouse for the DataGolf historical round and DFS archives, plus backfill progress
+
+CREATE TABLE IF NOT EXISTS golf_historical_events (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tour VARCHAR(20) NOT NULL,
+    event_id VARCHAR(50) NOT NULL,
+    year INTEGER NOT NULL,
+    event_name VARCHAR(255),
+    course VARCHAR(255),
+    course_key VARCHAR(255),
+    start_date TIMESTAMP WITH TIME ZONE,
+    end_date TIMESTAMP WITH TIME ZONE,
+    course_par INTEGER DEFAULT 0,
+    cut_line INTEGER,
+    field_size INTEGER DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT idx_golf_hist_event UNIQUE(tour, event_id, year)
+);
+
+CREATE TABLE IF NOT EXISTS golf_historical_rounds (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tour VARCHAR(20) NOT NULL,
+    event_id VARCHAR(50) NOT NULL,
+    year INTEGER NOT NULL,
+    round INTEGER NOT NULL,
+    dg_id INTEGER NOT NULL,
+    player_name VARCHAR(255),
+    score INTEGER NOT NULL,
+    course_par INTEGER DEFAULT 0,
+    sg_ott DECIMAL(6,3),
+    sg_app DECIMAL(6,3),
+    sg_arg DECIMAL(6,3),
+    sg_putt DECIMAL(6,3),
+    sg_total DECIMAL(6,3),
+    tee_time VARCHAR(50),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT idx_golf_hist_round UNIQUE(tour, event_id, year, round, dg_id)
+);
+
+CREATE TABLE IF NOT EXISTS golf_historical_dfs_results (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tour VARCHAR(20) NOT NULL,
+    site VARCHAR(20) NOT NULL,
+    event_id VARCHAR(50) NOT NULL,
+    year INTEGER NOT NULL,
+    dg_id INTEGER NOT NULL,
+    player_name VARCHAR(255),
+    salary INTEGER,
+    ownership DECIMAL(6,3),
+    points DECIMAL(7,2),
+    finish INTEGER,
+    made_cut BOOLEAN DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT idx_golf_hist_dfs UNIQUE(tour, site, event_id, year, dg_id)
+);
+
+CREATE TABLE IF NOT EXISTS golf_backfill_progress (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    dataset VARCHAR(50) NOT NULL,
+    tour VARCHAR(20) NOT NULL,
+    event_id VARCHAR(50) NOT NULL,
+    year INTEGER NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    rows INTEGER DEFAULT 0,
+    attempts INTEGER DEFAULT 0,
+    last_error TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT idx_golf_backfill UNIQUE(dataset, tour, event_id, year)
+);
+
+CREATE INDEX IF NOT EXISTS idx_golf_historical_events_course ON golf_historical_events(course_key);
+CREATE INDEX IF NOT EXISTS idx_golf_historical_rounds_player ON golf_historical_rounds(dg_id);
+CREATE INDEX IF NOT EXISTS idx_golf_historical_dfs_player ON golf_historical_dfs_results(dg_id);
+
+COMMENT ON TABLE golf_historical_events IS 'Historical tournament editions from DataGolf with derived par and cut line';
+COMMENT ON TABLE golf_historical_rounds IS 'Round-level scores and strokes gained from the DataGolf historical archive';
+COMMENT ON TABLE golf_historical_dfs_results IS 'Historical DFS salaries, ownership and fantasy points per event and site';
+COMMENT ON TABLE golf_backfill_progress IS 'Completed and failed event downloads so the historical backfill can resume';
+
+CREATE TRIGGER update_golf_historical_events_updated_at BEFORE UPDATE ON golf_historical_events
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+CREATE TRIGGER update_golf_backfill_progress_updated_at BEFORE UPDATE ON golf_backfill_progress
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
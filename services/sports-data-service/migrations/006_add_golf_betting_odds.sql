@@ -0,0 +1,40 @@
+-- 006_add_golf_betting_odds.sql
+-- No-vig sportsbook consensus for golf outright and matchup markets, per tournament
+
+CREATE TABLE IF NOT EXISTS golf_market_odds (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tournament_id UUID NOT NULL REFERENCES golf_tournaments(id) ON DELETE CASCADE,
+    market VARCHAR(20) NOT NULL,
+    dg_id INTEGER NOT NULL,
+    player_name VARCHAR(255),
+    implied_prob DECIMAL(7,6) NOT NULL,
+    datagolf_prob DECIMAL(7,6),
+    books INTEGER DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT idx_golf_market_odds UNIQUE(tournament_id, market, dg_id)
+);
+
+CREATE TABLE IF NOT EXISTS golf_matchup_odds (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tournament_id UUID NOT NULL REFERENCES golf_tournaments(id) ON DELETE CASCADE,
+    market VARCHAR(50) NOT NULL,
+    matchup_id VARCHAR(100) NOT NULL,
+    player VARCHAR(255) NOT NULL,
+    implied_prob DECIMAL(7,6) NOT NULL,
+    datagolf_prob DECIMAL(7,6),
+    books INTEGER DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT idx_golf_matchup_odds UNIQUE(tournament_id, market, matchup_id, player)
+);
+
+CREATE INDEX IF NOT EXISTS idx_golf_market_odds_player ON golf_market_odds(tournament_id, dg_id);
+
+COMMENT ON TABLE golf_market_odds IS 'Sportsbook consensus probabilities for win, top-N and make-cut markets with the vig removed';
+COMMENT ON TABLE golf_matchup_odds IS 'Sportsbook consensus probabilities for head-to-head and three-ball matchups with the vig removed';
+
+CREATE TRIGGER update_golf_market_odds_updated_at BEFORE UPDATE ON golf_market_odds
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+CREATE TRIGGER update_golf_matchup_odds_updated_at BEFORE UPDATE ON golf_matchup_odds
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,41 @@
+-- 007_add_golf_course_fits.sql
+-- Driving stats on historical rounds and per-course skill premiums fitted from them
+
+ALTER TABLE golf_historical_rounds ADD COLUMN IF NOT EXISTS driving_dist DECIMAL(6,1);
+ALTER TABLE golf_historical_rounds ADD COLUMN IF NOT EXISTS driving_acc DECIMAL(5,4);
+
+CREATE TABLE IF NOT EXISTS golf_course_fits (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    course_key VARCHAR(255) NOT NULL UNIQUE,
+    course VARCHAR(255),
+    events INTEGER DEFAULT 0,
+    observations INTEGER DEFAULT 0,
+    intercept DECIMAL(8,4) DEFAULT 0,
+    r_squared DECIMAL(6,4) DEFAULT 0,
+    rmse DECIMAL(8,4) DEFAULT 0,
+    tour_r_squared DECIMAL(6,4) DEFAULT 0,
+    shrinkage DECIMAL(10,2) DEFAULT 0,
+    fitted_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS golf_course_skill_premiums (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    course_key VARCHAR(255) NOT NULL REFERENCES golf_course_fits(course_key) ON DELETE CASCADE,
+    skill VARCHAR(30) NOT NULL,
+    coefficient DECIMAL(8,4) NOT NULL,
+    std_error DECIMAL(8,4) DEFAULT 0,
+    premium DECIMAL(8,4) DEFAULT 0,
+    importance DECIMAL(6,4) DEFAULT 0,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT idx_golf_course_skill UNIQUE(course_key, skill)
+);
+
+COMMENT ON TABLE golf_course_fits IS 'Diagnostics of the per-course regression of strokes gained on pre-event player skills';
+COMMENT ON TABLE golf_course_skill_premiums IS 'Fitted per-course skill coefficients and their premium over the tour-wide fit';
+
+CREATE TRIGGER update_golf_course_fits_updated_at BEFORE UPDATE ON golf_course_fits
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+CREATE TRIGGER update_golf_course_skill_premiums_updated_at BEFORE UPDATE ON golf_course_skill_premiums
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
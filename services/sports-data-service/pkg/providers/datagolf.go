@@ -33,6 +33,15 @@ type WeatherImpactAnalysis = providers.WeatherImpactAnalysis
 type EnhancedPlayerPrediction = providers.EnhancedPlayerPrediction
 type CourseModelData = providers.CourseModelData
 type WeatherModelData = providers.WeatherModelData
+type LivePredictionsResponse = providers.LivePredictionsResponse
+type DataGolfLivePrediction = providers.DataGolfLivePrediction
+type LiveHoleStatsResponse = providers.LiveHoleStatsResponse
+type HistoricalEventListResponse = providers.HistoricalEventListResponse
+type HistoricalRoundsResponse = providers.HistoricalRoundsResponse
+type HistoricalDFSEventListResponse = providers.HistoricalDFSEventListResponse
+type HistoricalDFSResponse = providers.HistoricalDFSResponse
+type OutrightsResponse = providers.OutrightsResponse
+type MatchupsResponse = providers.MatchupsResponse
 
 // LiveLeaderboardEntry wrapper for public access
 type LiveLeaderboardEntry struct {
@@ -14,7 +14,6 @@ import (
 	"github.com/sirupsen/logrus"
 
 	"github.com/stitts-dev/dfs-sim/services/user-service/internal/api/handlers"
-	"github.com/stitts-dev/dfs-sim/services/user-service/internal/models"
 	"github.com/stitts-dev/dfs-sim/services/user-service/internal/services"
 	"github.com/stitts-dev/dfs-sim/shared/pkg/config"
 	"github.com/stitts-dev/dfs-sim/shared/pkg/database"
@@ -56,12 +55,12 @@ func main() {
 	}
 	defer db.Close()
 
-	// Auto-migrate database schema to match Go models
-	// This ensures Supabase tables are compatible with our Go models
-	// Temporarily disabled to test API functionality
-	// if err := autoMigrateUserTables(db); err != nil {
-	//	logger.WithService("user-service").Fatalf("Failed to migrate database schema: %v", err)
-	// }
+	// Apply pending schema migrations when enabled
+	if cfg.RunMigrations {
+		if err := db.Migrate(context.Background(), "user-service", cfg.MigrationsDir, logger.WithService("user-service")); err != nil {
+			logger.WithService("user-service").Fatalf("Failed to migrate database: %v", err)
+		}
+	}
 
 	// Connect to Redis (use DB 3 for user service)
 	opt, err := redis.ParseURL(cfg.RedisURL)
@@ -92,6 +91,8 @@ func main() {
 	userHandler := handlers.NewSimpleUserHandler(userService, structuredLogger, db)
 	fmt.Printf("🔑 Creating auth handler...\n")
 	authHandler := handlers.NewAuthHandler(db, cfg, structuredLogger)
+	presetHandler := handlers.NewPresetHandler(structuredLogger, db)
+	workspaceHandler := handlers.NewWorkspaceHandler(structuredLogger, db)
 	// fmt.Printf("💳 Creating Stripe handler...\n")
 	// stripeHandler := handlers.NewStripeHandler(stripeService, userService)
 	fmt.Printf("✅ Handlers created successfully\n")
@@ -122,6 +123,26 @@ func main() {
 			users.PUT("/preferences", userHandler.UpdatePreferences)
 			users.GET("/subscription", userHandler.GetSubscription)
 			users.PUT("/subscription", userHandler.UpdateSubscription)
+
+			// Saved optimization presets
+			users.GET("/presets", presetHandler.ListPresets)
+			users.POST("/presets", presetHandler.CreatePreset)
+			users.GET("/presets/:id", presetHandler.GetPreset)
+			users.PUT("/presets/:id", presetHandler.UpdatePreset)
+			users.DELETE("/presets/:id", presetHandler.DeletePreset)
+			users.GET("/presets/:id/versions", presetHandler.ListPresetVersions)
+			users.GET("/presets/:id/shares", presetHandler.ListPresetShares)
+			users.POST("/presets/:id/shares", presetHandler.SharePreset)
+			users.DELETE("/presets/:id/shares/:user_id", presetHandler.UnsharePreset)
+
+			// Team workspaces
+			users.GET("/workspaces", workspaceHandler.ListWorkspaces)
+			users.POST("/workspaces", workspaceHandler.CreateWorkspace)
+			users.GET("/workspaces/:id", workspaceHandler.GetWorkspace)
+			users.POST("/workspaces/:id/members", workspaceHandler.AddMember)
+			users.PUT("/workspaces/:id/members/:user_id", workspaceHandler.UpdateMember)
+			users.DELETE("/workspaces/:id/members/:user_id", workspaceHandler.RemoveMember)
+			users.GET("/workspaces/:id/audit", workspaceHandler.GetAuditLog)
 		}
 
 		// User management endpoints (admin)
@@ -224,79 +245,3 @@ func main() {
 
 	logger.WithService("user-service").Info("User service exited")
 }
-
-// autoMigrateUserTables ensures Supabase database schema matches Go models
-func autoMigrateUserTables(db *database.DB) error {
-	logger.WithService("user-service").Info("Auto-migrating user database schema to match Go models")
-	
-	// AutoMigrate will create tables if they don't exist and add missing columns
-	// It won't delete existing columns or change column types
-	err := db.AutoMigrate(
-		&models.User{},
-		&models.UserPreferences{},
-		&models.SubscriptionTier{},
-	)
-	
-	if err != nil {
-		return fmt.Errorf("failed to auto-migrate user tables: %w", err)
-	}
-	
-	// Create default subscription tiers if they don't exist
-	if err := createDefaultSubscriptionTiers(db); err != nil {
-		return fmt.Errorf("failed to create default subscription tiers: %w", err)
-	}
-	
-	logger.WithService("user-service").Info("Database schema migration completed successfully")
-	return nil
-}
-
-// createDefaultSubscriptionTiers ensures default subscription tiers exist
-func createDefaultSubscriptionTiers(db *database.DB) error {
-	defaultTiers := []models.SubscriptionTier{
-		{
-			Name:                 "free",
-			PriceCents:           0,
-			Currency:             "USD",
-			MonthlyOptimizations: 10,
-			MonthlySimulations:   5,
-			AIRecommendations:    false,
-			BankVerification:     false,
-			PrioritySupport:      false,
-		},
-		{
-			Name:                 "basic",
-			PriceCents:           999,
-			Currency:             "USD",
-			MonthlyOptimizations: 50,
-			MonthlySimulations:   25,
-			AIRecommendations:    true,
-			BankVerification:     false,
-			PrioritySupport:      false,
-		},
-		{
-			Name:                 "premium",
-			PriceCents:           2999,
-			Currency:             "USD",
-			MonthlyOptimizations: -1, // unlimited
-			MonthlySimulations:   -1, // unlimited
-			AIRecommendations:    true,
-			BankVerification:     true,
-			PrioritySupport:      true,
-		},
-	}
-	
-	for _, tier := range defaultTiers {
-		// Check if tier already exists
-		var existingTier models.SubscriptionTier
-		err := db.Where("name = ?", tier.Name).First(&existingTier).Error
-		if err != nil {
-			// Tier doesn't exist, create it
-			if err := db.Create(&tier).Error; err != nil {
-				return fmt.Errorf("failed to create subscription tier %s: %w", tier.Name, err)
-			}
-			logger.WithService("user-service").WithField("tier", tier.Name).Info("Created default subscription tier")
-		}
-	}
-	
-	return nil
-}
\ No newline at end of file
@@ -0,0 +1,375 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/stitts-dev/dfs-sim/services/user-service/internal/models"
+	"github.com/stitts-dev/dfs-sim/shared/pkg/database"
+	"github.com/stitts-dev/dfs-sim/shared/pkg/workspace"
+	"github.com/stitts-dev/dfs-sim/shared/types"
+	"gorm.io/gorm"
+)
+
+// PresetHandler manages saved optimization presets
+type PresetHandler struct {
+	logger *logrus.Logger
+	db     *database.DB
+}
+
+// NewPresetHandler creates a new preset handler
+func NewPresetHandler(logger *logrus.Logger, db *database.DB) *PresetHandler {
+	return &PresetHandler{
+		logger: logger,
+		db:     db,
+	}
+}
+
+type presetRequest struct {
+	Name        string             `json:"name"`
+	Description *string            `json:"description"`
+	Sport       *string            `json:"sport"`
+	Rules       *types.PresetRules `json:"rules"`
+	// WorkspaceID creates the preset in a team workspace; it can't be changed afterwards
+	WorkspaceID *uuid.UUID `json:"workspace_id"`
+}
+
+// ListPresets lists the presets the current user owns, has been shared or reaches through a
+// workspace
+func (h *PresetHandler) ListPresets(c *gin.Context) {
+	userUUID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	presets, err := models.ListPresetsForUser(h.db, userUUID)
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to list presets")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list presets"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"presets": presets})
+}
+
+// CreatePreset saves a new preset with its rules as version 1
+func (h *PresetHandler) CreatePreset(c *gin.Context) {
+	userUUID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req presetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" || req.Rules == nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Preset name and rules are required"})
+		return
+	}
+	if err := req.Rules.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if req.WorkspaceID != nil {
+		if _, err := workspace.Authorize(h.db.DB, *req.WorkspaceID, userUUID, types.WorkspaceRoleEditor); err != nil {
+			respondWorkspaceError(c, h.logger, err)
+			return
+		}
+	}
+
+	preset := &types.OptimizationPreset{OwnerID: userUUID, WorkspaceID: req.WorkspaceID, Name: req.Name}
+	if req.Description != nil {
+		preset.Description = *req.Description
+	}
+	if req.Sport != nil {
+		preset.Sport = *req.Sport
+	}
+
+	if err := models.CreatePreset(h.db, preset, *req.Rules); err != nil {
+		h.logger.WithError(err).Error("Failed to create preset")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create preset"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"preset": preset, "rules": req.Rules})
+}
+
+// GetPreset returns a preset with its current rules, or the version in ?version=
+func (h *PresetHandler) GetPreset(c *gin.Context) {
+	userUUID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	preset, ok := h.readablePreset(c, userUUID)
+	if !ok {
+		return
+	}
+
+	version, err := strconv.Atoi(c.DefaultQuery("version", "0"))
+	if err != nil || version < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid version parameter"})
+		return
+	}
+
+	presetVersion, err := models.GetPresetVersion(h.db, preset, version)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Preset version not found"})
+			return
+		}
+		h.logger.WithError(err).Error("Failed to get preset version")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get preset"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"preset": preset, "version": presetVersion.Version, "rules": presetVersion.Rules})
+}
+
+// ListPresetVersions returns every revision of a preset's rules, newest first
+func (h *PresetHandler) ListPresetVersions(c *gin.Context) {
+	userUUID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	preset, ok := h.readablePreset(c, userUUID)
+	if !ok {
+		return
+	}
+
+	versions, err := models.ListPresetVersions(h.db, preset.ID)
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to list preset versions")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list preset versions"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"versions": versions})
+}
+
+// UpdatePreset renames or describes a preset; sending rules saves them as a new version
+func (h *PresetHandler) UpdatePreset(c *gin.Context) {
+	userUUID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	preset, ok := h.editablePreset(c, userUUID)
+	if !ok {
+		return
+	}
+
+	var req presetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.Rules != nil {
+		if err := req.Rules.Validate(); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	updates := make(map[string]interface{})
+	if name := strings.TrimSpace(req.Name); name != "" {
+		updates["name"] = name
+	}
+	if req.Description != nil {
+		updates["description"] = *req.Description
+	}
+	if req.Sport != nil {
+		updates["sport"] = *req.Sport
+	}
+
+	if err := models.UpdatePreset(h.db, preset, updates, req.Rules, userUUID); err != nil {
+		h.logger.WithError(err).Error("Failed to update preset")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update preset"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"preset": preset})
+}
+
+// DeletePreset removes a preset and every version of it
+func (h *PresetHandler) DeletePreset(c *gin.Context) {
+	userUUID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	preset, ok := h.editablePreset(c, userUUID)
+	if !ok {
+		return
+	}
+
+	if err := models.DeletePreset(h.db, preset, userUUID); err != nil {
+		h.logger.WithError(err).Error("Failed to delete preset")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete preset"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Preset deleted"})
+}
+
+// ListPresetShares returns who a preset is shared with
+func (h *PresetHandler) ListPresetShares(c *gin.Context) {
+	userUUID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	preset, ok := h.ownedPreset(c, userUUID)
+	if !ok {
+		return
+	}
+
+	shares, err := models.ListPresetShares(h.db, preset.ID)
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to list preset shares")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list preset shares"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"shares": shares})
+}
+
+// SharePreset lets a teammate read and optimize with a preset
+func (h *PresetHandler) SharePreset(c *gin.Context) {
+	userUUID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	preset, ok := h.ownedPreset(c, userUUID)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		UserID uuid.UUID `json:"user_id"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil || req.UserID == uuid.Nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "A user_id to share with is required"})
+		return
+	}
+	if req.UserID == userUUID {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Cannot share a preset with its owner"})
+		return
+	}
+	if _, err := models.GetUserByID(h.db, req.UserID); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		return
+	}
+
+	if err := models.SharePreset(h.db, preset.ID, req.UserID); err != nil {
+		h.logger.WithError(err).Error("Failed to share preset")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to share preset"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Preset shared"})
+}
+
+// UnsharePreset revokes a teammate's access to a preset
+func (h *PresetHandler) UnsharePreset(c *gin.Context) {
+	userUUID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	preset, ok := h.ownedPreset(c, userUUID)
+	if !ok {
+		return
+	}
+
+	sharedWith, err := uuid.Parse(c.Param("user_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	if err := models.UnsharePreset(h.db, preset.ID, sharedWith); err != nil {
+		h.logger.WithError(err).Error("Failed to unshare preset")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to unshare preset"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Preset unshared"})
+}
+
+// readablePreset loads the :id preset if the user owns it, it was shared with them or they are a
+// member of its workspace
+func (h *PresetHandler) readablePreset(c *gin.Context, userUUID uuid.UUID) (*types.OptimizationPreset, bool) {
+	presetID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid preset ID"})
+		return nil, false
+	}
+
+	preset, err := models.GetPresetForUser(h.db, presetID, userUUID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Preset not found"})
+			return nil, false
+		}
+		h.logger.WithError(err).Error("Failed to get preset")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get preset"})
+		return nil, false
+	}
+	return preset, true
+}
+
+// editablePreset loads the :id preset for changes, which its owner or an editor of its workspace
+// may make
+func (h *PresetHandler) editablePreset(c *gin.Context, userUUID uuid.UUID) (*types.OptimizationPreset, bool) {
+	preset, ok := h.readablePreset(c, userUUID)
+	if !ok {
+		return nil, false
+	}
+	if preset.OwnerID == userUUID {
+		return preset, true
+	}
+	if preset.WorkspaceID == nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Only the preset owner can change it"})
+		return nil, false
+	}
+	if _, err := workspace.Authorize(h.db.DB, *preset.WorkspaceID, userUUID, types.WorkspaceRoleEditor); err != nil {
+		respondWorkspaceError(c, h.logger, err)
+		return nil, false
+	}
+	return preset, true
+}
+
+// ownedPreset loads the :id preset for managing its shares, which only its owner may do
+func (h *PresetHandler) ownedPreset(c *gin.Context, userUUID uuid.UUID) (*types.OptimizationPreset, bool) {
+	preset, ok := h.readablePreset(c, userUUID)
+	if !ok {
+		return nil, false
+	}
+	if preset.OwnerID != userUUID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Only the preset owner can change it"})
+		return nil, false
+	}
+	return preset, true
+}
+
+// currentUserID reads the authenticated user, responding with an error when it is missing
+func currentUserID(c *gin.Context) (uuid.UUID, bool) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User ID not found"})
+		return uuid.Nil, false
+	}
+
+	userIDStr, _ := userID.(string)
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID format"})
+		return uuid.Nil, false
+	}
+	return userUUID, true
+}
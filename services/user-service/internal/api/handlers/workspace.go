@@ -0,0 +1,295 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/stitts-dev/dfs-sim/services/user-service/internal/models"
+	"github.com/stitts-dev/dfs-sim/shared/pkg/database"
+	"github.com/stitts-dev/dfs-sim/shared/pkg/workspace"
+	"github.com/stitts-dev/dfs-sim/shared/types"
+	"gorm.io/gorm"
+)
+
+// WorkspaceHandler manages team workspaces and their members
+type WorkspaceHandler struct {
+	logger *logrus.Logger
+	db     *database.DB
+}
+
+// NewWorkspaceHandler creates a new workspace handler
+func NewWorkspaceHandler(logger *logrus.Logger, db *database.DB) *WorkspaceHandler {
+	return &WorkspaceHandler{
+		logger: logger,
+		db:     db,
+	}
+}
+
+// ListWorkspaces lists the workspaces the current user belongs to with their role
+func (h *WorkspaceHandler) ListWorkspaces(c *gin.Context) {
+	userUUID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	workspaces, err := models.ListWorkspacesForUser(h.db, userUUID)
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to list workspaces")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list workspaces"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"workspaces": workspaces})
+}
+
+// CreateWorkspace creates a workspace owned by the current user
+func (h *WorkspaceHandler) CreateWorkspace(c *gin.Context) {
+	userUUID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		Name string `json:"name"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Workspace name is required"})
+		return
+	}
+
+	ws := &types.Workspace{Name: req.Name, CreatedBy: userUUID}
+	if err := models.CreateWorkspace(h.db, ws); err != nil {
+		h.logger.WithError(err).Error("Failed to create workspace")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create workspace"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"workspace": ws, "role": types.WorkspaceRoleOwner})
+}
+
+// GetWorkspace returns a workspace with its members
+func (h *WorkspaceHandler) GetWorkspace(c *gin.Context) {
+	userUUID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	workspaceID, role, ok := h.authorize(c, userUUID, types.WorkspaceRoleViewer)
+	if !ok {
+		return
+	}
+
+	ws, err := models.GetWorkspace(h.db, workspaceID)
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to get workspace")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get workspace"})
+		return
+	}
+	members, err := models.ListWorkspaceMembers(h.db, workspaceID)
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to list workspace members")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get workspace"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"workspace": ws, "role": role, "members": members})
+}
+
+// AddMember adds a user to the workspace with a role
+func (h *WorkspaceHandler) AddMember(c *gin.Context) {
+	userUUID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	workspaceID, _, ok := h.authorize(c, userUUID, types.WorkspaceRoleOwner)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		UserID uuid.UUID           `json:"user_id"`
+		Role   types.WorkspaceRole `json:"role"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil || req.UserID == uuid.Nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "A user_id to add is required"})
+		return
+	}
+	if req.Role == "" {
+		req.Role = types.WorkspaceRoleViewer
+	}
+	if !workspace.ValidRole(req.Role) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Role must be owner, editor or viewer"})
+		return
+	}
+	if _, err := models.GetUserByID(h.db, req.UserID); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		return
+	}
+	if _, err := workspace.MemberRole(h.db.DB, workspaceID, req.UserID); err == nil {
+		c.JSON(http.StatusConflict, gin.H{"error": "User is already a member"})
+		return
+	}
+
+	member := &types.WorkspaceMember{
+		WorkspaceID: workspaceID,
+		UserID:      req.UserID,
+		Role:        req.Role,
+		AddedBy:     userUUID,
+	}
+	if err := models.AddWorkspaceMember(h.db, member); err != nil {
+		h.logger.WithError(err).Error("Failed to add workspace member")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to add member"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, member)
+}
+
+// UpdateMember changes a member's role
+func (h *WorkspaceHandler) UpdateMember(c *gin.Context) {
+	userUUID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	workspaceID, _, ok := h.authorize(c, userUUID, types.WorkspaceRoleOwner)
+	if !ok {
+		return
+	}
+	memberID, err := uuid.Parse(c.Param("user_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	var req struct {
+		Role types.WorkspaceRole `json:"role"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil || !workspace.ValidRole(req.Role) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Role must be owner, editor or viewer"})
+		return
+	}
+
+	member, err := models.UpdateWorkspaceMemberRole(h.db, workspaceID, memberID, req.Role, userUUID)
+	if err != nil {
+		h.respondMemberError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, member)
+}
+
+// RemoveMember removes a member; owners can remove anyone and members can remove themselves
+func (h *WorkspaceHandler) RemoveMember(c *gin.Context) {
+	userUUID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	memberID, err := uuid.Parse(c.Param("user_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	need := types.WorkspaceRoleOwner
+	if memberID == userUUID {
+		need = types.WorkspaceRoleViewer
+	}
+	workspaceID, _, ok := h.authorize(c, userUUID, need)
+	if !ok {
+		return
+	}
+
+	if err := models.RemoveWorkspaceMember(h.db, workspaceID, memberID, userUUID); err != nil {
+		h.respondMemberError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Member removed"})
+}
+
+// GetAuditLog returns a page of the workspace's audit trail, newest first
+func (h *WorkspaceHandler) GetAuditLog(c *gin.Context) {
+	userUUID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	workspaceID, _, ok := h.authorize(c, userUUID, types.WorkspaceRoleViewer)
+	if !ok {
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if err != nil || limit <= 0 || limit > 500 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit parameter"})
+		return
+	}
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid offset parameter"})
+		return
+	}
+
+	entries, err := models.ListWorkspaceAudit(h.db, workspaceID, limit, offset)
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to list workspace audit log")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get audit log"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"entries": entries,
+		"limit":   limit,
+		"offset":  offset,
+	})
+}
+
+// authorize parses the :id workspace and checks the user's role in it allows need
+func (h *WorkspaceHandler) authorize(c *gin.Context, userUUID uuid.UUID, need types.WorkspaceRole) (uuid.UUID, types.WorkspaceRole, bool) {
+	workspaceID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid workspace ID"})
+		return uuid.Nil, "", false
+	}
+
+	role, err := workspace.Authorize(h.db.DB, workspaceID, userUUID, need)
+	if err != nil {
+		respondWorkspaceError(c, h.logger, err)
+		return uuid.Nil, "", false
+	}
+	return workspaceID, role, true
+}
+
+func (h *WorkspaceHandler) respondMemberError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Member not found"})
+	case errors.Is(err, models.ErrLastOwner):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		h.logger.WithError(err).Error("Failed to change workspace member")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to change member"})
+	}
+}
+
+// respondWorkspaceError reports a failed workspace role check; non-members get a 404 so workspace
+// IDs aren't confirmed to outsiders
+func respondWorkspaceError(c *gin.Context, logger *logrus.Logger, err error) {
+	switch {
+	case errors.Is(err, workspace.ErrNotMember):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Workspace not found"})
+	case errors.Is(err, workspace.ErrForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	default:
+		logger.WithError(err).Error("Failed to check workspace role")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check workspace access"})
+	}
+}
@@ -0,0 +1,226 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/stitts-dev/dfs-sim/shared/pkg/database"
+	"github.com/stitts-dev/dfs-sim/shared/types"
+)
+
+// newTestWorkspaceRouter serves the member routes as userID, backed by sqlmock
+func newTestWorkspaceRouter(t *testing.T, userID uuid.UUID) (*gin.Engine, sqlmock.Sqlmock) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	sqlDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { sqlDB.Close() })
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+
+	log := logrus.New()
+	log.SetLevel(logrus.PanicLevel)
+	handler := NewWorkspaceHandler(log, &database.DB{DB: gormDB})
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("user_id", userID.String())
+	})
+	router.POST("/workspaces/:id/members", handler.AddMember)
+	router.DELETE("/workspaces/:id/members/:user_id", handler.RemoveMember)
+	return router, mock
+}
+
+// expectRole answers a workspace role lookup; an empty role means userID isn't a member. This
+// gorm version binds First's LIMIT 1 as the last argument
+func expectRole(mock sqlmock.Sqlmock, workspaceID, userID uuid.UUID, role types.WorkspaceRole) {
+	rows := sqlmock.NewRows([]string{"workspace_id", "user_id", "role"})
+	if role != "" {
+		rows.AddRow(workspaceID, userID, role)
+	}
+	mock.ExpectQuery(`SELECT \* FROM "workspace_members" WHERE workspace_id = \$1 AND user_id = \$2`).
+		WithArgs(workspaceID.String(), userID.String(), 1).
+		WillReturnRows(rows)
+}
+
+// expectOwners answers the last-owner check, which locks the owner rows before counting them
+func expectOwners(mock sqlmock.Sqlmock, workspaceID uuid.UUID, owners ...uuid.UUID) {
+	rows := sqlmock.NewRows([]string{"user_id"})
+	for _, owner := range owners {
+		rows.AddRow(owner)
+	}
+	mock.ExpectQuery(`SELECT "user_id" FROM "workspace_members" WHERE workspace_id = \$1 AND role = \$2 FOR UPDATE`).
+		WithArgs(workspaceID.String(), types.WorkspaceRoleOwner).
+		WillReturnRows(rows)
+}
+
+// expectUser answers the added user's lookup and its preferences preload
+func expectUser(mock sqlmock.Sqlmock, userID uuid.UUID) {
+	mock.ExpectQuery(`SELECT \* FROM "users" WHERE id = \$1`).
+		WithArgs(userID.String(), 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(userID))
+	mock.ExpectQuery(`SELECT \* FROM "user_preferences" WHERE "user_preferences"."user_id" = \$1`).
+		WithArgs(userID.String()).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id"}))
+}
+
+func addMember(router *gin.Engine, workspaceID, userID uuid.UUID, role types.WorkspaceRole) *httptest.ResponseRecorder {
+	body, _ := json.Marshal(map[string]interface{}{"user_id": userID, "role": role})
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/workspaces/"+workspaceID.String()+"/members", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func removeMember(router *gin.Engine, workspaceID, userID uuid.UUID) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/workspaces/"+workspaceID.String()+"/members/"+userID.String(), nil))
+	return w
+}
+
+func TestAddMember_OwnerAddsWithAudit(t *testing.T) {
+	owner, added, workspaceID := uuid.New(), uuid.New(), uuid.New()
+	router, mock := newTestWorkspaceRouter(t, owner)
+
+	expectRole(mock, workspaceID, owner, types.WorkspaceRoleOwner)
+	expectUser(mock, added)
+	expectRole(mock, workspaceID, added, "")
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO "workspace_members"`).
+		WithArgs(workspaceID.String(), added.String(), types.WorkspaceRoleEditor, owner.String(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(`INSERT INTO "workspace_audit`).
+		WithArgs(workspaceID.String(), owner.String(), "member.added", "member", added.String(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()))
+	mock.ExpectCommit()
+
+	w := addMember(router, workspaceID, added, types.WorkspaceRoleEditor)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAddMember_Rejected(t *testing.T) {
+	workspaceID := uuid.New()
+
+	t.Run("editor cannot add members", func(t *testing.T) {
+		editor := uuid.New()
+		router, mock := newTestWorkspaceRouter(t, editor)
+		expectRole(mock, workspaceID, editor, types.WorkspaceRoleEditor)
+
+		w := addMember(router, workspaceID, uuid.New(), types.WorkspaceRoleViewer)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("non-member sees no workspace", func(t *testing.T) {
+		stranger := uuid.New()
+		router, mock := newTestWorkspaceRouter(t, stranger)
+		expectRole(mock, workspaceID, stranger, "")
+
+		w := addMember(router, workspaceID, uuid.New(), types.WorkspaceRoleViewer)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("invalid role", func(t *testing.T) {
+		owner := uuid.New()
+		router, mock := newTestWorkspaceRouter(t, owner)
+		expectRole(mock, workspaceID, owner, types.WorkspaceRoleOwner)
+
+		w := addMember(router, workspaceID, uuid.New(), "admin")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("already a member", func(t *testing.T) {
+		owner, existing := uuid.New(), uuid.New()
+		router, mock := newTestWorkspaceRouter(t, owner)
+		expectRole(mock, workspaceID, owner, types.WorkspaceRoleOwner)
+		expectUser(mock, existing)
+		expectRole(mock, workspaceID, existing, types.WorkspaceRoleViewer)
+
+		w := addMember(router, workspaceID, existing, types.WorkspaceRoleEditor)
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRemoveMember_MemberLeaves(t *testing.T) {
+	viewer, workspaceID := uuid.New(), uuid.New()
+	router, mock := newTestWorkspaceRouter(t, viewer)
+
+	// Removing yourself only needs membership
+	expectRole(mock, workspaceID, viewer, types.WorkspaceRoleViewer)
+	mock.ExpectBegin()
+	expectRole(mock, workspaceID, viewer, types.WorkspaceRoleViewer)
+	mock.ExpectExec(`DELETE FROM "workspace_members" WHERE workspace_id = \$1 AND user_id = \$2`).
+		WithArgs(workspaceID.String(), viewer.String()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(`INSERT INTO "workspace_audit`).
+		WithArgs(workspaceID.String(), viewer.String(), "member.removed", "member", viewer.String(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()))
+	mock.ExpectCommit()
+
+	w := removeMember(router, workspaceID, viewer)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRemoveMember_EditorCannotRemoveOthers(t *testing.T) {
+	editor, workspaceID := uuid.New(), uuid.New()
+	router, mock := newTestWorkspaceRouter(t, editor)
+	expectRole(mock, workspaceID, editor, types.WorkspaceRoleEditor)
+
+	w := removeMember(router, workspaceID, uuid.New())
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRemoveMember_LastOwner(t *testing.T) {
+	owner, workspaceID := uuid.New(), uuid.New()
+	router, mock := newTestWorkspaceRouter(t, owner)
+
+	expectRole(mock, workspaceID, owner, types.WorkspaceRoleOwner)
+	mock.ExpectBegin()
+	expectRole(mock, workspaceID, owner, types.WorkspaceRoleOwner)
+	expectOwners(mock, workspaceID, owner)
+	mock.ExpectRollback()
+
+	w := removeMember(router, workspaceID, owner)
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.NoError(t, mock.ExpectationsWereMet(), "the last owner is never deleted")
+}
+
+func TestRemoveMember_OwnerWithCoOwner(t *testing.T) {
+	owner, coOwner, workspaceID := uuid.New(), uuid.New(), uuid.New()
+	router, mock := newTestWorkspaceRouter(t, owner)
+
+	expectRole(mock, workspaceID, owner, types.WorkspaceRoleOwner)
+	mock.ExpectBegin()
+	expectRole(mock, workspaceID, coOwner, types.WorkspaceRoleOwner)
+	expectOwners(mock, workspaceID, owner, coOwner)
+	mock.ExpectExec(`DELETE FROM "workspace_members"`).
+		WithArgs(workspaceID.String(), coOwner.String()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(`INSERT INTO "workspace_audit`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()))
+	mock.ExpectCommit()
+
+	w := removeMember(router, workspaceID, coOwner)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
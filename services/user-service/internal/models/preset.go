@@ -0,0 +1,139 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"github.com/stitts-dev/dfs-sim/shared/pkg/database"
+	"github.com/stitts-dev/dfs-sim/shared/pkg/workspace"
+	"github.com/stitts-dev/dfs-sim/shared/types"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// presetAccess limits a query to presets the user owns, has been shared or can read through a
+// workspace
+func presetAccess(db *gorm.DB, userID uuid.UUID) *gorm.DB {
+	return db.Where("owner_id = ? OR id IN (?) OR workspace_id IN (?)", userID,
+		db.Session(&gorm.Session{NewDB: true}).Model(&types.OptimizationPresetShare{}).Select("preset_id").Where("user_id = ?", userID),
+		workspace.MemberWorkspaces(db, userID))
+}
+
+// ListPresetsForUser returns the presets a user owns, has been shared or reaches through a
+// workspace, by name
+func ListPresetsForUser(db *database.DB, userID uuid.UUID) ([]types.OptimizationPreset, error) {
+	var presets []types.OptimizationPreset
+	err := presetAccess(db.DB, userID).Order("name ASC").Find(&presets).Error
+	return presets, err
+}
+
+// GetPresetForUser fetches a preset the user can read, or gorm.ErrRecordNotFound
+func GetPresetForUser(db *database.DB, presetID, userID uuid.UUID) (*types.OptimizationPreset, error) {
+	var preset types.OptimizationPreset
+	err := presetAccess(db.DB, userID).Where("id = ?", presetID).First(&preset).Error
+	return &preset, err
+}
+
+// GetPresetVersion fetches one revision of a preset's rules; version 0 is the current one
+func GetPresetVersion(db *database.DB, preset *types.OptimizationPreset, version int) (*types.OptimizationPresetVersion, error) {
+	if version == 0 {
+		version = preset.CurrentVersion
+	}
+	var presetVersion types.OptimizationPresetVersion
+	err := db.Where("preset_id = ? AND version = ?", preset.ID, version).First(&presetVersion).Error
+	return &presetVersion, err
+}
+
+// ListPresetVersions returns every revision of a preset, newest first
+func ListPresetVersions(db *database.DB, presetID uuid.UUID) ([]types.OptimizationPresetVersion, error) {
+	var versions []types.OptimizationPresetVersion
+	err := db.Where("preset_id = ?", presetID).Order("version DESC").Find(&versions).Error
+	return versions, err
+}
+
+// CreatePreset saves a preset with its rules as version 1
+func CreatePreset(db *database.DB, preset *types.OptimizationPreset, rules types.PresetRules) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		preset.CurrentVersion = 1
+		if err := tx.Create(preset).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(&types.OptimizationPresetVersion{
+			PresetID:  preset.ID,
+			Version:   1,
+			Rules:     rules,
+			CreatedBy: preset.OwnerID,
+		}).Error; err != nil {
+			return err
+		}
+		return recordPresetChange(tx, preset, preset.OwnerID, "preset.created", types.AuditDetails{"name": preset.Name})
+	})
+}
+
+// UpdatePreset applies field updates and, when rules are given, saves them as the next version
+func UpdatePreset(db *database.DB, preset *types.OptimizationPreset, updates map[string]interface{}, rules *types.PresetRules, userID uuid.UUID) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		// Lock the preset so concurrent edits get consecutive versions
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(preset, "id = ?", preset.ID).Error; err != nil {
+			return err
+		}
+
+		if rules != nil {
+			next := preset.CurrentVersion + 1
+			if err := tx.Create(&types.OptimizationPresetVersion{
+				PresetID:  preset.ID,
+				Version:   next,
+				Rules:     *rules,
+				CreatedBy: userID,
+			}).Error; err != nil {
+				return err
+			}
+			updates["current_version"] = next
+		}
+
+		if len(updates) == 0 {
+			return nil
+		}
+		if err := tx.Model(preset).Updates(updates).Error; err != nil {
+			return err
+		}
+		if err := tx.First(preset, "id = ?", preset.ID).Error; err != nil {
+			return err
+		}
+		return recordPresetChange(tx, preset, userID, "preset.updated", types.AuditDetails(updates))
+	})
+}
+
+// DeletePreset removes a preset with its versions and shares
+func DeletePreset(db *database.DB, preset *types.OptimizationPreset, userID uuid.UUID) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&types.OptimizationPreset{}, "id = ?", preset.ID).Error; err != nil {
+			return err
+		}
+		return recordPresetChange(tx, preset, userID, "preset.deleted", types.AuditDetails{"name": preset.Name})
+	})
+}
+
+// recordPresetChange adds a workspace preset's change to the workspace audit trail
+func recordPresetChange(tx *gorm.DB, preset *types.OptimizationPreset, actorID uuid.UUID, action string, details types.AuditDetails) error {
+	if preset.WorkspaceID == nil {
+		return nil
+	}
+	return workspace.Record(tx, *preset.WorkspaceID, actorID, action, "preset", &preset.ID, details)
+}
+
+// SharePreset gives a user read access to a preset; sharing twice is a no-op
+func SharePreset(db *database.DB, presetID, userID uuid.UUID) error {
+	share := &types.OptimizationPresetShare{PresetID: presetID, UserID: userID}
+	return db.Clauses(clause.OnConflict{DoNothing: true}).Create(share).Error
+}
+
+// UnsharePreset revokes a user's access to a preset
+func UnsharePreset(db *database.DB, presetID, userID uuid.UUID) error {
+	return db.Delete(&types.OptimizationPresetShare{}, "preset_id = ? AND user_id = ?", presetID, userID).Error
+}
+
+// ListPresetShares returns who a preset is shared with
+func ListPresetShares(db *database.DB, presetID uuid.UUID) ([]types.OptimizationPresetShare, error) {
+	var shares []types.OptimizationPresetShare
+	err := db.Where("preset_id = ?", presetID).Order("created_at ASC").Find(&shares).Error
+	return shares, err
+}
@@ -0,0 +1,154 @@
+package models
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/stitts-dev/dfs-sim/shared/pkg/database"
+	"github.com/stitts-dev/dfs-sim/shared/pkg/workspace"
+	"github.com/stitts-dev/dfs-sim/shared/types"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrLastOwner is returned when a change would leave a workspace without an owner
+var ErrLastOwner = errors.New("a workspace must keep at least one owner")
+
+// WorkspaceMembership is a workspace with the current user's role in it
+type WorkspaceMembership struct {
+	types.Workspace
+	Role types.WorkspaceRole `json:"role"`
+}
+
+// CreateWorkspace creates a workspace with its creator as owner
+func CreateWorkspace(db *database.DB, ws *types.Workspace) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(ws).Error; err != nil {
+			return err
+		}
+		owner := &types.WorkspaceMember{
+			WorkspaceID: ws.ID,
+			UserID:      ws.CreatedBy,
+			Role:        types.WorkspaceRoleOwner,
+			AddedBy:     ws.CreatedBy,
+		}
+		if err := tx.Create(owner).Error; err != nil {
+			return err
+		}
+		return workspace.Record(tx, ws.ID, ws.CreatedBy, "workspace.created", "workspace", &ws.ID, types.AuditDetails{"name": ws.Name})
+	})
+}
+
+// ListWorkspacesForUser returns the workspaces a user belongs to with their role, by name
+func ListWorkspacesForUser(db *database.DB, userID uuid.UUID) ([]WorkspaceMembership, error) {
+	var memberships []WorkspaceMembership
+	err := db.Table("workspaces").
+		Select("workspaces.*, workspace_members.role").
+		Joins("JOIN workspace_members ON workspace_members.workspace_id = workspaces.id").
+		Where("workspace_members.user_id = ?", userID).
+		Order("workspaces.name ASC").
+		Scan(&memberships).Error
+	return memberships, err
+}
+
+// GetWorkspace fetches a workspace by ID
+func GetWorkspace(db *database.DB, workspaceID uuid.UUID) (*types.Workspace, error) {
+	var ws types.Workspace
+	err := db.Where("id = ?", workspaceID).First(&ws).Error
+	return &ws, err
+}
+
+// ListWorkspaceMembers returns a workspace's members, owners first
+func ListWorkspaceMembers(db *database.DB, workspaceID uuid.UUID) ([]types.WorkspaceMember, error) {
+	var members []types.WorkspaceMember
+	err := db.Where("workspace_id = ?", workspaceID).
+		Order("CASE role WHEN 'owner' THEN 0 WHEN 'editor' THEN 1 ELSE 2 END, created_at ASC").
+		Find(&members).Error
+	return members, err
+}
+
+// AddWorkspaceMember adds a user to a workspace and records who added them
+func AddWorkspaceMember(db *database.DB, member *types.WorkspaceMember) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(member).Error; err != nil {
+			return err
+		}
+		return workspace.Record(tx, member.WorkspaceID, member.AddedBy, "member.added", "member", &member.UserID,
+			types.AuditDetails{"role": member.Role})
+	})
+}
+
+// UpdateWorkspaceMemberRole changes a member's role, refusing to demote the last owner
+func UpdateWorkspaceMemberRole(db *database.DB, workspaceID, userID uuid.UUID, role types.WorkspaceRole, actorID uuid.UUID) (*types.WorkspaceMember, error) {
+	var member types.WorkspaceMember
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("workspace_id = ? AND user_id = ?", workspaceID, userID).First(&member).Error; err != nil {
+			return err
+		}
+		if member.Role == role {
+			return nil
+		}
+		if member.Role == types.WorkspaceRoleOwner {
+			if err := ensureAnotherOwner(tx, workspaceID); err != nil {
+				return err
+			}
+		}
+
+		previous := member.Role
+		if err := tx.Model(&member).Where("workspace_id = ? AND user_id = ?", workspaceID, userID).Update("role", role).Error; err != nil {
+			return err
+		}
+		member.Role = role
+		return workspace.Record(tx, workspaceID, actorID, "member.role_changed", "member", &userID,
+			types.AuditDetails{"from": previous, "to": role})
+	})
+	return &member, err
+}
+
+// RemoveWorkspaceMember removes a user from a workspace, refusing to remove the last owner
+func RemoveWorkspaceMember(db *database.DB, workspaceID, userID, actorID uuid.UUID) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		var member types.WorkspaceMember
+		if err := tx.Where("workspace_id = ? AND user_id = ?", workspaceID, userID).First(&member).Error; err != nil {
+			return err
+		}
+		if member.Role == types.WorkspaceRoleOwner {
+			if err := ensureAnotherOwner(tx, workspaceID); err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Where("workspace_id = ? AND user_id = ?", workspaceID, userID).Delete(&types.WorkspaceMember{}).Error; err != nil {
+			return err
+		}
+		return workspace.Record(tx, workspaceID, actorID, "member.removed", "member", &userID,
+			types.AuditDetails{"role": member.Role})
+	})
+}
+
+// ListWorkspaceAudit returns a page of a workspace's audit trail, newest first
+func ListWorkspaceAudit(db *database.DB, workspaceID uuid.UUID, limit, offset int) ([]types.WorkspaceAuditEntry, error) {
+	var entries []types.WorkspaceAuditEntry
+	err := db.Where("workspace_id = ?", workspaceID).
+		Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&entries).Error
+	return entries, err
+}
+
+// ensureAnotherOwner locks the workspace's owner rows for the rest of the transaction, so two
+// owners demoting or removing each other at once can't both pass the check
+func ensureAnotherOwner(tx *gorm.DB, workspaceID uuid.UUID) error {
+	var owners []uuid.UUID
+	if err := tx.Model(&types.WorkspaceMember{}).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("workspace_id = ? AND role = ?", workspaceID, types.WorkspaceRoleOwner).
+		Pluck("user_id", &owners).Error; err != nil {
+		return err
+	}
+	if len(owners) <= 1 {
+		return ErrLastOwner
+	}
+	return nil
+}
@@ -6,9 +6,9 @@
 -- Enable UUID extension
 CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
 
--- Users table (extends auth.users)
+-- Users table (extends auth.users on Supabase; see the foreign key below)
 CREATE TABLE public.users (
-  id UUID REFERENCES auth.users(id) PRIMARY KEY,
+  id UUID PRIMARY KEY,
   phone_number TEXT UNIQUE NOT NULL,
   first_name TEXT,
   last_name TEXT,
@@ -67,28 +67,43 @@ CREATE TABLE public.legacy_user_mapping (
 INSERT INTO public.subscription_tiers (name, price_cents, monthly_optimizations, monthly_simulations, ai_recommendations, bank_verification, priority_support) VALUES
 ('free', 0, 10, 5, false, false, false),
 ('basic', 999, 50, 25, true, false, false),
-('premium', 2999, -1, -1, true, true, true);
+('premium', 2999, -1, -1, true, true, true)
+ON CONFLICT (name) DO NOTHING;
 
--- Enable realtime for user data (after tables are created)
-ALTER PUBLICATION supabase_realtime ADD TABLE public.users;
-ALTER PUBLICATION supabase_realtime ADD TABLE public.user_preferences;
+-- Supabase-only setup: the auth schema, the realtime publication and row level security only
+-- apply when the database is a Supabase project, so a plain Postgres database skips them
+DO $$
+BEGIN
+  IF to_regclass('auth.users') IS NOT NULL THEN
+    ALTER TABLE public.users ADD CONSTRAINT users_id_fkey
+      FOREIGN KEY (id) REFERENCES auth.users(id);
+  END IF;
+
+  -- Enable realtime for user data (after tables are created)
+  IF EXISTS (SELECT 1 FROM pg_publication WHERE pubname = 'supabase_realtime') THEN
+    ALTER PUBLICATION supabase_realtime ADD TABLE public.users;
+    ALTER PUBLICATION supabase_realtime ADD TABLE public.user_preferences;
+  END IF;
 
--- Row Level Security Policies
-ALTER TABLE public.users ENABLE ROW LEVEL SECURITY;
-CREATE POLICY "Users can view own profile" ON public.users
-  FOR SELECT USING (auth.uid() = id);
-CREATE POLICY "Users can update own profile" ON public.users
-  FOR UPDATE USING (auth.uid() = id);
+  -- Row Level Security Policies, which need Supabase's auth.uid()
+  IF to_regprocedure('auth.uid()') IS NOT NULL THEN
+    ALTER TABLE public.users ENABLE ROW LEVEL SECURITY;
+    CREATE POLICY "Users can view own profile" ON public.users
+      FOR SELECT USING (auth.uid() = id);
+    CREATE POLICY "Users can update own profile" ON public.users
+      FOR UPDATE USING (auth.uid() = id);
 
--- User preferences access control
-ALTER TABLE public.user_preferences ENABLE ROW LEVEL SECURITY;
-CREATE POLICY "Users can manage own preferences" ON public.user_preferences
-  FOR ALL USING (auth.uid() = user_id);
+    -- User preferences access control
+    ALTER TABLE public.user_preferences ENABLE ROW LEVEL SECURITY;
+    CREATE POLICY "Users can manage own preferences" ON public.user_preferences
+      FOR ALL USING (auth.uid() = user_id);
 
--- Subscription tiers are publicly readable
-ALTER TABLE public.subscription_tiers ENABLE ROW LEVEL SECURITY;
-CREATE POLICY "Subscription tiers are publicly readable" ON public.subscription_tiers
-  FOR SELECT USING (true);
+    -- Subscription tiers are publicly readable
+    ALTER TABLE public.subscription_tiers ENABLE ROW LEVEL SECURITY;
+    CREATE POLICY "Subscription tiers are publicly readable" ON public.subscription_tiers
+      FOR SELECT USING (true);
+  END IF;
+END $$;
 
 -- Updated_at trigger function
 CREATE OR REPLACE FUNCTION update_updated_at_column()
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS optimization_preset_shares;
+DROP TABLE IF EXISTS optimization_preset_versions;
+DROP TABLE IF EXISTS optimization_presets;
@@ -0,0 +1,40 @@
+-- Migration: Add saved optimization presets
+-- Named, versioned rule sets that optimization requests reference by ID
+
+CREATE TABLE IF NOT EXISTS optimization_presets (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    owner_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    description TEXT,
+    sport VARCHAR(20),
+    current_version INTEGER NOT NULL DEFAULT 1,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE (owner_id, name)
+);
+
+-- Every revision of a preset's rules is kept so requests can pin a version
+CREATE TABLE IF NOT EXISTS optimization_preset_versions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    preset_id UUID NOT NULL REFERENCES optimization_presets(id) ON DELETE CASCADE,
+    version INTEGER NOT NULL,
+    rules JSONB NOT NULL,
+    created_by UUID NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    CONSTRAINT idx_preset_version UNIQUE (preset_id, version)
+);
+
+-- Teammates a preset has been shared with
+CREATE TABLE IF NOT EXISTS optimization_preset_shares (
+    preset_id UUID NOT NULL REFERENCES optimization_presets(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (preset_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_optimization_presets_owner ON optimization_presets(owner_id);
+CREATE INDEX IF NOT EXISTS idx_optimization_preset_shares_user ON optimization_preset_shares(user_id);
+
+COMMENT ON TABLE optimization_presets IS 'Named optimization rule sets saved per user';
+COMMENT ON TABLE optimization_preset_versions IS 'Immutable revisions of each preset''s constraints and settings';
+COMMENT ON TABLE optimization_preset_shares IS 'Users a preset is shared with';
@@ -0,0 +1,4 @@
+ALTER TABLE optimization_presets DROP COLUMN IF EXISTS workspace_id;
+DROP TABLE IF EXISTS workspace_audit_log;
+DROP TABLE IF EXISTS workspace_members;
+DROP TABLE IF EXISTS workspaces;
@@ -0,0 +1,111 @@
+// Command migrate applies, reverts and reports a service's schema migrations.
+//
+//	migrate -service optimization-service -dir services/optimization-service/migrations up
+//	migrate -service user-service -dir services/user-service/migrations down -steps 1
+//	migrate -service sports-data-service -dir services/sports-data-service/migrations status
+//	migrate -service realtime-service -dir services/realtime-service/migrations baseline -version 1
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/stitts-dev/dfs-sim/shared/pkg/database"
+	"github.com/stitts-dev/dfs-sim/shared/pkg/migrate"
+)
+
+func main() {
+	service := flag.String("service", "", "service whose migrations are run, e.g. optimization-service")
+	dir := flag.String("dir", "migrations", "directory holding the service's migration files")
+	databaseURL := flag.String("database", os.Getenv("DATABASE_URL"), "database URL (defaults to DATABASE_URL)")
+	steps := flag.Int("steps", 1, "number of migrations to revert with down")
+	version := flag.Int("version", 0, "latest version to record as applied with baseline")
+	allowDrift := flag.Bool("allow-drift", false, "apply pending migrations even when applied ones have changed")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: migrate -service NAME [flags] up|down|status|baseline\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if *service == "" || *databaseURL == "" || flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	logger := logrus.WithField("service", *service)
+	if err := run(context.Background(), flag.Arg(0), *service, *dir, *databaseURL, *steps, *version, *allowDrift, logger); err != nil {
+		logger.Fatal(err)
+	}
+}
+
+func run(ctx context.Context, command, service, dir, databaseURL string, steps, version int, allowDrift bool, logger *logrus.Entry) error {
+	migrations, err := migrate.Load(os.DirFS(dir))
+	if err != nil {
+		return err
+	}
+
+	db, err := database.NewConnection(databaseURL, false)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer db.Close()
+
+	sqlDB, err := db.DB.DB()
+	if err != nil {
+		return fmt.Errorf("failed to get database instance: %w", err)
+	}
+	runner := migrate.NewRunner(sqlDB, service, migrations, logger)
+	runner.AllowDrift = allowDrift
+
+	switch command {
+	case "up":
+		_, err = runner.Up(ctx)
+	case "down":
+		var reverted []migrate.Migration
+		reverted, err = runner.Down(ctx, steps)
+		logger.WithField("reverted", len(reverted)).Info("Migrations reverted")
+	case "baseline":
+		if version <= 0 {
+			return fmt.Errorf("baseline needs -version")
+		}
+		var recorded []migrate.Migration
+		recorded, err = runner.Baseline(ctx, version)
+		logger.WithField("recorded", len(recorded)).Info("Migrations baselined")
+	case "status":
+		err = printStatus(ctx, runner)
+	default:
+		return fmt.Errorf("unknown command %q", command)
+	}
+	return err
+}
+
+func printStatus(ctx context.Context, runner *migrate.Runner) error {
+	statuses, err := runner.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tSTATE\tAPPLIED AT")
+	for _, status := range statuses {
+		state, appliedAt := "pending", ""
+		switch {
+		case status.Missing:
+			state = "missing file"
+		case status.Drifted:
+			state = "drifted"
+		case status.Applied:
+			state = "applied"
+		}
+		if status.AppliedAt != nil {
+			appliedAt = status.AppliedAt.Format("2006-01-02 15:04:05")
+		}
+		fmt.Fprintf(w, "%03d\t%s\t%s\t%s\n", status.Version, status.Name, state, appliedAt)
+	}
+	return w.Flush()
+}
@@ -1,10 +1,12 @@
 package database
 
 import (
+	"context"
 	"fmt"
 	"time"
 
 	"github.com/sirupsen/logrus"
+	"github.com/stitts-dev/dfs-sim/shared/pkg/migrate"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
@@ -182,3 +184,12 @@ func (db *DB) HealthCheck() error {
 
 	return nil
 }
+
+// Migrate applies the service's pending migrations from dir, recording them in schema_migrations
+func (db *DB) Migrate(ctx context.Context, service, dir string, logger *logrus.Entry) error {
+	sqlDB, err := db.DB.DB()
+	if err != nil {
+		return fmt.Errorf("failed to get database instance: %w", err)
+	}
+	return migrate.UpFromDir(ctx, sqlDB, service, dir, logger)
+}
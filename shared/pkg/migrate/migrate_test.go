@@ -0,0 +1,124 @@
+package migrate_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stitts-dev/dfs-sim/shared/pkg/migrate"
+)
+
+func file(content string) *fstest.MapFile {
+	return &fstest.MapFile{Data: []byte(content)}
+}
+
+func TestLoadOrdersVersionsAndPairsDownFiles(t *testing.T) {
+	fsys := fstest.MapFS{
+		"014_add_results.sql":       file("CREATE TABLE results ();"),
+		"001_core.up.sql":           file("CREATE TABLE core ();"),
+		"001_core.down.sql":         file("DROP TABLE core;"),
+		"012_add_indexes.sql":       file("-- migrate:no-transaction\nCREATE INDEX CONCURRENTLY idx ON core (id);"),
+		"README.md":                 file("not a migration"),
+		"deploy-missing-tables.sql": file("SELECT 1;"),
+	}
+
+	migrations, err := migrate.Load(fsys)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	var versions []int
+	for _, m := range migrations {
+		versions = append(versions, m.Version)
+	}
+	if len(versions) != 3 || versions[0] != 1 || versions[1] != 12 || versions[2] != 14 {
+		t.Fatalf("versions = %v, want [1 12 14]", versions)
+	}
+
+	core := migrations[0]
+	if core.Name != "core" || core.Down != "DROP TABLE core;" {
+		t.Errorf("core = %+v, want name core with its down file", core)
+	}
+	if core.NoTransaction {
+		t.Error("core should run in a transaction")
+	}
+	if !migrations[1].NoTransaction {
+		t.Error("012 should opt out of the transaction")
+	}
+	if migrations[2].Down != "" {
+		t.Error("014 has no down file")
+	}
+}
+
+func TestLoadChecksumTracksUpContent(t *testing.T) {
+	load := func(up, down string) migrate.Migration {
+		t.Helper()
+		migrations, err := migrate.Load(fstest.MapFS{
+			"001_core.sql":      file(up),
+			"001_core.down.sql": file(down),
+		})
+		if err != nil {
+			t.Fatalf("Load: %v", err)
+		}
+		return migrations[0]
+	}
+
+	base := load("CREATE TABLE core ();", "DROP TABLE core;")
+	if len(base.Checksum) != 64 {
+		t.Fatalf("checksum %q is not a SHA-256 hex digest", base.Checksum)
+	}
+	if load("CREATE TABLE core ();", "DROP TABLE IF EXISTS core;").Checksum != base.Checksum {
+		t.Error("editing the down file should not change the checksum")
+	}
+	if load("CREATE TABLE core (id INT);", "DROP TABLE core;").Checksum == base.Checksum {
+		t.Error("editing the up file should change the checksum")
+	}
+}
+
+func TestLoadRejectsInvalidSets(t *testing.T) {
+	tests := []struct {
+		name string
+		fsys fstest.MapFS
+		want string
+	}{
+		{
+			name: "duplicate version",
+			fsys: fstest.MapFS{"002_a.sql": file("SELECT 1;"), "002_b.sql": file("SELECT 2;")},
+			want: "used by both",
+		},
+		{
+			name: "two up files",
+			fsys: fstest.MapFS{"003_a.sql": file("SELECT 1;"), "003_a.up.sql": file("SELECT 2;")},
+			want: "more than one up file",
+		},
+		{
+			name: "down without up",
+			fsys: fstest.MapFS{"004_a.down.sql": file("SELECT 1;")},
+			want: "no up file",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := migrate.Load(tt.fsys)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Load error = %v, want it to mention %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestDriftErrorListsChangedAndMissingVersions(t *testing.T) {
+	var err error = &migrate.DriftError{Service: "user-service", Changed: []int{2}, Missing: []int{5}}
+
+	var drift *migrate.DriftError
+	if !errors.As(err, &drift) {
+		t.Fatal("DriftError should be matchable with errors.As")
+	}
+	for _, want := range []string{"user-service", "changed since applied: [2]", "missing from disk: [5]"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("%q does not mention %q", err.Error(), want)
+		}
+	}
+}
@@ -0,0 +1,359 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const createSchemaTable = `CREATE TABLE IF NOT EXISTS schema_migrations (
+	service    TEXT NOT NULL,
+	version    INTEGER NOT NULL,
+	name       TEXT NOT NULL,
+	checksum   TEXT NOT NULL,
+	applied_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+	PRIMARY KEY (service, version)
+)`
+
+// AppliedMigration is a row of the schema table
+type AppliedMigration struct {
+	Version   int
+	Name      string
+	Checksum  string
+	AppliedAt time.Time
+}
+
+// Status is one migration's state in a database
+type Status struct {
+	Version   int        `json:"version"`
+	Name      string     `json:"name"`
+	Applied   bool       `json:"applied"`
+	AppliedAt *time.Time `json:"applied_at,omitempty"`
+	// Drifted is set when the file has changed since it was applied
+	Drifted bool `json:"drifted"`
+	// Missing is set when a version was applied but its file no longer exists
+	Missing bool `json:"missing"`
+}
+
+// DriftError reports applied migrations whose files have changed or disappeared
+type DriftError struct {
+	Service string
+	Changed []int
+	Missing []int
+}
+
+func (e *DriftError) Error() string {
+	var parts []string
+	if len(e.Changed) > 0 {
+		parts = append(parts, fmt.Sprintf("changed since applied: %v", e.Changed))
+	}
+	if len(e.Missing) > 0 {
+		parts = append(parts, fmt.Sprintf("applied but missing from disk: %v", e.Missing))
+	}
+	return fmt.Sprintf("%s migrations have drifted (%s)", e.Service, strings.Join(parts, "; "))
+}
+
+// Runner applies one service's migrations to a database
+type Runner struct {
+	db         *sql.DB
+	service    string
+	migrations []Migration
+	logger     *logrus.Entry
+	// AllowDrift applies pending migrations even when applied ones have changed on disk
+	AllowDrift bool
+}
+
+// NewRunner creates a runner for a service's migrations, as returned by Load
+func NewRunner(db *sql.DB, service string, migrations []Migration, logger *logrus.Entry) *Runner {
+	return &Runner{
+		db:         db,
+		service:    service,
+		migrations: migrations,
+		logger:     logger.WithField("service", service),
+	}
+}
+
+// UpFromDir applies a service's pending migrations from a directory; services call it at
+// startup when RUN_MIGRATIONS is set
+func UpFromDir(ctx context.Context, db *sql.DB, service, dir string, logger *logrus.Entry) error {
+	migrations, err := Load(os.DirFS(dir))
+	if err != nil {
+		return err
+	}
+	_, err = NewRunner(db, service, migrations, logger).Up(ctx)
+	return err
+}
+
+// Up applies every pending migration in version order, refusing to run if applied migrations
+// have drifted unless AllowDrift is set
+func (r *Runner) Up(ctx context.Context) ([]Migration, error) {
+	var applied []Migration
+	err := r.withLock(ctx, func(conn *sql.Conn) error {
+		done, err := r.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if drift := r.drift(done); drift != nil {
+			if !r.AllowDrift {
+				return drift
+			}
+			r.logger.WithError(drift).Warn("Applying migrations despite drift")
+		}
+
+		latest := 0
+		for version := range done {
+			latest = max(latest, version)
+		}
+		for _, migration := range r.migrations {
+			if _, ok := done[migration.Version]; ok {
+				continue
+			}
+			if migration.Version < latest {
+				r.logger.WithField("version", migration.Version).Warn("Applying migration older than the latest applied version")
+			}
+			if err := r.apply(ctx, conn, migration); err != nil {
+				return err
+			}
+			applied = append(applied, migration)
+		}
+		return nil
+	})
+	if err != nil {
+		return applied, err
+	}
+
+	r.logger.WithField("applied", len(applied)).Info("Migrations up to date")
+	return applied, nil
+}
+
+// Down reverts the latest steps applied migrations, newest first
+func (r *Runner) Down(ctx context.Context, steps int) ([]Migration, error) {
+	byVersion := make(map[int]Migration, len(r.migrations))
+	for _, migration := range r.migrations {
+		byVersion[migration.Version] = migration
+	}
+
+	var reverted []Migration
+	err := r.withLock(ctx, func(conn *sql.Conn) error {
+		done, err := r.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+		versions := make([]int, 0, len(done))
+		for version := range done {
+			versions = append(versions, version)
+		}
+		sort.Sort(sort.Reverse(sort.IntSlice(versions)))
+
+		for _, version := range versions[:min(steps, len(versions))] {
+			migration, ok := byVersion[version]
+			if !ok {
+				return fmt.Errorf("migration %d is applied but its file is missing", version)
+			}
+			if migration.Down == "" {
+				return fmt.Errorf("migration %d_%s has no down file", version, migration.Name)
+			}
+			if err := r.revert(ctx, conn, migration); err != nil {
+				return err
+			}
+			reverted = append(reverted, migration)
+		}
+		return nil
+	})
+	return reverted, err
+}
+
+// Status lists every migration on disk or in the schema table with its state
+func (r *Runner) Status(ctx context.Context) ([]Status, error) {
+	conn, err := r.db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	done, err := r.applied(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, 0, len(r.migrations))
+	onDisk := make(map[int]bool, len(r.migrations))
+	for _, migration := range r.migrations {
+		onDisk[migration.Version] = true
+		status := Status{Version: migration.Version, Name: migration.Name}
+		if row, ok := done[migration.Version]; ok {
+			appliedAt := row.AppliedAt
+			status.Applied = true
+			status.AppliedAt = &appliedAt
+			status.Drifted = row.Checksum != migration.Checksum
+		}
+		statuses = append(statuses, status)
+	}
+	for version, row := range done {
+		if !onDisk[version] {
+			appliedAt := row.AppliedAt
+			statuses = append(statuses, Status{Version: version, Name: row.Name, Applied: true, AppliedAt: &appliedAt, Missing: true})
+		}
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Version < statuses[j].Version
+	})
+	return statuses, nil
+}
+
+// Baseline records every migration up to version as applied without running it, for databases
+// whose schema was created before the runner tracked it
+func (r *Runner) Baseline(ctx context.Context, version int) ([]Migration, error) {
+	var recorded []Migration
+	err := r.withLock(ctx, func(conn *sql.Conn) error {
+		done, err := r.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for _, migration := range r.migrations {
+			if migration.Version > version {
+				break
+			}
+			if _, ok := done[migration.Version]; ok {
+				continue
+			}
+			if err := r.record(ctx, conn, migration); err != nil {
+				return err
+			}
+			recorded = append(recorded, migration)
+		}
+		return nil
+	})
+	return recorded, err
+}
+
+// withLock holds a session advisory lock so replicas starting together don't race
+func (r *Runner) withLock(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := r.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	lockKey := "schema_migrations:" + r.service
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock(hashtext($1))", lockKey); err != nil {
+		return fmt.Errorf("failed to lock migrations: %w", err)
+	}
+	defer conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock(hashtext($1))", lockKey)
+
+	return fn(conn)
+}
+
+func (r *Runner) applied(ctx context.Context, conn *sql.Conn) (map[int]AppliedMigration, error) {
+	if _, err := conn.ExecContext(ctx, createSchemaTable); err != nil {
+		return nil, fmt.Errorf("failed to create schema_migrations: %w", err)
+	}
+
+	rows, err := conn.QueryContext(ctx,
+		"SELECT version, name, checksum, applied_at FROM schema_migrations WHERE service = $1", r.service)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema_migrations: %w", err)
+	}
+	defer rows.Close()
+
+	done := make(map[int]AppliedMigration)
+	for rows.Next() {
+		var row AppliedMigration
+		if err := rows.Scan(&row.Version, &row.Name, &row.Checksum, &row.AppliedAt); err != nil {
+			return nil, err
+		}
+		done[row.Version] = row
+	}
+	return done, rows.Err()
+}
+
+func (r *Runner) drift(done map[int]AppliedMigration) *DriftError {
+	drift := &DriftError{Service: r.service}
+	onDisk := make(map[int]bool, len(r.migrations))
+	for _, migration := range r.migrations {
+		onDisk[migration.Version] = true
+		if row, ok := done[migration.Version]; ok && row.Checksum != migration.Checksum {
+			drift.Changed = append(drift.Changed, migration.Version)
+		}
+	}
+	for version := range done {
+		if !onDisk[version] {
+			drift.Missing = append(drift.Missing, version)
+		}
+	}
+	if len(drift.Changed) == 0 && len(drift.Missing) == 0 {
+		return nil
+	}
+	sort.Ints(drift.Missing)
+	return drift
+}
+
+func (r *Runner) apply(ctx context.Context, conn *sql.Conn, migration Migration) error {
+	logger := r.logger.WithFields(logrus.Fields{"version": migration.Version, "name": migration.Name})
+	logger.Info("Applying migration")
+
+	err := r.inTransaction(ctx, conn, !migration.NoTransaction, func(exec execer) error {
+		if _, err := exec.ExecContext(ctx, migration.Up); err != nil {
+			return err
+		}
+		return insertRecord(ctx, exec, r.service, migration)
+	})
+	if err != nil {
+		return fmt.Errorf("migration %d_%s failed: %w", migration.Version, migration.Name, err)
+	}
+	return nil
+}
+
+func (r *Runner) revert(ctx context.Context, conn *sql.Conn, migration Migration) error {
+	r.logger.WithFields(logrus.Fields{"version": migration.Version, "name": migration.Name}).Info("Reverting migration")
+
+	err := r.inTransaction(ctx, conn, !hasDirective(migration.Down, noTransactionDirective), func(exec execer) error {
+		if _, err := exec.ExecContext(ctx, migration.Down); err != nil {
+			return err
+		}
+		_, err := exec.ExecContext(ctx, "DELETE FROM schema_migrations WHERE service = $1 AND version = $2", r.service, migration.Version)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("reverting migration %d_%s failed: %w", migration.Version, migration.Name, err)
+	}
+	return nil
+}
+
+func (r *Runner) record(ctx context.Context, conn *sql.Conn, migration Migration) error {
+	return insertRecord(ctx, conn, r.service, migration)
+}
+
+// execer is a connection or a transaction
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+func (r *Runner) inTransaction(ctx context.Context, conn *sql.Conn, transactional bool, fn func(exec execer) error) error {
+	if !transactional {
+		return fn(conn)
+	}
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+func insertRecord(ctx context.Context, exec execer, service string, migration Migration) error {
+	_, err := exec.ExecContext(ctx,
+		"INSERT INTO schema_migrations (service, version, name, checksum) VALUES ($1, $2, $3, $4)",
+		service, migration.Version, migration.Name, migration.Checksum)
+	return err
+}
@@ -0,0 +1,373 @@
+package scoring
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+
+	"github.com/stitts-dev/dfs-sim/shared/types"
+)
+
+// holesPerRound is the number of holes in a complete round
+const holesPerRound = 18
+
+// GolfHoleTable is the points awarded for a single hole by its score relative to par
+type GolfHoleTable struct {
+	DoubleEagle          float64 `json:"double_eagle"` // or better
+	Eagle                float64 `json:"eagle"`
+	Birdie               float64 `json:"birdie"`
+	Par                  float64 `json:"par"`
+	Bogey                float64 `json:"bogey"`
+	DoubleBogey          float64 `json:"double_bogey"`
+	WorseThanDoubleBogey float64 `json:"worse_than_double_bogey"`
+	HoleInOne            float64 `json:"hole_in_one"` // bonus on top of the hole's score
+}
+
+// GolfBonusTable is the round and tournament bonuses
+type GolfBonusTable struct {
+	// BirdieStreak is awarded once per round for BirdieStreakLength consecutive birdies or better
+	BirdieStreak       float64 `json:"birdie_streak"`
+	BirdieStreakLength int     `json:"birdie_streak_length"`
+	BogeyFreeRound     float64 `json:"bogey_free_round"`
+	// AllRoundsUnder is awarded when every one of the four rounds is under AllRoundsUnderStrokes
+	AllRoundsUnder        float64 `json:"all_rounds_under"`
+	AllRoundsUnderStrokes int     `json:"all_rounds_under_strokes"`
+}
+
+// PlacementTier awards points for finishing between MinPosition and MaxPosition inclusive
+type PlacementTier struct {
+	MinPosition int     `json:"min_position"`
+	MaxPosition int     `json:"max_position"`
+	Points      float64 `json:"points"`
+}
+
+// GolfScoringRules is a site's complete classic golf scoring system
+type GolfScoringRules struct {
+	Platform  string          `json:"platform"`
+	Holes     GolfHoleTable   `json:"holes"`
+	Bonuses   GolfBonusTable  `json:"bonuses"`
+	Placement []PlacementTier `json:"placement"`
+}
+
+// DraftKingsGolf is DraftKings PGA TOUR classic scoring
+var DraftKingsGolf = GolfScoringRules{
+	Platform: "draftkings",
+	Holes: GolfHoleTable{
+		DoubleEagle:          13,
+		Eagle:                8,
+		Birdie:               3,
+		Par:                  0.5,
+		Bogey:                -0.5,
+		DoubleBogey:          -1,
+		WorseThanDoubleBogey: -1,
+		HoleInOne:            5,
+	},
+	Bonuses: GolfBonusTable{
+		BirdieStreak:          3,
+		BirdieStreakLength:    3,
+		BogeyFreeRound:        3,
+		AllRoundsUnder:        5,
+		AllRoundsUnderStrokes: 70,
+	},
+	Placement: []PlacementTier{
+		{1, 1, 30}, {2, 2, 20}, {3, 3, 18}, {4, 4, 16}, {5, 5, 14},
+		{6, 6, 12}, {7, 7, 10}, {8, 8, 9}, {9, 9, 8}, {10, 10, 7},
+		{11, 15, 6}, {16, 20, 5}, {21, 25, 4}, {26, 30, 3}, {31, 40, 2}, {41, 50, 1},
+	},
+}
+
+// FanDuelGolf is FanDuel PGA classic scoring
+var FanDuelGolf = GolfScoringRules{
+	Platform: "fanduel",
+	Holes: GolfHoleTable{
+		DoubleEagle:          13,
+		Eagle:                7,
+		Birdie:               3.1,
+		Par:                  1.5,
+		Bogey:                -1,
+		DoubleBogey:          -3,
+		WorseThanDoubleBogey: -5,
+	},
+	Bonuses: GolfBonusTable{
+		BogeyFreeRound:        5,
+		AllRoundsUnder:        5,
+		AllRoundsUnderStrokes: 70,
+	},
+	Placement: []PlacementTier{
+		{1, 1, 10}, {2, 2, 8}, {3, 3, 7}, {4, 4, 6}, {5, 5, 5},
+		{6, 10, 4}, {11, 15, 3}, {16, 20, 2}, {21, 30, 1},
+	},
+}
+
+// GolfRulesForPlatform returns the scoring rules for a platform name
+func GolfRulesForPlatform(platform string) (GolfScoringRules, error) {
+	switch normalizePlatform(platform) {
+	case "draftkings":
+		return DraftKingsGolf, nil
+	case "fanduel":
+		return FanDuelGolf, nil
+	default:
+		return GolfScoringRules{}, fmt.Errorf("no golf scoring rules for platform %q", platform)
+	}
+}
+
+// HolePoints returns the points for playing a hole of the given par in strokes
+func (r GolfScoringRules) HolePoints(par, strokes int) float64 {
+	points := 0.0
+	switch diff := strokes - par; {
+	case diff <= -3:
+		points = r.Holes.DoubleEagle
+	case diff == -2:
+		points = r.Holes.Eagle
+	case diff == -1:
+		points = r.Holes.Birdie
+	case diff == 0:
+		points = r.Holes.Par
+	case diff == 1:
+		points = r.Holes.Bogey
+	case diff == 2:
+		points = r.Holes.DoubleBogey
+	default:
+		points = r.Holes.WorseThanDoubleBogey
+	}
+
+	if strokes == 1 {
+		points += r.Holes.HoleInOne
+	}
+	return points
+}
+
+// PlacementPoints returns the points for a finishing position; tied players all receive the
+// points for the position they share
+func (r GolfScoringRules) PlacementPoints(position int) float64 {
+	if position <= 0 {
+		return 0
+	}
+	for _, tier := range r.Placement {
+		if position >= tier.MinPosition && position <= tier.MaxPosition {
+			return tier.Points
+		}
+	}
+	return 0
+}
+
+// GolfRoundPoints is the scoring for one round
+type GolfRoundPoints struct {
+	Round          int     `json:"round"`
+	HolesPlayed    int     `json:"holes_played"`
+	Strokes        int     `json:"strokes"`
+	HolePoints     float64 `json:"hole_points"`
+	StreakPoints   float64 `json:"streak_points"`
+	BogeyFreeBonus float64 `json:"bogey_free_bonus"`
+	Total          float64 `json:"total"`
+}
+
+// GolfFantasyPoints is a player's fantasy scoring for a tournament, live or final
+type GolfFantasyPoints struct {
+	Platform        string            `json:"platform"`
+	Rounds          []GolfRoundPoints `json:"rounds"`
+	HolePoints      float64           `json:"hole_points"`
+	BonusPoints     float64           `json:"bonus_points"`
+	PlacementPoints float64           `json:"placement_points"`
+	Total           float64           `json:"total"`
+	Final           bool              `json:"final"`
+}
+
+// GolfScoringInput is the scored data for one player. Rounds hold each round's holes in any
+// order; incomplete rounds score live, without the bonuses that need the full round.
+type GolfScoringInput struct {
+	Rounds [][]types.HoleScore
+	// Position is the finishing position; zero while the tournament is in progress or after a missed cut
+	Position int
+	// Final marks completed tournaments, which unlocks placement and four-round bonuses
+	Final bool
+}
+
+// ScoreRound scores a single round's holes
+func (r GolfScoringRules) ScoreRound(round int, holes []types.HoleScore) GolfRoundPoints {
+	ordered := make([]types.HoleScore, len(holes))
+	copy(ordered, holes)
+	sort.Slice(ordered, func(i, j int) bool { return ordered[i].Hole < ordered[j].Hole })
+
+	result := GolfRoundPoints{Round: round, HolesPlayed: len(ordered)}
+	streak, longestStreak := 0, 0
+	bogeyFree := true
+	for _, hole := range ordered {
+		result.Strokes += hole.Score
+		result.HolePoints += r.HolePoints(hole.Par, hole.Score)
+
+		if hole.Score < hole.Par {
+			streak++
+			longestStreak = max(longestStreak, streak)
+		} else {
+			streak = 0
+		}
+		if hole.Score > hole.Par {
+			bogeyFree = false
+		}
+	}
+
+	// Streaks pay as soon as they happen; bogey-free needs the round to be finished
+	if r.Bonuses.BirdieStreakLength > 0 && longestStreak >= r.Bonuses.BirdieStreakLength {
+		result.StreakPoints = r.Bonuses.BirdieStreak
+	}
+	if bogeyFree && result.HolesPlayed == holesPerRound {
+		result.BogeyFreeBonus = r.Bonuses.BogeyFreeRound
+	}
+
+	result.Total = result.HolePoints + result.StreakPoints + result.BogeyFreeBonus
+	return result
+}
+
+// ScoreTournament scores a player's tournament from hole-by-hole data
+func (r GolfScoringRules) ScoreTournament(input GolfScoringInput) GolfFantasyPoints {
+	return r.ScoreWindow(input, classicGolfWindow)
+}
+
+// HoleDistribution is the probability of each score relative to par on a single hole
+type HoleDistribution struct {
+	DoubleEagle          float64 `json:"double_eagle"`
+	Eagle                float64 `json:"eagle"`
+	Birdie               float64 `json:"birdie"`
+	Par                  float64 `json:"par"`
+	Bogey                float64 `json:"bogey"`
+	DoubleBogey          float64 `json:"double_bogey"`
+	WorseThanDoubleBogey float64 `json:"worse_than_double_bogey"`
+}
+
+// tourHoleDistribution is the average PGA TOUR hole, roughly a 71.3 scoring average on a par 71-72
+var tourHoleDistribution = HoleDistribution{
+	DoubleEagle:          0.0002,
+	Eagle:                0.006,
+	Birdie:               0.20,
+	Par:                  0.617,
+	Bogey:                0.15,
+	DoubleBogey:          0.022,
+	WorseThanDoubleBogey: 0.0048,
+}
+
+// TourHoleDistribution returns the per-hole score distribution for a player expected to shoot
+// roundToPar, shifting tour-average birdie and bogey rates by the difference in scoring
+func TourHoleDistribution(roundToPar float64) HoleDistribution {
+	d := tourHoleDistribution
+	shift := roundToPar/holesPerRound - d.mean()
+
+	// Better players convert pars into birdies and bogeys into pars; each transfer is one stroke
+	d.Birdie = clampProbability(d.Birdie - 0.6*shift)
+	d.Bogey = clampProbability(d.Bogey + 0.4*shift)
+	d.Par = clampProbability(1 - d.DoubleEagle - d.Eagle - d.Birdie - d.Bogey - d.DoubleBogey - d.WorseThanDoubleBogey)
+	return d
+}
+
+func (d HoleDistribution) mean() float64 {
+	return -3*d.DoubleEagle - 2*d.Eagle - d.Birdie + d.Bogey + 2*d.DoubleBogey + 3.3*d.WorseThanDoubleBogey
+}
+
+// BirdieOrBetter is the probability of scoring under par on a hole
+func (d HoleDistribution) BirdieOrBetter() float64 {
+	return d.DoubleEagle + d.Eagle + d.Birdie
+}
+
+// BogeyOrWorse is the probability of scoring over par on a hole
+func (d HoleDistribution) BogeyOrWorse() float64 {
+	return d.Bogey + d.DoubleBogey + d.WorseThanDoubleBogey
+}
+
+// ExpectedHolePoints returns the expected points for one hole (hole-in-one bonuses excluded)
+func (r GolfScoringRules) ExpectedHolePoints(d HoleDistribution) float64 {
+	return d.DoubleEagle*r.Holes.DoubleEagle +
+		d.Eagle*r.Holes.Eagle +
+		d.Birdie*r.Holes.Birdie +
+		d.Par*r.Holes.Par +
+		d.Bogey*r.Holes.Bogey +
+		d.DoubleBogey*r.Holes.DoubleBogey +
+		d.WorseThanDoubleBogey*r.Holes.WorseThanDoubleBogey
+}
+
+// ExpectedRoundPoints returns the expected hole, streak and bogey-free points for a full round
+func (r GolfScoringRules) ExpectedRoundPoints(d HoleDistribution) float64 {
+	points := holesPerRound * r.ExpectedHolePoints(d)
+	if r.Bonuses.BirdieStreakLength > 0 {
+		points += r.Bonuses.BirdieStreak * StreakProbability(d.BirdieOrBetter(), holesPerRound, r.Bonuses.BirdieStreakLength)
+	}
+	points += r.Bonuses.BogeyFreeRound * math.Pow(1-d.BogeyOrWorse(), holesPerRound)
+	return points
+}
+
+// StreakProbability is the probability of at least one run of length consecutive successes in
+// n independent trials with success probability p
+func StreakProbability(p float64, n, length int) float64 {
+	if length <= 0 {
+		return 1
+	}
+	if length > n {
+		return 0
+	}
+
+	// state[k] is the probability of currently being on a run of k without having completed a streak
+	state := make([]float64, length)
+	state[0] = 1
+	for trial := 0; trial < n; trial++ {
+		next := make([]float64, length)
+		for k, prob := range state {
+			next[0] += prob * (1 - p)
+			if k+1 < length {
+				next[k+1] += prob * p
+			}
+		}
+		state = next
+	}
+
+	none := 0.0
+	for _, prob := range state {
+		none += prob
+	}
+	return 1 - none
+}
+
+// SampleRound draws a simulated round from a hole distribution, one hole per par in pars, so
+// simulated rounds score through the same tables as live ones
+func SampleRound(rng *rand.Rand, pars []int, d HoleDistribution) []types.HoleScore {
+	holes := make([]types.HoleScore, len(pars))
+	for i, par := range pars {
+		u := rng.Float64()
+		diff := 3
+		for _, bucket := range []struct {
+			diff int
+			p    float64
+		}{
+			{-3, d.DoubleEagle}, {-2, d.Eagle}, {-1, d.Birdie}, {0, d.Par}, {1, d.Bogey}, {2, d.DoubleBogey},
+		} {
+			if u < bucket.p {
+				diff = bucket.diff
+				break
+			}
+			u -= bucket.p
+		}
+
+		// A double eagle on a par 3 would be a zero
+		strokes := par + diff
+		if strokes < 1 {
+			strokes = 1
+		}
+		holes[i] = types.HoleScore{Hole: i + 1, Par: par, Score: strokes}
+	}
+	return holes
+}
+
+// AveragePlacementPoints is the mean placement award across positions from..to inclusive
+func (r GolfScoringRules) AveragePlacementPoints(from, to int) float64 {
+	if to < from || from <= 0 {
+		return 0
+	}
+	total := 0.0
+	for position := from; position <= to; position++ {
+		total += r.PlacementPoints(position)
+	}
+	return total / float64(to-from+1)
+}
+
+func clampProbability(p float64) float64 {
+	return math.Max(0, math.Min(1, p))
+}
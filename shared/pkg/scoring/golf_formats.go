@@ -0,0 +1,141 @@
+package scoring
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// GolfContestFormat is the style of a golf contest, which decides its roster and the rounds it scores
+type GolfContestFormat string
+
+const (
+	// GolfFormatClassic scores all four rounds plus finishing position
+	GolfFormatClassic GolfContestFormat = "classic"
+	// GolfFormatWeekend locks after the cut and scores rounds three and four plus finishing position
+	GolfFormatWeekend GolfContestFormat = "weekend"
+	// GolfFormatShowdown scores a single round's holes and round bonuses
+	GolfFormatShowdown GolfContestFormat = "showdown"
+	// GolfFormatCaptain is a single-round showdown with one captain slot at CaptainMultiplier
+	GolfFormatCaptain GolfContestFormat = "captain"
+)
+
+// CaptainMultiplier scales both the salary and the points of the player in a captain slot
+const CaptainMultiplier = 1.5
+
+// GolfScoringWindow is the part of a tournament a contest format scores
+type GolfScoringWindow struct {
+	Format     GolfContestFormat `json:"format"`
+	FirstRound int               `json:"first_round"`
+	LastRound  int               `json:"last_round"`
+	// Placement awards finishing position points once the tournament is final
+	Placement bool `json:"placement"`
+	// AllRoundsBonus awards the four-rounds-under bonus, which only a full tournament can earn
+	AllRoundsBonus bool `json:"all_rounds_bonus"`
+}
+
+// Rounds is the number of rounds the window covers
+func (w GolfScoringWindow) Rounds() int {
+	return w.LastRound - w.FirstRound + 1
+}
+
+// Contains reports whether a round number falls inside the window
+func (w GolfScoringWindow) Contains(round int) bool {
+	return round >= w.FirstRound && round <= w.LastRound
+}
+
+// classicGolfWindow is the full-tournament window ScoreTournament scores
+var classicGolfWindow = GolfScoringWindow{Format: GolfFormatClassic, FirstRound: 1, LastRound: 4, Placement: true, AllRoundsBonus: true}
+
+// GolfWindowForFormat returns the scoring window for a format; round picks the scored round for
+// the single-round formats and is ignored otherwise
+func GolfWindowForFormat(format GolfContestFormat, round int) (GolfScoringWindow, error) {
+	switch format {
+	case GolfFormatClassic, "":
+		return classicGolfWindow, nil
+	case GolfFormatWeekend:
+		return GolfScoringWindow{Format: format, FirstRound: 3, LastRound: 4, Placement: true}, nil
+	case GolfFormatShowdown, GolfFormatCaptain:
+		if round < 1 || round > 4 {
+			return GolfScoringWindow{}, fmt.Errorf("%s contests need a round between 1 and 4, got %d", format, round)
+		}
+		return GolfScoringWindow{Format: format, FirstRound: round, LastRound: round}, nil
+	default:
+		return GolfScoringWindow{}, fmt.Errorf("unknown golf contest format %q", format)
+	}
+}
+
+var contestRoundPattern = regexp.MustCompile(`\b(?:round\s*|r)([1-4])\b`)
+
+// GolfWindowForContest works out a contest's format and scoring window from its name, contest type
+// and roster slots. Single-round contests take their round from the name ("Round 3", "R3") and
+// fall back to defaultRound, usually the tournament's current round.
+func GolfWindowForContest(name, contestType string, rosterSlots []string, defaultRound int) (GolfScoringWindow, error) {
+	text := strings.ToLower(name + " " + contestType)
+
+	format := GolfFormatClassic
+	switch {
+	case hasCaptainSlot(rosterSlots) || strings.Contains(text, "captain"):
+		format = GolfFormatCaptain
+	case strings.Contains(text, "showdown") || strings.Contains(text, "single round"):
+		format = GolfFormatShowdown
+	case strings.Contains(text, "weekend"):
+		format = GolfFormatWeekend
+	}
+
+	round := defaultRound
+	if match := contestRoundPattern.FindStringSubmatch(text); match != nil {
+		round, _ = strconv.Atoi(match[1])
+	}
+	if round == 0 {
+		round = 1
+	}
+	return GolfWindowForFormat(format, round)
+}
+
+func hasCaptainSlot(slots []string) bool {
+	for _, slot := range slots {
+		if strings.EqualFold(slot, "CPT") {
+			return true
+		}
+	}
+	return false
+}
+
+// ScoreWindow scores only the rounds inside a contest format's window. Rounds outside it are
+// ignored, and the placement and four-round bonuses apply only when the window allows them.
+func (r GolfScoringRules) ScoreWindow(input GolfScoringInput, window GolfScoringWindow) GolfFantasyPoints {
+	result := GolfFantasyPoints{
+		Platform: r.Platform,
+		Rounds:   make([]GolfRoundPoints, 0, window.Rounds()),
+		Final:    input.Final,
+	}
+
+	allUnder := window.AllRoundsBonus && len(input.Rounds) == 4
+	for i, holes := range input.Rounds {
+		if !window.Contains(i + 1) {
+			continue
+		}
+		round := r.ScoreRound(i+1, holes)
+		result.Rounds = append(result.Rounds, round)
+		result.HolePoints += round.HolePoints
+		result.BonusPoints += round.StreakPoints + round.BogeyFreeBonus
+
+		if round.HolesPlayed != holesPerRound || round.Strokes >= r.Bonuses.AllRoundsUnderStrokes {
+			allUnder = false
+		}
+	}
+
+	if input.Final {
+		if allUnder && r.Bonuses.AllRoundsUnderStrokes > 0 {
+			result.BonusPoints += r.Bonuses.AllRoundsUnder
+		}
+		if window.Placement {
+			result.PlacementPoints = r.PlacementPoints(input.Position)
+		}
+	}
+
+	result.Total = result.HolePoints + result.BonusPoints + result.PlacementPoints
+	return result
+}
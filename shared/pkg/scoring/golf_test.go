@@ -0,0 +1,226 @@
+package scoring_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stitts-dev/dfs-sim/shared/pkg/scoring"
+	"github.com/stitts-dev/dfs-sim/shared/types"
+)
+
+var par72 = []int{4, 4, 3, 5, 4, 4, 3, 4, 5, 4, 4, 3, 5, 4, 4, 3, 4, 5}
+
+// roundFromDiffs builds a round on par72 with each hole's score relative to par
+func roundFromDiffs(diffs ...int) []types.HoleScore {
+	holes := make([]types.HoleScore, len(diffs))
+	for i, diff := range diffs {
+		holes[i] = types.HoleScore{Hole: i + 1, Par: par72[i], Score: par72[i] + diff}
+	}
+	return holes
+}
+
+func evenRound() []types.HoleScore {
+	return roundFromDiffs(make([]int, 18)...)
+}
+
+func TestHolePoints(t *testing.T) {
+	tests := []struct {
+		name    string
+		rules   scoring.GolfScoringRules
+		par     int
+		strokes int
+		want    float64
+	}{
+		{"dk albatross", scoring.DraftKingsGolf, 5, 2, 13},
+		{"dk eagle", scoring.DraftKingsGolf, 5, 3, 8},
+		{"dk birdie", scoring.DraftKingsGolf, 4, 3, 3},
+		{"dk par", scoring.DraftKingsGolf, 4, 4, 0.5},
+		{"dk bogey", scoring.DraftKingsGolf, 4, 5, -0.5},
+		{"dk double bogey", scoring.DraftKingsGolf, 4, 6, -1},
+		{"dk triple bogey", scoring.DraftKingsGolf, 4, 7, -1},
+		{"dk ace is an eagle plus the hole in one bonus", scoring.DraftKingsGolf, 3, 1, 13},
+		{"fd birdie", scoring.FanDuelGolf, 4, 3, 3.1},
+		{"fd par", scoring.FanDuelGolf, 4, 4, 1.5},
+		{"fd double bogey", scoring.FanDuelGolf, 4, 6, -3},
+		{"fd worse than double", scoring.FanDuelGolf, 4, 8, -5},
+	}
+
+	for _, tt := range tests {
+		if got := tt.rules.HolePoints(tt.par, tt.strokes); got != tt.want {
+			t.Errorf("%s: HolePoints(%d, %d) = %v, want %v", tt.name, tt.par, tt.strokes, got, tt.want)
+		}
+	}
+}
+
+func TestScoreRound_Bonuses(t *testing.T) {
+	// Three straight birdies on 4-6, no bogeys: 15 pars, 3 birdies
+	streak := roundFromDiffs(0, 0, 0, -1, -1, -1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0)
+	round := scoring.DraftKingsGolf.ScoreRound(1, streak)
+	if round.HolePoints != 15*0.5+3*3 {
+		t.Errorf("hole points = %v", round.HolePoints)
+	}
+	if round.StreakPoints != 3 || round.BogeyFreeBonus != 3 {
+		t.Errorf("bonuses = streak %v, bogey-free %v, want 3 and 3", round.StreakPoints, round.BogeyFreeBonus)
+	}
+	if round.Strokes != 69 {
+		t.Errorf("strokes = %d, want 69", round.Strokes)
+	}
+
+	// Broken streak with a bogey: no bonuses
+	broken := roundFromDiffs(-1, -1, 0, -1, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0)
+	round = scoring.DraftKingsGolf.ScoreRound(1, broken)
+	if round.StreakPoints != 0 || round.BogeyFreeBonus != 0 {
+		t.Errorf("unexpected bonuses: streak %v, bogey-free %v", round.StreakPoints, round.BogeyFreeBonus)
+	}
+
+	// Holes arriving out of order still find the streak
+	shuffled := []types.HoleScore{streak[5], streak[3], streak[4]}
+	if got := scoring.DraftKingsGolf.ScoreRound(1, shuffled).StreakPoints; got != 3 {
+		t.Errorf("out-of-order streak points = %v, want 3", got)
+	}
+
+	// Bogey-free only pays for a finished round
+	if got := scoring.DraftKingsGolf.ScoreRound(1, evenRound()[:9]).BogeyFreeBonus; got != 0 {
+		t.Errorf("partial round bogey-free bonus = %v, want 0", got)
+	}
+}
+
+func TestScoreTournament_LiveAndFinal(t *testing.T) {
+	under70 := roundFromDiffs(-1, -1, 0, 0, 0, 0, 0, 0, -1, 0, 0, 0, 0, 0, 0, 0, 0, 0) // 69
+	rounds := [][]types.HoleScore{under70, under70, under70, under70}
+
+	live := scoring.DraftKingsGolf.ScoreTournament(scoring.GolfScoringInput{Rounds: rounds, Position: 1})
+	if live.PlacementPoints != 0 {
+		t.Errorf("live scoring awarded placement points: %v", live.PlacementPoints)
+	}
+
+	final := scoring.DraftKingsGolf.ScoreTournament(scoring.GolfScoringInput{Rounds: rounds, Position: 1, Final: true})
+	if final.PlacementPoints != 30 {
+		t.Errorf("win placement = %v, want 30", final.PlacementPoints)
+	}
+	// Four bogey-free rounds plus all four rounds under 70
+	if want := 4*3.0 + 5; final.BonusPoints != want {
+		t.Errorf("bonus points = %v, want %v", final.BonusPoints, want)
+	}
+	if final.Total != final.HolePoints+final.BonusPoints+final.PlacementPoints {
+		t.Errorf("total %v doesn't add up", final.Total)
+	}
+
+	// A 70 breaks the all-rounds-under bonus; a missed cut gets no placement points
+	missedCut := scoring.DraftKingsGolf.ScoreTournament(scoring.GolfScoringInput{
+		Rounds: [][]types.HoleScore{under70, roundFromDiffs(-1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0)},
+		Final:  true,
+	})
+	if missedCut.PlacementPoints != 0 || missedCut.BonusPoints != 6 {
+		t.Errorf("missed cut placement %v bonus %v, want 0 and 6", missedCut.PlacementPoints, missedCut.BonusPoints)
+	}
+}
+
+func TestPlacementPoints(t *testing.T) {
+	tests := []struct {
+		rules    scoring.GolfScoringRules
+		position int
+		want     float64
+	}{
+		{scoring.DraftKingsGolf, 1, 30},
+		{scoring.DraftKingsGolf, 10, 7},
+		{scoring.DraftKingsGolf, 13, 6},
+		{scoring.DraftKingsGolf, 50, 1},
+		{scoring.DraftKingsGolf, 51, 0},
+		{scoring.FanDuelGolf, 1, 10},
+		{scoring.FanDuelGolf, 7, 4},
+		{scoring.FanDuelGolf, 31, 0},
+	}
+	for _, tt := range tests {
+		if got := tt.rules.PlacementPoints(tt.position); got != tt.want {
+			t.Errorf("%s PlacementPoints(%d) = %v, want %v", tt.rules.Platform, tt.position, got, tt.want)
+		}
+	}
+}
+
+func TestStreakProbability(t *testing.T) {
+	if got := scoring.StreakProbability(1, 18, 3); math.Abs(got-1) > 1e-12 {
+		t.Errorf("certain birdies: %v", got)
+	}
+	if got := scoring.StreakProbability(0.5, 3, 3); math.Abs(got-0.125) > 1e-12 {
+		t.Errorf("three flips: %v, want 0.125", got)
+	}
+	// Four trials: HHHx or xHHH overlap only on HHHH, so 2/16 + 2/16 - 1/16
+	if got := scoring.StreakProbability(0.5, 4, 3); math.Abs(got-3.0/16) > 1e-12 {
+		t.Errorf("four flips: %v, want %v", got, 3.0/16)
+	}
+}
+
+func TestExpectedRoundPointsMatchesSampledRounds(t *testing.T) {
+	d := scoring.TourHoleDistribution(-2)
+	// Aces depend on the course's par 3s, so the expectation leaves them out
+	rules := scoring.DraftKingsGolf
+	rules.Holes.HoleInOne = 0
+	rng := rand.New(rand.NewSource(1))
+
+	const rounds = 40000
+	total := 0.0
+	strokes := 0
+	for i := 0; i < rounds; i++ {
+		round := scoring.SampleRound(rng, par72, d)
+		points := rules.ScoreRound(1, round)
+		total += points.Total
+		strokes += points.Strokes
+	}
+
+	want := rules.ExpectedRoundPoints(d)
+	if got := total / rounds; math.Abs(got-want) > 0.1 {
+		t.Errorf("sampled round points = %.3f, expected %.3f", got, want)
+	}
+	if got := float64(strokes)/rounds - 72; math.Abs(got+2) > 0.15 {
+		t.Errorf("sampled round to par = %.2f, want -2", got)
+	}
+}
+
+func TestScoreWindow_Formats(t *testing.T) {
+	under70 := roundFromDiffs(-1, -1, 0, 0, 0, 0, 0, 0, -1, 0, 0, 0, 0, 0, 0, 0, 0, 0) // 69
+	rounds := [][]types.HoleScore{under70, under70, under70, under70}
+	input := scoring.GolfScoringInput{Rounds: rounds, Position: 1, Final: true}
+	perRound := scoring.DraftKingsGolf.ScoreRound(1, under70).Total
+
+	weekend, _ := scoring.GolfWindowForFormat(scoring.GolfFormatWeekend, 0)
+	got := scoring.DraftKingsGolf.ScoreWindow(input, weekend)
+	if want := 2*perRound + 30; got.Total != want || len(got.Rounds) != 2 || got.Rounds[0].Round != 3 {
+		t.Errorf("weekend total %v over %d rounds, want %v over rounds 3-4", got.Total, len(got.Rounds), want)
+	}
+
+	showdown, _ := scoring.GolfWindowForFormat(scoring.GolfFormatShowdown, 2)
+	got = scoring.DraftKingsGolf.ScoreWindow(input, showdown)
+	if got.Total != perRound || got.PlacementPoints != 0 {
+		t.Errorf("showdown total %v placement %v, want %v and 0", got.Total, got.PlacementPoints, perRound)
+	}
+
+	if _, err := scoring.GolfWindowForFormat(scoring.GolfFormatCaptain, 0); err == nil {
+		t.Error("captain window without a round should fail")
+	}
+}
+
+func TestGolfWindowForContest(t *testing.T) {
+	tests := []struct {
+		name   string
+		slots  []string
+		format scoring.GolfContestFormat
+		round  int
+	}{
+		{"PGA TOUR $1M Millionaire", []string{"G", "G", "G", "G", "G", "G"}, scoring.GolfFormatClassic, 1},
+		{"PGA Weekend $100K", nil, scoring.GolfFormatWeekend, 3},
+		{"PGA Showdown Round 2", nil, scoring.GolfFormatShowdown, 2},
+		{"PGA TOUR R4 Showdown", nil, scoring.GolfFormatShowdown, 4},
+		{"PGA TOUR Single Round", []string{"CPT", "G", "G", "G", "G", "G"}, scoring.GolfFormatCaptain, 3},
+	}
+	for _, tt := range tests {
+		window, err := scoring.GolfWindowForContest(tt.name, "gpp", tt.slots, 3)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if window.Format != tt.format || window.FirstRound != tt.round && tt.format != scoring.GolfFormatClassic {
+			t.Errorf("%s: got %s round %d, want %s round %d", tt.name, window.Format, window.FirstRound, tt.format, tt.round)
+		}
+	}
+}
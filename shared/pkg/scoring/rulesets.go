@@ -0,0 +1,271 @@
+package scoring
+
+// NFL stats
+const (
+	StatPassYards            = "pass_yds"
+	StatPassTouchdowns       = "pass_td"
+	StatInterceptionsThrown  = "pass_int"
+	StatRushYards            = "rush_yds"
+	StatRushTouchdowns       = "rush_td"
+	StatReceptions           = "rec"
+	StatReceivingYards       = "rec_yds"
+	StatReceivingTouchdowns  = "rec_td"
+	StatReturnTouchdowns     = "return_td"
+	StatFumblesLost          = "fumbles_lost"
+	StatTwoPointConversions  = "two_pt"
+	StatFumbleRecoveryTD     = "fumble_recovery_td"
+	StatFieldGoals0To39      = "fg_0_39"
+	StatFieldGoals40To49     = "fg_40_49"
+	StatFieldGoals50Plus     = "fg_50_plus"
+	StatExtraPoints          = "xp"
+	StatDefSacks             = "dst_sacks"
+	StatDefInterceptions     = "dst_int"
+	StatDefFumbleRecoveries  = "dst_fumble_recoveries"
+	StatDefTouchdowns        = "dst_td"
+	StatDefSafeties          = "dst_safeties"
+	StatDefBlockedKicks      = "dst_blocked_kicks"
+	StatDefExtraPointReturns = "dst_xp_returns"
+	StatDefPointsAllowed     = "dst_points_allowed"
+)
+
+// NBA stats
+const (
+	StatPoints     = "pts"
+	StatThreesMade = "fg3m"
+	StatRebounds   = "reb"
+	StatAssists    = "ast"
+	StatSteals     = "stl"
+	StatBlocks     = "blk"
+	StatTurnovers  = "tov"
+)
+
+// MLB stats
+const (
+	StatSingles           = "1b"
+	StatDoubles           = "2b"
+	StatTriples           = "3b"
+	StatHomeRuns          = "hr"
+	StatRunsBattedIn      = "rbi"
+	StatRuns              = "r"
+	StatWalks             = "bb"
+	StatHitByPitch        = "hbp"
+	StatStolenBases       = "sb"
+	StatOutsPitched       = "outs"
+	StatStrikeouts        = "k"
+	StatPitcherWin        = "w"
+	StatEarnedRuns        = "er"
+	StatHitsAllowed       = "h_allowed"
+	StatWalksAllowed      = "bb_allowed"
+	StatHitBattersAllowed = "hbp_allowed"
+	StatCompleteGame      = "cg"
+	StatCompleteGameSHO   = "cgso"
+	StatNoHitter          = "no_hitter"
+	StatQualityStart      = "qs"
+)
+
+// NHL stats
+const (
+	StatGoals             = "g"
+	StatHockeyAssists     = "a"
+	StatShotsOnGoal       = "sog"
+	StatBlockedShots      = "blocked"
+	StatShorthandedPoints = "shp"
+	StatPowerPlayPoints   = "ppp"
+	StatShootoutGoals     = "so_goals"
+	StatGoalieWin         = "goalie_w"
+	StatGoalieOTLoss      = "goalie_otl"
+	StatSaves             = "saves"
+	StatGoalsAgainst      = "ga"
+	StatGoalieShutout     = "goalie_so"
+)
+
+// nflPointsAllowed is the defense points allowed scale both sites use
+var nflPointsAllowed = []StatTier{
+	{StatDefPointsAllowed, 0, 1, 10},
+	{StatDefPointsAllowed, 1, 7, 7},
+	{StatDefPointsAllowed, 7, 14, 4},
+	{StatDefPointsAllowed, 14, 21, 1},
+	{StatDefPointsAllowed, 21, 28, 0},
+	{StatDefPointsAllowed, 28, 35, -1},
+	{StatDefPointsAllowed, 35, 0, -4},
+}
+
+var nbaDoubleStats = []string{StatPoints, StatRebounds, StatAssists, StatSteals, StatBlocks}
+
+var statRulesets = map[string]StatRuleset{
+	"nfl:draftkings": {
+		Sport:    "nfl",
+		Platform: "draftkings",
+		Points: map[string]float64{
+			StatPassYards:            0.04,
+			StatPassTouchdowns:       4,
+			StatInterceptionsThrown:  -1,
+			StatRushYards:            0.1,
+			StatRushTouchdowns:       6,
+			StatReceptions:           1,
+			StatReceivingYards:       0.1,
+			StatReceivingTouchdowns:  6,
+			StatReturnTouchdowns:     6,
+			StatFumblesLost:          -1,
+			StatTwoPointConversions:  2,
+			StatFumbleRecoveryTD:     6,
+			StatDefSacks:             1,
+			StatDefInterceptions:     2,
+			StatDefFumbleRecoveries:  2,
+			StatDefTouchdowns:        6,
+			StatDefSafeties:          2,
+			StatDefBlockedKicks:      2,
+			StatDefExtraPointReturns: 2,
+		},
+		Bonuses: []StatBonus{
+			{Name: "300_pass_yds", Stats: []string{StatPassYards}, Threshold: 300, Points: 3},
+			{Name: "100_rush_yds", Stats: []string{StatRushYards}, Threshold: 100, Points: 3},
+			{Name: "100_rec_yds", Stats: []string{StatReceivingYards}, Threshold: 100, Points: 3},
+		},
+		Tiers: nflPointsAllowed,
+	},
+	"nfl:fanduel": {
+		Sport:    "nfl",
+		Platform: "fanduel",
+		Points: map[string]float64{
+			StatPassYards:            0.04,
+			StatPassTouchdowns:       4,
+			StatInterceptionsThrown:  -1,
+			StatRushYards:            0.1,
+			StatRushTouchdowns:       6,
+			StatReceptions:           0.5,
+			StatReceivingYards:       0.1,
+			StatReceivingTouchdowns:  6,
+			StatReturnTouchdowns:     6,
+			StatFumblesLost:          -2,
+			StatTwoPointConversions:  2,
+			StatFumbleRecoveryTD:     6,
+			StatFieldGoals0To39:      3,
+			StatFieldGoals40To49:     4,
+			StatFieldGoals50Plus:     5,
+			StatExtraPoints:          1,
+			StatDefSacks:             1,
+			StatDefInterceptions:     2,
+			StatDefFumbleRecoveries:  2,
+			StatDefTouchdowns:        6,
+			StatDefSafeties:          2,
+			StatDefBlockedKicks:      2,
+			StatDefExtraPointReturns: 2,
+		},
+		Tiers: nflPointsAllowed,
+	},
+	"nba:draftkings": {
+		Sport:    "nba",
+		Platform: "draftkings",
+		Points: map[string]float64{
+			StatPoints:     1,
+			StatThreesMade: 0.5,
+			StatRebounds:   1.25,
+			StatAssists:    1.5,
+			StatSteals:     2,
+			StatBlocks:     2,
+			StatTurnovers:  -0.5,
+		},
+		Bonuses: []StatBonus{
+			{Name: "double_double", Stats: nbaDoubleStats, Threshold: 10, MinCount: 2, Points: 1.5},
+			{Name: "triple_double", Stats: nbaDoubleStats, Threshold: 10, MinCount: 3, Points: 3},
+		},
+	},
+	"nba:fanduel": {
+		Sport:    "nba",
+		Platform: "fanduel",
+		Points: map[string]float64{
+			StatPoints:    1,
+			StatRebounds:  1.2,
+			StatAssists:   1.5,
+			StatSteals:    3,
+			StatBlocks:    3,
+			StatTurnovers: -1,
+		},
+	},
+	"mlb:draftkings": {
+		Sport:    "mlb",
+		Platform: "draftkings",
+		Points: map[string]float64{
+			StatSingles:           3,
+			StatDoubles:           5,
+			StatTriples:           8,
+			StatHomeRuns:          10,
+			StatRunsBattedIn:      2,
+			StatRuns:              2,
+			StatWalks:             2,
+			StatHitByPitch:        2,
+			StatStolenBases:       5,
+			StatOutsPitched:       0.75,
+			StatStrikeouts:        2,
+			StatPitcherWin:        4,
+			StatEarnedRuns:        -2,
+			StatHitsAllowed:       -0.6,
+			StatWalksAllowed:      -0.6,
+			StatHitBattersAllowed: -0.6,
+			StatCompleteGame:      2.5,
+			StatCompleteGameSHO:   2.5,
+			StatNoHitter:          5,
+		},
+	},
+	"mlb:fanduel": {
+		Sport:    "mlb",
+		Platform: "fanduel",
+		Points: map[string]float64{
+			StatSingles:      3,
+			StatDoubles:      6,
+			StatTriples:      9,
+			StatHomeRuns:     12,
+			StatRunsBattedIn: 3.5,
+			StatRuns:         3.2,
+			StatWalks:        3,
+			StatHitByPitch:   3,
+			StatStolenBases:  6,
+			StatOutsPitched:  1,
+			StatStrikeouts:   3,
+			StatPitcherWin:   6,
+			StatEarnedRuns:   -3,
+			StatQualityStart: 4,
+		},
+	},
+	"nhl:draftkings": {
+		Sport:    "nhl",
+		Platform: "draftkings",
+		Points: map[string]float64{
+			StatGoals:             8.5,
+			StatHockeyAssists:     5,
+			StatShotsOnGoal:       1.5,
+			StatBlockedShots:      1.3,
+			StatShorthandedPoints: 2,
+			StatShootoutGoals:     1.5,
+			StatGoalieWin:         6,
+			StatGoalieOTLoss:      2,
+			StatSaves:             0.7,
+			StatGoalsAgainst:      -3.5,
+			StatGoalieShutout:     4,
+		},
+		Bonuses: []StatBonus{
+			{Name: "hat_trick", Stats: []string{StatGoals}, Threshold: 3, Points: 3},
+			{Name: "5_shots_on_goal", Stats: []string{StatShotsOnGoal}, Threshold: 5, Points: 3},
+			{Name: "3_blocked_shots", Stats: []string{StatBlockedShots}, Threshold: 3, Points: 3},
+			{Name: "3_points", Stats: []string{StatGoals, StatHockeyAssists}, Threshold: 3, Sum: true, Points: 3},
+			{Name: "35_saves", Stats: []string{StatSaves}, Threshold: 35, Points: 3},
+		},
+	},
+	"nhl:fanduel": {
+		Sport:    "nhl",
+		Platform: "fanduel",
+		Points: map[string]float64{
+			StatGoals:             12,
+			StatHockeyAssists:     8,
+			StatShotsOnGoal:       1.6,
+			StatBlockedShots:      1.6,
+			StatPowerPlayPoints:   0.5,
+			StatShorthandedPoints: 2,
+			StatGoalieWin:         12,
+			StatSaves:             0.8,
+			StatGoalsAgainst:      -4,
+			StatGoalieShutout:     8,
+		},
+	},
+}
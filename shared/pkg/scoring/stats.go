@@ -0,0 +1,140 @@
+package scoring
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// StatLine is a player's box score or projection keyed by stat name (see the Stat constants)
+type StatLine map[string]float64
+
+// StatBonus awards Points when at least MinCount of Stats reach Threshold, such as 300 passing
+// yards (one stat) or a double-double (two of five stats at ten or more). With Sum set the
+// threshold applies to the stats added together, like goals plus assists for hockey points.
+type StatBonus struct {
+	Name      string   `json:"name"`
+	Stats     []string `json:"stats"`
+	Threshold float64  `json:"threshold"`
+	MinCount  int      `json:"min_count,omitempty"`
+	Sum       bool     `json:"sum,omitempty"`
+	Points    float64  `json:"points"`
+}
+
+// StatTier awards Points when Stat falls in [Min, Max), such as a defense's points allowed.
+// A zero Max leaves the tier unbounded above.
+type StatTier struct {
+	Stat   string  `json:"stat"`
+	Min    float64 `json:"min"`
+	Max    float64 `json:"max"`
+	Points float64 `json:"points"`
+}
+
+// StatRuleset is a site's scoring system for one sport
+type StatRuleset struct {
+	Sport    string             `json:"sport"`
+	Platform string             `json:"platform"`
+	Points   map[string]float64 `json:"points"`
+	Bonuses  []StatBonus        `json:"bonuses"`
+	Tiers    []StatTier         `json:"tiers"`
+}
+
+// StatScore is a scored stat line with the contribution of each stat, bonus and tier
+type StatScore struct {
+	Stats   map[string]float64 `json:"stats"`
+	Bonuses map[string]float64 `json:"bonuses"`
+	Total   float64            `json:"total"`
+}
+
+// Score returns the fantasy points for a stat line
+func (r StatRuleset) Score(stats StatLine) float64 {
+	return r.ScoreBreakdown(stats).Total
+}
+
+// ScoreBreakdown scores a stat line. Bonuses are all-or-nothing, so a projected stat line only
+// earns one when its projected values reach the threshold.
+func (r StatRuleset) ScoreBreakdown(stats StatLine) StatScore {
+	score := StatScore{
+		Stats:   make(map[string]float64),
+		Bonuses: make(map[string]float64),
+	}
+
+	for stat, value := range stats {
+		if points, ok := r.Points[stat]; ok && value != 0 {
+			score.Stats[stat] = value * points
+			score.Total += value * points
+		}
+	}
+
+	for _, bonus := range r.Bonuses {
+		minCount := bonus.MinCount
+		if minCount <= 0 {
+			minCount = 1
+		}
+		reached, total := 0, 0.0
+		for _, stat := range bonus.Stats {
+			total += stats[stat]
+			if stats[stat] >= bonus.Threshold {
+				reached++
+			}
+		}
+		if bonus.Sum && total >= bonus.Threshold || !bonus.Sum && reached >= minCount {
+			score.Bonuses[bonus.Name] += bonus.Points
+			score.Total += bonus.Points
+		}
+	}
+
+	for _, tier := range r.Tiers {
+		value, ok := stats[tier.Stat]
+		if !ok {
+			continue
+		}
+		upper := tier.Max
+		if upper == 0 {
+			upper = math.Inf(1)
+		}
+		if value >= tier.Min && value < upper {
+			score.Bonuses[tier.Stat] += tier.Points
+			score.Total += tier.Points
+		}
+	}
+
+	return score
+}
+
+// StatRulesetFor returns the ruleset for a sport on a platform
+func StatRulesetFor(sport, platform string) (StatRuleset, error) {
+	key := strings.ToLower(sport) + ":" + normalizePlatform(platform)
+	ruleset, ok := statRulesets[key]
+	if !ok {
+		return StatRuleset{}, fmt.Errorf("no %s scoring rules for platform %q", sport, platform)
+	}
+	return ruleset, nil
+}
+
+// StatRulesets lists every built-in ruleset, sorted by sport then platform
+func StatRulesets() []StatRuleset {
+	rulesets := make([]StatRuleset, 0, len(statRulesets))
+	for _, ruleset := range statRulesets {
+		rulesets = append(rulesets, ruleset)
+	}
+	sort.Slice(rulesets, func(i, j int) bool {
+		if rulesets[i].Sport != rulesets[j].Sport {
+			return rulesets[i].Sport < rulesets[j].Sport
+		}
+		return rulesets[i].Platform < rulesets[j].Platform
+	})
+	return rulesets
+}
+
+func normalizePlatform(platform string) string {
+	switch p := strings.ToLower(platform); p {
+	case "dk":
+		return "draftkings"
+	case "fd":
+		return "fanduel"
+	default:
+		return p
+	}
+}
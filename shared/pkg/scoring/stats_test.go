@@ -0,0 +1,115 @@
+package scoring_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stitts-dev/dfs-sim/shared/pkg/scoring"
+)
+
+func mustRuleset(t *testing.T, sport, platform string) scoring.StatRuleset {
+	t.Helper()
+	ruleset, err := scoring.StatRulesetFor(sport, platform)
+	if err != nil {
+		t.Fatalf("StatRulesetFor(%s, %s): %v", sport, platform, err)
+	}
+	return ruleset
+}
+
+func assertPoints(t *testing.T, name string, got, want float64) {
+	t.Helper()
+	if math.Abs(got-want) > 1e-9 {
+		t.Errorf("%s = %v, want %v", name, got, want)
+	}
+}
+
+func TestStatRuleset_NFLReceptionScoring(t *testing.T) {
+	// 8 catches, 112 yards, 1 TD
+	receiver := scoring.StatLine{
+		scoring.StatReceptions:          8,
+		scoring.StatReceivingYards:      112,
+		scoring.StatReceivingTouchdowns: 1,
+	}
+
+	// Full PPR plus the 100-yard bonus
+	assertPoints(t, "dk receiver", mustRuleset(t, "nfl", "draftkings").Score(receiver), 8+11.2+6+3)
+	// Half PPR, no yardage bonuses
+	assertPoints(t, "fd receiver", mustRuleset(t, "nfl", "fanduel").Score(receiver), 4+11.2+6)
+}
+
+func TestStatRuleset_NFLDefensePointsAllowed(t *testing.T) {
+	dk := mustRuleset(t, "nfl", "dk")
+	tests := []struct {
+		allowed float64
+		want    float64
+	}{
+		{0, 10}, {6, 7}, {7, 4}, {20, 1}, {27, 0}, {34, -1}, {35, -4}, {52, -4},
+	}
+	for _, tt := range tests {
+		assertPoints(t, "points allowed", dk.Score(scoring.StatLine{scoring.StatDefPointsAllowed: tt.allowed}), tt.want)
+	}
+
+	// Skill players have no points allowed stat and get no tier points
+	assertPoints(t, "no tier", dk.Score(scoring.StatLine{scoring.StatRushYards: 10}), 1)
+}
+
+func TestStatRuleset_NBADoubleAndTripleDoubles(t *testing.T) {
+	dk := mustRuleset(t, "nba", "draftkings")
+
+	tripleDouble := scoring.StatLine{
+		scoring.StatPoints:    25,
+		scoring.StatRebounds:  11,
+		scoring.StatAssists:   10,
+		scoring.StatSteals:    1,
+		scoring.StatTurnovers: 4,
+	}
+	score := dk.ScoreBreakdown(tripleDouble)
+	assertPoints(t, "double-double bonus", score.Bonuses["double_double"], 1.5)
+	assertPoints(t, "triple-double bonus", score.Bonuses["triple_double"], 3)
+	assertPoints(t, "dk total", score.Total, 25+13.75+15+2-2+1.5+3)
+
+	// FanDuel has no bonuses
+	fd := mustRuleset(t, "nba", "fanduel")
+	assertPoints(t, "fd total", fd.Score(tripleDouble), 25+13.2+15+3-4)
+}
+
+func TestStatRuleset_MLBPitcher(t *testing.T) {
+	// 6 innings, 7 K, 2 ER, 5 hits, 1 walk, win
+	pitcher := scoring.StatLine{
+		scoring.StatOutsPitched:  18,
+		scoring.StatStrikeouts:   7,
+		scoring.StatEarnedRuns:   2,
+		scoring.StatHitsAllowed:  5,
+		scoring.StatWalksAllowed: 1,
+		scoring.StatPitcherWin:   1,
+		scoring.StatQualityStart: 1,
+	}
+	assertPoints(t, "dk pitcher", mustRuleset(t, "mlb", "draftkings").Score(pitcher), 13.5+14-4-3.6+4)
+	assertPoints(t, "fd pitcher", mustRuleset(t, "mlb", "fanduel").Score(pitcher), 18+21-6+6+4)
+}
+
+func TestStatRuleset_NHLBonuses(t *testing.T) {
+	dk := mustRuleset(t, "nhl", "draftkings")
+
+	// 1 goal, 2 assists, 5 shots, 3 blocks: 3-point, 5-shot and 3-block bonuses
+	skater := scoring.StatLine{
+		scoring.StatGoals:         1,
+		scoring.StatHockeyAssists: 2,
+		scoring.StatShotsOnGoal:   5,
+		scoring.StatBlockedShots:  3,
+	}
+	score := dk.ScoreBreakdown(skater)
+	if len(score.Bonuses) != 3 {
+		t.Errorf("bonuses = %v, want 3_points, 5_shots_on_goal and 3_blocked_shots", score.Bonuses)
+	}
+	assertPoints(t, "dk skater", score.Total, 8.5+10+7.5+3.9+9)
+}
+
+func TestStatRulesetFor_UnknownCombination(t *testing.T) {
+	if _, err := scoring.StatRulesetFor("cricket", "draftkings"); err == nil {
+		t.Error("expected an error for an unknown sport")
+	}
+	if len(scoring.StatRulesets()) != 8 {
+		t.Errorf("expected 8 built-in rulesets, got %d", len(scoring.StatRulesets()))
+	}
+}
@@ -0,0 +1,376 @@
+package simulator
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+const (
+	// minEigenvalue keeps repaired matrices strictly positive definite so Cholesky succeeds
+	minEigenvalue = 1e-8
+	// higham iteration limits for the nearest correlation matrix repair
+	highamMaxIterations = 100
+	highamTolerance     = 1e-9
+	// jacobi sweep limit for the symmetric eigen decomposition
+	jacobiMaxSweeps = 100
+)
+
+// Marginal is a player's one-dimensional outcome distribution, sampled through its quantile function
+type Marginal interface {
+	// Quantile returns the outcome at cumulative probability p in (0, 1)
+	Quantile(p float64) float64
+}
+
+// NormalMarginal is a normal distribution clipped to [Floor, Ceiling] with a point mass at zero
+// for players who don't play (injury, scratch, missed cut)
+type NormalMarginal struct {
+	Mean            float64
+	StdDev          float64
+	Floor           float64
+	Ceiling         float64 // zero means unbounded
+	ZeroProbability float64
+}
+
+// Quantile implements Marginal
+func (m NormalMarginal) Quantile(p float64) float64 {
+	if p < m.ZeroProbability {
+		return 0
+	}
+	if m.ZeroProbability > 0 {
+		p = (p - m.ZeroProbability) / (1 - m.ZeroProbability)
+	}
+
+	value := m.Mean + m.StdDev*NormalQuantile(p)
+	value = math.Max(m.Floor, value)
+	if m.Ceiling > 0 {
+		value = math.Min(m.Ceiling, value)
+	}
+	return value
+}
+
+// EmpiricalMarginal is a marginal defined by a sample of outcomes, for distributions without a
+// closed-form quantile function
+type EmpiricalMarginal struct {
+	sorted []float64
+}
+
+// NewEmpiricalMarginal creates a marginal from sampled outcomes
+func NewEmpiricalMarginal(samples []float64) *EmpiricalMarginal {
+	sorted := make([]float64, len(samples))
+	copy(sorted, samples)
+	sort.Float64s(sorted)
+	return &EmpiricalMarginal{sorted: sorted}
+}
+
+// Quantile implements Marginal, interpolating linearly between order statistics
+func (m *EmpiricalMarginal) Quantile(p float64) float64 {
+	n := len(m.sorted)
+	if n == 0 {
+		return 0
+	}
+
+	position := p * float64(n-1)
+	lower := int(math.Floor(position))
+	if lower < 0 {
+		return m.sorted[0]
+	}
+	if lower >= n-1 {
+		return m.sorted[n-1]
+	}
+	fraction := position - float64(lower)
+	return m.sorted[lower] + fraction*(m.sorted[lower+1]-m.sorted[lower])
+}
+
+// CopulaSampler draws jointly distributed player outcomes: correlated standard normals are
+// generated from the Cholesky factor of the slate correlation matrix, mapped to uniforms through
+// the normal CDF (a Gaussian copula) and then through each player's marginal quantile function
+type CopulaSampler struct {
+	cholesky  [][]float64
+	marginals []Marginal
+	repaired  bool
+}
+
+// NewCopulaSampler creates a sampler for the given correlation matrix and per-player marginals.
+// Matrices that aren't positive semidefinite are replaced with the nearest valid correlation matrix.
+func NewCopulaSampler(correlation [][]float64, marginals []Marginal) (*CopulaSampler, error) {
+	n := len(marginals)
+	if len(correlation) != n {
+		return nil, fmt.Errorf("correlation matrix has %d rows for %d marginals", len(correlation), n)
+	}
+	for i, row := range correlation {
+		if len(row) != n {
+			return nil, fmt.Errorf("correlation matrix row %d has %d columns, expected %d", i, len(row), n)
+		}
+	}
+
+	sampler := &CopulaSampler{marginals: marginals}
+
+	lower, err := Cholesky(correlation)
+	if err != nil {
+		lower, err = Cholesky(NearestCorrelationMatrix(correlation))
+		if err != nil {
+			return nil, fmt.Errorf("correlation matrix could not be repaired: %w", err)
+		}
+		sampler.repaired = true
+	}
+	sampler.cholesky = lower
+
+	return sampler, nil
+}
+
+// Repaired reports whether the input correlation matrix had to be projected onto the PSD cone
+func (cs *CopulaSampler) Repaired() bool {
+	return cs.repaired
+}
+
+// Size returns the number of players sampled together
+func (cs *CopulaSampler) Size() int {
+	return len(cs.marginals)
+}
+
+// Sample writes one joint draw of player outcomes into out, which must have Size() elements
+func (cs *CopulaSampler) Sample(rng *rand.Rand, out []float64) {
+	n := len(cs.marginals)
+
+	independent := make([]float64, n)
+	for i := range independent {
+		independent[i] = rng.NormFloat64()
+	}
+
+	for i := 0; i < n; i++ {
+		z := 0.0
+		row := cs.cholesky[i]
+		for j := 0; j <= i; j++ {
+			z += row[j] * independent[j]
+		}
+		out[i] = cs.marginals[i].Quantile(NormalCDF(z))
+	}
+}
+
+// BuildCorrelationMatrix assembles a symmetric n x n correlation matrix with a unit diagonal from a
+// pairwise lookup, clamping entries to [-1, 1]
+func BuildCorrelationMatrix(n int, pairCorrelation func(i, j int) float64) [][]float64 {
+	matrix := make([][]float64, n)
+	for i := range matrix {
+		matrix[i] = make([]float64, n)
+		matrix[i][i] = 1
+	}
+
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			// Average both directions so asymmetric sources still give a symmetric matrix
+			corr := (pairCorrelation(i, j) + pairCorrelation(j, i)) / 2
+			corr = math.Max(-1, math.Min(1, corr))
+			matrix[i][j] = corr
+			matrix[j][i] = corr
+		}
+	}
+
+	return matrix
+}
+
+// Cholesky returns the lower-triangular factor L with L*Lᵀ = a, or an error when a is not
+// positive definite
+func Cholesky(a [][]float64) ([][]float64, error) {
+	n := len(a)
+	lower := make([][]float64, n)
+	for i := range lower {
+		lower[i] = make([]float64, n)
+	}
+
+	for i := 0; i < n; i++ {
+		for j := 0; j <= i; j++ {
+			sum := a[i][j]
+			for k := 0; k < j; k++ {
+				sum -= lower[i][k] * lower[j][k]
+			}
+
+			if i == j {
+				if sum <= 0 || math.IsNaN(sum) {
+					return nil, fmt.Errorf("matrix is not positive definite (pivot %d is %g)", i, sum)
+				}
+				lower[i][i] = math.Sqrt(sum)
+			} else {
+				lower[i][j] = sum / lower[j][j]
+			}
+		}
+	}
+
+	return lower, nil
+}
+
+// NearestCorrelationMatrix returns the positive definite correlation matrix closest to a in the
+// Frobenius norm, using Higham's alternating projections with Dykstra's correction
+func NearestCorrelationMatrix(a [][]float64) [][]float64 {
+	n := len(a)
+	y := cloneMatrix(a)
+	correction := newMatrix(n)
+
+	for iteration := 0; iteration < highamMaxIterations; iteration++ {
+		r := newMatrix(n)
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				r[i][j] = y[i][j] - correction[i][j]
+			}
+		}
+
+		x := projectPSD(r)
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				correction[i][j] = x[i][j] - r[i][j]
+			}
+		}
+
+		// Project onto matrices with a unit diagonal
+		change := 0.0
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				next := x[i][j]
+				if i == j {
+					next = 1
+				}
+				diff := next - y[i][j]
+				change += diff * diff
+				y[i][j] = next
+			}
+		}
+
+		if math.Sqrt(change) < highamTolerance {
+			break
+		}
+	}
+
+	// The unit-diagonal projection can leave eigenvalues marginally negative; a final
+	// clip-and-rescale guarantees a usable Cholesky factor
+	return rescaleToCorrelation(projectPSD(y))
+}
+
+// projectPSD clips the eigenvalues of a symmetric matrix to minEigenvalue
+func projectPSD(a [][]float64) [][]float64 {
+	eigenvalues, eigenvectors := symmetricEigen(a)
+	n := len(a)
+
+	result := newMatrix(n)
+	for k := 0; k < n; k++ {
+		lambda := math.Max(eigenvalues[k], minEigenvalue)
+		for i := 0; i < n; i++ {
+			vik := eigenvectors[i][k] * lambda
+			for j := 0; j < n; j++ {
+				result[i][j] += vik * eigenvectors[j][k]
+			}
+		}
+	}
+	return result
+}
+
+// rescaleToCorrelation scales a covariance matrix to unit diagonal
+func rescaleToCorrelation(a [][]float64) [][]float64 {
+	n := len(a)
+	scale := make([]float64, n)
+	for i := 0; i < n; i++ {
+		scale[i] = 1 / math.Sqrt(a[i][i])
+	}
+
+	result := newMatrix(n)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			result[i][j] = a[i][j] * scale[i] * scale[j]
+		}
+		result[i][i] = 1
+	}
+	return result
+}
+
+// symmetricEigen decomposes a symmetric matrix with the cyclic Jacobi method, returning the
+// eigenvalues and a matrix whose columns are the matching eigenvectors
+func symmetricEigen(a [][]float64) ([]float64, [][]float64) {
+	n := len(a)
+	m := cloneMatrix(a)
+	v := newMatrix(n)
+	for i := 0; i < n; i++ {
+		v[i][i] = 1
+	}
+
+	for sweep := 0; sweep < jacobiMaxSweeps; sweep++ {
+		offDiagonal := 0.0
+		for p := 0; p < n; p++ {
+			for q := p + 1; q < n; q++ {
+				offDiagonal += m[p][q] * m[p][q]
+			}
+		}
+		if offDiagonal < 1e-22 {
+			break
+		}
+
+		for p := 0; p < n-1; p++ {
+			for q := p + 1; q < n; q++ {
+				if math.Abs(m[p][q]) < 1e-300 {
+					continue
+				}
+
+				theta := (m[q][q] - m[p][p]) / (2 * m[p][q])
+				var t float64
+				if math.Abs(theta) > 1e150 {
+					t = 1 / (2 * theta)
+				} else {
+					t = math.Copysign(1, theta) / (math.Abs(theta) + math.Sqrt(theta*theta+1))
+				}
+				c := 1 / math.Sqrt(t*t+1)
+				s := t * c
+
+				for k := 0; k < n; k++ {
+					mkp, mkq := m[k][p], m[k][q]
+					m[k][p] = c*mkp - s*mkq
+					m[k][q] = s*mkp + c*mkq
+				}
+				for k := 0; k < n; k++ {
+					mpk, mqk := m[p][k], m[q][k]
+					m[p][k] = c*mpk - s*mqk
+					m[q][k] = s*mpk + c*mqk
+				}
+				for k := 0; k < n; k++ {
+					vkp, vkq := v[k][p], v[k][q]
+					v[k][p] = c*vkp - s*vkq
+					v[k][q] = s*vkp + c*vkq
+				}
+			}
+		}
+	}
+
+	eigenvalues := make([]float64, n)
+	for i := 0; i < n; i++ {
+		eigenvalues[i] = m[i][i]
+	}
+	return eigenvalues, v
+}
+
+// NormalCDF is the standard normal cumulative distribution function
+func NormalCDF(z float64) float64 {
+	return 0.5 * math.Erfc(-z/math.Sqrt2)
+}
+
+// NormalQuantile is the inverse of the standard normal CDF
+func NormalQuantile(p float64) float64 {
+	// Keep extreme uniforms finite so clipped marginals don't see ±Inf
+	p = math.Max(1e-12, math.Min(1-1e-12, p))
+	return math.Sqrt2 * math.Erfinv(2*p-1)
+}
+
+func newMatrix(n int) [][]float64 {
+	matrix := make([][]float64, n)
+	for i := range matrix {
+		matrix[i] = make([]float64, n)
+	}
+	return matrix
+}
+
+func cloneMatrix(a [][]float64) [][]float64 {
+	clone := make([][]float64, len(a))
+	for i, row := range a {
+		clone[i] = make([]float64, len(row))
+		copy(clone[i], row)
+	}
+	return clone
+}
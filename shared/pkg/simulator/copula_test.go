@@ -0,0 +1,206 @@
+package simulator_test
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stitts-dev/dfs-sim/shared/pkg/simulator"
+)
+
+const copulaTestSamples = 40000
+
+func sampleMatrix(t *testing.T, correlation [][]float64, marginals []simulator.Marginal) ([][]float64, *simulator.CopulaSampler) {
+	t.Helper()
+
+	sampler, err := simulator.NewCopulaSampler(correlation, marginals)
+	if err != nil {
+		t.Fatalf("NewCopulaSampler: %v", err)
+	}
+
+	rng := rand.New(rand.NewSource(42))
+	columns := make([][]float64, sampler.Size())
+	for i := range columns {
+		columns[i] = make([]float64, copulaTestSamples)
+	}
+
+	draw := make([]float64, sampler.Size())
+	for s := 0; s < copulaTestSamples; s++ {
+		sampler.Sample(rng, draw)
+		for i, value := range draw {
+			columns[i][s] = value
+		}
+	}
+	return columns, sampler
+}
+
+func pearson(x, y []float64) float64 {
+	n := float64(len(x))
+	var meanX, meanY float64
+	for i := range x {
+		meanX += x[i]
+		meanY += y[i]
+	}
+	meanX /= n
+	meanY /= n
+
+	var cov, varX, varY float64
+	for i := range x {
+		dx, dy := x[i]-meanX, y[i]-meanY
+		cov += dx * dy
+		varX += dx * dx
+		varY += dy * dy
+	}
+	return cov / math.Sqrt(varX*varY)
+}
+
+func ranks(values []float64) []float64 {
+	order := make([]int, len(values))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(a, b int) bool { return values[order[a]] < values[order[b]] })
+
+	result := make([]float64, len(values))
+	for rank, idx := range order {
+		result[idx] = float64(rank)
+	}
+	return result
+}
+
+func TestCopulaSampler_RecoversNormalCorrelations(t *testing.T) {
+	// QB, WR, TE stack plus an opposing DST
+	correlation := [][]float64{
+		{1.0, 0.6, 0.4, -0.3},
+		{0.6, 1.0, 0.2, -0.2},
+		{0.4, 0.2, 1.0, -0.1},
+		{-0.3, -0.2, -0.1, 1.0},
+	}
+	marginals := []simulator.Marginal{
+		simulator.NormalMarginal{Mean: 22, StdDev: 6, Floor: -100},
+		simulator.NormalMarginal{Mean: 16, StdDev: 7, Floor: -100},
+		simulator.NormalMarginal{Mean: 11, StdDev: 5, Floor: -100},
+		simulator.NormalMarginal{Mean: 8, StdDev: 5, Floor: -100},
+	}
+
+	columns, sampler := sampleMatrix(t, correlation, marginals)
+	if sampler.Repaired() {
+		t.Fatal("a valid correlation matrix should not be repaired")
+	}
+
+	for i := range correlation {
+		for j := i + 1; j < len(correlation); j++ {
+			got := pearson(columns[i], columns[j])
+			if math.Abs(got-correlation[i][j]) > 0.02 {
+				t.Errorf("correlation[%d][%d] = %.3f, want %.3f", i, j, got, correlation[i][j])
+			}
+		}
+	}
+
+	mean := 0.0
+	for _, v := range columns[0] {
+		mean += v
+	}
+	mean /= copulaTestSamples
+	if math.Abs(mean-22) > 0.1 {
+		t.Errorf("marginal mean = %.2f, want 22", mean)
+	}
+}
+
+func TestCopulaSampler_PreservesRankCorrelationWithEmpiricalMarginals(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+
+	// Skewed, zero-inflated marginals like a golfer who can miss the cut
+	skewed := func(scale float64) *simulator.EmpiricalMarginal {
+		samples := make([]float64, 5000)
+		for i := range samples {
+			if rng.Float64() < 0.3 {
+				continue
+			}
+			samples[i] = rng.ExpFloat64() * scale
+		}
+		return simulator.NewEmpiricalMarginal(samples)
+	}
+
+	rho := 0.5
+	correlation := [][]float64{{1, rho}, {rho, 1}}
+	columns, _ := sampleMatrix(t, correlation, []simulator.Marginal{skewed(40), skewed(30)})
+
+	// A Gaussian copula with correlation rho has Spearman correlation (6/pi)*asin(rho/2); ties at
+	// zero pull the observed value slightly lower
+	want := 6 / math.Pi * math.Asin(rho/2)
+	got := pearson(ranks(columns[0]), ranks(columns[1]))
+	if math.Abs(got-want) > 0.05 {
+		t.Errorf("spearman = %.3f, want %.3f", got, want)
+	}
+}
+
+func TestNearestCorrelationMatrix_RepairsIndefiniteMatrix(t *testing.T) {
+	// Pairwise rules can produce inconsistent triples: A~B and A~C strongly, but B and C opposed
+	indefinite := [][]float64{
+		{1.0, 0.9, 0.9},
+		{0.9, 1.0, -0.9},
+		{0.9, -0.9, 1.0},
+	}
+	if _, err := simulator.Cholesky(indefinite); err == nil {
+		t.Fatal("expected the test matrix to be indefinite")
+	}
+
+	repaired := simulator.NearestCorrelationMatrix(indefinite)
+	if _, err := simulator.Cholesky(repaired); err != nil {
+		t.Fatalf("repaired matrix is not positive definite: %v", err)
+	}
+	for i := range repaired {
+		if math.Abs(repaired[i][i]-1) > 1e-9 {
+			t.Errorf("diagonal[%d] = %f, want 1", i, repaired[i][i])
+		}
+		for j := range repaired {
+			if math.Abs(repaired[i][j]-repaired[j][i]) > 1e-9 {
+				t.Errorf("repaired matrix is not symmetric at [%d][%d]", i, j)
+			}
+		}
+	}
+	// The repair keeps the sign structure of the input
+	if repaired[0][1] <= 0 || repaired[0][2] <= 0 || repaired[1][2] >= 0 {
+		t.Errorf("repair changed correlation signs: %v", repaired)
+	}
+
+	marginals := []simulator.Marginal{
+		simulator.NormalMarginal{Mean: 10, StdDev: 2},
+		simulator.NormalMarginal{Mean: 10, StdDev: 2},
+		simulator.NormalMarginal{Mean: 10, StdDev: 2},
+	}
+	columns, sampler := sampleMatrix(t, indefinite, marginals)
+	if !sampler.Repaired() {
+		t.Error("sampler should report the matrix was repaired")
+	}
+	got := pearson(columns[1], columns[2])
+	if math.Abs(got-repaired[1][2]) > 0.03 {
+		t.Errorf("sampled correlation %.3f doesn't match repaired matrix %.3f", got, repaired[1][2])
+	}
+}
+
+func TestCholesky_ReconstructsMatrix(t *testing.T) {
+	a := [][]float64{
+		{4, 2, 0.4},
+		{2, 5, 1},
+		{0.4, 1, 3},
+	}
+	lower, err := simulator.Cholesky(a)
+	if err != nil {
+		t.Fatalf("Cholesky: %v", err)
+	}
+
+	for i := range a {
+		for j := range a {
+			sum := 0.0
+			for k := range a {
+				sum += lower[i][k] * lower[j][k]
+			}
+			if math.Abs(sum-a[i][j]) > 1e-12 {
+				t.Errorf("(L*Lt)[%d][%d] = %f, want %f", i, j, sum, a[i][j])
+			}
+		}
+	}
+}
@@ -40,11 +40,38 @@ func NewMonteCarloSimulator(
 	}
 }
 
-// SetCorrelationMatrix sets the correlation matrix for simulation
+// SetCorrelationMatrix sets the pairwise player correlations used for simulation, keyed by
+// "playerID:playerID" with either ordering
 func (mcs *MonteCarloSimulator) SetCorrelationMatrix(matrix map[string]float64) {
 	mcs.correlationMatrix = matrix
 }
 
+// pairCorrelation looks up the correlation between two players in either key order
+func (mcs *MonteCarloSimulator) pairCorrelation(a, b types.LineupPlayer) float64 {
+	if corr, ok := mcs.correlationMatrix[a.ID.String()+":"+b.ID.String()]; ok {
+		return corr
+	}
+	return mcs.correlationMatrix[b.ID.String()+":"+a.ID.String()]
+}
+
+// buildSampler creates the joint outcome sampler for a lineup's players
+func (mcs *MonteCarloSimulator) buildSampler(players []types.LineupPlayer) (*CopulaSampler, error) {
+	marginals := make([]Marginal, len(players))
+	for i, player := range players {
+		// Normal around the projection with a standard deviation of 20% of projected points
+		marginals[i] = NormalMarginal{
+			Mean:   player.ProjectedPoints,
+			StdDev: player.ProjectedPoints * 0.2,
+		}
+	}
+
+	correlation := BuildCorrelationMatrix(len(players), func(i, j int) float64 {
+		return mcs.pairCorrelation(players[i], players[j])
+	})
+
+	return NewCopulaSampler(correlation, marginals)
+}
+
 // LineupResult contains simulation results for a lineup
 type LineupResult struct {
 	LineupID          string                 `json:"lineup_id"`
@@ -123,7 +150,10 @@ func (mcs *MonteCarloSimulator) RunSimulation(
 		}
 
 		// Run simulation for this lineup
-		result := mcs.simulateLineup(lineup)
+		result, err := mcs.simulateLineup(lineup)
+		if err != nil {
+			return nil, fmt.Errorf("failed to simulate lineup %s: %w", lineup.ID, err)
+		}
 		results[i] = result
 	}
 
@@ -164,34 +194,35 @@ func (mcs *MonteCarloSimulator) RunSimulation(
 }
 
 // simulateLineup runs Monte Carlo simulation for a single lineup
-func (mcs *MonteCarloSimulator) simulateLineup(lineup types.Lineup) LineupResult {
+func (mcs *MonteCarloSimulator) simulateLineup(lineup types.Lineup) (LineupResult, error) {
 	// Initialize random number generator with time-based seed
 	// Use hash of UUID string to create deterministic but unique seed
 	h := hash.Hash32(crc32.NewIEEE())
 	h.Write([]byte(lineup.ID.String()))
 	rng := rand.New(rand.NewSource(time.Now().UnixNano() + int64(h.Sum32())))
 
+	// Sample players jointly so correlated players (stacks) move together
+	sampler, err := mcs.buildSampler(lineup.Players)
+	if err != nil {
+		return LineupResult{}, err
+	}
+	if sampler.Repaired() && mcs.logger != nil {
+		mcs.logger.WithField("lineup_id", lineup.ID).Warn("Correlation matrix was not positive semidefinite; using nearest valid matrix")
+	}
+
 	scores := make([]float64, mcs.simulationCount)
+	playerScores := make([]float64, sampler.Size())
 	totalScore := 0.0
 
 	// Run simulations for this lineup
 	for i := 0; i < mcs.simulationCount; i++ {
-		// Generate random performance for each player
+		sampler.Sample(rng, playerScores)
+
 		lineupScore := 0.0
-		for _, player := range lineup.Players {
-			// Simple simulation: normal distribution around projected points
-			// with standard deviation of 20% of projected points
-			stdDev := player.ProjectedPoints * 0.2
-			playerScore := rng.NormFloat64()*stdDev + player.ProjectedPoints
-			
-			// Ensure non-negative score
-			if playerScore < 0 {
-				playerScore = 0
-			}
-			
+		for _, playerScore := range playerScores {
 			lineupScore += playerScore
 		}
-		
+
 		scores[i] = lineupScore
 		totalScore += lineupScore
 	}
@@ -262,5 +293,5 @@ func (mcs *MonteCarloSimulator) simulateLineup(lineup types.Lineup) LineupResult
 			"max_score":       sortedScores[len(sortedScores)-1],
 			"std_dev":         math.Sqrt(variance),
 		},
-	}
+	}, nil
 }
\ No newline at end of file
@@ -0,0 +1,326 @@
+package weather
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	holesPerRound = 18
+	// minWaveGap is the smallest break between consecutive round 1 tee times that is treated as
+	// the split between the morning and afternoon waves
+	minWaveGap = 60 * time.Minute
+)
+
+// Wave is a player's draw for the first two rounds
+type Wave string
+
+const (
+	// WaveEarlyLate tees off in the morning in round 1 and the afternoon in round 2
+	WaveEarlyLate Wave = "early_late"
+	// WaveLateEarly tees off in the afternoon in round 1 and the morning in round 2
+	WaveLateEarly Wave = "late_early"
+)
+
+// HourlyForecast is the forecast at a course for one point in time. Forecasts with coarser steps
+// work too, since conditions are interpolated between points.
+type HourlyForecast struct {
+	Time        time.Time `json:"time"`
+	Temperature float64   `json:"temperature"` // degrees F
+	WindSpeed   float64   `json:"wind_speed"`  // mph
+	WindGust    float64   `json:"wind_gust"`   // mph
+	// Precipitation is the forecast rate in mm per hour if it rains, and PrecipProbability the
+	// chance that it does; zero probability means the amount is already an expectation
+	Precipitation     float64 `json:"precipitation"`
+	PrecipProbability float64 `json:"precip_probability"`
+}
+
+// Exposure is the average weather a player faces over the holes of one round
+type Exposure struct {
+	WindSpeed     float64 `json:"wind_speed"`
+	WindGust      float64 `json:"wind_gust"`
+	Precipitation float64 `json:"precipitation"`
+}
+
+// PlayerTeeTimes are a player's first and second round tee times. Round2 may be zero when only
+// the first round draw is out.
+type PlayerTeeTimes struct {
+	PlayerID string    `json:"player_id"`
+	Round1   time.Time `json:"round1"`
+	Round2   time.Time `json:"round2"`
+}
+
+// PlayerWaveAdvantage is the weather a player is expected to face and what it is worth
+type PlayerWaveAdvantage struct {
+	PlayerID      string   `json:"player_id"`
+	Wave          Wave     `json:"wave"`
+	Round1        Exposure `json:"round1"`
+	Round2        Exposure `json:"round2"`
+	Round1Strokes float64  `json:"round1_strokes"`
+	Round2Strokes float64  `json:"round2_strokes"`
+	// StrokesAdvantage is how many strokes easier the player's two rounds are than the field's
+	// average draw; positive is an advantage
+	StrokesAdvantage float64 `json:"strokes_advantage"`
+}
+
+// WaveAdvantage summarises one wave
+type WaveAdvantage struct {
+	Wave             Wave    `json:"wave"`
+	Players          int     `json:"players"`
+	Round1Strokes    float64 `json:"round1_strokes"`
+	Round2Strokes    float64 `json:"round2_strokes"`
+	StrokesAdvantage float64 `json:"strokes_advantage"`
+}
+
+// WaveReport is the wave analysis for a tournament's first two rounds
+type WaveReport struct {
+	Waves   map[Wave]*WaveAdvantage         `json:"waves"`
+	Players map[string]*PlayerWaveAdvantage `json:"players"`
+}
+
+// WaveModel converts forecast wind and rain during a player's holes into strokes
+type WaveModel struct {
+	RoundDuration time.Duration
+	// CalmWind is the sustained wind speed below which scoring isn't affected
+	CalmWind float64
+	// WindStrokesPerMph is strokes per round added for each mph of effective wind above calm
+	WindStrokesPerMph float64
+	// GustWeight is the share of the gap between gusts and sustained wind counted as wind
+	GustWeight float64
+	// RainStrokesPerMM is strokes per round added per mm/h of expected rain
+	RainStrokesPerMM float64
+}
+
+// DefaultWaveModel uses a 4h45m round for threesomes. Tour scoring averages rise by roughly a
+// tenth of a stroke per mph of wind beyond a light breeze, and steady rain costs around a stroke.
+func DefaultWaveModel() WaveModel {
+	return WaveModel{
+		RoundDuration:     4*time.Hour + 45*time.Minute,
+		CalmWind:          8,
+		WindStrokesPerMph: 0.1,
+		GustWeight:        0.25,
+		RainStrokesPerMM:  0.5,
+	}
+}
+
+// RoundExposure averages the forecast at the time the player is expected to be on each hole. The
+// forecast must be in time order.
+func (m WaveModel) RoundExposure(forecast []HourlyForecast, teeTime time.Time) Exposure {
+	var exposure Exposure
+	if len(forecast) == 0 || teeTime.IsZero() {
+		return exposure
+	}
+
+	perHole := m.RoundDuration / holesPerRound
+	for hole := 0; hole < holesPerRound; hole++ {
+		at := teeTime.Add(time.Duration(hole)*perHole + perHole/2)
+		conditions := conditionsAt(forecast, at)
+		exposure.WindSpeed += conditions.WindSpeed
+		exposure.WindGust += math.Max(conditions.WindGust, conditions.WindSpeed)
+		rain := conditions.Precipitation
+		if conditions.PrecipProbability > 0 {
+			rain *= conditions.PrecipProbability
+		}
+		exposure.Precipitation += rain
+	}
+
+	exposure.WindSpeed /= holesPerRound
+	exposure.WindGust /= holesPerRound
+	exposure.Precipitation /= holesPerRound
+	return exposure
+}
+
+// RoundStrokes is the strokes the conditions add to a round
+func (m WaveModel) RoundStrokes(exposure Exposure) float64 {
+	wind := exposure.WindSpeed + m.GustWeight*math.Max(0, exposure.WindGust-exposure.WindSpeed)
+	return m.WindStrokesPerMph*math.Max(0, wind-m.CalmWind) + m.RainStrokesPerMM*exposure.Precipitation
+}
+
+// Analyze works out each player's weather cost for rounds one and two, assigns waves from the
+// round 1 tee sheet and compares everyone against the field's average draw
+func (m WaveModel) Analyze(forecast []HourlyForecast, teeTimes []PlayerTeeTimes) *WaveReport {
+	report := &WaveReport{
+		Waves:   make(map[Wave]*WaveAdvantage),
+		Players: make(map[string]*PlayerWaveAdvantage),
+	}
+
+	forecast = append([]HourlyForecast(nil), forecast...)
+	sort.Slice(forecast, func(i, j int) bool { return forecast[i].Time.Before(forecast[j].Time) })
+
+	round1 := make(map[string]time.Time, len(teeTimes))
+	for _, t := range teeTimes {
+		if !t.Round1.IsZero() {
+			round1[t.PlayerID] = t.Round1
+		}
+	}
+	waves := AssignWaves(round1)
+
+	fieldTotal := 0.0
+	for _, t := range teeTimes {
+		wave, ok := waves[t.PlayerID]
+		if !ok {
+			continue
+		}
+		player := &PlayerWaveAdvantage{
+			PlayerID: t.PlayerID,
+			Wave:     wave,
+			Round1:   m.RoundExposure(forecast, t.Round1),
+			Round2:   m.RoundExposure(forecast, t.Round2),
+		}
+		player.Round1Strokes = m.RoundStrokes(player.Round1)
+		if !t.Round2.IsZero() {
+			player.Round2Strokes = m.RoundStrokes(player.Round2)
+		}
+		fieldTotal += player.Round1Strokes + player.Round2Strokes
+		report.Players[t.PlayerID] = player
+	}
+	if len(report.Players) == 0 {
+		return report
+	}
+
+	fieldAverage := fieldTotal / float64(len(report.Players))
+	for _, player := range report.Players {
+		player.StrokesAdvantage = fieldAverage - player.Round1Strokes - player.Round2Strokes
+
+		wave, ok := report.Waves[player.Wave]
+		if !ok {
+			wave = &WaveAdvantage{Wave: player.Wave}
+			report.Waves[player.Wave] = wave
+		}
+		wave.Players++
+		wave.Round1Strokes += player.Round1Strokes
+		wave.Round2Strokes += player.Round2Strokes
+		wave.StrokesAdvantage += player.StrokesAdvantage
+	}
+	for _, wave := range report.Waves {
+		n := float64(wave.Players)
+		wave.Round1Strokes /= n
+		wave.Round2Strokes /= n
+		wave.StrokesAdvantage /= n
+	}
+	return report
+}
+
+// AssignWaves splits players into waves by round 1 tee time of day. The split is the biggest
+// break in the tee sheet when there is one of at least an hour, otherwise the median tee time.
+func AssignWaves(round1 map[string]time.Time) map[string]Wave {
+	waves := make(map[string]Wave, len(round1))
+	if len(round1) == 0 {
+		return waves
+	}
+
+	times := make([]time.Duration, 0, len(round1))
+	for _, t := range round1 {
+		times = append(times, timeOfDay(t))
+	}
+	sort.Slice(times, func(i, j int) bool { return times[i] < times[j] })
+
+	split := times[len(times)/2]
+	largestGap := time.Duration(0)
+	for i := 1; i < len(times); i++ {
+		if gap := times[i] - times[i-1]; gap > largestGap {
+			largestGap = gap
+			if gap >= minWaveGap {
+				split = times[i]
+			}
+		}
+	}
+
+	for playerID, t := range round1 {
+		if timeOfDay(t) < split {
+			waves[playerID] = WaveEarlyLate
+		} else {
+			waves[playerID] = WaveLateEarly
+		}
+	}
+	return waves
+}
+
+// AdjustCutProbability shifts a cut probability by a 36-hole scoring advantage in strokes,
+// treating the player's score relative to the cut line as normal with the given per-round
+// standard deviation
+func AdjustCutProbability(probability, strokesAdvantage, roundStdDev float64) float64 {
+	if strokesAdvantage == 0 || roundStdDev <= 0 {
+		return probability
+	}
+	p := math.Max(0.001, math.Min(0.999, probability))
+	z := math.Sqrt2*math.Erfinv(2*p-1) + strokesAdvantage/(roundStdDev*math.Sqrt2)
+	return 0.5 * math.Erfc(-z/math.Sqrt2)
+}
+
+// ParseTeeTime reads the tee time formats used by the providers and the optimizer's player pool.
+// Times without a date are returned on the zero date, which is enough to assign waves. Times
+// without a zone are read as UTC and times with one keep it, so the clock time is as published;
+// use ParseTeeTimeIn to place tee times against a forecast.
+func ParseTeeTime(value string) (time.Time, bool) {
+	return ParseTeeTimeIn(value, nil)
+}
+
+// ParseTeeTimeIn reads a tee time in the course's time zone. Tee sheets are published in local
+// time, so times without a zone are taken as local to the course; times with one are converted to
+// it. Either way the result is an absolute instant that lines up with a UTC forecast, while its
+// clock time stays the course's for splitting waves.
+func ParseTeeTimeIn(value string, loc *time.Location) (time.Time, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, false
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		if loc != nil {
+			t = t.In(loc)
+		}
+		return t, true
+	}
+	if loc == nil {
+		loc = time.UTC
+	}
+	layouts := []string{
+		"2006-01-02 15:04:05",
+		"2006-01-02T15:04:05",
+		"2006-01-02 15:04",
+		"15:04",
+		"3:04 PM",
+		"3:04PM",
+		"3:04pm",
+	}
+	for _, layout := range layouts {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func timeOfDay(t time.Time) time.Duration {
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+}
+
+// conditionsAt interpolates the forecast linearly, holding the first and last points beyond the
+// ends of the forecast
+func conditionsAt(forecast []HourlyForecast, at time.Time) HourlyForecast {
+	i := sort.Search(len(forecast), func(i int) bool { return !forecast[i].Time.Before(at) })
+	switch {
+	case i == 0:
+		return forecast[0]
+	case i == len(forecast):
+		return forecast[len(forecast)-1]
+	}
+
+	before, after := forecast[i-1], forecast[i]
+	span := after.Time.Sub(before.Time)
+	if span <= 0 {
+		return after
+	}
+	w := float64(at.Sub(before.Time)) / float64(span)
+	lerp := func(a, b float64) float64 { return a + w*(b-a) }
+	return HourlyForecast{
+		Time:              at,
+		Temperature:       lerp(before.Temperature, after.Temperature),
+		WindSpeed:         lerp(before.WindSpeed, after.WindSpeed),
+		WindGust:          lerp(before.WindGust, after.WindGust),
+		Precipitation:     lerp(before.Precipitation, after.Precipitation),
+		PrecipProbability: lerp(before.PrecipProbability, after.PrecipProbability),
+	}
+}
@@ -0,0 +1,121 @@
+package weather
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestAnalyzeFavoursCalmDraw(t *testing.T) {
+	day1 := time.Date(2025, 4, 10, 0, 0, 0, 0, time.UTC)
+	day2 := day1.AddDate(0, 0, 1)
+
+	// Calm first-round morning, wind building to 25 mph in the afternoon, then a calm Friday
+	var forecast []HourlyForecast
+	for h := 6; h <= 20; h++ {
+		wind := 5.0
+		if h >= 12 {
+			wind = 25
+		}
+		forecast = append(forecast, HourlyForecast{Time: day1.Add(time.Duration(h) * time.Hour), WindSpeed: wind, WindGust: wind})
+	}
+	for h := 6; h <= 20; h++ {
+		forecast = append(forecast, HourlyForecast{Time: day2.Add(time.Duration(h) * time.Hour), WindSpeed: 5, WindGust: 5})
+	}
+
+	at := func(day time.Time, hour, minute int) time.Time {
+		return day.Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute)
+	}
+	teeTimes := []PlayerTeeTimes{
+		{PlayerID: "am1", Round1: at(day1, 7, 0), Round2: at(day2, 12, 30)},
+		{PlayerID: "am2", Round1: at(day1, 7, 11), Round2: at(day2, 12, 41)},
+		{PlayerID: "pm1", Round1: at(day1, 12, 30), Round2: at(day2, 7, 0)},
+		{PlayerID: "pm2", Round1: at(day1, 12, 41), Round2: at(day2, 7, 11)},
+	}
+
+	report := DefaultWaveModel().Analyze(forecast, teeTimes)
+
+	if got := report.Players["am1"].Wave; got != WaveEarlyLate {
+		t.Fatalf("am1 wave = %s, want %s", got, WaveEarlyLate)
+	}
+	if got := report.Players["pm1"].Wave; got != WaveLateEarly {
+		t.Fatalf("pm1 wave = %s, want %s", got, WaveLateEarly)
+	}
+
+	early, late := report.Waves[WaveEarlyLate], report.Waves[WaveLateEarly]
+	if early.Players != 2 || late.Players != 2 {
+		t.Fatalf("wave sizes = %d/%d, want 2/2", early.Players, late.Players)
+	}
+	if early.StrokesAdvantage <= 0 || late.StrokesAdvantage >= 0 {
+		t.Fatalf("advantages early=%.2f late=%.2f, want early positive and late negative", early.StrokesAdvantage, late.StrokesAdvantage)
+	}
+	if math.Abs(early.StrokesAdvantage+late.StrokesAdvantage) > 1e-9 {
+		t.Fatalf("advantages should be relative to the field: early=%.2f late=%.2f", early.StrokesAdvantage, late.StrokesAdvantage)
+	}
+	// 25 mph is 17 over calm, so roughly 1.7 strokes on the afternoon wave
+	if late.Round1Strokes < 1.5 || late.Round1Strokes > 1.8 {
+		t.Fatalf("afternoon round 1 strokes = %.2f, want about 1.7", late.Round1Strokes)
+	}
+}
+
+func TestAssignWavesUsesMedianWithoutBreak(t *testing.T) {
+	base := time.Date(2025, 1, 1, 7, 0, 0, 0, time.UTC)
+	round1 := map[string]time.Time{
+		"a": base,
+		"b": base.Add(10 * time.Minute),
+		"c": base.Add(20 * time.Minute),
+		"d": base.Add(30 * time.Minute),
+	}
+
+	waves := AssignWaves(round1)
+	if waves["a"] != WaveEarlyLate || waves["b"] != WaveEarlyLate || waves["c"] != WaveLateEarly || waves["d"] != WaveLateEarly {
+		t.Fatalf("unexpected waves: %v", waves)
+	}
+}
+
+func TestAdjustCutProbability(t *testing.T) {
+	base := 0.6
+	better := AdjustCutProbability(base, 1.5, 2.8)
+	worse := AdjustCutProbability(base, -1.5, 2.8)
+	if !(worse < base && base < better) {
+		t.Fatalf("adjusted probabilities not ordered: worse=%.3f base=%.3f better=%.3f", worse, base, better)
+	}
+	if got := AdjustCutProbability(base, 0, 2.8); got != base {
+		t.Fatalf("zero advantage changed probability to %.3f", got)
+	}
+}
+
+func TestParseTeeTimeIn(t *testing.T) {
+	eastern, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone database unavailable: %v", err)
+	}
+
+	tests := []struct {
+		value    string
+		expected time.Time
+	}{
+		// A 7:40 local tee time at Augusta is 11:40 UTC during daylight saving
+		{"2025-04-10 07:40:00", time.Date(2025, 4, 10, 11, 40, 0, 0, time.UTC)},
+		{"2025-04-10 07:40", time.Date(2025, 4, 10, 11, 40, 0, 0, time.UTC)},
+		{"2025-04-10T13:05:00Z", time.Date(2025, 4, 10, 13, 5, 0, 0, time.UTC)},
+		{"2025-04-10T07:40:00-04:00", time.Date(2025, 4, 10, 11, 40, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		got, ok := ParseTeeTimeIn(tt.value, eastern)
+		if !ok {
+			t.Fatalf("%q did not parse", tt.value)
+		}
+		if !got.Equal(tt.expected) {
+			t.Errorf("%q parsed as %v, want %v", tt.value, got.UTC(), tt.expected)
+		}
+		// The clock time stays local so waves split on the course's morning and afternoon
+		if got.Location() != eastern {
+			t.Errorf("%q parsed in %v, want the course zone", tt.value, got.Location())
+		}
+	}
+
+	if _, ok := ParseTeeTimeIn("", eastern); ok {
+		t.Error("empty tee time parsed")
+	}
+}
@@ -0,0 +1,81 @@
+// Package workspace checks team workspace roles and writes the workspace audit trail, for every
+// service that stores workspace-owned lineups, presets or projections.
+package workspace
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/stitts-dev/dfs-sim/shared/types"
+)
+
+var (
+	// ErrNotMember is returned when the user has no role in the workspace
+	ErrNotMember = errors.New("not a member of this workspace")
+	// ErrForbidden is returned when the user's role is below the one required
+	ErrForbidden = errors.New("workspace role does not allow this action")
+)
+
+// roleRank orders roles so a higher role includes every lower one's access
+var roleRank = map[types.WorkspaceRole]int{
+	types.WorkspaceRoleViewer: 1,
+	types.WorkspaceRoleEditor: 2,
+	types.WorkspaceRoleOwner:  3,
+}
+
+// ValidRole reports whether role is one of the workspace roles
+func ValidRole(role types.WorkspaceRole) bool {
+	_, ok := roleRank[role]
+	return ok
+}
+
+// RoleAllows reports whether role grants at least the access of need
+func RoleAllows(role, need types.WorkspaceRole) bool {
+	return ValidRole(role) && roleRank[role] >= roleRank[need]
+}
+
+// MemberRole returns the user's role in a workspace, or ErrNotMember
+func MemberRole(db *gorm.DB, workspaceID, userID uuid.UUID) (types.WorkspaceRole, error) {
+	var member types.WorkspaceMember
+	err := db.Where("workspace_id = ? AND user_id = ?", workspaceID, userID).First(&member).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", ErrNotMember
+	}
+	if err != nil {
+		return "", err
+	}
+	return member.Role, nil
+}
+
+// Authorize returns the user's role in a workspace if it grants at least need; otherwise it
+// returns ErrNotMember or ErrForbidden
+func Authorize(db *gorm.DB, workspaceID, userID uuid.UUID, need types.WorkspaceRole) (types.WorkspaceRole, error) {
+	role, err := MemberRole(db, workspaceID, userID)
+	if err != nil {
+		return "", err
+	}
+	if !RoleAllows(role, need) {
+		return role, ErrForbidden
+	}
+	return role, nil
+}
+
+// MemberWorkspaces selects the IDs of the workspaces a user belongs to, for use as a subquery
+func MemberWorkspaces(db *gorm.DB, userID uuid.UUID) *gorm.DB {
+	return db.Session(&gorm.Session{NewDB: true}).Model(&types.WorkspaceMember{}).
+		Select("workspace_id").Where("user_id = ?", userID)
+}
+
+// Record appends an entry to a workspace's audit trail
+func Record(db *gorm.DB, workspaceID, actorID uuid.UUID, action, entityType string, entityID *uuid.UUID, details types.AuditDetails) error {
+	return db.Create(&types.WorkspaceAuditEntry{
+		WorkspaceID: workspaceID,
+		ActorID:     actorID,
+		Action:      action,
+		EntityType:  entityType,
+		EntityID:    entityID,
+		Details:     details,
+	}).Error
+}
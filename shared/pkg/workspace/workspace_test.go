@@ -0,0 +1,31 @@
+package workspace_test
+
+import (
+	"testing"
+
+	"github.com/stitts-dev/dfs-sim/shared/pkg/workspace"
+	"github.com/stitts-dev/dfs-sim/shared/types"
+)
+
+func TestRoleAllows(t *testing.T) {
+	tests := []struct {
+		role types.WorkspaceRole
+		need types.WorkspaceRole
+		want bool
+	}{
+		{types.WorkspaceRoleOwner, types.WorkspaceRoleOwner, true},
+		{types.WorkspaceRoleOwner, types.WorkspaceRoleViewer, true},
+		{types.WorkspaceRoleEditor, types.WorkspaceRoleEditor, true},
+		{types.WorkspaceRoleEditor, types.WorkspaceRoleOwner, false},
+		{types.WorkspaceRoleViewer, types.WorkspaceRoleViewer, true},
+		{types.WorkspaceRoleViewer, types.WorkspaceRoleEditor, false},
+		{"admin", types.WorkspaceRoleViewer, false},
+		{"", types.WorkspaceRoleViewer, false},
+	}
+
+	for _, tt := range tests {
+		if got := workspace.RoleAllows(tt.role, tt.need); got != tt.want {
+			t.Errorf("RoleAllows(%q, %q) = %v, want %v", tt.role, tt.need, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package types
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Bankroll transaction kinds
+const (
+	BankrollDeposit    = "deposit"
+	BankrollWithdrawal = "withdrawal"
+	BankrollEntryFee   = "entry_fee"
+	BankrollWinnings   = "winnings"
+	BankrollAdjustment = "adjustment"
+)
+
+// BankrollTransaction is one entry in a user's bankroll ledger. Amount is the signed change to the
+// balance: deposits and winnings add, withdrawals and entry fees subtract, and adjustments may do
+// either.
+type BankrollTransaction struct {
+	ID        uuid.UUID  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	UserID    uuid.UUID  `gorm:"type:uuid;not null;index" json:"user_id"`
+	Kind      string     `gorm:"size:20;not null" json:"kind"`
+	Amount    float64    `gorm:"not null" json:"amount"`
+	ContestID *uuid.UUID `gorm:"type:uuid;index" json:"contest_id,omitempty"`
+	LineupID  *uuid.UUID `gorm:"type:uuid" json:"lineup_id,omitempty"`
+	// Entries is how many contest entries an entry fee or winnings row covers
+	Entries    int       `gorm:"not null;default:0" json:"entries,omitempty"`
+	Note       string    `json:"note,omitempty"`
+	OccurredAt time.Time `gorm:"not null" json:"occurred_at"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// TableName returns the table name for BankrollTransaction
+func (BankrollTransaction) TableName() string {
+	return "bankroll_transactions"
+}
+
+// BankrollSettings holds a user's sizing defaults and drawdown alert threshold. Fractions are
+// shares of the bankroll, so 0.02 is 2%.
+type BankrollSettings struct {
+	UserID uuid.UUID `gorm:"type:uuid;primaryKey" json:"user_id"`
+	// DrawdownAlertThreshold is the drop from peak balance that raises an alert; 0 disables alerts
+	DrawdownAlertThreshold float64 `gorm:"not null;default:0.2" json:"drawdown_alert_threshold"`
+	KellyMultiplier        float64 `gorm:"not null;default:0.25" json:"kelly_multiplier"`
+	FixedFraction          float64 `gorm:"not null;default:0.02" json:"fixed_fraction"`
+	MaxContestFraction     float64 `gorm:"not null;default:0.1" json:"max_contest_fraction"`
+	// DrawdownAlerted is set once the current drawdown has been alerted and clears when the
+	// bankroll recovers above the threshold, so each drawdown alerts once
+	DrawdownAlerted   bool       `gorm:"not null;default:false" json:"drawdown_alerted"`
+	DrawdownAlertedAt *time.Time `json:"drawdown_alerted_at,omitempty"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+}
+
+// TableName returns the table name for BankrollSettings
+func (BankrollSettings) TableName() string {
+	return "bankroll_settings"
+}
+
+// DefaultBankrollSettings returns the settings used before a user saves their own
+func DefaultBankrollSettings(userID uuid.UUID) BankrollSettings {
+	return BankrollSettings{
+		UserID:                 userID,
+		DrawdownAlertThreshold: 0.2,
+		KellyMultiplier:        0.25,
+		FixedFraction:          0.02,
+		MaxContestFraction:     0.1,
+	}
+}
@@ -286,11 +286,31 @@ type LineupPlayer struct {
 	ID              uuid.UUID `json:"id"`
 	Name            string    `json:"name"`
 	Team            string    `json:"team"`
+	Opponent        string    `json:"opponent,omitempty"`
 	Position        string    `json:"position"`
+	// Slot is the roster slot the player fills, e.g. PG, G or UTIL on DraftKings NBA
+	Slot            string    `json:"slot,omitempty"`
 	Salary          int       `json:"salary"`
 	ProjectedPoints float64   `json:"projected_points"`
 }
 
+// LineupStack is a group of same-team or same-game players in a lineup
+type LineupStack struct {
+	Type      string      `json:"type"` // "team", "qb" or "bring_back"
+	Team      string      `json:"team"`
+	Game      string      `json:"game,omitempty"`
+	Size      int         `json:"size"`
+	Positions []string    `json:"positions"`
+	PlayerIDs []uuid.UUID `json:"player_ids"`
+}
+
+// StackSummary counts the lineups in a set that share a stack description
+type StackSummary struct {
+	Description string  `json:"description"`
+	Lineups     int     `json:"lineups"`
+	Share       float64 `json:"share"`
+}
+
 // GeneratedLineup represents an optimized lineup
 type GeneratedLineup struct {
 	ID               string         `json:"id"`
@@ -299,6 +319,7 @@ type GeneratedLineup struct {
 	ProjectedPoints  float64        `json:"projected_points"`
 	Exposure         float64        `json:"exposure"`
 	StackDescription string         `json:"stack_description,omitempty"`
+	Stacks           []LineupStack  `json:"stacks,omitempty"`
 }
 
 // OptimizationRequest represents a request to optimize lineups
@@ -308,6 +329,14 @@ type OptimizationRequest struct {
 	Constraints OptimizationConstraints `json:"constraints"`
 	Settings    OptimizationSettings    `json:"settings"`
 	UserID      uuid.UUID               `json:"user_id,omitempty"`
+	// OptimizationID lets a client name the run so it can cancel it; one is generated when empty
+	OptimizationID string `json:"optimization_id,omitempty"`
+	// PresetID fills constraints and settings from a saved preset; fields sent with the request
+	// override the preset's. PresetVersion pins a revision, 0 uses the current one.
+	PresetID      *uuid.UUID `json:"preset_id,omitempty"`
+	PresetVersion int        `json:"preset_version,omitempty"`
+	// ProjectionSetID replaces the player pool's projections with a blended projection set
+	ProjectionSetID *uuid.UUID `json:"projection_set_id,omitempty"`
 }
 
 // OptimizationPlayer represents a player for optimization
@@ -316,12 +345,14 @@ type OptimizationPlayer struct {
 	ExternalID      string    `json:"external_id"`
 	Name            string    `json:"name"`
 	Team            string    `json:"team"`
+	Opponent        string    `json:"opponent,omitempty"`
 	Position        string    `json:"position"`
 	Salary          int       `json:"salary"`
 	ProjectedPoints float64   `json:"projected_points"`
 	FloorPoints     float64   `json:"floor_points"`
 	CeilingPoints   float64   `json:"ceiling_points"`
 	Ownership       float64   `json:"ownership"`
+	BattingOrder    int       `json:"batting_order,omitempty"`   // MLB specific
 	TeeTime         string    `json:"tee_time,omitempty"`     // Golf specific
 	CutProbability  float64   `json:"cut_probability,omitempty"` // Golf specific
 }
@@ -351,15 +382,20 @@ type OptimizationSettings struct {
 	MaxExposure         map[uuid.UUID]float64 `json:"max_exposure"`
 	UniquenessFactor    float64             `json:"uniqueness_factor"`
 	RandomnessLevel     float64             `json:"randomness_level"`
-	Timeout             int                 `json:"timeout"`
+	Timeout             int                 `json:"timeout"` // seconds; 0 runs to completion
+	// RandomizationMode is "floor_ceiling" (default) or "distribution" when RandomnessLevel is set
+	RandomizationMode   string                `json:"randomization_mode,omitempty"`
+	VolatilityOverrides map[uuid.UUID]float64 `json:"volatility_overrides,omitempty"`
+	RandomSeed          int64                 `json:"random_seed,omitempty"`
 }
 
 // StackingRule represents a stacking rule for optimization
 type StackingRule struct {
-	Type       string   `json:"type"` // "team", "game", "mini"
+	Type       string   `json:"type"` // "team", "game", "mini", "wave"
 	MinPlayers int      `json:"min_players"`
 	MaxPlayers int      `json:"max_players"`
 	Teams      []string `json:"teams,omitempty"`
+	Wave       string   `json:"wave,omitempty"` // Golf: "early_late" or "late_early", empty for any wave
 }
 
 // OptimizationMeta represents metadata about an optimization
@@ -399,6 +435,23 @@ type OptimizationMetadata struct {
 	TopProjection    float64       `json:"top_projection"`
 	AverageProjection float64      `json:"average_projection"`
 	StacksGenerated  int           `json:"stacks_generated"`
+	// UnmetTargets lists the lineup count, uniqueness and exposure targets the set fell short of
+	UnmetTargets []LineupTargetShortfall `json:"unmet_targets,omitempty"`
+	// StackSummaries counts lineups by stack description, most common first
+	StackSummaries []StackSummary `json:"stack_summaries,omitempty"`
+	OptimizationID string `json:"optimization_id,omitempty"`
+	// Partial is set when the run was cancelled or timed out; the lineups are the best found so far
+	Partial    bool   `json:"partial,omitempty"`
+	StopReason string `json:"stop_reason,omitempty"` // "cancelled" or "deadline_exceeded"
+}
+
+// LineupTargetShortfall is a multi-lineup target the optimizer could not satisfy
+type LineupTargetShortfall struct {
+	Target   string     `json:"target"` // "num_lineups", "min_different_players", "min_exposure" or "max_exposure"
+	PlayerID *uuid.UUID `json:"player_id,omitempty"`
+	Required float64    `json:"required"`
+	Achieved float64    `json:"achieved"`
+	Message  string     `json:"message"`
 }
 
 // ProgressUpdate represents a progress update for optimization/simulation
@@ -409,6 +462,7 @@ type ProgressUpdate struct {
 	CurrentStep string  `json:"current_step"`
 	TotalSteps  int     `json:"total_steps"`
 	Timestamp   time.Time `json:"timestamp"`
+	OptimizationID string `json:"optimization_id,omitempty"`
 }
 
 // ErrorResponse represents a standard error response
@@ -456,6 +510,8 @@ type UserPreferences struct {
 type Lineup struct {
 	ID              uuid.UUID      `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
 	UserID          uuid.UUID      `gorm:"type:uuid;not null" json:"user_id"`
+	// WorkspaceID shares the lineup with a team workspace; nil keeps it private to UserID
+	WorkspaceID     *uuid.UUID     `gorm:"type:uuid;index" json:"workspace_id,omitempty"`
 	Name            string         `gorm:"not null" json:"name"`
 	Sport           string         `gorm:"not null" json:"sport"`
 	Platform        string         `gorm:"not null" json:"platform"`
@@ -0,0 +1,102 @@
+package types
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OptimizationPreset is a named optimization rule set saved by a user. Editing its rules adds a
+// version, so a request can pin the exact rules a lineup set was built with.
+type OptimizationPreset struct {
+	ID      uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	OwnerID uuid.UUID `gorm:"type:uuid;not null;index" json:"owner_id"`
+	// WorkspaceID makes the preset readable by the workspace's members and editable by its editors
+	WorkspaceID    *uuid.UUID `gorm:"type:uuid;index" json:"workspace_id,omitempty"`
+	Name           string     `gorm:"size:100;not null" json:"name"`
+	Description    string     `json:"description,omitempty"`
+	Sport          string     `gorm:"size:20" json:"sport,omitempty"`
+	CurrentVersion int        `gorm:"not null;default:1" json:"current_version"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// TableName returns the table name for OptimizationPreset
+func (OptimizationPreset) TableName() string {
+	return "optimization_presets"
+}
+
+// OptimizationPresetVersion is one immutable revision of a preset's rules
+type OptimizationPresetVersion struct {
+	ID        uuid.UUID   `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	PresetID  uuid.UUID   `gorm:"type:uuid;not null;uniqueIndex:idx_preset_version" json:"preset_id"`
+	Version   int         `gorm:"not null;uniqueIndex:idx_preset_version" json:"version"`
+	Rules     PresetRules `gorm:"type:jsonb;not null" json:"rules"`
+	CreatedBy uuid.UUID   `gorm:"type:uuid;not null" json:"created_by"`
+	CreatedAt time.Time   `json:"created_at"`
+}
+
+// TableName returns the table name for OptimizationPresetVersion
+func (OptimizationPresetVersion) TableName() string {
+	return "optimization_preset_versions"
+}
+
+// OptimizationPresetShare lets a teammate read and optimize with another user's preset
+type OptimizationPresetShare struct {
+	PresetID  uuid.UUID `gorm:"type:uuid;primaryKey" json:"preset_id"`
+	UserID    uuid.UUID `gorm:"type:uuid;primaryKey" json:"user_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TableName returns the table name for OptimizationPresetShare
+func (OptimizationPresetShare) TableName() string {
+	return "optimization_preset_shares"
+}
+
+// PresetRules are the constraints and settings a preset supplies to an optimization request:
+// stacking rules, exposures, team and game stacks, locks, uniqueness and randomness
+type PresetRules struct {
+	Constraints OptimizationConstraints `json:"constraints"`
+	Settings    OptimizationSettings    `json:"settings"`
+}
+
+// Value implements driver.Valuer for database storage
+func (r PresetRules) Value() (driver.Value, error) {
+	return json.Marshal(r)
+}
+
+// Scan implements sql.Scanner for database retrieval
+func (r *PresetRules) Scan(value interface{}) error {
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+	return json.Unmarshal(bytes, r)
+}
+
+// Validate checks the rules are usable before they are saved as a version
+func (r PresetRules) Validate() error {
+	if r.Settings.MaxLineups < 0 || r.Settings.MinDifferentPlayers < 0 {
+		return errors.New("max_lineups and min_different_players cannot be negative")
+	}
+	if r.Settings.RandomnessLevel < 0 || r.Settings.RandomnessLevel > 1 {
+		return errors.New("randomness_level must be between 0 and 1")
+	}
+	for _, exposures := range []map[uuid.UUID]float64{r.Settings.MinExposure, r.Settings.MaxExposure} {
+		for id, exposure := range exposures {
+			if exposure < 0 || exposure > 1 {
+				return fmt.Errorf("exposure for player %s must be a fraction between 0 and 1", id)
+			}
+		}
+	}
+	for _, rule := range r.Settings.StackingRules {
+		if rule.MinPlayers < 0 || (rule.MaxPlayers > 0 && rule.MinPlayers > rule.MaxPlayers) {
+			return fmt.Errorf("%s stacking rule has min_players %d above max_players %d", rule.Type, rule.MinPlayers, rule.MaxPlayers)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+package types
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ProjectionSource is a named set of projections a user uploaded, such as a third-party model or
+// their own spreadsheet. Entries are keyed by player so several sources can be blended.
+type ProjectionSource struct {
+	ID      uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	OwnerID uuid.UUID `gorm:"type:uuid;not null;index" json:"owner_id"`
+	// WorkspaceID makes the source readable by the workspace's members and editable by its editors
+	WorkspaceID *uuid.UUID `gorm:"type:uuid;index" json:"workspace_id,omitempty"`
+	Name        string     `gorm:"size:100;not null" json:"name"`
+	Description string     `json:"description,omitempty"`
+	Sport       string     `gorm:"size:20" json:"sport,omitempty"`
+	EntryCount  int        `gorm:"not null;default:0" json:"entry_count"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// TableName returns the table name for ProjectionSource
+func (ProjectionSource) TableName() string {
+	return "projection_sources"
+}
+
+// ProjectionEntry is one player's projection within a source. PlayerKey is the normalized player
+// ID, external ID or name (with any team and position) the upload used to identify the player.
+type ProjectionEntry struct {
+	SourceID        uuid.UUID `gorm:"type:uuid;primaryKey" json:"source_id"`
+	PlayerKey       string    `gorm:"size:150;primaryKey" json:"player_key"`
+	PlayerName      string    `gorm:"size:150" json:"player_name,omitempty"`
+	Position        string    `gorm:"size:20" json:"position,omitempty"`
+	Team            string    `gorm:"size:10" json:"team,omitempty"`
+	ProjectedPoints float64   `gorm:"not null" json:"projected_points"`
+	FloorPoints     float64   `json:"floor_points,omitempty"`
+	CeilingPoints   float64   `json:"ceiling_points,omitempty"`
+	Ownership       float64   `json:"ownership,omitempty"`
+}
+
+// TableName returns the table name for ProjectionEntry
+func (ProjectionEntry) TableName() string {
+	return "projection_entries"
+}
+
+// ProjectionSet blends projection sources with weights and per-player manual overrides.
+// Optimization and simulation requests reference a set by ID to replace provider projections.
+type ProjectionSet struct {
+	ID          uuid.UUID         `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	OwnerID     uuid.UUID         `gorm:"type:uuid;not null;index" json:"owner_id"`
+	WorkspaceID *uuid.UUID        `gorm:"type:uuid;index" json:"workspace_id,omitempty"`
+	Name        string            `gorm:"size:100;not null" json:"name"`
+	Description string            `json:"description,omitempty"`
+	Sport       string            `gorm:"size:20" json:"sport,omitempty"`
+	Weights     ProjectionWeights `gorm:"type:jsonb;not null" json:"weights"`
+	CreatedAt   time.Time         `json:"created_at"`
+	UpdatedAt   time.Time         `json:"updated_at"`
+}
+
+// TableName returns the table name for ProjectionSet
+func (ProjectionSet) TableName() string {
+	return "projection_sets"
+}
+
+// ProjectionSourceWeight is one source's share of a blend. PositionWeights replace Weight for
+// players at those positions, so a source can be trusted for some positions only. A nil SourceID
+// stands for the provider projections already on the players.
+type ProjectionSourceWeight struct {
+	SourceID        *uuid.UUID         `json:"source_id,omitempty"`
+	Weight          float64            `json:"weight"`
+	PositionWeights map[string]float64 `json:"position_weights,omitempty"`
+}
+
+// ProjectionWeights are the sources a projection set blends
+type ProjectionWeights []ProjectionSourceWeight
+
+// Value implements driver.Valuer for database storage
+func (w ProjectionWeights) Value() (driver.Value, error) {
+	return json.Marshal(w)
+}
+
+// Scan implements sql.Scanner for database retrieval
+func (w *ProjectionWeights) Scan(value interface{}) error {
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+	return json.Unmarshal(bytes, w)
+}
+
+// Validate checks the weights can produce a blend
+func (w ProjectionWeights) Validate() error {
+	if len(w) == 0 {
+		return errors.New("at least one source weight is required")
+	}
+	seen := make(map[string]bool, len(w))
+	total := 0.0
+	for _, sw := range w {
+		key := "provider"
+		if sw.SourceID != nil {
+			key = sw.SourceID.String()
+		}
+		if seen[key] {
+			return fmt.Errorf("source %s is weighted more than once", key)
+		}
+		seen[key] = true
+
+		if sw.Weight < 0 {
+			return fmt.Errorf("weight for source %s cannot be negative", key)
+		}
+		total += sw.Weight
+		for position, weight := range sw.PositionWeights {
+			if weight < 0 {
+				return fmt.Errorf("%s weight for source %s cannot be negative", position, key)
+			}
+			total += weight
+		}
+	}
+	if total == 0 {
+		return errors.New("at least one weight must be positive")
+	}
+	return nil
+}
+
+// ProjectionOverride pins a player's projection in a set, after blending
+type ProjectionOverride struct {
+	SetID           uuid.UUID `gorm:"type:uuid;primaryKey" json:"set_id"`
+	PlayerKey       string    `gorm:"size:150;primaryKey" json:"player_key"`
+	ProjectedPoints float64   `gorm:"not null" json:"projected_points"`
+	FloorPoints     *float64  `json:"floor_points,omitempty"`
+	CeilingPoints   *float64  `json:"ceiling_points,omitempty"`
+	Note            string    `json:"note,omitempty"`
+	UpdatedBy       uuid.UUID `gorm:"type:uuid;not null" json:"updated_by"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// TableName returns the table name for ProjectionOverride
+func (ProjectionOverride) TableName() string {
+	return "projection_overrides"
+}
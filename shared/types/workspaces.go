@@ -0,0 +1,92 @@
+package types
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WorkspaceRole is a member's level of access to a team workspace
+type WorkspaceRole string
+
+const (
+	// WorkspaceRoleOwner manages members as well as editing
+	WorkspaceRoleOwner WorkspaceRole = "owner"
+	// WorkspaceRoleEditor creates and changes lineups, presets and projections
+	WorkspaceRoleEditor WorkspaceRole = "editor"
+	// WorkspaceRoleViewer reads the workspace's data
+	WorkspaceRoleViewer WorkspaceRole = "viewer"
+)
+
+// Workspace is a team that owns lineups, presets and projection overrides together
+type Workspace struct {
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	Name      string    `gorm:"size:100;not null" json:"name"`
+	CreatedBy uuid.UUID `gorm:"type:uuid;not null" json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName returns the table name for Workspace
+func (Workspace) TableName() string {
+	return "workspaces"
+}
+
+// WorkspaceMember grants a user a role in a workspace
+type WorkspaceMember struct {
+	WorkspaceID uuid.UUID     `gorm:"type:uuid;primaryKey" json:"workspace_id"`
+	UserID      uuid.UUID     `gorm:"type:uuid;primaryKey" json:"user_id"`
+	Role        WorkspaceRole `gorm:"size:20;not null" json:"role"`
+	AddedBy     uuid.UUID     `gorm:"type:uuid" json:"added_by"`
+	CreatedAt   time.Time     `json:"created_at"`
+	UpdatedAt   time.Time     `json:"updated_at"`
+}
+
+// TableName returns the table name for WorkspaceMember
+func (WorkspaceMember) TableName() string {
+	return "workspace_members"
+}
+
+// WorkspaceAuditEntry records who changed what in a workspace
+type WorkspaceAuditEntry struct {
+	ID          uuid.UUID    `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	WorkspaceID uuid.UUID    `gorm:"type:uuid;not null;index" json:"workspace_id"`
+	ActorID     uuid.UUID    `gorm:"type:uuid;not null" json:"actor_id"`
+	Action      string       `gorm:"size:50;not null" json:"action"`      // e.g. "lineup.updated", "member.added"
+	EntityType  string       `gorm:"size:50;not null" json:"entity_type"` // "lineup", "preset", "member", "workspace"
+	EntityID    *uuid.UUID   `gorm:"type:uuid" json:"entity_id,omitempty"`
+	Details     AuditDetails `gorm:"type:jsonb" json:"details,omitempty"`
+	CreatedAt   time.Time    `json:"created_at"`
+}
+
+// TableName returns the table name for WorkspaceAuditEntry
+func (WorkspaceAuditEntry) TableName() string {
+	return "workspace_audit_log"
+}
+
+// AuditDetails holds an audit entry's context, such as the fields that changed
+type AuditDetails map[string]interface{}
+
+// Value implements driver.Valuer for database storage
+func (d AuditDetails) Value() (driver.Value, error) {
+	if d == nil {
+		return nil, nil
+	}
+	return json.Marshal(d)
+}
+
+// Scan implements sql.Scanner for database retrieval
+func (d *AuditDetails) Scan(value interface{}) error {
+	if value == nil {
+		*d = nil
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+	return json.Unmarshal(bytes, d)
+}